@@ -12,8 +12,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcjson"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	"github.com/decred/dcrd/txscript/v4/stdscript"
+	ltcjson "github.com/ltcsuite/ltcd/btcjson"
 
 	"github.com/decred/dcrdata/v8/db/dbtypes"
 	"github.com/decred/dcrdata/v8/txhelpers"
@@ -86,8 +88,9 @@ type BlockTransactions struct {
 // Tx models TxShort with the number of confirmations and block info Block
 type Tx struct {
 	TxShort
-	Confirmations int64    `json:"confirmations"`
-	Block         *BlockID `json:"block,omitempty"`
+	Confirmations        int64                           `json:"confirmations"`
+	Block                *BlockID                        `json:"block,omitempty"`
+	ConfirmationEstimate *txhelpers.ConfirmationEstimate `json:"confirmation_estimate,omitempty"`
 }
 
 // Vin is an alias for dcrd's rpc/jsonrpc/types/v4.Vin type.
@@ -121,6 +124,20 @@ type AgendaAPIResponse struct {
 	ByTime   *dbtypes.AgendaVoteChoices `json:"by_time"`
 }
 
+// BTCTx is a verbose BTC transaction with the confirmation estimate of an
+// unconfirmed transaction.
+type BTCTx struct {
+	*btcjson.TxRawResult
+	ConfirmationEstimate *txhelpers.ConfirmationEstimate `json:"confirmation_estimate,omitempty"`
+}
+
+// LTCTx is a verbose LTC transaction with the confirmation estimate of an
+// unconfirmed transaction.
+type LTCTx struct {
+	*ltcjson.TxRawResult
+	ConfirmationEstimate *txhelpers.ConfirmationEstimate `json:"confirmation_estimate,omitempty"`
+}
+
 // TrimmedTx models data to resemble to result of the decoderawtransaction RPC.
 type TrimmedTx struct {
 	TxID     string `json:"txid"`
@@ -894,6 +911,65 @@ type TicketPoolChartsData struct {
 	Mempool      *PriceCountTime          `json:"mempool"`
 }
 
+// TicketVoteModel is the expected number of blocks from maturity to vote of
+// the tickets that vote, for a ticket pool of PoolSize tickets. Distribution is
+// the expected fraction of the votes in each bucket of BucketBlocks blocks of
+// delay, and MedianBlocks is -1 if most tickets expire.
+type TicketVoteModel struct {
+	PoolSize     int64     `json:"pool_size"`
+	VoteChance   float64   `json:"vote_chance"`
+	MeanBlocks   float64   `json:"mean_blocks"`
+	MedianBlocks int64     `json:"median_blocks"`
+	ExpiryChance float64   `json:"expiry_chance"`
+	BucketBlocks int64     `json:"bucket_blocks"`
+	Distribution []float64 `json:"distribution"`
+}
+
+// TicketVoteDelays compares the observed vote delays of the tickets that voted
+// between From and To with the expected delays for the current pool size.
+type TicketVoteDelays struct {
+	From     int64               `json:"from"`
+	To       int64               `json:"to"`
+	Observed *dbtypes.VoteDelays `json:"observed"`
+	Expected *TicketVoteModel    `json:"expected"`
+}
+
+// TicketVoteCalculation is the outlook of a ticket bought at Height, for the
+// pool size at that height. ProbVote is the probability that the ticket votes
+// within Days days of purchase, and ProbExpiry that it expires without voting.
+type TicketVoteCalculation struct {
+	Height         int64   `json:"height"`
+	PoolSize       int64   `json:"pool_size"`
+	Days           float64 `json:"days"`
+	MaturityBlocks int64   `json:"maturity_blocks"`
+	ExpiryBlocks   int64   `json:"expiry_blocks"`
+	ProbVote       float64 `json:"prob_vote"`
+	ProbExpiry     float64 `json:"prob_expiry"`
+	// MeanDays and MedianDays are the expected days from purchase to vote of
+	// the tickets that vote. MedianDays is -1 if most tickets expire.
+	MeanDays   float64 `json:"mean_days"`
+	MedianDays float64 `json:"median_days"`
+}
+
+// LiquidityShare is an exchange's percentage of the aggregated order book
+// volume within 2 percent of the mid-gap.
+type LiquidityShare struct {
+	Time  int64   `json:"time"`
+	Share float64 `json:"share"`
+}
+
+// LiquidityChart is the order book liquidity history for an exchange, or for
+// the aggregated order book. Contributions is only set for the aggregated
+// order book, and holds the share of each exchange over time.
+type LiquidityChart struct {
+	ChainType     string                       `json:"chain"`
+	Token         string                       `json:"token"`
+	Bands         [3]float64                   `json:"bands"`
+	SlippageSizes [3]float64                   `json:"slippage_sizes"`
+	Snapshots     []*dbtypes.LiquiditySnapshot `json:"snapshots"`
+	Contributions map[string][]*LiquidityShare `json:"contributions,omitempty"`
+}
+
 // PowerlessTicket is the purchase block height and value of a missed or expired
 // ticket.
 type PowerlessTicket struct {
@@ -944,6 +1020,86 @@ type ProposalReportData struct {
 	TotalRemaining float64     `json:"totalRemaining"`
 }
 
+// The reconciliation statuses of the proposal, group and monthly budgets.
+const (
+	// ReconcileActive budgets have work months not yet paid.
+	ReconcileActive = "active"
+	// ReconcileReconciled budgets were paid within the tolerance.
+	ReconcileReconciled = "reconciled"
+	// ReconcileOverspent budgets were paid more than the tolerance over.
+	ReconcileOverspent = "overspent"
+	// ReconcileUnspent budgets were fully paid out with more than the
+	// tolerance left over.
+	ReconcileUnspent = "unspent"
+)
+
+// ProposalReconciliation is the approved budget of a proposal against its
+// estimated share of the treasury payouts. Treasury spends do not identify the
+// proposals they pay, so Paid and PaidDCR are a share of the monthly payouts in
+// proportion to the budget, and the Status of a proposal follows from the
+// statuses of its work months. Amounts are in USD, except PaidDCR.
+type ProposalReconciliation struct {
+	Token     string  `json:"token"`
+	Name      string  `json:"name"`
+	Owner     string  `json:"owner"`
+	Domain    string  `json:"domain"`
+	Start     string  `json:"start"`
+	End       string  `json:"end"`
+	Budget    float64 `json:"budget"`
+	Paid      float64 `json:"paid"`
+	PaidDCR   float64 `json:"paidDcr"`
+	Remaining float64 `json:"remaining"`
+	Status    string  `json:"status"`
+}
+
+// GroupReconciliation sums the reconciliations of the proposals of a domain or
+// an owner. Overspent and Unspent count the flagged proposals.
+type GroupReconciliation struct {
+	Name      string  `json:"name"`
+	Proposals int     `json:"proposals"`
+	Budget    float64 `json:"budget"`
+	Paid      float64 `json:"paid"`
+	PaidDCR   float64 `json:"paidDcr"`
+	Remaining float64 `json:"remaining"`
+	Overspent int     `json:"overspent"`
+	Unspent   int     `json:"unspent"`
+	Status    string  `json:"status"`
+}
+
+// MonthReconciliation is the proposal budget of a work month against the
+// treasury payouts of PayoutMonth. Unallocated is the part of the payouts with
+// no budget to attribute it to.
+type MonthReconciliation struct {
+	Month       string  `json:"month"`
+	PayoutMonth string  `json:"payoutMonth"`
+	Budget      float64 `json:"budget"`
+	Paid        float64 `json:"paid"`
+	PaidDCR     float64 `json:"paidDcr"`
+	TSpendDCR   float64 `json:"tspendDcr"`
+	LegacyDCR   float64 `json:"legacyDcr"`
+	Unallocated float64 `json:"unallocated"`
+	UsdRate     float64 `json:"usdRate"`
+	Status      string  `json:"status"`
+}
+
+// TreasuryReconciliation links the approved proposal budgets to the treasury
+// spend and legacy treasury payouts. The payouts of a month pay the budgets of
+// the work month Lag months earlier. Months, proposals, domains and owners
+// paid more than Tolerance (a fraction) over or under their budget are
+// flagged. The per-proposal amounts are estimated shares of the monthly
+// payouts.
+type TreasuryReconciliation struct {
+	Lag         int                       `json:"lag"`
+	Tolerance   float64                   `json:"tolerance"`
+	Budget      float64                   `json:"budget"`
+	Paid        float64                   `json:"paid"`
+	Unallocated float64                   `json:"unallocated"`
+	Proposals   []*ProposalReconciliation `json:"proposals"`
+	Domains     []*GroupReconciliation    `json:"domains"`
+	Owners      []*GroupReconciliation    `json:"owners"`
+	Months      []*MonthReconciliation    `json:"months"`
+}
+
 type AuthorDataObject struct {
 	Name           string  `json:"name"`
 	Proposals      int     `json:"proposals"`
@@ -113,11 +113,13 @@ var (
 	defaultDisabledExchanges = "bittrex,dragonex,poloniex"
 	defaultRateCertFile      = filepath.Join(defaultHomeDir, "rpc.cert")
 
-	defaultMainnetLink  = "https://bisonexplorer.com/"
-	defaultTestnetLink  = "https://testnet.bisonexplorer.com/"
-	defaultBinanceAPI   = "https://api.binance.com"
-	defaultOnionAddress = ""
-	defaultCoinCaps     = "btc,ltc,dcr,eth,xmr"
+	defaultMainnetLink   = "https://bisonexplorer.com/"
+	defaultTestnetLink   = "https://testnet.bisonexplorer.com/"
+	defaultBinanceAPI    = "https://api.binance.com"
+	defaultDEXDataURL    = "https://dex.decred.org:7232"
+	defaultDEXHistoryURL = "https://raw.githubusercontent.com/bochinchero/dcrsnapcsv/main/data/stream/dex_decred_org_VolUSD.csv"
+	defaultOnionAddress  = ""
+	defaultCoinCaps      = "btc,ltc,dcr,eth,xmr"
 
 	maxSyncStatusLimit = 5000
 )
@@ -173,6 +175,7 @@ type config struct {
 	LTCChartsCacheDump string `long:"ltcchartscache" description:"Defines the file name that holds the ltc charts cache data on system exit." env:"DCRDATA_LTC_CHARTS_CACHE"`
 	BTCChartsCacheDump string `long:"btcchartscache" description:"Defines the file name that holds the btc charts cache data on system exit." env:"DCRDATA_BTC_CHARTS_CACHE"`
 	XMRChartsCacheDump string `long:"xmrchartscache" description:"Defines the file name that holds the xmr charts cache data on system exit." env:"DCRDATA_XMR_CHARTS_CACHE"`
+	EnableWebhooks     bool   `long:"webhooks" description:"Enable the chain event webhooks API. Webhooks for new blocks, reorgs, address activity and tx confirmation depth may be registered on any enabled chain." env:"DCRDATA_ENABLE_WEBHOOKS"`
 	// DB backend
 	PGDBName         string        `long:"pgdbname" description:"PostgreSQL DB name." env:"DCRDATA_PG_DB_NAME"`
 	PGUser           string        `long:"pguser" description:"PostgreSQL DB user." env:"DCRDATA_POSTGRES_USER"`
@@ -212,10 +215,16 @@ type config struct {
 	// ExchangeBot settings
 	EnableExchangeBot bool   `long:"exchange-monitor" description:"Enable the exchange monitor" env:"DCRDATA_MONITOR_EXCHANGES"`
 	DisabledExchanges string `long:"disable-exchange" description:"Exchanges to disable. See /exchanges/exchanges.go for available exchanges. Use a comma to separate multiple exchanges" env:"DCRDATA_DISABLE_EXCHANGES"`
-	ExchangeCurrency  string `long:"exchange-currency" description:"The default bitcoin price index. A 3-letter currency code" env:"DCRDATA_EXCHANGE_INDEX"`
+	ExchangeCurrency  string `long:"exchange-currency" description:"The default bitcoin price index, which is also the currency of the LTC and XMR prices. A 3-letter currency code" env:"DCRDATA_EXCHANGE_INDEX"`
+	IndexSources      string `long:"index-sources" description:"BTC index sources in priority order, separated by commas. Available: coinbase, coingecko, blockchain, coindesk" env:"DCRDATA_INDEX_SOURCES"`
+	IndexMode         string `long:"index-mode" description:"How the BTC index sources are combined. priority uses the first source with a fresh price, median uses the median of all fresh prices" env:"DCRDATA_INDEX_MODE"`
+	IndexExpiry       string `long:"index-expiry" description:"Age after which an index source's price for a currency is considered stale, e.g. 30m. Defaults to 60m" env:"DCRDATA_INDEX_EXPIRY"`
 	RateMaster        string `long:"ratemaster" description:"The address of a DCRRates instance. Exchange monitoring will get all data from a DCRRates subscription." env:"DCRDATA_RATE_MASTER"`
 	RateCertificate   string `long:"ratecert" description:"File containing DCRRates TLS certificate file." env:"DCRDATA_RATE_MASTER"`
 	BinanceAPI        string `long:"binance-api" description:"Link to Binance data. Default is Binance API URL" env:"DCRRATES_BINANCEAPI_INDEX"`
+	AlertRulesFile    string `long:"alert-rules" description:"JSON file of price and market alert rules. Alerts are posted to the webhooks given in each rule. Requires the exchange monitor." env:"DCRDATA_ALERT_RULES"`
+	DEXDataURL        string `long:"dex-data-url" description:"dcrdex server whose public market data is collected for the Bison Wallet dashboard. Set to an empty string to disable collection." env:"DCRDATA_DEX_DATA_URL"`
+	DEXHistoryURL     string `long:"dex-history-url" description:"CSV file of the daily USD volume of the dcrdex markets, used for the days before the server's market data. Set to an empty string to only use the server's data." env:"DCRDATA_DEX_HISTORY_URL"`
 	// Links
 	MainnetLink    string `long:"mainnet-link" description:"When dcrdata is on testnet, this address will be used to direct a user to a dcrdata on mainnet when appropriate." env:"DCRDATA_MAINNET_LINK"`
 	TestnetLink    string `long:"testnet-link" description:"When dcrdata is on mainnet, this address will be used to direct a user to a dcrdata on testnet when appropriate." env:"DCRDATA_TESTNET_LINK"`
@@ -273,6 +282,8 @@ var (
 		TestnetLink:         defaultTestnetLink,
 		OnionAddress:        defaultOnionAddress,
 		BinanceAPI:          defaultBinanceAPI,
+		DEXDataURL:          defaultDEXDataURL,
+		DEXHistoryURL:       defaultDEXHistoryURL,
 		CoincapActive:       defaultCoinCaps,
 	}
 )
@@ -767,6 +778,9 @@ func loadConfig() (*config, error) {
 	cfg.AgendasDBFileName = cleanAndExpandPath(cfg.AgendasDBFileName)
 	cfg.ProposalsFileName = cleanAndExpandPath(cfg.ProposalsFileName)
 	cfg.RateCertificate = cleanAndExpandPath(cfg.RateCertificate)
+	if cfg.AlertRulesFile != "" {
+		cfg.AlertRulesFile = cleanAndExpandPath(cfg.AlertRulesFile)
+	}
 	cfg.ChartsCacheDump = cleanAndExpandPath(cfg.ChartsCacheDump)
 	cfg.LTCChartsCacheDump = cleanAndExpandPath(cfg.LTCChartsCacheDump)
 	cfg.BTCChartsCacheDump = cleanAndExpandPath(cfg.BTCChartsCacheDump)
@@ -22,6 +22,7 @@ require (
 	github.com/decred/dcrd/chaincfg/chainhash v1.0.4
 	github.com/decred/dcrd/chaincfg/v3 v3.2.0
 	github.com/decred/dcrd/dcrutil/v4 v4.0.1
+	github.com/decred/dcrd/hdkeychain/v3 v3.1.0
 	github.com/decred/dcrd/rpc/jsonrpc/types/v4 v4.1.0
 	github.com/decred/dcrd/rpcclient/v8 v8.0.0
 	github.com/decred/dcrd/txscript/v4 v4.1.0
@@ -39,6 +40,7 @@ require (
 	github.com/google/gops v0.3.27
 	github.com/googollee/go-socket.io v1.4.4
 	github.com/gorilla/websocket v1.5.0
+	github.com/graph-gophers/graphql-go v1.7.0
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/jrick/logrotate v1.0.0
 	github.com/ltcsuite/ltcd v0.23.5
@@ -109,7 +111,6 @@ require (
 	github.com/decred/dcrd/gcs/v2 v2.1.0 // indirect
 	github.com/decred/dcrd/gcs/v3 v3.0.0 // indirect
 	github.com/decred/dcrd/gcs/v4 v4.0.0 // indirect
-	github.com/decred/dcrd/hdkeychain/v3 v3.1.0 // indirect
 	github.com/decred/dcrd/lru v1.1.1 // indirect
 	github.com/decred/dcrd/rpc/jsonrpc/types/v3 v3.0.0 // indirect
 	github.com/decred/dcrd/rpcclient/v7 v7.0.0 // indirect
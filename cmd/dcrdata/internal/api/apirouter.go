@@ -140,6 +140,8 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 			rd.With(m.BlockIndex0PathCtx, m.BlockIndexPathCtx).Get("/r/{idx0}/{idx}", app.getStakeDiffRange)
 		})
 		r.Get("/powerless", app.getPowerlessTickets)
+		r.Get("/xpub/{xpub}", app.getXpubTicketPortfolio)
+		r.Get("/simulate", app.simulateStaking)
 	})
 
 	mux.Route("/tx", func(r chi.Router) {
@@ -166,6 +168,9 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 		r.With(m.MultichainTxHashCtx).Get("/decoded/{chaintype}/{txid}", app.getMultichainDecodedTx)
 		r.With(m.TransactionHashCtx).Get("/swaps/{txid}", app.getTxSwapsInfo)
 		r.With(m.MultichainTxHashCtx).Get("/swaps/{chaintype}/{txid}", app.getMultichainTxSwapsInfo)
+		r.With(m.MultichainTxHashCtx).Get("/replacements/{chaintype}/{txid}", app.getMultichainTxReplacements)
+		r.With(m.MultichainTxHashCtx).Get("/fate/{chaintype}/{txid}", app.getMultichainTxFate)
+		r.With(m.MultichainTxHashCtx).Get("/eta/{chaintype}/{txid}", app.getMultichainTxConfirmationEstimate)
 	})
 
 	mux.Route("/txs", func(r chi.Router) {
@@ -224,8 +229,16 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 		r.Route("/votechart", func(rd chi.Router) {
 			rd.With(m.TSpendVotesIdCtx).Get("/{txhash}", app.getTSpendVoteChartData)
 		})
+		r.Route("/tspends", func(rd chi.Router) {
+			rd.Get("/", app.getTSpendLifecycles)
+			rd.With(m.TSpendVotesIdCtx).Get("/{txhash}", app.getTSpendLifecycle)
+		})
 	})
 
+	// Governance and consensus events with estimated times.
+	mux.Get("/calendar", app.getCalendar)
+	mux.Get("/calendar.ics", app.getCalendarICS)
+
 	// Returns agenda data like; description, name, lockedin activated and other
 	// high level agenda details for all agendas.
 	mux.Route("/agendas", func(r chi.Router) {
@@ -235,6 +248,7 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 	// Returns the charts data for the respective individual agendas.
 	mux.Route("/agenda", func(r chi.Router) {
 		r.With(m.AgendaIdCtx).Get("/{agendaId}", app.getAgendaData)
+		r.With(m.AgendaIdCtx).Get("/{agendaId}/forecast", app.getAgendaForecast)
 	})
 
 	mux.Route("/mempool", func(r chi.Router) {
@@ -247,6 +261,12 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 			rd.Get("/details", app.getSSTxDetails)
 			rd.With(m.NPathCtx).Get("/details/{N}", app.getSSTxDetails)
 		})
+		// projected blocks and fee rate histogram of the btc and ltc mempools
+		r.Get("/{chaintype}/projected", app.getMutilchainMempoolProjection)
+		// recent replace-by-fee and double spend replacements
+		r.Get("/{chaintype}/replacements", app.getMutilchainMempoolReplacements)
+		// recent double spends, from the ledger of the dcr, btc and ltc mempools
+		r.Get("/{chaintype}/doublespends", app.getMempoolDoubleSpends)
 	})
 
 	mux.Route("/chart", func(r chi.Router) {
@@ -255,6 +275,7 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 			rd.Use(m.ExchangeTokenContext)
 			rd.With(m.StickWidthContext).Get("/candlestick/{bin}", app.getCandlestickChart)
 			rd.Get("/depth", app.getDepthChart)
+			rd.Get("/liquidity", app.getLiquidityChart)
 		})
 		r.Route("/submarket/{token}", func(rd chi.Router) {
 			rd.Use(m.ExchangeTokenContext)
@@ -263,11 +284,18 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 		r.With(m.ChartTypeCtx).Get("/{charttype}", app.ChartTypeData)
 	})
 
+	mux.Route("/chain/{chaintype}", func(r chi.Router) {
+		// fee rate estimates from recent blocks and the mempool
+		r.Get("/fees", app.getChainFeeEstimates)
+		r.Get("/fees/history", app.getChainFeeEstimateHistory)
+	})
+
 	mux.Route("/chainchart", func(r chi.Router) {
 		r.Route("/{chaintype}/market/{token}", func(rd chi.Router) {
 			rd.Use(m.ExchangeTokenContext)
 			rd.With(m.StickWidthContext).Get("/candlestick/{bin}", app.getMutilchainCandlestickChart)
 			rd.Get("/depth", app.getMutilchainDepthChart)
+			rd.Get("/liquidity", app.getMutilchainLiquidityChart)
 		})
 		r.Route("/{chaintype}/submarket/{token}", func(rd chi.Router) {
 			rd.Use(m.ExchangeTokenContext)
@@ -286,12 +314,16 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 		r.Get("/treasury", app.getTreasuryReport)
 		r.Get("/detail", app.getReportDetail)
 		r.Get("/time-range", app.getReportTimeRange)
+		r.Get("/reconciliation", app.getTreasuryReconciliation)
+		r.Get("/reconciliation.csv", app.getTreasuryReconciliationCSV)
 	})
 
 	mux.Route("/ticketpool", func(r chi.Router) {
 		r.Get("/", app.getTicketPoolByDate)
 		r.With(m.TicketPoolCtx).Get("/bydate/{tp}", app.getTicketPoolByDate)
 		r.Get("/charts", app.getTicketPoolCharts)
+		r.Get("/vote-delays", app.getTicketVoteDelays)
+		r.Get("/calculator", app.getTicketVoteCalculation)
 	})
 
 	mux.Route("/proposal", func(r chi.Router) {
@@ -305,6 +337,45 @@ func NewAPIRouter(app *appContext, JSONIndent string, useRealIP, compressLarge b
 	mux.Route("/exchanges", func(r chi.Router) {
 		r.Get("/", app.getExchanges)
 		r.Get("/codes", app.getCurrencyCodes)
+		r.Get("/indices", app.getIndexStatuses)
+		r.Get("/{chaintype}/arbitrage", app.getArbitrage)
+		r.Route("/alerts", func(rd chi.Router) {
+			rd.Get("/", app.getAlertRules)
+			rd.Get("/deliveries", app.getAlertDeliveries)
+		})
+	})
+
+	mux.Get("/events", app.getEvents)
+
+	mux.Get("/graphql", app.graphQL)
+	mux.Post("/graphql", app.graphQL)
+
+	mux.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", app.getWebhooks)
+		r.With(limitNewWebhookTokens(useRealIP)).Post("/", app.postWebhook)
+		r.Route("/{id}", func(rd chi.Router) {
+			rd.Get("/", app.getWebhook)
+			rd.Delete("/", app.deleteWebhook)
+			rd.Get("/deliveries", app.getWebhookDeliveries)
+		})
+	})
+
+	mux.Route("/attack-cost", func(r chi.Router) {
+		r.Get("/{chaintype}", app.getMutilchainAttackCost)
+	})
+
+	mux.Route("/bwdash", func(r chi.Router) {
+		r.Get("/", app.getBwDashMarketDays)
+	})
+
+	mux.Route("/vsps", func(r chi.Router) {
+		r.Get("/", app.getVSPs)
+		r.Get("/{vsp}/history", app.getVSPHistory)
+	})
+
+	mux.Route("/mixes", func(r chi.Router) {
+		r.Get("/", app.getMixes)
+		r.Get("/daily", app.getMixDays)
 	})
 
 	mux.Route("/broadcast", func(r chi.Router) {
@@ -381,6 +452,36 @@ func (lw loggerFunc) Printf(str string, args ...interface{}) {
 }
 
 // Stacks some middleware common to both file and api router.
+// newWebhookTokensPerSec is the rate, per client IP, of the webhook
+// registrations without a token, each of which makes a new token.
+const newWebhookTokensPerSec = 1.0 / 60
+
+// limitNewWebhookTokens creates a middleware that rate limits the requests
+// without a webhook management token by client IP. The hooks of a token are
+// limited by the webhooks Dispatcher.
+func limitNewWebhookTokens(useRealIP bool) func(http.Handler) http.Handler {
+	limiter := m.NewLimiter(newWebhookTokensPerSec)
+	limiter.SetMessage("You have reached the limit of new webhook tokens. " +
+		"Register more webhooks with your token.")
+	if useRealIP {
+		// RealIP sets RemoteAddr
+		limiter.SetIPLookups([]string{"RemoteAddr"})
+	} else {
+		limiter.SetIPLookups([]string{"X-Forwarded-For", "X-Real-IP", "RemoteAddr"})
+	}
+	limit := m.Tollbooth(limiter)
+	return func(next http.Handler) http.Handler {
+		limited := limit(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if webhookToken(r) == "" {
+				limited.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func stackedMux(useRealIP bool) *chi.Mux {
 	mux := chi.NewRouter()
 	if useRealIP {
@@ -16,6 +16,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"reflect"
 	"slices"
 	"sort"
@@ -24,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcjson"
 	btcClient "github.com/btcsuite/btcd/rpcclient"
 	"github.com/decred/dcrd/blockchain/standalone/v2"
 	"github.com/decred/dcrd/chaincfg/chainhash"
@@ -40,14 +42,18 @@ import (
 	apitypes "github.com/decred/dcrdata/v8/api/types"
 	"github.com/decred/dcrdata/v8/db/cache"
 	"github.com/decred/dcrdata/v8/db/dbtypes"
+	exptypes "github.com/decred/dcrdata/v8/explorer/types"
 	"github.com/decred/dcrdata/v8/mutilchain"
 	"github.com/decred/dcrdata/v8/mutilchain/externalapi"
 	"github.com/decred/dcrdata/v8/txhelpers"
+	"github.com/decred/dcrdata/v8/webhooks"
 	"github.com/go-chi/chi/v5"
+	ltcjson "github.com/ltcsuite/ltcd/btcjson"
 	ltcClient "github.com/ltcsuite/ltcd/rpcclient"
 	agents "github.com/monperrus/crawler-user-agents"
 	"github.com/x-way/crawlerdetect"
 
+	"github.com/decred/dcrdata/cmd/dcrdata/internal/calendar"
 	m "github.com/decred/dcrdata/cmd/dcrdata/internal/middleware"
 )
 
@@ -124,6 +130,14 @@ type DataSource interface {
 	GetMempoolSSTxSummary() *apitypes.MempoolTicketFeeInfo
 	GetMempoolSSTxFeeRates(N int) *apitypes.MempoolTicketFees
 	GetMempoolSSTxDetails(N int) *apitypes.MempoolTicketDetails
+	GetMutilchainMempoolProjection(chainType string) *exptypes.MempoolProjection
+	GetMutilchainTxReplacements(chainType, txid string) *exptypes.TxReplacementInfo
+	GetMutilchainRecentReplacements(chainType string, n int) []*txhelpers.TxReplacement
+	GetTxFate(chainType, txid string) (*txhelpers.LedgerEntry, error)
+	GetTxConfirmationEstimate(chainType, txid string) (*txhelpers.ConfirmationEstimate, error)
+	GetRecentDoubleSpends(chainType string, n int) []*txhelpers.DoubleSpend
+	GetFeeEstimates(chainType string) (*txhelpers.FeeEstimates, error)
+	GetFeeEstimateHistory(chainType string, limit int) ([]*dbtypes.FeeEstimateStat, error)
 	GetAddressTransactionsRawWithSkip(addr string, count, skip int) []*apitypes.AddressTxRaw
 	GetMempoolPriceCountTime() *apitypes.PriceCountTime
 	GetAllProposalMeta(searchKey string) (list []map[string]string, err error)
@@ -155,6 +169,16 @@ type DataSource interface {
 	GetMultichainSwapInfoData(txid, chainType string) (swapsInfo *txhelpers.TxAtomicSwaps, err error)
 	InsertToBlackList(agent, ip, note string) error
 	CheckOnBlackList(agent, ip string) (bool, error)
+	GetLiquiditySnapshots(chainType, token string, from, to int64) ([]*dbtypes.LiquiditySnapshot, error)
+	GetDEXMarketDays(from, to int64) ([]*dbtypes.DEXMarketDay, error)
+	GetVSPs() ([]*dbtypes.VSPStatus, error)
+	GetVSPSnapshots(vsp string, from, to int64) ([]*dbtypes.VSPSnapshot, error)
+	GetMixes(from, to int64, limit int) ([]*dbtypes.MixTx, error)
+	GetMixDays(from, to int64) ([]*dbtypes.MixDay, error)
+	GetTSpendLifecycles(limit int) ([]*dbtypes.TSpendLifecycle, error)
+	GetTSpendLifecycle(txid string) (*dbtypes.TSpendLifecycle, error)
+	GetTicketPortfolio(addresses []string) (*dbtypes.TicketPortfolio, error)
+	GetVoteDelays(from, to int64, blocks int, bucketBlocks int64) (*dbtypes.VoteDelays, error)
 	MoneroDecodeOutputs(txid, address, viewkey string) ([]externalapi.TxOutput, error)
 	MoneroProveOutputs(txid, address, txkey string) ([]externalapi.TxOutput, error)
 }
@@ -168,7 +192,13 @@ type appContext struct {
 	DataSource       DataSource
 	Status           *apitypes.Status
 	xcBot            *exchanges.ExchangeBot
+	alerts           *exchanges.AlertEngine
+	webhooks         *webhooks.Dispatcher
+	eventStream      http.HandlerFunc
+	graphQLHandler   http.Handler
 	AgendaDB         *agendas.AgendaDB
+	VoteTracker      *agendas.VoteTracker
+	Calendar         *calendar.Calendar
 	ProposalsDB      *politeia.ProposalsDB
 	maxCSVAddrs      int
 	charts           *cache.ChartData
@@ -178,6 +208,8 @@ type appContext struct {
 	ChainDisabledMap map[string]bool
 	CoinCaps         []string
 	CoinCapDataList  []*dbtypes.MarketCapData
+	// MutilchainHashRate returns the network hash rate in H/s of a PoW chain.
+	MutilchainHashRate func(chainType string) float64
 }
 
 // AppContextConfig is the configuration for the appContext and the only
@@ -189,7 +221,13 @@ type AppContextConfig struct {
 	Params            *chaincfg.Params
 	DataSource        DataSource
 	XcBot             *exchanges.ExchangeBot
+	AlertEngine       *exchanges.AlertEngine
+	Webhooks          *webhooks.Dispatcher
+	EventStream       http.HandlerFunc
+	GraphQL           http.Handler
 	AgendasDBInstance *agendas.AgendaDB
+	Tracker           *agendas.VoteTracker
+	Calendar          *calendar.Calendar
 	ProposalsDB       *politeia.ProposalsDB
 	MaxAddrs          int
 	Charts            *cache.ChartData
@@ -230,7 +268,13 @@ func NewContext(cfg *AppContextConfig) *appContext {
 		Params:           cfg.Params,
 		DataSource:       cfg.DataSource,
 		xcBot:            cfg.XcBot,
+		alerts:           cfg.AlertEngine,
+		webhooks:         cfg.Webhooks,
+		eventStream:      cfg.EventStream,
+		graphQLHandler:   cfg.GraphQL,
 		AgendaDB:         cfg.AgendasDBInstance,
+		VoteTracker:      cfg.Tracker,
+		Calendar:         cfg.Calendar,
 		ProposalsDB:      cfg.ProposalsDB,
 		Status:           apitypes.NewStatus(uint32(nodeHeight), conns, APIVersion, cfg.AppVer, cfg.Params.Name),
 		maxCSVAddrs:      cfg.MaxAddrs,
@@ -729,6 +773,13 @@ func (c *appContext) getTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if tx.Confirmations == 0 {
+		tx.ConfirmationEstimate, err = c.DataSource.GetTxConfirmationEstimate(mutilchain.TYPEDCR, txid.String())
+		if err != nil {
+			apiLog.Warnf("GetTxConfirmationEstimate(%s) error: %v", txid, err)
+		}
+	}
+
 	if withSpends {
 		if err := c.setTxSpends(tx); err != nil {
 			errStr := html.EscapeString(err.Error())
@@ -783,6 +834,55 @@ func (c *appContext) getMultichainTxSwapsInfo(w http.ResponseWriter, r *http.Req
 	writeJSON(w, swapsInfo, m.GetIndentCtx(r))
 }
 
+// getMultichainTxReplacements serves the replacement history and the CPFP
+// package of a BTC or LTC transaction, including one replaced before it was
+// mined.
+func (c *appContext) getMultichainTxReplacements(w http.ResponseWriter, r *http.Request) {
+	chainType, txid := m.GetMultichainTxID(r)
+	if chainType == "" || txid == "" {
+		http.Error(w, http.StatusText(422), 422)
+		return
+	}
+	if chainType != mutilchain.TYPEBTC && chainType != mutilchain.TYPELTC {
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return
+	}
+	info := c.DataSource.GetMutilchainTxReplacements(chainType, txid)
+	if info == nil {
+		apiLog.Errorf("No %s mempool replacement tracking available", chainType)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, info, m.GetIndentCtx(r))
+}
+
+// getMultichainTxFate serves the first-seen and last-seen times of a DCR, BTC
+// or LTC transaction seen in mempool, and its fate once it left mempool.
+func (c *appContext) getMultichainTxFate(w http.ResponseWriter, r *http.Request) {
+	chainType, txid := m.GetMultichainTxID(r)
+	if chainType == "" || txid == "" {
+		http.Error(w, http.StatusText(422), 422)
+		return
+	}
+	switch chainType {
+	case mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPELTC:
+	default:
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return
+	}
+	entry, err := c.DataSource.GetTxFate(chainType, txid)
+	if err != nil {
+		apiLog.Errorf("GetTxFate(%s, %s) error: %v", chainType, txid, err)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	if entry == nil {
+		http.Error(w, "transaction not seen in mempool", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, entry, m.GetIndentCtx(r))
+}
+
 func (c *appContext) getMultichainDecodedTx(w http.ResponseWriter, r *http.Request) {
 	chainType, txid := m.GetMultichainTxID(r)
 	if chainType == "" || txid == "" {
@@ -795,9 +895,64 @@ func (c *appContext) getMultichainDecodedTx(w http.ResponseWriter, r *http.Reque
 		http.Error(w, http.StatusText(422), 422)
 		return
 	}
+	// An unconfirmed BTC or LTC transaction is served with its confirmation
+	// estimate.
+	switch rawTx := tx.(type) {
+	case *btcjson.TxRawResult:
+		tx = &apitypes.BTCTx{
+			TxRawResult:          rawTx,
+			ConfirmationEstimate: c.mempoolTxConfirmationEstimate(chainType, txid, rawTx.Confirmations),
+		}
+	case *ltcjson.TxRawResult:
+		tx = &apitypes.LTCTx{
+			TxRawResult:          rawTx,
+			ConfirmationEstimate: c.mempoolTxConfirmationEstimate(chainType, txid, rawTx.Confirmations),
+		}
+	}
 	writeJSON(w, tx, m.GetIndentCtx(r))
 }
 
+// mempoolTxConfirmationEstimate returns the confirmation estimate of a
+// transaction without confirmations, or nil if it has no estimate.
+func (c *appContext) mempoolTxConfirmationEstimate(chainType, txid string, confirmations uint64) *txhelpers.ConfirmationEstimate {
+	if confirmations > 0 {
+		return nil
+	}
+	est, err := c.DataSource.GetTxConfirmationEstimate(chainType, txid)
+	if err != nil {
+		apiLog.Warnf("GetTxConfirmationEstimate(%s, %s) error: %v", chainType, txid, err)
+		return nil
+	}
+	return est
+}
+
+// getMultichainTxConfirmationEstimate serves the confirmation estimate of a
+// transaction in the DCR, BTC or LTC mempool.
+func (c *appContext) getMultichainTxConfirmationEstimate(w http.ResponseWriter, r *http.Request) {
+	chainType, txid := m.GetMultichainTxID(r)
+	if chainType == "" || txid == "" {
+		http.Error(w, http.StatusText(422), 422)
+		return
+	}
+	switch chainType {
+	case mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPELTC:
+	default:
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return
+	}
+	est, err := c.DataSource.GetTxConfirmationEstimate(chainType, txid)
+	if err != nil {
+		apiLog.Errorf("GetTxConfirmationEstimate(%s, %s) error: %v", chainType, txid, err)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	if est == nil {
+		http.Error(w, "transaction not in mempool", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, est, m.GetIndentCtx(r))
+}
+
 func (c *appContext) getProposalTimeMinMax() (int64, int64, error) {
 	//Get All Proposal Metadata for Report
 	proposalMetaList, err := c.DataSource.GetAllProposalMeta("")
@@ -1198,6 +1353,125 @@ func (c *appContext) getProposalReport(w http.ResponseWriter, r *http.Request) {
 	}, m.GetIndentCtx(r))
 }
 
+// treasuryReconciliation reconciles the approved proposal budgets with the
+// monthly treasury spend and legacy treasury payouts, using the lag and
+// tolerance query parameters. The lag is the number of months between the work
+// and its payout, 1 by default, and the tolerance is in percent, 5 by default.
+func (c *appContext) treasuryReconciliation(r *http.Request) (*apitypes.TreasuryReconciliation, int, error) {
+	lag, tolerance := 1, 5.0
+	var err error
+	if lagParam := r.URL.Query().Get("lag"); lagParam != "" {
+		lag, err = strconv.Atoi(lagParam)
+		if err != nil || lag < 0 || lag > 12 {
+			return nil, http.StatusBadRequest, errors.New("lag must be between 0 and 12 months")
+		}
+	}
+	if toleranceParam := r.URL.Query().Get("tolerance"); toleranceParam != "" {
+		tolerance, err = strconv.ParseFloat(toleranceParam, 64)
+		if err != nil || tolerance < 0 || tolerance > 100 {
+			return nil, http.StatusBadRequest, errors.New("tolerance must be between 0 and 100 percent")
+		}
+	}
+
+	proposalMetaList, err := c.DataSource.GetAllProposalMeta("")
+	if err != nil {
+		apiLog.Errorf("GetAllProposalMeta: %v", err)
+		return nil, http.StatusInternalServerError, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	proposals := make([]*budgetProposal, 0, len(proposalMetaList))
+	for _, meta := range proposalMetaList {
+		startInt, err := strconv.ParseInt(meta["StartDate"], 0, 64)
+		endInt, err2 := strconv.ParseInt(meta["EndDate"], 0, 64)
+		amount, err3 := strconv.ParseFloat(meta["Amount"], 64)
+		if amount == 0 || startInt == 0 || endInt == 0 || err != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		proposals = append(proposals, &budgetProposal{
+			token:  meta["Token"],
+			name:   meta["Name"],
+			owner:  meta["Username"],
+			domain: meta["Domain"],
+			// The amounts are in US cents.
+			budget: amount / 100,
+			start:  time.Unix(startInt, 0),
+			end:    time.Unix(endInt, 0),
+		})
+	}
+
+	treasurySummary, err := c.DataSource.GetTreasurySummary()
+	legacySummary, legacyErr := c.DataSource.GetLegacySummary()
+	if err != nil || legacyErr != nil {
+		apiLog.Errorf("Get treasury/legacy summary data failed: %v, %v", err, legacyErr)
+		return nil, http.StatusInternalServerError, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	payouts := make(map[int]*monthPayout)
+	for _, summary := range treasurySummary {
+		payouts[monthIndex(summary.MonthTime)] = &monthPayout{
+			tspend: summary.Outvalue,
+			price:  summary.MonthPrice,
+		}
+	}
+	for _, summary := range legacySummary {
+		idx := monthIndex(summary.MonthTime)
+		payout, ok := payouts[idx]
+		if !ok {
+			payout = &monthPayout{price: summary.MonthPrice}
+			payouts[idx] = payout
+		}
+		// The legacy address spends include its transfers to the treasury.
+		legacy := summary.Outvalue
+		for _, ts := range treasurySummary {
+			if ts.Month == summary.Month {
+				legacy -= ts.TaddValue
+				break
+			}
+		}
+		if legacy > 0 {
+			payout.legacy = legacy
+		}
+	}
+
+	return reconcileTreasury(proposals, payouts, lag, tolerance/100, time.Now()), http.StatusOK, nil
+}
+
+// getTreasuryReconciliation serves the reconciliation of the approved proposal
+// budgets with the treasury payouts as JSON.
+func (c *appContext) getTreasuryReconciliation(w http.ResponseWriter, r *http.Request) {
+	rec, status, err := c.treasuryReconciliation(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, rec, m.GetIndentCtx(r))
+}
+
+// getTreasuryReconciliationCSV serves one view of the reconciliation of the
+// approved proposal budgets with the treasury payouts as a CSV file. The view
+// query parameter is proposals, domains, owners or months.
+func (c *appContext) getTreasuryReconciliationCSV(w http.ResponseWriter, r *http.Request) {
+	view := r.URL.Query().Get("view")
+	switch view {
+	case "":
+		view = "proposals"
+	case "proposals", "domains", "owners", "months":
+	default:
+		http.Error(w, "view must be proposals, domains, owners or months", http.StatusBadRequest)
+		return
+	}
+	rec, status, err := c.treasuryReconciliation(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	filename := fmt.Sprintf("treasury-reconciliation-%s-%d.csv", view, time.Now().Unix())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", filename))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err = writeReconciliationCSV(w, rec, view); err != nil {
+		apiLog.Errorf("writeReconciliationCSV: %v", err) // too late to write an error code
+	}
+}
+
 func getTimeCompare(timeStr string) int64 {
 	timeArr := strings.Split(timeStr, "-")
 	if len(timeArr) < 2 {
@@ -2645,6 +2919,210 @@ func (c *appContext) getPowerlessTickets(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, tickets, m.GetIndentCtx(r))
 }
 
+// Default and maximum number of addresses derived on each branch of an
+// extended public key by getXpubTicketPortfolio.
+const (
+	defaultXpubAddresses = 200
+	maxXpubAddresses     = 1000
+)
+
+// getXpubTicketPortfolio serves the ticket portfolio of the account extended
+// public key in the URL path. The tickets are found by the first count
+// addresses of the key's external and internal branches.
+func (c *appContext) getXpubTicketPortfolio(w http.ResponseWriter, r *http.Request) {
+	count := uint32(defaultXpubAddresses)
+	if countParam := r.URL.Query().Get("count"); countParam != "" {
+		n, err := strconv.ParseUint(countParam, 10, 32)
+		if err != nil || n == 0 || n > maxXpubAddresses {
+			http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxXpubAddresses),
+				http.StatusBadRequest)
+			return
+		}
+		count = uint32(n)
+	}
+	addrs, err := xpubAddresses(chi.URLParam(r, "xpub"), count, c.Params)
+	if err != nil {
+		http.Error(w, "invalid extended public key: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	portfolio, err := c.DataSource.GetTicketPortfolio(addrs)
+	if dbtypes.IsTimeoutErr(err) {
+		apiLog.Errorf("GetTicketPortfolio timeout error: %v", err)
+		http.Error(w, "Database timeout.", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		apiLog.Errorf("GetTicketPortfolio: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, portfolio, m.GetIndentCtx(r))
+}
+
+// subsidySplitVariant is the subsidy split in effect at the height according
+// to the current status of the subsidy split agendas.
+func (c *appContext) subsidySplitVariant(height int64) standalone.SubsidySplitVariant {
+	switch {
+	case c.DataSource.IsDCP0012Active(height):
+		return standalone.SSVDCP0012
+	case c.DataSource.IsDCP0010Active(height):
+		return standalone.SSVDCP0010
+	}
+	return standalone.SSVOriginal
+}
+
+// floatQueryParam parses the named query parameter as a number from min to
+// max, returning def if the parameter is not set.
+func floatQueryParam(query url.Values, name string, def, min, max float64) (float64, error) {
+	param := query.Get(name)
+	if param == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || f < min || f > max {
+		return 0, fmt.Errorf("%s must be a number between %v and %v", name, min, max)
+	}
+	return f, nil
+}
+
+// maxStakeSimDays is the longest staking simulation served by simulateStaking.
+const maxStakeSimDays = 10 * 365
+
+// simulateStaking serves a projection of the staking returns of a balance from
+// the current ticket price and coin supply. The query parameters and their
+// defaults are balance (1000 DCR), days (365), compounding (all or principal),
+// integer (false, for whole tickets only), vsp_fee (0 percent of the vote
+// reward), participation (the current percent of the supply staked) and
+// price_change (0 percent per year).
+func (c *appContext) simulateStaking(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	cfg := &txhelpers.StakeSimConfig{
+		Params:      c.Params,
+		Compounding: txhelpers.CompoundAll,
+		SSV:         c.subsidySplitVariant,
+	}
+	var participation, priceChange float64
+	for _, p := range []struct {
+		dst           *float64
+		name          string
+		def, min, max float64
+	}{
+		{&cfg.Balance, "balance", 1000, 0, 1e9},
+		{&cfg.Days, "days", 365, 1, maxStakeSimDays},
+		{&cfg.VSPFee, "vsp_fee", 0, 0, 100},
+		{&participation, "participation", 0, 0, 100},
+		{&priceChange, "price_change", 0, -99, 1000},
+	} {
+		f, err := floatQueryParam(query, p.name, p.def, p.min, p.max)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*p.dst = f
+	}
+	cfg.Participation, cfg.PriceChange = participation/100, priceChange/100
+	switch compounding := query.Get("compounding"); compounding {
+	case "", txhelpers.CompoundAll:
+	case txhelpers.CompoundPrincipal:
+		cfg.Compounding = compounding
+	default:
+		http.Error(w, "compounding must be all or principal", http.StatusBadRequest)
+		return
+	}
+	if integer := query.Get("integer"); integer != "" {
+		var err error
+		if cfg.IntegerTickets, err = strconv.ParseBool(integer); err != nil {
+			http.Error(w, "integer must be a boolean", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cfg.Height = c.DataSource.Height()
+	supply := c.DataSource.CurrentCoinSupply()
+	stakeDiff := c.DataSource.GetStakeDiffEstimates()
+	poolInfo := c.DataSource.GetPoolInfo(int(cfg.Height))
+	if supply == nil || supply.Mined <= 0 || stakeDiff == nil || poolInfo == nil {
+		apiLog.Errorf("Unable to get the coin supply, ticket price or ticket pool info")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	cfg.CoinSupply = dcrutil.Amount(supply.Mined).ToCoin()
+	cfg.TicketPrice = stakeDiff.CurrentStakeDifficulty
+	cfg.StakedFraction = poolInfo.Value / cfg.CoinSupply
+
+	writeJSON(w, struct {
+		Height         int64   `json:"height"`
+		CoinSupply     float64 `json:"coin_supply"`
+		TicketPrice    float64 `json:"ticket_price"`
+		StakedFraction float64 `json:"staked_fraction"`
+		*txhelpers.StakeSimulation
+	}{cfg.Height, cfg.CoinSupply, cfg.TicketPrice, cfg.StakedFraction,
+		txhelpers.SimulateStaking(cfg)}, m.GetIndentCtx(r))
+}
+
+const (
+	// maxAttackHashRate is the highest network hash rate in H/s accepted by
+	// getMutilchainAttackCost, a thousand times the BTC hash rate in 2026.
+	maxAttackHashRate = 1e24
+	// minAttackDeviceHashRate is the lowest device hash rate, in the hash unit
+	// of the chain, accepted by getMutilchainAttackCost.
+	minAttackDeviceHashRate = 1e-3
+)
+
+// getMutilchainAttackCost estimates the cost of a 51% attack on a PoW chain
+// from its hash rate and price, with the hardware, electricity and rental
+// market assumptions overridable by the query parameters. Assumptions that
+// cannot give a finite estimate are a 422.
+func (c *appContext) getMutilchainAttackCost(w http.ResponseWriter, r *http.Request) {
+	chainType := chi.URLParam(r, "chaintype")
+	cfg := mutilchain.DefaultAttackCostConfig(chainType)
+	if cfg == nil || c.ChainDisabledMap[chainType] {
+		http.Error(w, "unsupported chain", http.StatusBadRequest)
+		return
+	}
+	if c.MutilchainHashRate != nil {
+		cfg.HashRate = c.MutilchainHashRate(chainType)
+	}
+	if c.xcBot != nil {
+		cfg.Price = c.xcBot.State().GetMutilchainPrice(chainType)
+	}
+
+	query := r.URL.Query()
+	for _, p := range []struct {
+		dst      *float64
+		name     string
+		min, max float64
+	}{
+		{&cfg.HashRate, "hashrate", 0, maxAttackHashRate},
+		{&cfg.Price, "price", 0, 1e9},
+		{&cfg.AttackPercent, "attack_percent", 1, 99},
+		{&cfg.DeviceHashRate, "device_hashrate", minAttackDeviceHashRate, 1e9},
+		{&cfg.DevicePower, "device_power", 0, 1e6},
+		{&cfg.DeviceCost, "device_cost", 0, 1e7},
+		{&cfg.ElectricityCost, "electricity_cost", 0, 100},
+		{&cfg.OtherCosts, "other_costs", 0, 1000},
+		{&cfg.RentalPrice, "rental_price", 0, 1e6},
+		{&cfg.RentalAvailable, "rental_available", 0, 100},
+	} {
+		f, err := floatQueryParam(query, p.name, *p.dst, p.min, p.max)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*p.dst = f
+	}
+	if device := query.Get("device"); device != "" {
+		cfg.Device = device
+	}
+
+	est, err := mutilchain.EstimateAttackCost(cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	writeJSON(w, est, m.GetIndentCtx(r))
+}
+
 func (c *appContext) getStakeDiffCurrent(w http.ResponseWriter, r *http.Request) {
 	stakeDiff := c.DataSource.GetStakeDiffEstimates()
 	if stakeDiff == nil {
@@ -2707,6 +3185,157 @@ func (c *appContext) getSSTxDetails(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, sstxDetails, m.GetIndentCtx(r))
 }
 
+// getMutilchainMempoolProjection serves the projected next blocks and the fee
+// rate histogram of the BTC or LTC mempool.
+func (c *appContext) getMutilchainMempoolProjection(w http.ResponseWriter, r *http.Request) {
+	chainType := chi.URLParam(r, "chaintype")
+	if chainType != mutilchain.TYPEBTC && chainType != mutilchain.TYPELTC {
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return
+	}
+	projection := c.DataSource.GetMutilchainMempoolProjection(chainType)
+	if projection == nil {
+		apiLog.Errorf("No %s mempool projection available", chainType)
+		http.Error(w, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
+		return
+	}
+
+	writeJSON(w, projection, m.GetIndentCtx(r))
+}
+
+// maxFeeEstimateHistory is the maximum number of stored fee estimates served
+// by getChainFeeEstimateHistory.
+const maxFeeEstimateHistory = 1000
+
+// mempoolChain reads the chain type of a fee estimate or mempool ledger
+// request, and writes an error response unless the chain is an enabled DCR,
+// BTC or LTC chain.
+func (c *appContext) mempoolChain(w http.ResponseWriter, r *http.Request) (string, bool) {
+	chainType := chi.URLParam(r, "chaintype")
+	switch chainType {
+	case mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPELTC:
+	default:
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return "", false
+	}
+	if c.ChainDisabledMap[chainType] {
+		http.Error(w, "chain disabled", http.StatusBadRequest)
+		return "", false
+	}
+	return chainType, true
+}
+
+// getChainFeeEstimates serves the low, medium and high priority fee rate
+// estimates of a chain from its recent blocks and mempool.
+func (c *appContext) getChainFeeEstimates(w http.ResponseWriter, r *http.Request) {
+	chainType, ok := c.mempoolChain(w, r)
+	if !ok {
+		return
+	}
+	estimates, err := c.DataSource.GetFeeEstimates(chainType)
+	if err != nil {
+		apiLog.Errorf("GetFeeEstimates(%s) error: %v", chainType, err)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, estimates, m.GetIndentCtx(r))
+}
+
+// getChainFeeEstimateHistory serves the stored fee estimates of a chain, most
+// recent first. The limit query parameter sets the number of estimates.
+func (c *appContext) getChainFeeEstimateHistory(w http.ResponseWriter, r *http.Request) {
+	chainType, ok := c.mempoolChain(w, r)
+	if !ok {
+		return
+	}
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxFeeEstimateHistory {
+			limit = maxFeeEstimateHistory
+		}
+	}
+	history, err := c.DataSource.GetFeeEstimateHistory(chainType, limit)
+	if err != nil {
+		apiLog.Errorf("GetFeeEstimateHistory(%s) error: %v", chainType, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, history, m.GetIndentCtx(r))
+}
+
+// maxRecentReplacements is the maximum number of replacements served by
+// getMutilchainMempoolReplacements.
+const maxRecentReplacements = 1000
+
+// getMutilchainMempoolReplacements serves the most recent transaction
+// replacements in the BTC or LTC mempool, most recent first. The limit query
+// parameter sets the number of replacements.
+func (c *appContext) getMutilchainMempoolReplacements(w http.ResponseWriter, r *http.Request) {
+	chainType := chi.URLParam(r, "chaintype")
+	if chainType != mutilchain.TYPEBTC && chainType != mutilchain.TYPELTC {
+		http.Error(w, "unsupported chain type", http.StatusBadRequest)
+		return
+	}
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxRecentReplacements {
+			limit = maxRecentReplacements
+		}
+	}
+	replacements := c.DataSource.GetMutilchainRecentReplacements(chainType, limit)
+	if replacements == nil {
+		apiLog.Errorf("No %s mempool replacement tracking available", chainType)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, replacements, m.GetIndentCtx(r))
+}
+
+// maxRecentDoubleSpends is the maximum number of double spends served by
+// getMempoolDoubleSpends.
+const maxRecentDoubleSpends = 1000
+
+// getMempoolDoubleSpends serves the most recent double spends in the DCR, BTC
+// or LTC mempool, most recent first. The limit query parameter sets the number
+// of double spends.
+func (c *appContext) getMempoolDoubleSpends(w http.ResponseWriter, r *http.Request) {
+	chainType, ok := c.mempoolChain(w, r)
+	if !ok {
+		return
+	}
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxRecentDoubleSpends {
+			limit = maxRecentDoubleSpends
+		}
+	}
+	doubleSpends := c.DataSource.GetRecentDoubleSpends(chainType, limit)
+	if doubleSpends == nil {
+		apiLog.Errorf("No %s mempool ledger available", chainType)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, doubleSpends, m.GetIndentCtx(r))
+}
+
 // getTicketPoolCharts pulls the initial data to populate the /ticketpool page
 // charts.
 func (c *appContext) getTicketPoolCharts(w http.ResponseWriter, r *http.Request) {
@@ -2773,23 +3402,141 @@ func (c *appContext) getTicketPoolByDate(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, tpResponse, m.GetIndentCtx(r))
 }
 
-func (c *appContext) getProposalChartData(w http.ResponseWriter, r *http.Request) {
-	token := m.GetProposalTokenCtx(r)
+const (
+	// defaultVoteDelayBlocks and maxVoteDelayBlocks are the number of recent
+	// blocks with vote delay statistics served by getTicketVoteDelays.
+	defaultVoteDelayBlocks = 100
+	maxVoteDelayBlocks     = 1000
 
-	proposal, err := c.ProposalsDB.ProposalByToken(token)
-	if dbtypes.IsTimeoutErr(err) {
-		apiLog.Errorf("ProposalByToken: %v", err)
-		http.Error(w, "Database timeout.", http.StatusServiceUnavailable)
-		return
+	// maxVoteDelayRange is the longest range of the days served by
+	// getTicketVoteDelays.
+	maxVoteDelayRange = 365 * 24 * time.Hour
+)
+
+// blocksPerDay is the number of blocks in a day at the target block time.
+func (c *appContext) blocksPerDay() int64 {
+	return int64(24 * time.Hour / c.Params.TargetTimePerBlock)
+}
+
+// currentPoolSize is the number of live tickets at the best block.
+func (c *appContext) currentPoolSize() (int64, int64, error) {
+	height := c.DataSource.Height()
+	tpi := c.DataSource.GetPoolInfo(int(height))
+	if tpi == nil || tpi.Size == 0 {
+		return 0, 0, fmt.Errorf("no ticket pool info at height %d", height)
 	}
+	return int64(tpi.Size), height, nil
+}
+
+// getTicketVoteDelays serves the number of blocks from maturity to vote of the
+// tickets that voted between the optional from and to UNIX timestamps, by day
+// and for the most recent blocks, with the distribution expected from the
+// current ticket pool size. The range defaults to the last 30 days, and may
+// not exceed maxVoteDelayRange.
+func (c *appContext) getTicketVoteDelays(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseTimeRange(r, 30*24*time.Hour, maxVoteDelayRange)
 	if err != nil {
-		apiLog.Errorf("Unable to get proposal chart data for token %s : %v", token, err)
-		http.Error(w, http.StatusText(http.StatusUnprocessableEntity),
-			http.StatusUnprocessableEntity)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	writeJSON(w, proposal.ChartData, m.GetIndentCtx(r))
+	blocks := defaultVoteDelayBlocks
+	if blocksParam := r.URL.Query().Get("blocks"); blocksParam != "" {
+		blocks, err = strconv.Atoi(blocksParam)
+		if err != nil || blocks <= 0 || blocks > maxVoteDelayBlocks {
+			http.Error(w, fmt.Sprintf("blocks must be between 1 and %d", maxVoteDelayBlocks),
+				http.StatusBadRequest)
+			return
+		}
+	}
+	poolSize, _, err := c.currentPoolSize()
+	if err != nil {
+		apiLog.Errorf("currentPoolSize: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	bucketBlocks := c.blocksPerDay()
+	observed, err := c.DataSource.GetVoteDelays(from, to, blocks, bucketBlocks)
+	if dbtypes.IsTimeoutErr(err) {
+		apiLog.Errorf("GetVoteDelays timeout error: %v", err)
+		http.Error(w, "Database timeout.", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		apiLog.Errorf("GetVoteDelays: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, &apitypes.TicketVoteDelays{
+		From:     from,
+		To:       to,
+		Observed: observed,
+		Expected: &apitypes.TicketVoteModel{
+			PoolSize:     poolSize,
+			VoteChance:   txhelpers.VoteChance(poolSize, c.Params),
+			MeanBlocks:   txhelpers.ExpectedVoteBlocks(poolSize, c.Params),
+			MedianBlocks: txhelpers.VoteBlocksQuantile(0.5, poolSize, c.Params),
+			ExpiryChance: txhelpers.ProbTicketExpiry(poolSize, c.Params),
+			BucketBlocks: bucketBlocks,
+			Distribution: txhelpers.VoteBlocksDistribution(bucketBlocks, poolSize, c.Params),
+		},
+	}, m.GetIndentCtx(r))
+}
+
+// getTicketVoteCalculation serves the probability that a ticket bought now
+// votes within the number of days in the days query parameter, and that it
+// expires, for the current ticket pool size.
+func (c *appContext) getTicketVoteCalculation(w http.ResponseWriter, r *http.Request) {
+	days, err := strconv.ParseFloat(r.URL.Query().Get("days"), 64)
+	if err != nil || days < 0 || math.IsInf(days, 0) || math.IsNaN(days) {
+		http.Error(w, "days must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+	poolSize, height, err := c.currentPoolSize()
+	if err != nil {
+		apiLog.Errorf("currentPoolSize: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	blocksPerDay := float64(c.blocksPerDay())
+	maturity := int64(c.Params.TicketMaturity)
+	calc := &apitypes.TicketVoteCalculation{
+		Height:         height,
+		PoolSize:       poolSize,
+		Days:           days,
+		MaturityBlocks: maturity,
+		ExpiryBlocks:   int64(c.Params.TicketExpiry),
+		// The ticket is called no sooner than the block after it matures.
+		ProbVote:   txhelpers.ProbVoteWithin(int64(days*blocksPerDay)-maturity, poolSize, c.Params),
+		ProbExpiry: txhelpers.ProbTicketExpiry(poolSize, c.Params),
+		MeanDays:   (float64(maturity) + txhelpers.ExpectedVoteBlocks(poolSize, c.Params)) / blocksPerDay,
+		MedianDays: -1,
+	}
+	if median := txhelpers.VoteBlocksQuantile(0.5, poolSize, c.Params); median >= 0 {
+		calc.MedianDays = float64(maturity+median) / blocksPerDay
+	}
+	writeJSON(w, calc, m.GetIndentCtx(r))
+}
+
+func (c *appContext) getProposalChartData(w http.ResponseWriter, r *http.Request) {
+	token := m.GetProposalTokenCtx(r)
+
+	proposal, err := c.ProposalsDB.ProposalByToken(token)
+	if dbtypes.IsTimeoutErr(err) {
+		apiLog.Errorf("ProposalByToken: %v", err)
+		http.Error(w, "Database timeout.", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		apiLog.Errorf("Unable to get proposal chart data for token %s : %v", token, err)
+		http.Error(w, http.StatusText(http.StatusUnprocessableEntity),
+			http.StatusUnprocessableEntity)
+		return
+	}
+
+	writeJSON(w, proposal.ChartData, m.GetIndentCtx(r))
 }
 
 func (c *appContext) getBlockSize(w http.ResponseWriter, r *http.Request) {
@@ -2836,13 +3583,7 @@ func (c *appContext) blockSubsidies(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ssv := standalone.SSVOriginal
-	if c.DataSource.IsDCP0012Active(idx) {
-		ssv = standalone.SSVDCP0012
-	} else if c.DataSource.IsDCP0010Active(idx) {
-		ssv = standalone.SSVDCP0010
-	}
-	work, stake, tax := txhelpers.RewardsAtBlock(idx, uint16(numVotes), c.Params, ssv)
+	work, stake, tax := txhelpers.RewardsAtBlock(idx, uint16(numVotes), c.Params, c.subsidySplitVariant(idx))
 	rewards := apitypes.BlockSubsidies{
 		BlockNum:   idx,
 		BlockHash:  hash,
@@ -3656,6 +4397,231 @@ func (c *appContext) getMutilchainCandlestickChart(w http.ResponseWriter, r *htt
 	writeJSONBytes(w, chart)
 }
 
+// route: /market/{token}/liquidity
+func (c *appContext) getLiquidityChart(w http.ResponseWriter, r *http.Request) {
+	c.writeLiquidityChart(w, r, mutilchain.TYPEDCR)
+}
+
+// route: /chainchart/{chaintype}/market/{token}/liquidity
+func (c *appContext) getMutilchainLiquidityChart(w http.ResponseWriter, r *http.Request) {
+	chainType := chi.URLParam(r, "chaintype")
+	if !slices.Contains(dbtypes.MutilchainList, chainType) && chainType != mutilchain.TYPEDCR {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	c.writeLiquidityChart(w, r, chainType)
+}
+
+// maxLiquidityRange is the longest range of the snapshots served by
+// writeLiquidityChart, which is also how long the snapshots are kept.
+const maxLiquidityRange = 90 * 24 * time.Hour
+
+// writeLiquidityChart writes the stored order book liquidity snapshots for
+// the exchange token, or for the aggregated order book. The from and to query
+// parameters are UNIX timestamps, and default to the last 7 days. The range
+// may not exceed maxLiquidityRange.
+func (c *appContext) writeLiquidityChart(w http.ResponseWriter, r *http.Request, chainType string) {
+	token := m.RetrieveExchangeTokenCtx(r)
+	if token == "" {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	from, to, err := parseTimeRange(r, 7*24*time.Hour, maxLiquidityRange)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// For the aggregated order book, get every token to find the exchange
+	// contributions.
+	var queryToken string
+	isAggregated := token == exchanges.AggregatedToken
+	if !isAggregated {
+		queryToken = token
+	}
+	snapshots, err := c.DataSource.GetLiquiditySnapshots(chainType, queryToken, from, to)
+	if err != nil {
+		apiLog.Errorf("GetLiquiditySnapshots error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	chart := &apitypes.LiquidityChart{
+		ChainType:     chainType,
+		Token:         token,
+		Bands:         exchanges.LiquidityBands,
+		SlippageSizes: exchanges.SlippageSizes,
+		Snapshots:     snapshots,
+	}
+	if isAggregated {
+		chart.Snapshots = make([]*dbtypes.LiquiditySnapshot, 0, len(snapshots))
+		chart.Contributions = make(map[string][]*apitypes.LiquidityShare)
+		for _, s := range snapshots {
+			if s.Token == exchanges.AggregatedToken {
+				chart.Snapshots = append(chart.Snapshots, s)
+				continue
+			}
+			chart.Contributions[s.Token] = append(chart.Contributions[s.Token], &apitypes.LiquidityShare{
+				Time:  s.Time,
+				Share: s.Share,
+			})
+		}
+	}
+	writeJSON(w, chart, m.GetIndentCtx(r))
+}
+
+// getBwDashMarketDays serves the daily volume and trade counts of the Bison
+// Wallet (dcrdex) markets. The optional from and to query parameters are UNIX
+// timestamps, and default to all days.
+func (c *appContext) getBwDashMarketDays(w http.ResponseWriter, r *http.Request) {
+	to := time.Now().Unix()
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		var err error
+		to, err = strconv.ParseInt(toParam, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid to", http.StatusBadRequest)
+			return
+		}
+	}
+	var from int64
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		var err error
+		from, err = strconv.ParseInt(fromParam, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid from", http.StatusBadRequest)
+			return
+		}
+	}
+	days, err := c.DataSource.GetDEXMarketDays(from, to)
+	if err != nil {
+		apiLog.Errorf("GetDEXMarketDays error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, days, m.GetIndentCtx(r))
+}
+
+// getVSPs serves every VSP that has been listed by the VSP API, with its
+// latest snapshot.
+func (c *appContext) getVSPs(w http.ResponseWriter, r *http.Request) {
+	vsps, err := c.DataSource.GetVSPs()
+	if err != nil {
+		apiLog.Errorf("GetVSPs error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, vsps, m.GetIndentCtx(r))
+}
+
+// getVSPHistory serves the stored snapshots of a VSP between the optional
+// from and to UNIX timestamps.
+func (c *appContext) getVSPHistory(w http.ResponseWriter, r *http.Request) {
+	vsp := chi.URLParam(r, "vsp")
+	if vsp == "" {
+		http.Error(w, "invalid vsp", http.StatusBadRequest)
+		return
+	}
+	to := time.Now().Unix()
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		var err error
+		to, err = strconv.ParseInt(toParam, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid to", http.StatusBadRequest)
+			return
+		}
+	}
+	var from int64
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		var err error
+		from, err = strconv.ParseInt(fromParam, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid from", http.StatusBadRequest)
+			return
+		}
+	}
+	snapshots, err := c.DataSource.GetVSPSnapshots(vsp, from, to)
+	if err != nil {
+		apiLog.Errorf("GetVSPSnapshots error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, snapshots, m.GetIndentCtx(r))
+}
+
+// maxMixesCount is the maximum number of mixes served by getMixes.
+const maxMixesCount = 1000
+
+// parseTimeRange parses the optional from and to UNIX timestamp query
+// parameters. to defaults to now, and from to defaultFrom before to. The range
+// may not be longer than maxRange, unless maxRange is zero.
+func parseTimeRange(r *http.Request, defaultFrom, maxRange time.Duration) (from, to int64, err error) {
+	to = time.Now().Unix()
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if to, err = strconv.ParseInt(toParam, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid to")
+		}
+	}
+	from = to - int64(defaultFrom/time.Second)
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		if from, err = strconv.ParseInt(fromParam, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid from")
+		}
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("from is after to")
+	}
+	if maxRange > 0 && to-from > int64(maxRange/time.Second) {
+		return 0, 0, fmt.Errorf("the range from to to may not exceed %d days", maxRange/(24*time.Hour))
+	}
+	return from, to, nil
+}
+
+// getMixes serves the valid mainchain mixing transactions between the
+// optional from and to UNIX timestamps, most recent first. The range defaults
+// to the last day. At most count mixes are served.
+func (c *appContext) getMixes(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseTimeRange(r, 24*time.Hour, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	count := maxMixesCount
+	if countParam := r.URL.Query().Get("count"); countParam != "" {
+		count, err = strconv.Atoi(countParam)
+		if err != nil || count <= 0 {
+			http.Error(w, "invalid count", http.StatusBadRequest)
+			return
+		}
+		if count > maxMixesCount {
+			count = maxMixesCount
+		}
+	}
+	mixes, err := c.DataSource.GetMixes(from, to, count)
+	if err != nil {
+		apiLog.Errorf("GetMixes error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, mixes, m.GetIndentCtx(r))
+}
+
+// getMixDays serves the daily CSPP mix volume, average participants and
+// unmixed change of each denomination between the optional from and to UNIX
+// timestamps. The range defaults to the last 90 days.
+func (c *appContext) getMixDays(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseTimeRange(r, 90*24*time.Hour, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	days, err := c.DataSource.GetMixDays(from, to)
+	if err != nil {
+		apiLog.Errorf("GetMixDays error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, days, m.GetIndentCtx(r))
+}
+
 // route: /market/{token}/candlestick/{bin}
 func (c *appContext) getCandlestickChart(w http.ResponseWriter, r *http.Request) {
 	if c.xcBot == nil {
@@ -3957,6 +4923,113 @@ func (c *appContext) getAgendaData(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, data, "")
 }
 
+// getAgendaForecast serves the voting forecast of the agenda from
+// /agenda/{agendaId}/forecast.
+func (c *appContext) getAgendaForecast(w http.ResponseWriter, r *http.Request) {
+	agendaID := m.GetAgendaIdCtx(r)
+	if agendaID == "" {
+		http.Error(w, http.StatusText(422), 422)
+		return
+	}
+	if c.VoteTracker == nil {
+		http.Error(w, "vote tracker disabled", http.StatusServiceUnavailable)
+		return
+	}
+	forecast := c.VoteTracker.Forecast(agendaID)
+	if forecast == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, forecast, m.GetIndentCtx(r))
+}
+
+// calendarEvents parses the optional chain, from and to query parameters of a
+// calendar request and returns the matching events. If there is an error, it
+// is written to the response and nil is returned.
+func (c *appContext) calendarEvents(w http.ResponseWriter, r *http.Request) []*calendar.Event {
+	if c.Calendar == nil {
+		http.Error(w, "calendar disabled", http.StatusServiceUnavailable)
+		return nil
+	}
+	var from, to int64
+	var err error
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		if from, err = strconv.ParseInt(fromParam, 10, 64); err != nil {
+			http.Error(w, "invalid from", http.StatusBadRequest)
+			return nil
+		}
+	}
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		if to, err = strconv.ParseInt(toParam, 10, 64); err != nil {
+			http.Error(w, "invalid to", http.StatusBadRequest)
+			return nil
+		}
+	}
+	return c.Calendar.Events(r.URL.Query().Get("chain"), from, to)
+}
+
+// getCalendar serves the governance and consensus events of the calendar,
+// with estimated times for future blocks. The events may be filtered by chain
+// and by the from and to UNIX timestamps.
+func (c *appContext) getCalendar(w http.ResponseWriter, r *http.Request) {
+	events := c.calendarEvents(w, r)
+	if events == nil {
+		return
+	}
+	writeJSON(w, events, m.GetIndentCtx(r))
+}
+
+// getCalendarICS serves the events of getCalendar as an iCalendar feed.
+func (c *appContext) getCalendarICS(w http.ResponseWriter, r *http.Request) {
+	events := c.calendarEvents(w, r)
+	if events == nil {
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "inline;filename=dcrdata.ics")
+	w.WriteHeader(http.StatusOK)
+	if err := calendar.WriteICS(w, events, m.RequestBaseURL(r), time.Now()); err != nil {
+		apiLog.Debugf("WriteICS: %v", err)
+	}
+}
+
+// maxTSpendLifecycles is the number of tracked tspends served by
+// getTSpendLifecycles.
+const maxTSpendLifecycles = 100
+
+// getTSpendLifecycles serves the most recently seen tracked tspends, with
+// their lifecycle status and vote projection.
+func (c *appContext) getTSpendLifecycles(w http.ResponseWriter, r *http.Request) {
+	tspends, err := c.DataSource.GetTSpendLifecycles(maxTSpendLifecycles)
+	if err != nil {
+		apiLog.Errorf("GetTSpendLifecycles error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tspends, m.GetIndentCtx(r))
+}
+
+// getTSpendLifecycle serves a tracked tspend's lifecycle status and vote
+// projection.
+func (c *appContext) getTSpendLifecycle(w http.ResponseWriter, r *http.Request) {
+	txHash := m.GetTspendTxIdCtx(r)
+	if txHash == "" {
+		http.Error(w, http.StatusText(422), 422)
+		return
+	}
+	tspend, err := c.DataSource.GetTSpendLifecycle(txHash)
+	if err != nil {
+		apiLog.Errorf("GetTSpendLifecycle error: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if tspend == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, tspend, m.GetIndentCtx(r))
+}
+
 func (c *appContext) getTSpendVoteChartData(w http.ResponseWriter, r *http.Request) {
 	txHash := m.GetTspendTxIdCtx(r)
 	if txHash == "" {
@@ -4046,6 +5119,20 @@ func (c *appContext) getExchangeRates(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, rates, m.GetIndentCtx(r))
 }
 
+// getIndexStatuses lists each BTC index source's price for the currency given
+// by the code query parameter, or for the default index, with its staleness.
+func (c *appContext) getIndexStatuses(w http.ResponseWriter, r *http.Request) {
+	if c.xcBot == nil {
+		http.Error(w, "Exchange monitoring disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		code = c.xcBot.BtcIndex
+	}
+	writeJSON(w, c.xcBot.IndexStatuses(code), m.GetIndentCtx(r))
+}
+
 func (c *appContext) getCurrencyCodes(w http.ResponseWriter, r *http.Request) {
 	if c.xcBot == nil {
 		http.Error(w, "Exchange monitoring disabled.", http.StatusServiceUnavailable)
@@ -4060,6 +5147,248 @@ func (c *appContext) getCurrencyCodes(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, codes, m.GetIndentCtx(r))
 }
 
+// getArbitrage returns each exchange's best bid and ask for the chain, the
+// cross-exchange spread, and the profit of trading the requested size between
+// exchanges. The quote query parameter selects the quote asset, usdt (default)
+// or btc, and size is in units of the chain's coin (default 1).
+func (c *appContext) getArbitrage(w http.ResponseWriter, r *http.Request) {
+	if c.xcBot == nil {
+		http.Error(w, "Exchange monitoring disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	chainType := chi.URLParam(r, "chaintype")
+	quote := exchanges.QuoteUSDT
+	if quoteParam := r.URL.Query().Get("quote"); quoteParam != "" {
+		quote = strings.ToLower(quoteParam)
+	}
+	size := 1.0
+	if sizeParam := r.URL.Query().Get("size"); sizeParam != "" {
+		var err error
+		size, err = strconv.ParseFloat(sizeParam, 64)
+		if err != nil || size < 0 {
+			http.Error(w, "invalid size", http.StatusBadRequest)
+			return
+		}
+	}
+	view, err := c.xcBot.Arbitrage(chainType, quote, size)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, view, m.GetIndentCtx(r))
+}
+
+// getAlertRules returns the configured price and market alert rules. The
+// webhook URLs are reduced to their host, as they often carry secret tokens.
+func (c *appContext) getAlertRules(w http.ResponseWriter, r *http.Request) {
+	if c.alerts == nil {
+		http.Error(w, "Market alerts disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, c.alerts.Rules(), m.GetIndentCtx(r))
+}
+
+// getAlertDeliveries returns the webhook delivery log for the market alerts,
+// with the webhook URLs reduced to their host.
+func (c *appContext) getAlertDeliveries(w http.ResponseWriter, r *http.Request) {
+	if c.alerts == nil {
+		http.Error(w, "Market alerts disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, c.alerts.Deliveries(), m.GetIndentCtx(r))
+}
+
+// getEvents streams the pubsub hub messages as Server-Sent Events, for the
+// clients that cannot use the /ps websocket.
+func (c *appContext) getEvents(w http.ResponseWriter, r *http.Request) {
+	if c.eventStream == nil {
+		http.Error(w, "Event stream disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	c.eventStream(w, r)
+}
+
+// graphQL serves the GraphQL API, which resolves the blocks, transactions and
+// addresses of a view in one request rather than many REST calls.
+func (c *appContext) graphQL(w http.ResponseWriter, r *http.Request) {
+	if c.graphQLHandler == nil {
+		http.Error(w, "GraphQL disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	c.graphQLHandler.ServeHTTP(w, r)
+}
+
+// maxWebhookDeliveries is the limit of delivery attempts returned by
+// getWebhookDeliveries.
+const maxWebhookDeliveries = 500
+
+// webhookID gets the id URL parameter of a webhook, writing a 400 if it is
+// invalid.
+func webhookID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid webhook id", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// webhookToken gets the management token of the webhooks from the
+// "Authorization: Bearer <token>" request header, or an empty string if there
+// is none.
+func webhookToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len("Bearer ") || !strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(auth[len("Bearer "):])
+}
+
+// requireWebhookToken gets the management token of the webhooks, writing a 401
+// if there is none.
+func requireWebhookToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := webhookToken(r)
+	if token == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="webhooks"`)
+		http.Error(w, "webhook management token required", http.StatusUnauthorized)
+		return "", false
+	}
+	return token, true
+}
+
+// getWebhooks returns the chain event webhooks managed with the request's
+// token, optionally of the chain given by the URL query ?chain=.
+func (c *appContext) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	if c.webhooks == nil {
+		http.Error(w, "Webhooks disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	token, ok := requireWebhookToken(w, r)
+	if !ok {
+		return
+	}
+	chainType := strings.ToLower(r.URL.Query().Get("chain"))
+	writeJSON(w, c.webhooks.Hooks(token, chainType), m.GetIndentCtx(r))
+}
+
+// postWebhook registers a chain event webhook from the JSON request body. The
+// hook is managed with the request's token, or with a new token if there is
+// none. The response is the new hook with its management token and the secret
+// of its payload signatures. New tokens are rate limited by client IP.
+func (c *appContext) postWebhook(w http.ResponseWriter, r *http.Request) {
+	if c.webhooks == nil {
+		http.Error(w, "Webhooks disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	var hook dbtypes.Webhook
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&hook); err != nil {
+		http.Error(w, "invalid webhook JSON", http.StatusBadRequest)
+		return
+	}
+	added, err := c.webhooks.AddHook(&hook, webhookToken(r))
+	if err != nil {
+		if errors.Is(err, webhooks.ErrInvalidHook) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		apiLog.Errorf("Failed to add webhook: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSONWithStatus(w, added, http.StatusCreated, m.GetIndentCtx(r))
+}
+
+// getWebhook returns a chain event webhook managed with the request's token.
+func (c *appContext) getWebhook(w http.ResponseWriter, r *http.Request) {
+	if c.webhooks == nil {
+		http.Error(w, "Webhooks disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	token, ok := requireWebhookToken(w, r)
+	if !ok {
+		return
+	}
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	hook, found := c.webhooks.Hook(token, id)
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, hook, m.GetIndentCtx(r))
+}
+
+// deleteWebhook removes a chain event webhook managed with the request's token,
+// and its delivery log.
+func (c *appContext) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if c.webhooks == nil {
+		http.Error(w, "Webhooks disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	token, ok := requireWebhookToken(w, r)
+	if !ok {
+		return
+	}
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	removed, err := c.webhooks.RemoveHook(token, id)
+	if err != nil {
+		apiLog.Errorf("Failed to remove webhook %d: %v", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !removed {
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getWebhookDeliveries returns the most recent delivery attempts of a chain
+// event webhook managed with the request's token, up to the URL query ?limit=
+// (default 100).
+func (c *appContext) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if c.webhooks == nil {
+		http.Error(w, "Webhooks disabled.", http.StatusServiceUnavailable)
+		return
+	}
+	token, ok := requireWebhookToken(w, r)
+	if !ok {
+		return
+	}
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+	if _, found := c.webhooks.Hook(token, id); !found {
+		http.NotFound(w, r)
+		return
+	}
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxWebhookDeliveries {
+			limit = maxWebhookDeliveries
+		}
+	}
+	deliveries, err := c.webhooks.Deliveries(id, limit)
+	if err != nil {
+		apiLog.Errorf("Failed to retrieve the deliveries of webhook %d: %v", id, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, deliveries, m.GetIndentCtx(r))
+}
+
 // getAgendasData returns high level agendas details that includes Name,
 // Description, Vote Version, VotingDone height, Activated, HardForked,
 // StartTime and ExpireTime.
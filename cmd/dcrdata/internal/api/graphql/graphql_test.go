@@ -0,0 +1,448 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apitypes "github.com/decred/dcrdata/v8/api/types"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/pubsub"
+)
+
+const (
+	testHeight  = 4
+	txsPerBlock = 3
+	zeroHash    = "0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// fakeDB is a DataSource of the DCR blocks 0 to testHeight that counts the
+// calls of the batch methods.
+type fakeDB struct {
+	mtx   sync.Mutex
+	calls map[string]int
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{calls: make(map[string]int)}
+}
+
+func (db *fakeDB) count(method string) {
+	db.mtx.Lock()
+	db.calls[method]++
+	db.mtx.Unlock()
+}
+
+func (db *fakeDB) numCalls(method string) int {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.calls[method]
+}
+
+func testBlock(height int64) *dbtypes.Block {
+	b := &dbtypes.Block{
+		Hash:   fmt.Sprintf("b%d", height),
+		Height: uint32(height),
+		NumTx:  txsPerBlock,
+		Time:   dbtypes.NewTimeDefFromUNIX(1600000000 + height*300),
+	}
+	if height > 0 {
+		b.PreviousHash = fmt.Sprintf("b%d", height-1)
+	}
+	return b
+}
+
+func parseTxID(txid string) (height int64, index int, ok bool) {
+	n, err := fmt.Sscanf(txid, "t%d-%d", &height, &index)
+	return height, index, err == nil && n == 2 && height >= 0 && height <= testHeight && index >= 0 && index < txsPerBlock
+}
+
+func (db *fakeDB) BlocksByHashes(_ context.Context, _ string, hashes []string) ([]*dbtypes.Block, error) {
+	db.count("BlocksByHashes")
+	var blocks []*dbtypes.Block
+	for _, hash := range hashes {
+		var height int64
+		if _, err := fmt.Sscanf(hash, "b%d", &height); err == nil && height >= 0 && height <= testHeight {
+			blocks = append(blocks, testBlock(height))
+		}
+	}
+	return blocks, nil
+}
+
+func (db *fakeDB) BlocksByHeights(_ context.Context, _ string, heights []int64) ([]*dbtypes.Block, error) {
+	db.count("BlocksByHeights")
+	var blocks []*dbtypes.Block
+	for _, height := range heights {
+		if height >= 0 && height <= testHeight {
+			blocks = append(blocks, testBlock(height))
+		}
+	}
+	return blocks, nil
+}
+
+func (db *fakeDB) TxIDsByBlockHashes(_ context.Context, _ string, blockHashes []string) (map[string][]string, error) {
+	db.count("TxIDsByBlockHashes")
+	txids := make(map[string][]string)
+	for _, hash := range blockHashes {
+		var height int64
+		if _, err := fmt.Sscanf(hash, "b%d", &height); err != nil {
+			continue
+		}
+		for i := 0; i < txsPerBlock; i++ {
+			txids[hash] = append(txids[hash], fmt.Sprintf("t%d-%d", height, i))
+		}
+	}
+	return txids, nil
+}
+
+func (db *fakeDB) TransactionsByHashes(_ context.Context, _ string, txids []string) ([]*dbtypes.Tx, error) {
+	db.count("TransactionsByHashes")
+	var txs []*dbtypes.Tx
+	for _, txid := range txids {
+		height, index, ok := parseTxID(txid)
+		if !ok {
+			continue
+		}
+		txs = append(txs, &dbtypes.Tx{
+			TxID:             txid,
+			BlockHash:        fmt.Sprintf("b%d", height),
+			BlockHeight:      height,
+			BlockIndex:       uint32(index),
+			Fees:             1000,
+			Sent:             2e8,
+			NumVin:           1,
+			NumVout:          2,
+			IsMainchainBlock: true,
+		})
+	}
+	return txs, nil
+}
+
+func (db *fakeDB) VinsByTxHashes(_ context.Context, _ string, txids []string) ([]*dbtypes.VinTxProperty, error) {
+	db.count("VinsByTxHashes")
+	var vins []*dbtypes.VinTxProperty
+	for _, txid := range txids {
+		height, index, ok := parseTxID(txid)
+		if !ok {
+			continue
+		}
+		vin := &dbtypes.VinTxProperty{TxID: txid, PrevTxHash: zeroHash, ValueIn: 1e8}
+		if index > 0 {
+			vin.PrevTxHash = fmt.Sprintf("t%d-%d", height, index-1)
+		}
+		vins = append(vins, vin)
+	}
+	return vins, nil
+}
+
+func (db *fakeDB) VoutsByTxHashes(_ context.Context, _ string, txids []string) ([]*dbtypes.Vout, error) {
+	db.count("VoutsByTxHashes")
+	var vouts []*dbtypes.Vout
+	for _, txid := range txids {
+		if _, _, ok := parseTxID(txid); !ok {
+			continue
+		}
+		for i := 0; i < 2; i++ {
+			vouts = append(vouts, &dbtypes.Vout{
+				TxHash:  txid,
+				TxIndex: uint32(i),
+				Value:   1e8,
+				ScriptPubKeyData: dbtypes.ScriptPubKeyData{
+					Addresses: []string{fmt.Sprintf("a%d", i)},
+				},
+			})
+		}
+	}
+	return vouts, nil
+}
+
+func (db *fakeDB) AddressBalances(_ context.Context, _ string, addresses []string) ([]*dbtypes.AddressBalance, error) {
+	db.count("AddressBalances")
+	var balances []*dbtypes.AddressBalance
+	for _, addr := range addresses {
+		balances = append(balances, &dbtypes.AddressBalance{Address: addr, NumUnspent: 1, TotalUnspent: 3e8})
+	}
+	return balances, nil
+}
+
+func (db *fakeDB) GetMutilchainBestBlock(string) (int64, string) {
+	return testHeight, fmt.Sprintf("b%d", testHeight)
+}
+
+func (db *fakeDB) GetTicketInfo(string) (*apitypes.TicketInfo, error) {
+	return nil, dbtypes.ErrNoResult
+}
+
+func (db *fakeDB) GetAtomicSwapList(int64, int64, string, string, string) ([]*dbtypes.AtomicSwapFullData, int64, error) {
+	return nil, 0, nil
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func postQuery(t *testing.T, srv *httptest.Server, query string) *response {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	var r response
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	return &r
+}
+
+func newTestServer(t *testing.T, cfg *Config) *httptest.Server {
+	t.Helper()
+	h, err := NewHandler(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBatching(t *testing.T) {
+	db := newFakeDB()
+	srv := newTestServer(t, &Config{DataSource: db})
+
+	resp := postQuery(t, srv, `{
+		blocks(chain: DCR, first: 5) {
+			height
+			confirmations
+			previousBlock { hash }
+			transactions {
+				txid
+				fee
+				inputs { amount prevOutput { amount addresses { address balance } } }
+				outputs { index addresses { address unspentCount } }
+			}
+		}
+	}`)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %v", resp.Errors)
+	}
+	var data struct {
+		Blocks []struct {
+			Height        int
+			Confirmations int
+			PreviousBlock *struct{ Hash string }
+			Transactions  []struct {
+				Txid    string
+				Fee     float64
+				Inputs  []struct{ PrevOutput *struct{ Amount float64 } }
+				Outputs []struct{ Addresses []struct{ Address string } }
+			}
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Blocks) != testHeight+1 {
+		t.Fatalf("got %d blocks", len(data.Blocks))
+	}
+	top := data.Blocks[0]
+	if top.Height != testHeight || top.Confirmations != 1 || top.PreviousBlock.Hash != "b3" {
+		t.Fatalf("unexpected block %+v", top)
+	}
+	if len(top.Transactions) != txsPerBlock || top.Transactions[0].Fee != 1e-5 ||
+		top.Transactions[0].Inputs[0].PrevOutput != nil || top.Transactions[1].Inputs[0].PrevOutput == nil ||
+		top.Transactions[1].Outputs[1].Addresses[0].Address != "a1" {
+		t.Fatalf("unexpected transactions %+v", top.Transactions)
+	}
+	if data.Blocks[testHeight].PreviousBlock != nil {
+		t.Fatal("the genesis block has a previous block")
+	}
+
+	// The items of the lists are loaded together rather than one at a time.
+	if n := db.numCalls("BlocksByHeights"); n != 1 {
+		t.Errorf("BlocksByHeights called %d times", n)
+	}
+	for _, method := range []string{"BlocksByHashes", "TxIDsByBlockHashes", "TransactionsByHashes",
+		"VinsByTxHashes", "VoutsByTxHashes", "AddressBalances"} {
+		if n := db.numCalls(method); n == 0 || n >= testHeight+1 {
+			t.Errorf("%s called %d times", method, n)
+		}
+	}
+}
+
+func TestChargeBeforeLoad(t *testing.T) {
+	db := newFakeDB()
+	srv := newTestServer(t, &Config{DataSource: db, MaxCost: 4})
+
+	resp := postQuery(t, srv, `{ block(chain: DCR, height: 1) { transactions(first: 1) { inputs { amount } outputs { amount } } } }`)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "query cost exceeds the limit of 4") {
+		t.Errorf("unexpected errors %v for a query over the cost limit", resp.Errors)
+	}
+	if n := db.numCalls("VoutsByTxHashes"); n != 0 {
+		t.Errorf("VoutsByTxHashes called %d times over the cost limit", n)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	srv := newTestServer(t, &Config{
+		DataSource:     newFakeDB(),
+		MaxCost:        15,
+		DisabledChains: map[string]bool{"ltc": true},
+	})
+
+	deep := "{ bestBlock(chain: DCR) { " + strings.Repeat("previousBlock { ", 12) + "hash" +
+		strings.Repeat(" }", 12) + " } }"
+	if resp := postQuery(t, srv, deep); len(resp.Errors) == 0 {
+		t.Error("no error for a query over the depth limit")
+	}
+
+	resp := postQuery(t, srv, `{ blocks(chain: DCR) { transactions { txid } } }`)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "query cost exceeds the limit of 15") {
+		t.Errorf("unexpected errors %v for a query over the cost limit", resp.Errors)
+	}
+
+	if resp = postQuery(t, srv, `{ bestBlock(chain: LTC) { hash } }`); len(resp.Errors) == 0 {
+		t.Error("no error for a disabled chain")
+	}
+
+	resp = postQuery(t, srv, `subscription { newBlock(chain: DCR) { hash } }`)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "text/event-stream") {
+		t.Errorf("unexpected errors %v for a subscription without streaming", resp.Errors)
+	}
+
+	// The variables of a GET request are JSON.
+	q := url.Values{
+		"query":     {`query($h: Int) { block(chain: DCR, height: $h) { hash time } }`},
+		"variables": {`{"h": 2}`},
+	}
+	res, err := http.Get(srv.URL + "?" + q.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var r response
+	if err = json.NewDecoder(res.Body).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"block":{"hash":"b2","time":1600000600}}`; string(r.Data) != want {
+		t.Errorf("got %s, expected %s", r.Data, want)
+	}
+
+	res, err = http.Post(srv.URL, "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, res.StatusCode)
+	}
+}
+
+// fakeEvents is an EventSource of the events sent to it.
+type fakeEvents struct {
+	subs chan string
+	evs  chan *pubsub.Event
+}
+
+func (f *fakeEvents) SubscribeEvents(ctx context.Context, events, chains string) (<-chan *pubsub.Event, error) {
+	f.subs <- events + "/" + chains
+	out := make(chan *pubsub.Event)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case ev := <-f.evs:
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func TestSubscription(t *testing.T) {
+	events := &fakeEvents{subs: make(chan string, 1), evs: make(chan *pubsub.Event)}
+	srv := newTestServer(t, &Config{DataSource: newFakeDB(), Events: events})
+
+	q := url.Values{"query": {`subscription { newBlock(chain: DCR) { hash height transactions(first: 1) { txid } } }`}}
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"?"+q.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type %q", ct)
+	}
+	select {
+	case sub := <-events.subs:
+		if sub != "newblock/dcr" {
+			t.Fatalf("subscribed to %s", sub)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the subscription")
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if line := sc.Text(); line != "" {
+				lines <- line
+			}
+		}
+	}()
+	next := func() string {
+		t.Helper()
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the stream")
+		}
+		return ""
+	}
+
+	events.evs <- &pubsub.Event{ID: "1-1", Event: "newblock", Data: json.RawMessage(`{"block":{"hash":"b3","height":3}}`)}
+	if line := next(); line != "event: next" {
+		t.Fatalf("unexpected line %q", line)
+	}
+	want := `data: {"data":{"newBlock":{"hash":"b3","height":3,"transactions":[{"txid":"t3-0"}]}}}`
+	if line := next(); line != want {
+		t.Fatalf("got %q, expected %q", line, want)
+	}
+
+	// The subscription completes when the event stream hangs up.
+	events.evs <- &pubsub.Event{Event: "bye"}
+	if line := next(); line != "event: complete" {
+		t.Fatalf("unexpected line %q", line)
+	}
+}
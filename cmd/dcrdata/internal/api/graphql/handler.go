@@ -0,0 +1,256 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+
+	"github.com/decred/dcrdata/exchanges/v3"
+	apitypes "github.com/decred/dcrdata/v8/api/types"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/pubsub"
+)
+
+const (
+	// DefaultMaxDepth is the default maximum nesting depth of a query.
+	DefaultMaxDepth = 12
+	// DefaultMaxCost is the default maximum cost of a query, or of an event
+	// of a subscription.
+	DefaultMaxCost = 1000
+
+	maxQueryLength = 8192
+	maxBodySize    = 1 << 16
+	maxParallelism = 50
+
+	// subscribeTimeout is the time allowed to resolve an event of a
+	// subscription.
+	subscribeTimeout = 10 * time.Second
+	writeTimeout     = 5 * time.Second
+)
+
+// DataSource is the database of the chains. The batch methods omit the items
+// that are not found.
+type DataSource interface {
+	BlocksByHashes(ctx context.Context, chainType string, hashes []string) ([]*dbtypes.Block, error)
+	BlocksByHeights(ctx context.Context, chainType string, heights []int64) ([]*dbtypes.Block, error)
+	TxIDsByBlockHashes(ctx context.Context, chainType string, blockHashes []string) (map[string][]string, error)
+	TransactionsByHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.Tx, error)
+	VinsByTxHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.VinTxProperty, error)
+	VoutsByTxHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.Vout, error)
+	AddressBalances(ctx context.Context, chainType string, addresses []string) ([]*dbtypes.AddressBalance, error)
+	GetMutilchainBestBlock(chainType string) (int64, string)
+	GetTicketInfo(txid string) (*apitypes.TicketInfo, error)
+	GetAtomicSwapList(n, offset int64, pair, status, searchKey string) ([]*dbtypes.AtomicSwapFullData, int64, error)
+}
+
+// PriceSource is the source of the market prices.
+type PriceSource interface {
+	State() *exchanges.ExchangeBotState
+}
+
+// EventSource is the source of the events of the subscriptions.
+type EventSource interface {
+	SubscribeEvents(ctx context.Context, events, chains string) (<-chan *pubsub.Event, error)
+}
+
+// Config is the configuration of a Handler. Prices and Events are optional.
+type Config struct {
+	DataSource DataSource
+	Prices     PriceSource
+	Events     EventSource
+	// DisabledChains are the chains that are not served.
+	DisabledChains map[string]bool
+	// MaxDepth and MaxCost default to DefaultMaxDepth and DefaultMaxCost.
+	MaxDepth int
+	MaxCost  int64
+}
+
+// Handler serves the GraphQL API. Queries are served as JSON for GET and POST
+// requests. Subscriptions, and queries, are streamed as server-sent events to
+// the requests that accept text/event-stream.
+type Handler struct {
+	db       DataSource
+	prices   PriceSource
+	events   EventSource
+	disabled map[string]bool
+	maxCost  int64
+	schema   *graphqlgo.Schema
+}
+
+// NewHandler creates a Handler.
+func NewHandler(cfg *Config) (*Handler, error) {
+	if cfg.DataSource == nil {
+		return nil, fmt.Errorf("no data source")
+	}
+	h := &Handler{
+		db:       cfg.DataSource,
+		prices:   cfg.Prices,
+		events:   cfg.Events,
+		disabled: cfg.DisabledChains,
+		maxCost:  cfg.MaxCost,
+	}
+	if h.maxCost <= 0 {
+		h.maxCost = DefaultMaxCost
+	}
+	maxDepth := cfg.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	var err error
+	h.schema, err = graphqlgo.ParseSchema(schema, &resolver{h},
+		graphqlgo.UseStringDescriptions(),
+		graphqlgo.MaxDepth(maxDepth),
+		graphqlgo.MaxQueryLength(maxQueryLength),
+		graphqlgo.MaxParallelism(maxParallelism),
+		graphqlgo.SubscribeResolverTimeout(subscribeTimeout),
+		graphqlgo.Logger(panicLogger{}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return h, nil
+}
+
+// panicLogger logs the panics of the resolvers.
+type panicLogger struct{}
+
+func (panicLogger) LogPanic(_ context.Context, value interface{}) {
+	log.Errorf("GraphQL resolver panic: %v\n%s", value, debug.Stack())
+}
+
+type params struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP serves a GraphQL request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var p params
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		p.Query = q.Get("query")
+		p.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &p.Variables); err != nil {
+				http.Error(w, "Invalid variables.", http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			http.Error(w, "Invalid request body.", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if p.Query == "" {
+		http.Error(w, "Missing query.", http.StatusBadRequest)
+		return
+	}
+	// Subscribe does not check the length of the query.
+	if len(p.Query) > maxQueryLength {
+		http.Error(w, fmt.Sprintf("The query is longer than %d bytes.", maxQueryLength), http.StatusBadRequest)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		h.serveStream(w, r, &p)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), requestCtxKey{}, h.newRequest(r.Context()))
+	resp := h.schema.Exec(ctx, p.Query, p.OperationName, p.Variables)
+	for _, err := range resp.Errors {
+		if err.Message == "graphql-ws protocol header is missing" {
+			err.Message = "subscriptions require the Accept: text/event-stream header"
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Debugf("Failed to write GraphQL response: %v", err)
+	}
+}
+
+// serveStream streams the responses of a subscription, or the response of a
+// query, as the next events of the distinct connections mode of GraphQL over
+// server-sent events, followed by a complete event.
+func (h *Handler) serveStream(w http.ResponseWriter, r *http.Request, p *params) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported.", http.StatusInternalServerError)
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	ctx = context.WithValue(ctx, requestCtxKey{}, h.newRequest(ctx))
+	responses, err := h.schema.Subscribe(ctx, p.Query, p.OperationName, p.Variables)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Do not let proxies buffer the stream.
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	// Send the headers before the first event, which may be a while.
+	flusher.Flush()
+
+	// Each write has its own deadline, since the stream outlives the
+	// server's write timeout.
+	rc := http.NewResponseController(w)
+	write := func(fn func() error) bool {
+		if err := rc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			log.Tracef("SetWriteDeadline failed: %v", err)
+		}
+		if err := fn(); err != nil {
+			log.Debugf("GraphQL stream write failed: %v", err)
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	ticker := time.NewTicker(pubsub.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case resp, open := <-responses:
+			if !open {
+				write(func() error { _, err := w.Write([]byte("event: complete\ndata:\n\n")); return err })
+				return
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				log.Errorf("Failed to encode GraphQL response: %v", err)
+				return
+			}
+			if !write(func() error { _, err := fmt.Fprintf(w, "event: next\ndata: %s\n\n", data); return err }) {
+				return
+			}
+		case <-ticker.C:
+			// A comment keeps the connection alive through proxies.
+			if !write(func() error { _, err := w.Write([]byte(": ping\n\n")); return err }) {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// batchWait is how long a loader collects the keys of concurrent
+	// resolvers before fetching them.
+	batchWait = 2 * time.Millisecond
+
+	// maxBatch is the most keys fetched by one batch query.
+	maxBatch = 250
+)
+
+// loadResult is the result of the load of a key, ready once done is closed.
+type loadResult[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// loader batches the loads of the resolvers of a request. The keys requested
+// within batchWait of the first key of a batch are fetched together by one
+// call of fetch, and the results are cached for the request. The keys missing
+// from the map returned by fetch load the zero value.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mtx     sync.Mutex
+	results map[K]*loadResult[V]
+	batch   []K
+	timer   *time.Timer
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(context.Context, []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		results: make(map[K]*loadResult[V]),
+	}
+}
+
+// enqueue adds the keys that are not loaded or loading to the batch, and
+// returns their results.
+func (l *loader[K, V]) enqueue(keys ...K) []*loadResult[V] {
+	results := make([]*loadResult[V], len(keys))
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	for i, key := range keys {
+		res, found := l.results[key]
+		if !found {
+			res = &loadResult[V]{done: make(chan struct{})}
+			l.results[key] = res
+			l.batch = append(l.batch, key)
+		}
+		results[i] = res
+	}
+	switch {
+	case len(l.batch) >= maxBatch:
+		if l.timer != nil {
+			l.timer.Stop()
+			l.timer = nil
+		}
+		go l.dispatch()
+	case len(l.batch) > 0 && l.timer == nil:
+		l.timer = time.AfterFunc(batchWait, l.dispatch)
+	}
+	return results
+}
+
+// dispatch fetches the keys of the current batch.
+func (l *loader[K, V]) dispatch() {
+	l.mtx.Lock()
+	keys := l.batch
+	l.batch = nil
+	l.timer = nil
+	results := make([]*loadResult[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.results[key]
+	}
+	l.mtx.Unlock()
+
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > maxBatch {
+			n = maxBatch
+		}
+		vals, err := l.fetch(l.ctx, keys[:n])
+		for i, key := range keys[:n] {
+			res := results[i]
+			res.val, res.err = vals[key], err
+			close(res.done)
+		}
+		keys, results = keys[n:], results[n:]
+	}
+}
+
+// load loads the value of a key.
+func (l *loader[K, V]) load(ctx context.Context, key K) (V, error) {
+	return l.wait(ctx, l.enqueue(key)[0])
+}
+
+// loadMany loads the values of the keys in one batch.
+func (l *loader[K, V]) loadMany(ctx context.Context, keys []K) ([]V, error) {
+	vals := make([]V, len(keys))
+	for i, res := range l.enqueue(keys...) {
+		val, err := l.wait(ctx, res)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+func (l *loader[K, V]) wait(ctx context.Context, res *loadResult[V]) (V, error) {
+	select {
+	case <-res.done:
+		return res.val, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
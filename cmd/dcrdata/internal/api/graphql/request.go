@@ -0,0 +1,163 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+)
+
+// The costs of the resolved objects, which are charged to the budget of a
+// request.
+const (
+	costBlock   = 1
+	costTx      = 1
+	costVinVout = 1
+	costAddress = 2
+	costTicket  = 2
+	costSwaps   = 5
+)
+
+// maxFirst is the most items of a list argument or page.
+const maxFirst = 100
+
+type requestCtxKey struct{}
+
+// request is the state of the resolution of a query, or of an event of a
+// subscription. The loaders batch and cache the database queries of its
+// resolvers, and the cost of the resolved objects is limited by maxCost.
+type request struct {
+	ctx     context.Context
+	h       *Handler
+	maxCost int64
+	cost    atomic.Int64
+
+	mtx     sync.Mutex
+	loaders map[string]*chainLoaders
+}
+
+func (h *Handler) newRequest(ctx context.Context) *request {
+	return &request{
+		ctx:     ctx,
+		h:       h,
+		maxCost: h.maxCost,
+		loaders: make(map[string]*chainLoaders),
+	}
+}
+
+// requestFromContext gets the request set by the handler.
+func requestFromContext(ctx context.Context) (*request, error) {
+	req, ok := ctx.Value(requestCtxKey{}).(*request)
+	if !ok {
+		return nil, fmt.Errorf("no request in the context")
+	}
+	return req, nil
+}
+
+// charge adds the cost n to the request, and fails when the request goes over
+// its budget.
+func (req *request) charge(n int) error {
+	if req.cost.Add(int64(n)) > req.maxCost {
+		return fmt.Errorf("query cost exceeds the limit of %d", req.maxCost)
+	}
+	return nil
+}
+
+// chainLoaders are the loaders of a chain.
+type chainLoaders struct {
+	blocks       *loader[string, *dbtypes.Block]
+	blockHeights *loader[int64, *dbtypes.Block]
+	blockTxs     *loader[string, []string]
+	txs          *loader[string, *dbtypes.Tx]
+	vins         *loader[string, []*dbtypes.VinTxProperty]
+	vouts        *loader[string, []*dbtypes.Vout]
+	balances     *loader[string, *dbtypes.AddressBalance]
+}
+
+// chain gets the loaders of the chain, creating them on first use.
+func (req *request) chain(chainType string) *chainLoaders {
+	req.mtx.Lock()
+	defer req.mtx.Unlock()
+	if cl, found := req.loaders[chainType]; found {
+		return cl
+	}
+	db := req.h.db
+	cl := &chainLoaders{
+		blocks: newLoader(req.ctx, func(ctx context.Context, hashes []string) (map[string]*dbtypes.Block, error) {
+			blocks, err := db.BlocksByHashes(ctx, chainType, hashes)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string]*dbtypes.Block, len(blocks))
+			for _, b := range blocks {
+				m[b.Hash] = b
+			}
+			return m, nil
+		}),
+		blockHeights: newLoader(req.ctx, func(ctx context.Context, heights []int64) (map[int64]*dbtypes.Block, error) {
+			blocks, err := db.BlocksByHeights(ctx, chainType, heights)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[int64]*dbtypes.Block, len(blocks))
+			for _, b := range blocks {
+				m[int64(b.Height)] = b
+			}
+			return m, nil
+		}),
+		blockTxs: newLoader(req.ctx, func(ctx context.Context, hashes []string) (map[string][]string, error) {
+			return db.TxIDsByBlockHashes(ctx, chainType, hashes)
+		}),
+		txs: newLoader(req.ctx, func(ctx context.Context, txids []string) (map[string]*dbtypes.Tx, error) {
+			txs, err := db.TransactionsByHashes(ctx, chainType, txids)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string]*dbtypes.Tx, len(txs))
+			for _, tx := range txs {
+				m[tx.TxID] = tx
+			}
+			return m, nil
+		}),
+		vins: newLoader(req.ctx, func(ctx context.Context, txids []string) (map[string][]*dbtypes.VinTxProperty, error) {
+			vins, err := db.VinsByTxHashes(ctx, chainType, txids)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string][]*dbtypes.VinTxProperty, len(txids))
+			for _, vin := range vins {
+				m[vin.TxID] = append(m[vin.TxID], vin)
+			}
+			return m, nil
+		}),
+		vouts: newLoader(req.ctx, func(ctx context.Context, txids []string) (map[string][]*dbtypes.Vout, error) {
+			vouts, err := db.VoutsByTxHashes(ctx, chainType, txids)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string][]*dbtypes.Vout, len(txids))
+			for _, vout := range vouts {
+				m[vout.TxHash] = append(m[vout.TxHash], vout)
+			}
+			return m, nil
+		}),
+		balances: newLoader(req.ctx, func(ctx context.Context, addrs []string) (map[string]*dbtypes.AddressBalance, error) {
+			balances, err := db.AddressBalances(ctx, chainType, addrs)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string]*dbtypes.AddressBalance, len(balances))
+			for _, bal := range balances {
+				m[bal.Address] = bal
+			}
+			return m, nil
+		}),
+	}
+	req.loaders[chainType] = cl
+	return cl
+}
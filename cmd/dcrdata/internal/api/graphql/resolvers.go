@@ -0,0 +1,759 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apitypes "github.com/decred/dcrdata/v8/api/types"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	"github.com/decred/dcrdata/v8/txhelpers"
+)
+
+// resolver is the root resolver of the queries and subscriptions.
+type resolver struct {
+	h *Handler
+}
+
+// chainType validates the Chain enum value, and converts it to the chain type
+// of the database.
+func (h *Handler) chainType(chain string) (string, error) {
+	chainType := strings.ToLower(chain)
+	if h.disabled[chainType] {
+		return "", fmt.Errorf("the %s chain is disabled", chain)
+	}
+	return chainType, nil
+}
+
+// hasOutpoints checks if the inputs, outputs and addresses of the chain are in
+// the database.
+func hasOutpoints(chainType string) bool {
+	return chainType != mutilchain.TYPEXMR
+}
+
+func coins(amount int64, chainType string) float64 {
+	return dbtypes.GetMutilchainCoinAmount(amount, chainType)
+}
+
+// limit clamps the first argument of a list to the range [0, maxFirst].
+func limit(first int32) int {
+	if first < 0 {
+		return 0
+	}
+	if first > maxFirst {
+		return maxFirst
+	}
+	return int(first)
+}
+
+// pageSize is the number of the first items from offset of a list of n items.
+func pageSize(n int, first, offset int32) int {
+	if offset < 0 || int(offset) >= n {
+		return 0
+	}
+	return min(n-int(offset), limit(first))
+}
+
+// page gets the first items from offset.
+func page[T any](items []T, first, offset int32) []T {
+	n := pageSize(len(items), first, offset)
+	if n == 0 {
+		return nil
+	}
+	return items[offset : int(offset)+n]
+}
+
+// block loads the block with the hash, which is nil if it is not found.
+func (req *request) block(ctx context.Context, chainType, hash string) (*blockResolver, error) {
+	if err := req.charge(costBlock); err != nil {
+		return nil, err
+	}
+	b, err := req.chain(chainType).blocks.load(ctx, hash)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return &blockResolver{req: req, chain: chainType, b: b}, nil
+}
+
+// blockAtHeight loads the main chain block at the height, which is nil if it
+// is not found.
+func (req *request) blockAtHeight(ctx context.Context, chainType string, height int64) (*blockResolver, error) {
+	if err := req.charge(costBlock); err != nil {
+		return nil, err
+	}
+	b, err := req.chain(chainType).blockHeights.load(ctx, height)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return &blockResolver{req: req, chain: chainType, b: b}, nil
+}
+
+// tx loads the transaction with the ID, which is nil if it is not found.
+func (req *request) tx(ctx context.Context, chainType, txid string) (*txResolver, error) {
+	if err := req.charge(costTx); err != nil {
+		return nil, err
+	}
+	tx, err := req.chain(chainType).txs.load(ctx, txid)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return &txResolver{req: req, chain: chainType, tx: tx}, nil
+}
+
+// confirmations is the number of confirmations of the block at the height.
+func (req *request) confirmations(chainType string, height int64) int32 {
+	best, _ := req.h.db.GetMutilchainBestBlock(chainType)
+	if height < 0 || best < height {
+		return 0
+	}
+	return int32(best - height + 1)
+}
+
+type chainArgs struct {
+	Chain string
+}
+
+func (r *resolver) BestBlock(ctx context.Context, args chainArgs) (*blockResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	_, hash := r.h.db.GetMutilchainBestBlock(chainType)
+	if hash == "" {
+		return nil, nil
+	}
+	return req.block(ctx, chainType, hash)
+}
+
+func (r *resolver) Block(ctx context.Context, args struct {
+	Chain  string
+	Hash   *string
+	Height *int32
+}) (*blockResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case args.Hash != nil:
+		return req.block(ctx, chainType, *args.Hash)
+	case args.Height != nil:
+		return req.blockAtHeight(ctx, chainType, int64(*args.Height))
+	default:
+		return nil, fmt.Errorf("either the hash or the height of the block is required")
+	}
+}
+
+func (r *resolver) Blocks(ctx context.Context, args struct {
+	Chain  string
+	Height *int32
+	First  int32
+}) ([]*blockResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	top, _ := r.h.db.GetMutilchainBestBlock(chainType)
+	if args.Height != nil && int64(*args.Height) < top {
+		top = int64(*args.Height)
+	}
+	first := limit(args.First)
+	var heights []int64
+	for height := top; height >= 0 && len(heights) < first; height-- {
+		heights = append(heights, height)
+	}
+	if err = req.charge(costBlock * len(heights)); err != nil {
+		return nil, err
+	}
+	blocks, err := req.chain(chainType).blockHeights.loadMany(ctx, heights)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*blockResolver, 0, len(blocks))
+	for _, b := range blocks {
+		if b != nil {
+			resolvers = append(resolvers, &blockResolver{req: req, chain: chainType, b: b})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Transaction(ctx context.Context, args struct {
+	Chain string
+	Txid  string
+}) (*txResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	return req.tx(ctx, chainType, args.Txid)
+}
+
+func (r *resolver) Transactions(ctx context.Context, args struct {
+	Chain string
+	Txids []string
+}) ([]*txResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if len(args.Txids) > maxFirst {
+		return nil, fmt.Errorf("at most %d transactions may be requested", maxFirst)
+	}
+	if err = req.charge(costTx * len(args.Txids)); err != nil {
+		return nil, err
+	}
+	txs, err := req.chain(chainType).txs.loadMany(ctx, args.Txids)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*txResolver, len(txs))
+	for i, tx := range txs {
+		if tx != nil {
+			resolvers[i] = &txResolver{req: req, chain: chainType, tx: tx}
+		}
+	}
+	return resolvers, nil
+}
+
+// addresses loads the balances of the addresses.
+func (req *request) addresses(ctx context.Context, chainType string, addrs []string) ([]*addressResolver, error) {
+	if !hasOutpoints(chainType) {
+		return nil, fmt.Errorf("the addresses of %s are not supported", strings.ToUpper(chainType))
+	}
+	if err := req.charge(costAddress * len(addrs)); err != nil {
+		return nil, err
+	}
+	balances, err := req.chain(chainType).balances.loadMany(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*addressResolver, len(addrs))
+	for i, addr := range addrs {
+		resolvers[i] = &addressResolver{chain: chainType, address: addr, bal: balances[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Address(ctx context.Context, args struct {
+	Chain   string
+	Address string
+}) (*addressResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := req.addresses(ctx, chainType, []string{args.Address})
+	if err != nil {
+		return nil, err
+	}
+	return addrs[0], nil
+}
+
+func (r *resolver) Addresses(ctx context.Context, args struct {
+	Chain     string
+	Addresses []string
+}) ([]*addressResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if len(args.Addresses) > maxFirst {
+		return nil, fmt.Errorf("at most %d addresses may be requested", maxFirst)
+	}
+	return req.addresses(ctx, chainType, args.Addresses)
+}
+
+func (r *resolver) Ticket(ctx context.Context, args struct{ Txid string }) (*ticketResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.h.chainType("DCR"); err != nil {
+		return nil, err
+	}
+	if err = req.charge(costTicket); err != nil {
+		return nil, err
+	}
+	info, err := r.h.db.GetTicketInfo(args.Txid)
+	if err != nil {
+		if errors.Is(err, dbtypes.ErrNoResult) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ticketResolver{req: req, txid: args.Txid, info: info}, nil
+}
+
+func (r *resolver) Swaps(ctx context.Context, args struct {
+	Chain  *string
+	Status string
+	Search *string
+	First  int32
+	Offset int32
+}) ([]*swapResolver, error) {
+	req, err := requestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.h.chainType("DCR"); err != nil {
+		return nil, err
+	}
+	// The pair and status are part of the SQL of the query, so only the
+	// known values are passed.
+	pair := "all"
+	if args.Chain != nil {
+		pair, err = r.h.chainType(*args.Chain)
+		if err != nil {
+			return nil, err
+		}
+		if pair != mutilchain.TYPEBTC && pair != mutilchain.TYPELTC {
+			return nil, fmt.Errorf("there are no atomic swaps of DCR with %s", *args.Chain)
+		}
+	}
+	var status string
+	switch args.Status {
+	case "REFUND":
+		status = "refund"
+	case "REDEMPTION":
+		status = "redemption"
+	default:
+		status = "all"
+	}
+	var search string
+	if args.Search != nil {
+		search = *args.Search
+	}
+	if args.Offset < 0 {
+		return nil, fmt.Errorf("invalid offset %d", args.Offset)
+	}
+	first := limit(args.First)
+	if err = req.charge(costSwaps + first); err != nil {
+		return nil, err
+	}
+	if first == 0 {
+		return []*swapResolver{}, nil
+	}
+	swaps, _, err := r.h.db.GetAtomicSwapList(int64(first), int64(args.Offset), pair, status, search)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*swapResolver, 0, len(swaps))
+	for _, swap := range swaps {
+		resolvers = append(resolvers, &swapResolver{req: req, s: swap})
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) MarketPrice(args chainArgs) (*marketPriceResolver, error) {
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if r.h.prices == nil {
+		return nil, nil
+	}
+	state := r.h.prices.State()
+	if state == nil {
+		return nil, nil
+	}
+	low, high := state.GetMutilchainLowHighPrice(chainType)
+	return &marketPriceResolver{
+		chain:  chainType,
+		index:  state.BtcIndex,
+		price:  state.GetMutilchainPrice(chainType),
+		low:    low,
+		high:   high,
+		change: state.GetMutilchainPriceChange(chainType),
+		volume: state.GetMutilchainVolumn(chainType),
+	}, nil
+}
+
+type blockResolver struct {
+	req   *request
+	chain string
+	b     *dbtypes.Block
+}
+
+func (b *blockResolver) Chain() string        { return strings.ToUpper(b.chain) }
+func (b *blockResolver) Hash() string         { return b.b.Hash }
+func (b *blockResolver) Height() int32        { return int32(b.b.Height) }
+func (b *blockResolver) Time() Int64          { return Int64(b.b.Time.UNIX()) }
+func (b *blockResolver) Size() int32          { return int32(b.b.Size) }
+func (b *blockResolver) Version() int32       { return int32(b.b.Version) }
+func (b *blockResolver) Difficulty() float64  { return b.b.Difficulty }
+func (b *blockResolver) TxCount() int32       { return int32(b.b.NumTx) }
+func (b *blockResolver) PreviousHash() string { return b.b.PreviousHash }
+
+func (b *blockResolver) Confirmations() int32 {
+	return b.req.confirmations(b.chain, int64(b.b.Height))
+}
+
+func (b *blockResolver) PreviousBlock(ctx context.Context) (*blockResolver, error) {
+	if b.b.PreviousHash == "" || b.b.Height == 0 {
+		return nil, nil
+	}
+	return b.req.block(ctx, b.chain, b.b.PreviousHash)
+}
+
+func (b *blockResolver) NextBlock(ctx context.Context) (*blockResolver, error) {
+	return b.req.blockAtHeight(ctx, b.chain, int64(b.b.Height)+1)
+}
+
+func (b *blockResolver) Transactions(ctx context.Context, args struct {
+	First  int32
+	Offset int32
+}) ([]*txResolver, error) {
+	// Charge the page of the transaction count of the block before loading
+	// the transactions.
+	n := pageSize(int(b.b.NumTx), args.First, args.Offset)
+	if err := b.req.charge(costTx * n); err != nil {
+		return nil, err
+	}
+	cl := b.req.chain(b.chain)
+	txids, err := cl.blockTxs.load(ctx, b.b.Hash)
+	if err != nil {
+		return nil, err
+	}
+	txids = page(txids, args.First, args.Offset)
+	if len(txids) > n {
+		txids = txids[:n]
+	}
+	txs, err := cl.txs.loadMany(ctx, txids)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*txResolver, 0, len(txs))
+	for _, tx := range txs {
+		if tx != nil {
+			resolvers = append(resolvers, &txResolver{req: b.req, chain: b.chain, tx: tx})
+		}
+	}
+	return resolvers, nil
+}
+
+type txResolver struct {
+	req   *request
+	chain string
+	tx    *dbtypes.Tx
+}
+
+func (t *txResolver) Chain() string      { return strings.ToUpper(t.chain) }
+func (t *txResolver) Txid() string       { return t.tx.TxID }
+func (t *txResolver) BlockHash() string  { return t.tx.BlockHash }
+func (t *txResolver) BlockHeight() Int64 { return Int64(t.tx.BlockHeight) }
+func (t *txResolver) BlockIndex() int32  { return int32(t.tx.BlockIndex) }
+func (t *txResolver) Time() Int64        { return Int64(t.tx.BlockTime.UNIX()) }
+func (t *txResolver) Size() int32        { return int32(t.tx.Size) }
+func (t *txResolver) Fee() float64       { return coins(t.tx.Fees, t.chain) }
+func (t *txResolver) Sent() float64      { return coins(t.tx.Sent, t.chain) }
+func (t *txResolver) Mainchain() bool    { return t.tx.IsMainchainBlock }
+func (t *txResolver) Confirmations() int32 {
+	if !t.tx.IsMainchainBlock {
+		return 0
+	}
+	return t.req.confirmations(t.chain, t.tx.BlockHeight)
+}
+
+func (t *txResolver) Type() *string {
+	if t.chain != mutilchain.TYPEDCR {
+		return nil
+	}
+	txType := txhelpers.TxTypeToString(int(t.tx.TxType))
+	return &txType
+}
+
+func (t *txResolver) Block(ctx context.Context) (*blockResolver, error) {
+	if t.tx.BlockHash == "" {
+		return nil, nil
+	}
+	return t.req.block(ctx, t.chain, t.tx.BlockHash)
+}
+
+func (t *txResolver) Inputs(ctx context.Context) ([]*inputResolver, error) {
+	if !hasOutpoints(t.chain) {
+		return []*inputResolver{}, nil
+	}
+	// Charge the load and the input count of the transaction before loading
+	// them.
+	if err := t.req.charge(costVinVout * (1 + int(t.tx.NumVin))); err != nil {
+		return nil, err
+	}
+	vins, err := t.req.chain(t.chain).vins.load(ctx, t.tx.TxID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*inputResolver, len(vins))
+	for i, vin := range vins {
+		resolvers[i] = &inputResolver{req: t.req, chain: t.chain, vin: vin}
+	}
+	return resolvers, nil
+}
+
+func (t *txResolver) Outputs(ctx context.Context) ([]*outputResolver, error) {
+	if !hasOutpoints(t.chain) {
+		return []*outputResolver{}, nil
+	}
+	// Charge the load and the output count of the transaction before loading
+	// them.
+	if err := t.req.charge(costVinVout * (1 + int(t.tx.NumVout))); err != nil {
+		return nil, err
+	}
+	vouts, err := t.req.chain(t.chain).vouts.load(ctx, t.tx.TxID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*outputResolver, len(vouts))
+	for i, vout := range vouts {
+		resolvers[i] = &outputResolver{req: t.req, chain: t.chain, vout: vout}
+	}
+	return resolvers, nil
+}
+
+type inputResolver struct {
+	req   *request
+	chain string
+	vin   *dbtypes.VinTxProperty
+}
+
+func (in *inputResolver) Index() int32     { return int32(in.vin.TxIndex) }
+func (in *inputResolver) Tree() int32      { return int32(in.vin.TxTree) }
+func (in *inputResolver) PrevTxid() string { return in.vin.PrevTxHash }
+func (in *inputResolver) PrevIndex() Int64 { return Int64(in.vin.PrevTxIndex) }
+func (in *inputResolver) Amount() float64  { return coins(in.vin.ValueIn, in.chain) }
+
+func (in *inputResolver) PrevOutput(ctx context.Context) (*outputResolver, error) {
+	// Coinbase and stakebase inputs spend the zero hash.
+	if strings.Trim(in.vin.PrevTxHash, "0") == "" {
+		return nil, nil
+	}
+	if err := in.req.charge(costVinVout); err != nil {
+		return nil, err
+	}
+	vouts, err := in.req.chain(in.chain).vouts.load(ctx, in.vin.PrevTxHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, vout := range vouts {
+		if vout.TxIndex == in.vin.PrevTxIndex {
+			return &outputResolver{req: in.req, chain: in.chain, vout: vout}, nil
+		}
+	}
+	return nil, nil
+}
+
+type outputResolver struct {
+	req   *request
+	chain string
+	vout  *dbtypes.Vout
+}
+
+func (out *outputResolver) Index() int32       { return int32(out.vout.TxIndex) }
+func (out *outputResolver) Tree() int32        { return int32(out.vout.TxTree) }
+func (out *outputResolver) Amount() float64    { return coins(int64(out.vout.Value), out.chain) }
+func (out *outputResolver) Version() int32     { return int32(out.vout.Version) }
+func (out *outputResolver) ScriptType() string { return out.vout.ScriptPubKeyData.Type.String() }
+
+func (out *outputResolver) RequiredSignatures() int32 {
+	return int32(out.vout.ScriptPubKeyData.ReqSigs)
+}
+
+func (out *outputResolver) Addresses(ctx context.Context) ([]*addressResolver, error) {
+	addrs := out.vout.ScriptPubKeyData.Addresses
+	if len(addrs) == 0 {
+		return []*addressResolver{}, nil
+	}
+	return out.req.addresses(ctx, out.chain, addrs)
+}
+
+func (out *outputResolver) Transaction(ctx context.Context) (*txResolver, error) {
+	return out.req.tx(ctx, out.chain, out.vout.TxHash)
+}
+
+type addressResolver struct {
+	chain   string
+	address string
+	bal     *dbtypes.AddressBalance
+}
+
+func (a *addressResolver) balance() *dbtypes.AddressBalance {
+	if a.bal == nil {
+		return &dbtypes.AddressBalance{Address: a.address}
+	}
+	return a.bal
+}
+
+func (a *addressResolver) Chain() string       { return strings.ToUpper(a.chain) }
+func (a *addressResolver) Address() string     { return a.address }
+func (a *addressResolver) Balance() float64    { return coins(a.balance().TotalUnspent, a.chain) }
+func (a *addressResolver) Received() float64   { return coins(a.balance().TotalReceived, a.chain) }
+func (a *addressResolver) Sent() float64       { return coins(a.balance().TotalSpent, a.chain) }
+func (a *addressResolver) UnspentCount() Int64 { return Int64(a.balance().NumUnspent) }
+func (a *addressResolver) SpentCount() Int64   { return Int64(a.balance().NumSpent) }
+
+type ticketResolver struct {
+	req  *request
+	txid string
+	info *apitypes.TicketInfo
+}
+
+func (t *ticketResolver) Txid() string            { return t.txid }
+func (t *ticketResolver) Status() string          { return t.info.Status }
+func (t *ticketResolver) MaturityHeight() int32   { return int32(t.info.MaturityHeight) }
+func (t *ticketResolver) ExpirationHeight() int32 { return int32(t.info.ExpirationHeight) }
+
+func (t *ticketResolver) PurchaseBlock(ctx context.Context) (*blockResolver, error) {
+	if t.info.PurchaseBlock == nil {
+		return nil, nil
+	}
+	return t.req.block(ctx, mutilchain.TYPEDCR, t.info.PurchaseBlock.Hash)
+}
+
+func (t *ticketResolver) LotteryBlock(ctx context.Context) (*blockResolver, error) {
+	if t.info.LotteryBlock == nil {
+		return nil, nil
+	}
+	return t.req.block(ctx, mutilchain.TYPEDCR, t.info.LotteryBlock.Hash)
+}
+
+func (t *ticketResolver) Vote(ctx context.Context) (*txResolver, error) {
+	if t.info.Vote == nil {
+		return nil, nil
+	}
+	return t.req.tx(ctx, mutilchain.TYPEDCR, *t.info.Vote)
+}
+
+func (t *ticketResolver) Revocation(ctx context.Context) (*txResolver, error) {
+	if t.info.Revocation == nil {
+		return nil, nil
+	}
+	return t.req.tx(ctx, mutilchain.TYPEDCR, *t.info.Revocation)
+}
+
+func (t *ticketResolver) Transaction(ctx context.Context) (*txResolver, error) {
+	return t.req.tx(ctx, mutilchain.TYPEDCR, t.txid)
+}
+
+type swapResolver struct {
+	req *request
+	s   *dbtypes.AtomicSwapFullData
+}
+
+func (s *swapResolver) Chain() *string {
+	if s.s.TargetToken == "" {
+		return nil
+	}
+	chain := strings.ToUpper(s.s.TargetToken)
+	return &chain
+}
+
+func (s *swapResolver) ContractTxid() string { return s.s.GroupTx }
+func (s *swapResolver) Time() Int64          { return Int64(s.s.Time) }
+func (s *swapResolver) Refund() bool         { return s.s.IsRefund }
+
+func (s *swapResolver) Source() *swapSideResolver {
+	side := s.s.Source
+	if side == nil {
+		side = new(dbtypes.AtomicSwapForTokenData)
+	}
+	return &swapSideResolver{req: s.req, chain: mutilchain.TYPEDCR, side: side}
+}
+
+func (s *swapResolver) Target() *swapSideResolver {
+	if s.s.Target == nil || s.s.TargetToken == "" {
+		return nil
+	}
+	return &swapSideResolver{req: s.req, chain: s.s.TargetToken, side: s.s.Target}
+}
+
+type swapSideResolver struct {
+	req   *request
+	chain string
+	side  *dbtypes.AtomicSwapForTokenData
+}
+
+func (s *swapSideResolver) Chain() string   { return strings.ToUpper(s.chain) }
+func (s *swapSideResolver) Amount() float64 { return coins(s.side.TotalAmount, s.chain) }
+
+func (s *swapSideResolver) Contracts() []*swapTxResolver {
+	return s.txs(s.side.Contracts)
+}
+
+func (s *swapSideResolver) Results() []*swapTxResolver {
+	return s.txs(s.side.Results)
+}
+
+func (s *swapSideResolver) txs(txs []*dbtypes.AtomicSwapTxData) []*swapTxResolver {
+	resolvers := make([]*swapTxResolver, len(txs))
+	for i, tx := range txs {
+		resolvers[i] = &swapTxResolver{req: s.req, chain: s.chain, tx: tx}
+	}
+	return resolvers
+}
+
+type swapTxResolver struct {
+	req   *request
+	chain string
+	tx    *dbtypes.AtomicSwapTxData
+}
+
+func (s *swapTxResolver) Txid() string    { return s.tx.Txid }
+func (s *swapTxResolver) Time() Int64     { return Int64(s.tx.Time) }
+func (s *swapTxResolver) LockTime() Int64 { return Int64(s.tx.LockTime) }
+func (s *swapTxResolver) Height() Int64   { return Int64(s.tx.Height) }
+func (s *swapTxResolver) Fee() float64    { return coins(s.tx.Fees, s.chain) }
+func (s *swapTxResolver) Amount() float64 { return coins(s.tx.Value, s.chain) }
+
+func (s *swapTxResolver) Transaction(ctx context.Context) (*txResolver, error) {
+	if _, err := s.req.h.chainType(s.chain); err != nil {
+		return nil, err
+	}
+	return s.req.tx(ctx, s.chain, s.tx.Txid)
+}
+
+type marketPriceResolver struct {
+	chain                            string
+	index                            string
+	price, low, high, change, volume float64
+}
+
+func (m *marketPriceResolver) Chain() string      { return strings.ToUpper(m.chain) }
+func (m *marketPriceResolver) Index() string      { return m.index }
+func (m *marketPriceResolver) Price() float64     { return m.price }
+func (m *marketPriceResolver) Low() float64       { return m.low }
+func (m *marketPriceResolver) High() float64      { return m.high }
+func (m *marketPriceResolver) Change24h() float64 { return m.change }
+func (m *marketPriceResolver) Volume() float64    { return m.volume }
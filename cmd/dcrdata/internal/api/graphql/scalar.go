@@ -0,0 +1,46 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Int64 is the Int64 scalar of the schema, for the values like amounts in
+// atoms and UNIX times that overflow the 32-bit Int of GraphQL.
+type Int64 int64
+
+// ImplementsGraphQLType maps Int64 to the Int64 scalar.
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+// UnmarshalGraphQL parses an Int64 argument, which may be given as a number
+// or a string.
+func (i *Int64) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*i = Int64(v)
+	case int:
+		*i = Int64(v)
+	case int64:
+		*i = Int64(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt64 {
+			return fmt.Errorf("invalid Int64 %v", v)
+		}
+		*i = Int64(v)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Int64 %q", v)
+		}
+		*i = Int64(n)
+	default:
+		return fmt.Errorf("invalid Int64 type %T", input)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+// schema is the GraphQL schema of the API. The amounts are in coins, and the
+// times are UNIX timestamps.
+const schema = `
+schema {
+	query: Query
+	subscription: Subscription
+}
+
+"""
+A 64-bit integer.
+"""
+scalar Int64
+
+enum Chain {
+	DCR
+	BTC
+	LTC
+	XMR
+}
+
+enum SwapStatus {
+	ALL
+	REFUND
+	REDEMPTION
+}
+
+type Query {
+	"""
+	The best block of the chain.
+	"""
+	bestBlock(chain: Chain!): Block
+	"""
+	The block with the hash, or the main chain block at the height.
+	"""
+	block(chain: Chain!, hash: String, height: Int): Block
+	"""
+	The main chain blocks down from the height, or from the best block.
+	"""
+	blocks(chain: Chain!, height: Int, first: Int = 10): [Block!]!
+	transaction(chain: Chain!, txid: String!): Transaction
+	transactions(chain: Chain!, txids: [String!]!): [Transaction]!
+	address(chain: Chain!, address: String!): Address!
+	addresses(chain: Chain!, addresses: [String!]!): [Address!]!
+	"""
+	The Decred ticket with the transaction ID.
+	"""
+	ticket(txid: String!): Ticket
+	"""
+	The atomic swaps of Decred with the chain, the latest first. The search
+	matches the transaction IDs of the swaps.
+	"""
+	swaps(chain: Chain, status: SwapStatus = ALL, search: String, first: Int = 20, offset: Int = 0): [Swap!]!
+	marketPrice(chain: Chain!): MarketPrice
+}
+
+type Subscription {
+	"""
+	The new blocks of DCR, BTC or LTC.
+	"""
+	newBlock(chain: Chain!): Block!
+	"""
+	The new mempool transactions of DCR, BTC or LTC.
+	"""
+	newTransaction(chain: Chain!): MempoolTransaction!
+	"""
+	The transactions of a Decred address as they are seen.
+	"""
+	addressTransaction(address: String!): AddressTransaction!
+}
+
+type Block {
+	chain: Chain!
+	hash: String!
+	height: Int!
+	time: Int64!
+	size: Int!
+	version: Int!
+	difficulty: Float!
+	txCount: Int!
+	confirmations: Int!
+	previousHash: String!
+	previousBlock: Block
+	nextBlock: Block
+	transactions(first: Int = 25, offset: Int = 0): [Transaction!]!
+}
+
+type Transaction {
+	chain: Chain!
+	txid: String!
+	"""
+	The Decred transaction type, e.g. Ticket or Vote.
+	"""
+	type: String
+	blockHash: String!
+	blockHeight: Int64!
+	blockIndex: Int!
+	time: Int64!
+	size: Int!
+	fee: Float!
+	sent: Float!
+	mainchain: Boolean!
+	confirmations: Int!
+	block: Block
+	inputs: [Input!]!
+	outputs: [Output!]!
+}
+
+type Input {
+	index: Int!
+	tree: Int!
+	prevTxid: String!
+	prevIndex: Int64!
+	amount: Float!
+	"""
+	The spent output, which is null for coinbase and stakebase inputs.
+	"""
+	prevOutput: Output
+}
+
+type Output {
+	index: Int!
+	tree: Int!
+	amount: Float!
+	version: Int!
+	scriptType: String!
+	requiredSignatures: Int!
+	addresses: [Address!]!
+	transaction: Transaction
+}
+
+type Address {
+	chain: Chain!
+	address: String!
+	balance: Float!
+	received: Float!
+	sent: Float!
+	unspentCount: Int64!
+	spentCount: Int64!
+}
+
+type Ticket {
+	txid: String!
+	status: String!
+	maturityHeight: Int!
+	expirationHeight: Int!
+	purchaseBlock: Block
+	lotteryBlock: Block
+	vote: Transaction
+	revocation: Transaction
+	transaction: Transaction
+}
+
+type Swap {
+	"""
+	The chain of the target side of the swap.
+	"""
+	chain: Chain
+	contractTxid: String!
+	time: Int64!
+	refund: Boolean!
+	source: SwapSide!
+	target: SwapSide
+}
+
+type SwapSide {
+	chain: Chain!
+	amount: Float!
+	contracts: [SwapTransaction!]!
+	results: [SwapTransaction!]!
+}
+
+type SwapTransaction {
+	txid: String!
+	time: Int64!
+	lockTime: Int64!
+	height: Int64!
+	fee: Float!
+	amount: Float!
+	transaction: Transaction
+}
+
+type MarketPrice {
+	chain: Chain!
+	index: String!
+	price: Float!
+	low: Float!
+	high: Float!
+	change24h: Float!
+	volume: Float!
+}
+
+type MempoolTransaction {
+	chain: Chain!
+	txid: String!
+	type: String
+	time: Int64!
+	size: Int!
+	fee: Float!
+	amount: Float!
+	"""
+	The transaction once it is mined.
+	"""
+	transaction: Transaction
+}
+
+type AddressTransaction {
+	address: Address!
+	txid: String!
+	transaction: Transaction
+}
+`
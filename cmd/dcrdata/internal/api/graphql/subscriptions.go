@@ -0,0 +1,168 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	"github.com/decred/dcrdata/v8/pubsub"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+)
+
+// subscribe subscribes to the events of the chain, and relays them to the
+// channel of a subscription with relay. The channel is closed when the
+// subscription ends, the event stream hangs up or relay returns false.
+func subscribe[T any](ctx context.Context, h *Handler, events, chainType string, relay func(*pubsub.Event, chan<- T) bool) (<-chan T, error) {
+	if h.events == nil {
+		return nil, fmt.Errorf("subscriptions are not available")
+	}
+	evs, err := h.events.SubscribeEvents(ctx, events, chainType)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan T)
+	go func() {
+		defer close(c)
+		for ev := range evs {
+			if ev.Event == pstypes.SigByeNow.String() || !relay(ev, c) {
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// send sends v to the channel of a subscription, failing when the
+// subscription has ended.
+func send[T any](ctx context.Context, c chan<- T, v T) bool {
+	select {
+	case c <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (r *resolver) NewBlock(ctx context.Context, args chainArgs) (<-chan *blockResolver, error) {
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if chainType == mutilchain.TYPEXMR {
+		return nil, fmt.Errorf("there are no block events of XMR")
+	}
+	return subscribe(ctx, r.h, "newblock", chainType, func(ev *pubsub.Event, c chan<- *blockResolver) bool {
+		var msg exptypes.WebsocketBlock
+		if err := json.Unmarshal(ev.Data, &msg); err != nil || msg.Block == nil {
+			log.Errorf("Invalid %s event: %v", ev.Event, err)
+			return true
+		}
+		// Each event is resolved as a request of its own.
+		b, err := r.h.newRequest(ctx).block(ctx, chainType, msg.Block.Hash)
+		if err != nil {
+			log.Errorf("Failed to load %s block %s: %v", chainType, msg.Block.Hash, err)
+			return true
+		}
+		if b == nil {
+			log.Debugf("New %s block %s not found.", chainType, msg.Block.Hash)
+			return true
+		}
+		return send(ctx, c, b)
+	})
+}
+
+func (r *resolver) NewTransaction(ctx context.Context, args chainArgs) (<-chan *mempoolTxResolver, error) {
+	chainType, err := r.h.chainType(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if chainType == mutilchain.TYPEXMR {
+		return nil, fmt.Errorf("there are no transaction events of XMR")
+	}
+	return subscribe(ctx, r.h, "newtxs", chainType, func(ev *pubsub.Event, c chan<- *mempoolTxResolver) bool {
+		var txs pstypes.TxList
+		var err error
+		if chainType == mutilchain.TYPEDCR {
+			err = json.Unmarshal(ev.Data, &txs)
+		} else {
+			var msg pstypes.ChainTxList
+			err = json.Unmarshal(ev.Data, &msg)
+			txs = msg.Txs
+		}
+		if err != nil {
+			log.Errorf("Invalid %s event: %v", ev.Event, err)
+			return true
+		}
+		for _, tx := range txs {
+			mtx := &mempoolTxResolver{req: r.h.newRequest(ctx), chain: chainType, tx: tx}
+			if !send(ctx, c, mtx) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (r *resolver) AddressTransaction(ctx context.Context, args struct{ Address string }) (<-chan *addressTxResolver, error) {
+	chainType, err := r.h.chainType("DCR")
+	if err != nil {
+		return nil, err
+	}
+	return subscribe(ctx, r.h, "address:"+args.Address, chainType, func(ev *pubsub.Event, c chan<- *addressTxResolver) bool {
+		var msg pstypes.AddressMessage
+		if err := json.Unmarshal(ev.Data, &msg); err != nil {
+			log.Errorf("Invalid %s event: %v", ev.Event, err)
+			return true
+		}
+		return send(ctx, c, &addressTxResolver{req: r.h.newRequest(ctx), msg: &msg})
+	})
+}
+
+type mempoolTxResolver struct {
+	req   *request
+	chain string
+	tx    *exptypes.MempoolTx
+}
+
+func (m *mempoolTxResolver) Chain() string   { return strings.ToUpper(m.chain) }
+func (m *mempoolTxResolver) Txid() string    { return m.tx.TxID }
+func (m *mempoolTxResolver) Time() Int64     { return Int64(m.tx.Time) }
+func (m *mempoolTxResolver) Size() int32     { return m.tx.Size }
+func (m *mempoolTxResolver) Fee() float64    { return m.tx.Fees }
+func (m *mempoolTxResolver) Amount() float64 { return m.tx.TotalOut }
+
+func (m *mempoolTxResolver) Type() *string {
+	if m.chain != mutilchain.TYPEDCR || m.tx.Type == "" {
+		return nil
+	}
+	return &m.tx.Type
+}
+
+func (m *mempoolTxResolver) Transaction(ctx context.Context) (*txResolver, error) {
+	return m.req.tx(ctx, m.chain, m.tx.TxID)
+}
+
+type addressTxResolver struct {
+	req *request
+	msg *pstypes.AddressMessage
+}
+
+func (a *addressTxResolver) Txid() string { return a.msg.TxHash }
+
+func (a *addressTxResolver) Address(ctx context.Context) (*addressResolver, error) {
+	addrs, err := a.req.addresses(ctx, mutilchain.TYPEDCR, []string{a.msg.Address})
+	if err != nil {
+		return nil, err
+	}
+	return addrs[0], nil
+}
+
+func (a *addressTxResolver) Transaction(ctx context.Context) (*txResolver, error) {
+	return a.req.tx(ctx, mutilchain.TYPEDCR, a.msg.TxHash)
+}
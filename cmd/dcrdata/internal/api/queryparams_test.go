@@ -0,0 +1,41 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestFloatQueryParam(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		ok    bool
+	}{
+		{"", 365, true},
+		{"30", 30, true},
+		{"3650", 3650, true},
+		{"0", 0, false},
+		{"3651", 0, false},
+		{"x", 0, false},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+		{"-Inf", 0, false},
+	}
+	for _, tt := range tests {
+		query := url.Values{}
+		if tt.value != "" {
+			query.Set("days", tt.value)
+		}
+		f, err := floatQueryParam(query, "days", 365, 1, maxStakeSimDays)
+		if (err == nil) != tt.ok {
+			t.Errorf("%q: unexpected error %v", tt.value, err)
+			continue
+		}
+		if f != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.value, f, tt.want)
+		}
+	}
+}
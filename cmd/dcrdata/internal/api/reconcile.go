@@ -0,0 +1,270 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	apitypes "github.com/decred/dcrdata/v8/api/types"
+)
+
+// budgetProposal is an approved proposal budget in USD for the UTC days start
+// to end, inclusive.
+type budgetProposal struct {
+	token, name, owner, domain string
+	budget                     float64
+	start, end                 time.Time
+}
+
+// monthPayout is the treasury outflow of a month in atoms, by treasury spends
+// and legacy treasury address spends, and the DCR price in USD of the month.
+type monthPayout struct {
+	tspend, legacy int64
+	price          float64
+}
+
+// monthIndex counts the months since year 0, so that months can be added.
+func monthIndex(t time.Time) int {
+	t = t.UTC()
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+func monthStart(idx int) time.Time {
+	return time.Date(idx/12, time.Month(idx%12+1), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthKey(idx int) string {
+	return monthStart(idx).Format("2006-01")
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) float64 {
+	return math.Round(to.Sub(from).Hours()/24) + 1
+}
+
+// monthlyBudgets splits the budget over the months of the proposal period in
+// proportion to the days of the period in each month.
+func (p *budgetProposal) monthlyBudgets() map[int]float64 {
+	start, end := utcDay(p.start), utcDay(p.end)
+	days := daysBetween(start, end)
+	if days <= 0 {
+		return nil
+	}
+	perDay := p.budget / days
+	budgets := make(map[int]float64)
+	for idx := monthIndex(start); idx <= monthIndex(end); idx++ {
+		from, to := monthStart(idx), monthStart(idx+1).AddDate(0, 0, -1)
+		if start.After(from) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
+		}
+		budgets[idx] = perDay * daysBetween(from, to)
+	}
+	return budgets
+}
+
+// reconcileStatus flags a budget that was paid more than the tolerance over,
+// or that was paid out with more than the tolerance left over.
+func reconcileStatus(budget, paid float64, paidOut bool, tolerance float64) string {
+	switch {
+	case paid > budget*(1+tolerance):
+		return apitypes.ReconcileOverspent
+	case !paidOut:
+		return apitypes.ReconcileActive
+	case paid < budget*(1-tolerance):
+		return apitypes.ReconcileUnspent
+	default:
+		return apitypes.ReconcileReconciled
+	}
+}
+
+func cents(usd float64) float64 {
+	return math.Round(usd*100) / 100
+}
+
+// reconcileTreasury reconciles the payouts of each month, keyed by monthIndex,
+// with the proposal budgets of the work month lag months earlier. A work month
+// is paid out once its payout month is over at the now time, and a proposal
+// once its last work month is paid out. The payouts do not identify the
+// proposals they pay, so the proposals are credited an estimated share of the
+// payouts in proportion to their budgets in the month. The months, proposals,
+// domains and owners are flagged. Payouts with no budget to attribute them to
+// are unallocated.
+func reconcileTreasury(proposals []*budgetProposal, payouts map[int]*monthPayout,
+	lag int, tolerance float64, now time.Time) *apitypes.TreasuryReconciliation {
+	budgets := make([]map[int]float64, len(proposals))
+	monthBudgets := make(map[int]float64)
+	workMonths := make(map[int]bool)
+	for i, p := range proposals {
+		budgets[i] = p.monthlyBudgets()
+		for idx, budget := range budgets[i] {
+			monthBudgets[idx] += budget
+			workMonths[idx] = true
+		}
+	}
+	for idx := range payouts {
+		workMonths[idx-lag] = true
+	}
+	months := make([]int, 0, len(workMonths))
+	for idx := range workMonths {
+		months = append(months, idx)
+	}
+	sort.Ints(months)
+
+	rec := &apitypes.TreasuryReconciliation{
+		Lag:       lag,
+		Tolerance: tolerance,
+		Proposals: make([]*apitypes.ProposalReconciliation, 0, len(proposals)),
+		Domains:   make([]*apitypes.GroupReconciliation, 0),
+		Owners:    make([]*apitypes.GroupReconciliation, 0),
+		Months:    make([]*apitypes.MonthReconciliation, 0, len(months)),
+	}
+	nowIdx := monthIndex(now)
+	paid := make([]float64, len(proposals))
+	paidDCR := make([]float64, len(proposals))
+	for _, idx := range months {
+		m := &apitypes.MonthReconciliation{
+			Month:       monthKey(idx),
+			PayoutMonth: monthKey(idx + lag),
+			Budget:      monthBudgets[idx],
+		}
+		if payout := payouts[idx+lag]; payout != nil {
+			m.TSpendDCR = float64(payout.tspend) / 1e8
+			m.LegacyDCR = float64(payout.legacy) / 1e8
+			m.PaidDCR = m.TSpendDCR + m.LegacyDCR
+			m.Paid = m.PaidDCR * payout.price
+			m.UsdRate = payout.price
+		}
+		if m.Budget > 0 {
+			for i := range proposals {
+				share := budgets[i][idx] / m.Budget
+				paid[i] += share * m.Paid
+				paidDCR[i] += share * m.PaidDCR
+			}
+		} else {
+			m.Unallocated = m.Paid
+		}
+		rec.Budget += m.Budget
+		rec.Paid += m.Paid
+		rec.Unallocated += m.Unallocated
+		m.Budget, m.Paid, m.Unallocated = cents(m.Budget), cents(m.Paid), cents(m.Unallocated)
+		m.Status = reconcileStatus(m.Budget, m.Paid, idx+lag < nowIdx, tolerance)
+		rec.Months = append(rec.Months, m)
+	}
+	rec.Budget, rec.Paid, rec.Unallocated = cents(rec.Budget), cents(rec.Paid), cents(rec.Unallocated)
+
+	domains := make(map[string]*apitypes.GroupReconciliation)
+	owners := make(map[string]*apitypes.GroupReconciliation)
+	groupsPaidOut := make(map[*apitypes.GroupReconciliation]bool)
+	addToGroup := func(groups map[string]*apitypes.GroupReconciliation, name string,
+		pr *apitypes.ProposalReconciliation, paidOut bool) {
+		g, ok := groups[name]
+		if !ok {
+			g = &apitypes.GroupReconciliation{Name: name}
+			groups[name] = g
+			groupsPaidOut[g] = true
+		}
+		g.Proposals++
+		g.Budget += pr.Budget
+		g.Paid += pr.Paid
+		g.PaidDCR += pr.PaidDCR
+		switch pr.Status {
+		case apitypes.ReconcileOverspent:
+			g.Overspent++
+		case apitypes.ReconcileUnspent:
+			g.Unspent++
+		}
+		groupsPaidOut[g] = groupsPaidOut[g] && paidOut
+	}
+
+	for i, p := range proposals {
+		paidOut := monthIndex(p.end)+lag < nowIdx
+		pr := &apitypes.ProposalReconciliation{
+			Token:     p.token,
+			Name:      p.name,
+			Owner:     p.owner,
+			Domain:    p.domain,
+			Start:     p.start.UTC().Format("2006-01-02"),
+			End:       p.end.UTC().Format("2006-01-02"),
+			Budget:    cents(p.budget),
+			Paid:      cents(paid[i]),
+			PaidDCR:   paidDCR[i],
+			Remaining: cents(p.budget - paid[i]),
+			Status:    reconcileStatus(p.budget, paid[i], paidOut, tolerance),
+		}
+		rec.Proposals = append(rec.Proposals, pr)
+		addToGroup(domains, p.domain, pr, paidOut)
+		addToGroup(owners, p.owner, pr, paidOut)
+	}
+
+	sortedGroups := func(groups map[string]*apitypes.GroupReconciliation) []*apitypes.GroupReconciliation {
+		list := make([]*apitypes.GroupReconciliation, 0, len(groups))
+		for _, g := range groups {
+			g.Budget, g.Paid = cents(g.Budget), cents(g.Paid)
+			g.Remaining = cents(g.Budget - g.Paid)
+			g.Status = reconcileStatus(g.Budget, g.Paid, groupsPaidOut[g], tolerance)
+			list = append(list, g)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		return list
+	}
+	rec.Domains = sortedGroups(domains)
+	rec.Owners = sortedGroups(owners)
+	return rec
+}
+
+// writeReconciliationCSV writes one view of the reconciliation as CSV: the
+// proposals, domains, owners or months.
+func writeReconciliationCSV(w io.Writer, rec *apitypes.TreasuryReconciliation, view string) error {
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	var records [][]string
+	switch view {
+	case "proposals":
+		records = append(records, []string{"token", "name", "owner", "domain", "start", "end",
+			"budget_usd", "paid_usd", "paid_dcr", "remaining_usd", "status"})
+		for _, p := range rec.Proposals {
+			records = append(records, []string{p.Token, p.Name, p.Owner, p.Domain, p.Start, p.End,
+				formatFloat(p.Budget), formatFloat(p.Paid), formatFloat(p.PaidDCR),
+				formatFloat(p.Remaining), p.Status})
+		}
+	case "domains", "owners":
+		groups := rec.Domains
+		if view == "owners" {
+			groups = rec.Owners
+		}
+		records = append(records, []string{view[:len(view)-1], "proposals", "budget_usd", "paid_usd",
+			"paid_dcr", "remaining_usd", "overspent", "unspent", "status"})
+		for _, g := range groups {
+			records = append(records, []string{g.Name, strconv.Itoa(g.Proposals),
+				formatFloat(g.Budget), formatFloat(g.Paid), formatFloat(g.PaidDCR),
+				formatFloat(g.Remaining), strconv.Itoa(g.Overspent), strconv.Itoa(g.Unspent), g.Status})
+		}
+	case "months":
+		records = append(records, []string{"month", "payout_month", "budget_usd", "paid_usd",
+			"paid_dcr", "tspend_dcr", "legacy_dcr", "unallocated_usd", "usd_rate", "status"})
+		for _, m := range rec.Months {
+			records = append(records, []string{m.Month, m.PayoutMonth, formatFloat(m.Budget),
+				formatFloat(m.Paid), formatFloat(m.PaidDCR), formatFloat(m.TSpendDCR),
+				formatFloat(m.LegacyDCR), formatFloat(m.Unallocated), formatFloat(m.UsdRate), m.Status})
+		}
+	default:
+		return fmt.Errorf("unknown view %q", view)
+	}
+	writer := csv.NewWriter(w)
+	return writer.WriteAll(records)
+}
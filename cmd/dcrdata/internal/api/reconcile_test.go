@@ -0,0 +1,120 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	apitypes "github.com/decred/dcrdata/v8/api/types"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestMonthlyBudgets(t *testing.T) {
+	p := &budgetProposal{budget: 620, start: date(2023, 1, 16), end: date(2023, 2, 14)}
+	budgets := p.monthlyBudgets()
+	jan, feb := monthIndex(date(2023, 1, 1)), monthIndex(date(2023, 2, 1))
+	if len(budgets) != 2 || math.Abs(budgets[jan]-16*620/30.) > 1e-9 || math.Abs(budgets[feb]-14*620/30.) > 1e-9 {
+		t.Errorf("wrong monthly budgets %v", budgets)
+	}
+	if monthKey(feb) != "2023-02" || monthKey(monthIndex(date(2022, 12, 31))+1) != "2023-01" {
+		t.Errorf("wrong month keys")
+	}
+}
+
+func TestReconcileTreasury(t *testing.T) {
+	proposals := []*budgetProposal{
+		{token: "p1", name: "Dev", owner: "alice", domain: "development", budget: 3100,
+			start: date(2023, 1, 1), end: date(2023, 1, 31)},
+		{token: "p2", name: "Ads", owner: "bob", domain: "marketing", budget: 2800,
+			start: date(2023, 2, 1), end: date(2023, 2, 28)},
+		{token: "p3", name: "Ops", owner: "alice", domain: "development", budget: 1000,
+			start: date(2023, 1, 1), end: date(2023, 1, 31)},
+		{token: "p4", name: "Research", owner: "carol", domain: "research", budget: 1000,
+			start: date(2022, 6, 1), end: date(2022, 6, 30)},
+		{token: "p5", name: "Ongoing", owner: "carol", domain: "research", budget: 500,
+			start: date(2023, 6, 1), end: date(2023, 6, 30)},
+	}
+	payouts := map[int]*monthPayout{
+		// Pays the January work.
+		monthIndex(date(2023, 2, 1)): {tspend: 3000e8, legacy: 1100e8, price: 1},
+		// Pays the February work, 4000 USD for a 2800 USD budget.
+		monthIndex(date(2023, 3, 1)): {tspend: 2000e8, price: 2},
+		// Pays work without a budget.
+		monthIndex(date(2023, 5, 1)): {tspend: 100e8, price: 1},
+	}
+	rec := reconcileTreasury(proposals, payouts, 1, 0.05, date(2023, 6, 15))
+
+	proposalStatuses := map[string]string{
+		"p1": apitypes.ReconcileReconciled,
+		"p2": apitypes.ReconcileOverspent,
+		"p3": apitypes.ReconcileReconciled,
+		"p4": apitypes.ReconcileUnspent,
+		"p5": apitypes.ReconcileActive,
+	}
+	for _, p := range rec.Proposals {
+		if p.Status != proposalStatuses[p.Token] {
+			t.Errorf("proposal %s has status %s, expected %s", p.Token, p.Status, proposalStatuses[p.Token])
+		}
+	}
+
+	if p1 := rec.Proposals[0]; p1.Paid != 3100 || p1.PaidDCR != 3100 || p1.Remaining != 0 {
+		t.Errorf("wrong p1 reconciliation %+v", p1)
+	}
+	if p2 := rec.Proposals[1]; p2.Paid != 4000 || p2.Remaining != -1200 {
+		t.Errorf("wrong p2 reconciliation %+v", p2)
+	}
+	if rec.Budget != 8400 || rec.Paid != 8200 || rec.Unallocated != 100 {
+		t.Errorf("wrong totals: budget %v, paid %v, unallocated %v", rec.Budget, rec.Paid, rec.Unallocated)
+	}
+
+	if len(rec.Domains) != 3 || rec.Domains[0].Name != "development" || rec.Domains[0].Paid != 4100 ||
+		rec.Domains[0].Status != apitypes.ReconcileReconciled || rec.Domains[1].Remaining != -1200 ||
+		rec.Domains[1].Overspent != 1 || rec.Domains[2].Budget != 1500 || rec.Domains[2].Unspent != 1 ||
+		rec.Domains[2].Status != apitypes.ReconcileActive {
+		t.Errorf("wrong domains %+v %+v %+v", rec.Domains[0], rec.Domains[1], rec.Domains[2])
+	}
+	if len(rec.Owners) != 3 || rec.Owners[0].Name != "alice" || rec.Owners[0].Proposals != 2 || rec.Owners[0].Budget != 4100 {
+		t.Errorf("wrong owners %+v", rec.Owners)
+	}
+
+	statuses := map[string]string{
+		"2022-06": apitypes.ReconcileUnspent,
+		"2023-01": apitypes.ReconcileReconciled,
+		"2023-02": apitypes.ReconcileOverspent,
+		"2023-04": apitypes.ReconcileOverspent,
+		"2023-06": apitypes.ReconcileActive,
+	}
+	var unallocated *apitypes.MonthReconciliation
+	for _, m := range rec.Months {
+		if status, ok := statuses[m.Month]; ok && m.Status != status {
+			t.Errorf("month %s has status %s, expected %s", m.Month, m.Status, status)
+		}
+		if m.Month == "2023-04" {
+			unallocated = m
+		}
+	}
+	if unallocated == nil || unallocated.PayoutMonth != "2023-05" || unallocated.Unallocated != 100 || unallocated.Budget != 0 {
+		t.Errorf("wrong unallocated month %+v", unallocated)
+	}
+
+	var buf bytes.Buffer
+	if err := writeReconciliationCSV(&buf, rec, "domains"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "domain,proposals,") ||
+		lines[2] != "marketing,1,2800,4000,2000,-1200,1,0,overspent" {
+		t.Errorf("wrong CSV:\n%s", buf.String())
+	}
+	if err := writeReconciliationCSV(&buf, rec, "bogus"); err == nil {
+		t.Errorf("expected an error for an unknown view")
+	}
+}
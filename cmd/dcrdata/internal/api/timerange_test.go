@@ -0,0 +1,43 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		query    string
+		from, to int64
+		ok       bool
+	}{
+		{"?to=100000", 100000 - 86400, 100000, true},
+		{"?from=1000&to=2000", 1000, 2000, true},
+		{"?from=2000&to=1000", 0, 0, false},
+		{"?from=x", 0, 0, false},
+		{"?from=0&to=31536000", 0, 31536000, true},
+		{"?from=0&to=31536001", 0, 0, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/"+tt.query, nil)
+		from, to, err := parseTimeRange(r, day, 365*day)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected error %v", tt.query, err)
+			continue
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("%s: got %d to %d, want %d to %d", tt.query, from, to, tt.from, tt.to)
+		}
+	}
+
+	// No limit.
+	r := httptest.NewRequest("GET", "/?from=0&to=1000000000", nil)
+	if _, _, err := parseTimeRange(r, day, 0); err != nil {
+		t.Errorf("unexpected error %v without a limit", err)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLimitNewWebhookTokens(t *testing.T) {
+	handler := limitNewWebhookTokens(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	post := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", nil)
+		req.RemoteAddr = "203.0.113.1:1234"
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// One new token per minute, and any number of hooks with a token.
+	if code := post(""); code != http.StatusCreated {
+		t.Fatalf("first new token rejected with %d", code)
+	}
+	if code := post(""); code != http.StatusTooManyRequests {
+		t.Errorf("second new token not limited, got %d", code)
+	}
+	for i := 0; i < 3; i++ {
+		if code := post("token"); code != http.StatusCreated {
+			t.Errorf("hook with a token limited, got %d", code)
+		}
+	}
+}
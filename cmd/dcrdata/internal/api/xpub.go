@@ -0,0 +1,49 @@
+// Copyright (c) 2026, The dcrdata developers
+// See LICENSE for details.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+)
+
+// xpubAddresses derives the P2PKH addresses of the first count children of the
+// external (0) and internal (1) branches of an account extended public key.
+// Extended private keys are rejected.
+func xpubAddresses(xpub string, count uint32, params *chaincfg.Params) ([]string, error) {
+	key, err := hdkeychain.NewKeyFromString(xpub, params)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsPrivate() {
+		return nil, fmt.Errorf("extended private keys are not accepted")
+	}
+	addrs := make([]string, 0, 2*count)
+	for branch := uint32(0); branch < 2; branch++ {
+		branchKey, err := key.Child(branch)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			child, err := branchKey.Child(i)
+			if err == hdkeychain.ErrInvalidChild {
+				// Wallets skip the rare invalid children too.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			pkHash := stdaddr.Hash160(child.SerializedPubKey())
+			addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(pkHash, params)
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, addr.String())
+		}
+	}
+	return addrs, nil
+}
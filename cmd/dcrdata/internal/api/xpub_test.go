@@ -0,0 +1,52 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+)
+
+func TestXpubAddresses(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{1}, 32), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// m/44'/42'/0'
+	account := master
+	for _, i := range []uint32{44, 42, 0} {
+		if account, err = account.Child(hdkeychain.HardenedKeyStart + i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = xpubAddresses(account.String(), 5, params); err == nil {
+		t.Errorf("expected an error for an extended private key")
+	}
+	if _, err = xpubAddresses("dpubinvalid", 5, params); err == nil {
+		t.Errorf("expected an error for an invalid key")
+	}
+
+	addrs, err := xpubAddresses(account.Neuter().String(), 5, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 10 {
+		t.Fatalf("expected 10 addresses, got %d", len(addrs))
+	}
+	// The public derivation matches the private derivation.
+	for branch := uint32(0); branch < 2; branch++ {
+		branchKey, _ := account.Child(branch)
+		for i := uint32(0); i < 5; i++ {
+			child, _ := branchKey.Child(i)
+			addr, _ := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(
+				stdaddr.Hash160(child.SerializedPubKey()), params)
+			if got := addrs[branch*5+i]; got != addr.String() {
+				t.Errorf("branch %d child %d: got %s, expected %s", branch, i, got, addr)
+			}
+		}
+	}
+}
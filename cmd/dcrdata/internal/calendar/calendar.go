@@ -0,0 +1,357 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+// Package calendar collects the governance and consensus events of the
+// supported chains, such as proposal votes, rule change intervals, treasury
+// spend expiries and block reward reductions, and estimates their times from
+// block heights. The estimates are updated as blocks arrive.
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+	"github.com/decred/dcrdata/gov/v6/agendas"
+	pitypes "github.com/decred/dcrdata/gov/v6/politeia/types"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/mutilchain"
+)
+
+// The kinds of calendar events.
+const (
+	KindProposalVoteStart = "proposal_vote_start"
+	KindProposalVoteEnd   = "proposal_vote_end"
+	KindRCIEnd            = "rci_end"
+	KindAgendaActivation  = "agenda_activation"
+	KindTSpendExpiry      = "tspend_expiry"
+	KindSubsidyReduction  = "subsidy_reduction"
+)
+
+// pastDuration is how long events stay on the calendar after they happen.
+const pastDuration = 30 * 24 * time.Hour
+
+// maxTSpends is the number of tracked treasury spends checked for expiries.
+const maxTSpends = 100
+
+// Event is a calendar event at a block height of a chain. Time is the block
+// time if the block is known, and an estimate from the chain tip and the target
+// block time otherwise.
+type Event struct {
+	UID         string `json:"uid"`
+	Chain       string `json:"chain"`
+	Kind        string `json:"kind"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// Path is the explorer page of the event, relative to the site root.
+	Path      string `json:"path,omitempty"`
+	Height    int64  `json:"height"`
+	Time      int64  `json:"time"`
+	Estimated bool   `json:"estimated"`
+}
+
+// ProposalSource is satisfied by *politeia.ProposalsDB.
+type ProposalSource interface {
+	GetAllProposals() ([]*pitypes.ProposalRecord, error)
+}
+
+// AgendaSource is satisfied by *agendas.VoteTracker.
+type AgendaSource interface {
+	Summary() *agendas.VoteSummary
+}
+
+// TSpendSource is satisfied by *dcrpg.ChainDB.
+type TSpendSource interface {
+	GetTSpendLifecycles(limit int) ([]*dbtypes.TSpendLifecycle, error)
+}
+
+// Chain is a chain whose block reward reductions are on the calendar.
+type Chain struct {
+	Name      string
+	BlockTime time.Duration
+	// SubsidyReductionInterval is the number of blocks between block reward
+	// reductions, or 0 if the chain does not reduce the reward at fixed
+	// intervals.
+	SubsidyReductionInterval int64
+}
+
+// Config is the configuration for a Calendar. The sources are optional.
+type Config struct {
+	// Params are the Decred chain parameters.
+	Params *chaincfg.Params
+	// Chains are the other chains whose block reward reductions are on the
+	// calendar.
+	Chains    []Chain
+	Proposals ProposalSource
+	Agendas   AgendaSource
+	TSpends   TSpendSource
+	// BlockTime returns the time of a Decred mainchain block.
+	BlockTime func(height int64) (int64, error)
+}
+
+type chainTip struct {
+	height int64
+	time   int64
+}
+
+// Calendar is the collection of events. Update it with the tip of each chain
+// as blocks arrive.
+type Calendar struct {
+	cfg    Config
+	chains map[string]Chain
+
+	// refreshMtx serializes the collection of the Decred governance events and
+	// protects blockTimes, the cache of Decred block times for past events.
+	refreshMtx sync.Mutex
+	blockTimes map[int64]int64
+
+	mtx       sync.RWMutex
+	tips      map[string]chainTip
+	govEvents []*Event
+	events    []*Event
+}
+
+// New is the constructor for a Calendar.
+func New(cfg *Config) *Calendar {
+	chains := map[string]Chain{
+		mutilchain.TYPEDCR: {
+			Name:                     mutilchain.TYPEDCR,
+			BlockTime:                cfg.Params.TargetTimePerBlock,
+			SubsidyReductionInterval: cfg.Params.SubsidyReductionInterval,
+		},
+	}
+	for _, chain := range cfg.Chains {
+		chains[chain.Name] = chain
+	}
+	return &Calendar{
+		cfg:        *cfg,
+		chains:     chains,
+		blockTimes: make(map[int64]int64),
+		tips:       make(map[string]chainTip),
+	}
+}
+
+// Update sets the tip of the chain and recomputes the event times. For Decred,
+// the governance events are collected again from the sources.
+func (c *Calendar) Update(chain string, height, blockTime int64) {
+	if _, ok := c.chains[chain]; !ok {
+		return
+	}
+	var govEvents []*Event
+	if chain == mutilchain.TYPEDCR {
+		govEvents = c.governanceEvents(height)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.tips[chain] = chainTip{height: height, time: blockTime}
+	if govEvents != nil {
+		c.govEvents = govEvents
+	}
+	c.events = c.estimate(time.Now().Add(-pastDuration).Unix())
+}
+
+// ConnectBlock is the Decred block handler that updates the tip, using the
+// timestamp of the block header as the block time.
+func (c *Calendar) ConnectBlock(header *wire.BlockHeader) error {
+	c.Update(mutilchain.TYPEDCR, int64(header.Height), header.Timestamp.Unix())
+	return nil
+}
+
+// Events returns the events of the chain between the from and to times,
+// inclusive, sorted by time. An empty chain selects all chains, and a zero to
+// time selects all future events.
+func (c *Calendar) Events(chain string, from, to int64) []*Event {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	events := make([]*Event, 0, len(c.events))
+	for _, ev := range c.events {
+		if (chain != "" && ev.Chain != chain) || ev.Time < from || (to > 0 && ev.Time > to) {
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// Set the times of the governance events and add the next block reward
+// reduction of each chain. Events before the cutoff time are dropped. Must be
+// called with the mutex locked.
+func (c *Calendar) estimate(cutoff int64) []*Event {
+	events := make([]*Event, 0, len(c.govEvents)+len(c.chains))
+	for _, ev := range c.govEvents {
+		ev := *ev
+		if ev.Time == 0 {
+			tip, ok := c.tips[ev.Chain]
+			if !ok {
+				continue
+			}
+			ev.Time = c.estimateTime(ev.Chain, tip, ev.Height)
+			ev.Estimated = true
+		}
+		events = append(events, &ev)
+	}
+
+	for name, chain := range c.chains {
+		tip, ok := c.tips[name]
+		if !ok || chain.SubsidyReductionInterval <= 0 {
+			continue
+		}
+		height := (tip.height/chain.SubsidyReductionInterval + 1) * chain.SubsidyReductionInterval
+		events = append(events, &Event{
+			UID:       fmt.Sprintf("%s-%s-%d", name, KindSubsidyReduction, height),
+			Chain:     name,
+			Kind:      KindSubsidyReduction,
+			Title:     strings.ToUpper(name) + " block reward reduction",
+			Height:    height,
+			Time:      c.estimateTime(name, tip, height),
+			Estimated: true,
+		})
+	}
+
+	kept := events[:0]
+	for _, ev := range events {
+		if ev.Time >= cutoff {
+			kept = append(kept, ev)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].Time == kept[j].Time {
+			return kept[i].UID < kept[j].UID
+		}
+		return kept[i].Time < kept[j].Time
+	})
+	return kept
+}
+
+// The estimated time of the block at the height, from the tip and the target
+// block time of the chain.
+func (c *Calendar) estimateTime(chain string, tip chainTip, height int64) int64 {
+	blockTime := int64(c.chains[chain].BlockTime / time.Second)
+	return tip.time + (height-tip.height)*blockTime
+}
+
+// Collect the Decred governance events from the sources. Times are set for
+// events at or below the tip height, and left for estimate otherwise.
+func (c *Calendar) governanceEvents(tipHeight int64) []*Event {
+	c.refreshMtx.Lock()
+	defer c.refreshMtx.Unlock()
+
+	dcr := mutilchain.TYPEDCR
+	blockTime := int64(c.cfg.Params.TargetTimePerBlock / time.Second)
+	minHeight := tipHeight - int64(pastDuration/time.Second)/blockTime
+	events := make([]*Event, 0)
+	add := func(ev *Event) {
+		if ev.Height < minHeight {
+			return
+		}
+		ev.Chain = dcr
+		ev.UID = fmt.Sprintf("%s-%s-%s", dcr, ev.Kind, ev.UID)
+		if ev.Height <= tipHeight {
+			ev.Time = c.pastBlockTime(ev.Height)
+		}
+		events = append(events, ev)
+	}
+
+	if c.cfg.Proposals != nil {
+		proposals, err := c.cfg.Proposals.GetAllProposals()
+		if err != nil {
+			log.Errorf("GetAllProposals: %v", err)
+		}
+		for _, p := range proposals {
+			if p.StartBlockHeight == 0 || p.EndBlockHeight == 0 {
+				continue
+			}
+			path := "/proposal/" + p.Token
+			add(&Event{
+				UID:    p.Token,
+				Kind:   KindProposalVoteStart,
+				Title:  "Proposal vote starts: " + p.Name,
+				Path:   path,
+				Height: int64(p.StartBlockHeight),
+			})
+			add(&Event{
+				UID:    p.Token,
+				Kind:   KindProposalVoteEnd,
+				Title:  "Proposal vote ends: " + p.Name,
+				Path:   path,
+				Height: int64(p.EndBlockHeight),
+			})
+		}
+	}
+
+	if c.cfg.Agendas != nil {
+		if summary := c.cfg.Agendas.Summary(); summary != nil && summary.NextRCIHeight > 0 {
+			add(&Event{
+				UID:         fmt.Sprint(summary.NextRCIHeight),
+				Kind:        KindRCIEnd,
+				Title:       "Rule change interval ends",
+				Description: "Agenda votes are tallied and the next rule change interval starts.",
+				Path:        "/agendas",
+				Height:      int64(summary.NextRCIHeight),
+			})
+			for idx := range summary.Agendas {
+				agenda := &summary.Agendas[idx]
+				if agenda.Forecast == nil || agenda.Forecast.EarliestActivation == 0 {
+					continue
+				}
+				title := "Agenda activates: " + agenda.ID
+				if !agenda.IsLocked {
+					title = "Earliest agenda activation: " + agenda.ID
+				}
+				add(&Event{
+					UID:         agenda.ID,
+					Kind:        KindAgendaActivation,
+					Title:       title,
+					Description: agenda.Description,
+					Path:        "/agenda/" + agenda.ID,
+					Height:      agenda.Forecast.EarliestActivation,
+				})
+			}
+		}
+	}
+
+	if c.cfg.TSpends != nil {
+		tspends, err := c.cfg.TSpends.GetTSpendLifecycles(maxTSpends)
+		if err != nil {
+			log.Errorf("GetTSpendLifecycles: %v", err)
+		}
+		for _, ts := range tspends {
+			if ts.IsFinal() {
+				continue
+			}
+			add(&Event{
+				UID:         ts.TxHash,
+				Kind:        KindTSpendExpiry,
+				Title:       "Treasury spend expires",
+				Description: fmt.Sprintf("Treasury spend %s (%s) expires if not mined.", ts.TxHash, ts.Status),
+				Path:        "/tx/" + ts.TxHash,
+				Height:      ts.Expiry,
+			})
+		}
+	}
+
+	return events
+}
+
+// The time of a Decred block at or below the tip, or 0 if it is not known.
+// Must be called with refreshMtx locked.
+func (c *Calendar) pastBlockTime(height int64) int64 {
+	if t, ok := c.blockTimes[height]; ok {
+		return t
+	}
+	if c.cfg.BlockTime == nil {
+		return 0
+	}
+	t, err := c.cfg.BlockTime(height)
+	if err != nil {
+		log.Warnf("Block time at height %d: %v", height, err)
+		return 0
+	}
+	c.blockTimes[height] = t
+	return t
+}
@@ -0,0 +1,150 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrdata/gov/v6/agendas"
+	pitypes "github.com/decred/dcrdata/gov/v6/politeia/types"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/mutilchain"
+)
+
+type proposalsStub []*pitypes.ProposalRecord
+
+func (p proposalsStub) GetAllProposals() ([]*pitypes.ProposalRecord, error) {
+	return p, nil
+}
+
+type agendasStub agendas.VoteSummary
+
+func (a *agendasStub) Summary() *agendas.VoteSummary {
+	return (*agendas.VoteSummary)(a)
+}
+
+type tspendsStub []*dbtypes.TSpendLifecycle
+
+func (t tspendsStub) GetTSpendLifecycles(int) ([]*dbtypes.TSpendLifecycle, error) {
+	return t, nil
+}
+
+func TestCalendar(t *testing.T) {
+	now := time.Now().Unix()
+	const tipHeight = 800000
+	cal := New(&Config{
+		Params: chaincfg.MainNetParams(),
+		Chains: []Chain{{
+			Name:                     mutilchain.TYPEBTC,
+			BlockTime:                10 * time.Minute,
+			SubsidyReductionInterval: 210000,
+		}},
+		Proposals: proposalsStub{
+			{Token: "abc", Name: "Voting", StartBlockHeight: tipHeight - 100, EndBlockHeight: tipHeight + 100},
+			{Token: "old", Name: "Old", StartBlockHeight: 1000, EndBlockHeight: 3000},
+			{Token: "new", Name: "Unvoted"},
+		},
+		Agendas: &agendasStub{
+			NextRCIHeight: tipHeight + 10,
+			Agendas: []agendas.AgendaSummary{
+				{ID: "locked", IsLocked: true, Forecast: &agendas.AgendaForecast{EarliestActivation: tipHeight + 10}},
+				{ID: "failed", Forecast: &agendas.AgendaForecast{}},
+			},
+		},
+		TSpends: tspendsStub{
+			{TxHash: "aa", Status: dbtypes.TSpendVoting, Expiry: tipHeight + 20},
+			{TxHash: "bb", Status: dbtypes.TSpendMined, Expiry: tipHeight + 20},
+		},
+		BlockTime: func(height int64) (int64, error) {
+			return now - (tipHeight-height)*300, nil
+		},
+	})
+
+	cal.Update(mutilchain.TYPEBTC, 839999, now)
+	cal.Update(mutilchain.TYPEDCR, tipHeight, now)
+
+	events := cal.Events("", 0, 0)
+	got := make(map[string]*Event)
+	for i, ev := range events {
+		got[ev.UID] = ev
+		if i > 0 && events[i-1].Time > ev.Time {
+			t.Errorf("events not sorted by time")
+		}
+	}
+	if len(events) != 7 {
+		t.Errorf("expected 7 events, got %d", len(events))
+	}
+
+	check := func(uid string, height, evTime int64, estimated bool) {
+		t.Helper()
+		ev := got[uid]
+		if ev == nil {
+			t.Errorf("missing event %s", uid)
+			return
+		}
+		if ev.Height != height || ev.Time != evTime || ev.Estimated != estimated {
+			t.Errorf("wrong event %s: %+v", uid, ev)
+		}
+	}
+	check("dcr-proposal_vote_start-abc", tipHeight-100, now-100*300, false)
+	check("dcr-proposal_vote_end-abc", tipHeight+100, now+100*300, true)
+	check("dcr-rci_end-800010", tipHeight+10, now+10*300, true)
+	check("dcr-agenda_activation-locked", tipHeight+10, now+10*300, true)
+	check("dcr-tspend_expiry-aa", tipHeight+20, now+20*300, true)
+	check("dcr-subsidy_reduction-804864", 804864, now+4864*300, true)
+	check("btc-subsidy_reduction-840000", 840000, now+600, true)
+	if got["dcr-proposal_vote_start-old"] != nil || got["dcr-tspend_expiry-bb"] != nil {
+		t.Errorf("unexpected old or final events")
+	}
+
+	// A new block moves the estimates.
+	cal.Update(mutilchain.TYPEBTC, 839999, now+60)
+	if ev := cal.Events(mutilchain.TYPEBTC, 0, 0); len(ev) != 1 || ev[0].Time != now+660 {
+		t.Errorf("wrong BTC events after update: %+v", ev)
+	}
+	if ev := cal.Events("", now, now+20*300); len(ev) != 4 {
+		t.Errorf("expected 4 events in the time range, got %d", len(ev))
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	events := []*Event{{
+		UID:       "dcr-proposal_vote_end-abc",
+		Chain:     mutilchain.TYPEDCR,
+		Kind:      KindProposalVoteEnd,
+		Title:     "Proposal vote ends: Marketing, events; and more " + strings.Repeat("ü", 40),
+		Path:      "/proposal/abc",
+		Height:    100,
+		Time:      1700000000,
+		Estimated: true,
+	}}
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, events, "https://dcrdata.org", time.Unix(1600000000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	ics := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:dcr-proposal_vote_end-abc@dcrdata\r\n",
+		"DTSTAMP:20200913T122640Z\r\n",
+		"DTSTART:20231114T221320Z\r\n",
+		`SUMMARY:Proposal vote ends: Marketing\, events\; and more`,
+		"URL:https://dcrdata.org/proposal/abc\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("missing %q in\n%s", want, ics)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > icsLineLength {
+			t.Errorf("line longer than %d octets: %q", icsLineLength, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, strings.Repeat("ü", 40)+"\r\n") {
+		t.Errorf("folded summary does not unfold to the title")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsTimeFormat is the UTC date-time format of RFC 5545.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsLineLength is the maximum length of a content line in octets, excluding
+// the line break.
+const icsLineLength = 75
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", "")
+
+// WriteICS writes the events as an iCalendar (RFC 5545) feed. baseURL is
+// prepended to the event paths, and stamp is the creation time of the feed.
+// Estimated events say so in their descriptions, since the times change as
+// blocks arrive.
+func WriteICS(w io.Writer, events []*Event, baseURL string, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	writeLine := func(name, value string) {
+		line := name + ":" + value
+		// Fold the line without splitting a UTF-8 sequence. Continuation lines
+		// start with a space, which counts toward their length.
+		limit := icsLineLength
+		for len(line) > limit {
+			cut := limit
+			for cut > 1 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			bw.WriteString(line[:cut])
+			bw.WriteString("\r\n ")
+			line = line[cut:]
+			limit = icsLineLength - 1
+		}
+		bw.WriteString(line)
+		bw.WriteString("\r\n")
+	}
+
+	dtStamp := stamp.UTC().Format(icsTimeFormat)
+	writeLine("BEGIN", "VCALENDAR")
+	writeLine("VERSION", "2.0")
+	writeLine("PRODID", "-//dcrdata//Governance Calendar//EN")
+	writeLine("CALSCALE", "GREGORIAN")
+	writeLine("X-WR-CALNAME", "dcrdata governance calendar")
+	for _, ev := range events {
+		description := ev.Description
+		if ev.Estimated {
+			if description != "" {
+				description += "\n"
+			}
+			description += "The time is estimated from the block height and the target block time."
+		}
+		writeLine("BEGIN", "VEVENT")
+		writeLine("UID", ev.UID+"@dcrdata")
+		writeLine("DTSTAMP", dtStamp)
+		writeLine("DTSTART", time.Unix(ev.Time, 0).UTC().Format(icsTimeFormat))
+		writeLine("SUMMARY", icsEscaper.Replace(ev.Title))
+		if description != "" {
+			writeLine("DESCRIPTION", icsEscaper.Replace(description))
+		}
+		if ev.Path != "" {
+			writeLine("URL", baseURL+ev.Path)
+		}
+		writeLine("CATEGORIES", icsEscaper.Replace(ev.Kind))
+		writeLine("END", "VEVENT")
+	}
+	writeLine("END", "VCALENDAR")
+	return bw.Flush()
+}
@@ -143,6 +143,8 @@ type explorerDataSource interface {
 	GetDaemonMutilchainBlockHash(idx int64, chainType string) (string, error)
 	GetExplorerTx(txid string) *types.TxInfo
 	GetMutilchainExplorerTx(txid string, chainType string) *types.TxInfo
+	GetMutilchainTxReplacements(chainType, txid string) *types.TxReplacementInfo
+	GetTxConfirmationEstimate(chainType, txid string) (*txhelpers.ConfirmationEstimate, error)
 	GetTip() (*types.WebBasicBlock, error)
 	DecodeRawTransaction(txhex string) (*chainjson.TxRawResult, error)
 	SendRawTransaction(txhex string) (string, error)
@@ -192,6 +194,9 @@ type explorerDataSource interface {
 	GetExplorerBlockBasic(height int) *types.BlockBasic
 	GetAvgBlockFormattedSize() (string, error)
 	GetBwDashData() (int64, int64, int64)
+	StoreVSPSnapshots(snapshots []*dbtypes.VSPSnapshot, stamp int64) error
+	GetVSPs() ([]*dbtypes.VSPStatus, error)
+	GetMixes(from, to int64, limit int) ([]*dbtypes.MixTx, error)
 	GetAvgTxFee() (int64, error)
 	GetTicketsSummaryInfo() (*dbtypes.TicketsSummaryInfo, error)
 	Get24hActiveAddressesCount() int64
@@ -564,7 +569,7 @@ func New(cfg *ExplorerConfig) *ExplorerUI {
 		"chain_address", "chain_mempool", "chain_charts", "chain_market",
 		"chain_addresstable", "supply", "marketlist", "chain_parameters",
 		"whatsnew", "chain_visualblocks", "bwdash", "atomicswaps", "atomicswaps_table",
-		"about", "xmr_mempool"}
+		"about", "xmr_mempool", "vsps", "mixes", "stakesim", "chain_attackcost", "chain_replacedtx"}
 
 	for _, name := range tmpls {
 		if err := exp.templates.addTemplate(name); err != nil {
@@ -656,6 +661,28 @@ func (exp *ExplorerUI) MutilchainMempoolInfo(chainType string) *types.Mutilchain
 	}
 }
 
+// MutilchainHashRate returns the network hash rate in H/s of the last block of
+// a PoW chain, or zero for other chains.
+func (exp *ExplorerUI) MutilchainHashRate(chainType string) float64 {
+	switch chainType {
+	case mutilchain.TYPEBTC:
+		exp.BtcPageData.RLock()
+		defer exp.BtcPageData.RUnlock()
+		// The BTC and LTC hash rates are in MH/s.
+		return exp.BtcPageData.HomeInfo.HashRate * 1e6
+	case mutilchain.TYPELTC:
+		exp.LtcPageData.RLock()
+		defer exp.LtcPageData.RUnlock()
+		return exp.LtcPageData.HomeInfo.HashRate * 1e6
+	case mutilchain.TYPEXMR:
+		exp.XmrPageData.RLock()
+		defer exp.XmrPageData.RUnlock()
+		return exp.XmrPageData.HomeInfo.HashRate
+	default:
+		return 0
+	}
+}
+
 // MempoolID safely fetches the current mempool inventory ID.
 func (exp *ExplorerUI) MempoolID() uint64 {
 	exp.invsMtx.RLock()
@@ -861,6 +888,8 @@ func (exp *ExplorerUI) Store(blockData *blockdata.BlockData, msgBlock *wire.MsgB
 		vspList, err := externalapi.GetVSPList()
 		if err != nil {
 			log.Errorf("VspAPI: Get vsp list failed: %v", err)
+		} else if err = exp.storeVSPSnapshots(vspList); err != nil {
+			log.Errorf("VspAPI: Store vsp snapshots failed: %v", err)
 		}
 		// Get tickets summary
 		ticketSummaryInfo, err := exp.dataSource.GetTicketsSummaryInfo()
@@ -1496,8 +1525,14 @@ func (exp *ExplorerUI) LTCStore(blockData *blockdataltc.BlockData, msgBlock *ltc
 	return nil
 }
 
-// Generate xmr updates for mempool data
-func (exp *ExplorerUI) UpdateXMRMempoolData(xmrClient *xmrclient.XMRClient, stop <-chan struct{}) error {
+// XMRMempoolDataSaver is a destination of the XMR mempool data.
+type XMRMempoolDataSaver interface {
+	StoreXMRMPData(mp *xmrutil.Mempool)
+}
+
+// UpdateXMRMempoolData polls the XMR mempool, and stores it for the explorer
+// and with each of savers.
+func (exp *ExplorerUI) UpdateXMRMempoolData(xmrClient *xmrclient.XMRClient, stop <-chan struct{}, savers ...XMRMempoolDataSaver) error {
 	xmrMempoolUpdateInterval := 15 * time.Second
 	ticker := time.NewTicker(xmrMempoolUpdateInterval)
 	defer ticker.Stop()
@@ -1572,6 +1607,10 @@ func (exp *ExplorerUI) UpdateXMRMempoolData(xmrClient *xmrclient.XMRClient, stop
 			exp.XmrPageData.MempoolData = &mp
 			exp.XmrPageData.Unlock()
 
+			for _, s := range savers {
+				go s.StoreXMRMPData(&mp)
+			}
+
 			// send to websocket
 			go func() {
 				select {
@@ -1653,6 +1692,27 @@ func (exp *ExplorerUI) updateDevFundBalance() {
 	}
 }
 
+// storeVSPSnapshots persists the VSP API listing for the VSP history.
+func (exp *ExplorerUI) storeVSPSnapshots(vspList []externalapi.VSPResponse) error {
+	now := time.Now().Unix()
+	snapshots := make([]*dbtypes.VSPSnapshot, 0, len(vspList))
+	for _, vsp := range vspList {
+		snapshots = append(snapshots, &dbtypes.VSPSnapshot{
+			VSP:               vsp.VSPLink,
+			Time:              now,
+			LastUpdated:       vsp.LastUpdated,
+			FeePercentage:     vsp.FeePercentage,
+			Voting:            vsp.Voting,
+			Voted:             vsp.Voted,
+			Missed:            vsp.Missed,
+			Expired:           vsp.Expired,
+			NetworkProportion: vsp.EstimatedNetworkProportion,
+			VspdVersion:       vsp.VspdVersion,
+		})
+	}
+	return exp.dataSource.StoreVSPSnapshots(snapshots, now)
+}
+
 type loggerFunc func(string, ...interface{})
 
 func (lw loggerFunc) Printf(str string, args ...interface{}) {
@@ -1807,6 +1867,8 @@ func (exp *ExplorerUI) watchExchanges() {
 			chainType = mutilchain.TYPEBTC
 		case exchanges.LTCSYMBOL:
 			chainType = mutilchain.TYPELTC
+		case exchanges.XMRSYMBOL:
+			chainType = mutilchain.TYPEXMR
 		default:
 			chainType = mutilchain.TYPEDCR
 		}
@@ -230,6 +230,92 @@ type MutilchainHomeInfo struct {
 	Price        float64
 }
 
+// VSPsPage is the page handler for the "/decred/vsps" path. It lists every
+// VSP that has been listed by the VSP API, including those that have since
+// disappeared, with charts of each VSP's history.
+func (exp *ExplorerUI) VSPsPage(w http.ResponseWriter, r *http.Request) {
+	vsps, err := exp.dataSource.GetVSPs()
+	if exp.timeoutErrorPage(w, err, "GetVSPs") {
+		return
+	}
+	if err != nil {
+		log.Errorf("GetVSPs error: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+	var listed int
+	for _, vsp := range vsps {
+		if vsp.RemovedAt == 0 {
+			listed++
+		}
+	}
+	str, err := exp.templates.exec("vsps", struct {
+		*CommonPageData
+		VSPs    []*dbtypes.VSPStatus
+		Listed  int
+		Removed int
+	}{
+		CommonPageData: exp.commonData(r),
+		VSPs:           vsps,
+		Listed:         listed,
+		Removed:        len(vsps) - listed,
+	})
+	if err != nil {
+		log.Errorf("Template execute failure: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, str)
+}
+
+// recentMixesCount is the number of mixes listed on the mixes page.
+const recentMixesCount = 100
+
+// MixesPage is the page handler for the "/decred/mixes" path. It lists the
+// most recent mixing transactions, with charts of the daily mixing activity.
+func (exp *ExplorerUI) MixesPage(w http.ResponseWriter, r *http.Request) {
+	mixes, err := exp.dataSource.GetMixes(0, time.Now().Unix(), recentMixesCount)
+	if exp.timeoutErrorPage(w, err, "GetMixes") {
+		return
+	}
+	if err != nil {
+		log.Errorf("GetMixes error: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+	str, err := exp.templates.exec("mixes", struct {
+		*CommonPageData
+		Mixes []*dbtypes.MixTx
+	}{
+		CommonPageData: exp.commonData(r),
+		Mixes:          mixes,
+	})
+	if err != nil {
+		log.Errorf("Template execute failure: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, str)
+}
+
+// StakeSimulatorPage is the page handler for the "/decred/stake-simulator"
+// path. The simulations are requested from the API by the page.
+func (exp *ExplorerUI) StakeSimulatorPage(w http.ResponseWriter, r *http.Request) {
+	str, err := exp.templates.exec("stakesim", exp.commonData(r))
+	if err != nil {
+		log.Errorf("Template execute failure: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, str)
+}
+
 // Home is the page handler for the "/" path.
 func (exp *ExplorerUI) DecredHome(w http.ResponseWriter, r *http.Request) {
 	height, err := exp.dataSource.GetHeight()
@@ -1450,6 +1536,17 @@ func (exp *ExplorerUI) GetAddressListFromPkScript(pkScriptsStr []byte, chainType
 	}
 }
 
+// txConfirmationEstimate returns the confirmation estimate of a mempool
+// transaction, or nil if it is not in the last mempool snapshot.
+func (exp *ExplorerUI) txConfirmationEstimate(chainType, txid string) *txhelpers.ConfirmationEstimate {
+	est, err := exp.dataSource.GetTxConfirmationEstimate(chainType, txid)
+	if err != nil {
+		log.Warnf("GetTxConfirmationEstimate(%s, %s) error: %v", chainType, txid, err)
+		return nil
+	}
+	return est
+}
+
 // TxPage is the page handler for the "/tx" path.
 func (exp *ExplorerUI) MutilchainTxPage(w http.ResponseWriter, r *http.Request) {
 	// attempt to get tx hash string from URL path
@@ -1474,10 +1571,40 @@ func (exp *ExplorerUI) MutilchainTxPage(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// The replacement history and CPFP package of a BTC or LTC transaction.
+	var replacements *types.TxReplacementInfo
+	if chainType == mutilchain.TYPEBTC || chainType == mutilchain.TYPELTC {
+		replacements = exp.dataSource.GetMutilchainTxReplacements(chainType, hash)
+	}
+
 	tx := exp.dataSource.GetMutilchainExplorerTx(hash, chainType)
 	if chainType == mutilchain.TYPEXMR && tx == nil {
 		exp.StatusPage(w, defaultErrorCode, "XMR: Get transaction by ID failed", "", ExpStatusError)
 		return
+	} else if tx == nil && replacements != nil && replacements.History != nil && replacements.History.Replaced {
+		// A transaction replaced in mempool before it was mined is only known
+		// by its replacements.
+		str, err := exp.templates.exec("chain_replacedtx", struct {
+			*CommonPageData
+			ChainType    string
+			TxID         string
+			Replacements *types.TxReplacementInfo
+		}{
+			CommonPageData: exp.commonData(r),
+			ChainType:      chainType,
+			TxID:           hash,
+			Replacements:   replacements,
+		})
+		if err != nil {
+			log.Errorf("Template execute failure: %v", err)
+			exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Turbolinks-Location", r.URL.RequestURI())
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, str)
+		return
 	} else if tx == nil {
 		log.Warnf("No transaction information for %v. Trying tables in case this is an orphaned txn.", hash)
 		// Search for occurrences of the transaction in the database.
@@ -1680,13 +1807,15 @@ func (exp *ExplorerUI) MutilchainTxPage(w http.ResponseWriter, r *http.Request)
 
 	pageData := struct {
 		*CommonPageData
-		Data            *types.TxInfo
-		ChainType       string
-		SwapsFound      string
-		SwapFirstSource *dbtypes.AtomicSwapForTokenData
-		TargetToken     string
-		IsRefund        bool
-		Conversions     struct {
+		Data                 *types.TxInfo
+		ChainType            string
+		SwapsFound           string
+		SwapFirstSource      *dbtypes.AtomicSwapForTokenData
+		TargetToken          string
+		IsRefund             bool
+		Replacements         *types.TxReplacementInfo
+		ConfirmationEstimate *txhelpers.ConfirmationEstimate
+		Conversions          struct {
 			Total *exchanges.Conversion
 			Fees  *exchanges.Conversion
 		}
@@ -1697,6 +1826,10 @@ func (exp *ExplorerUI) MutilchainTxPage(w http.ResponseWriter, r *http.Request)
 		SwapsFound:      swapsInfo.Found,
 		SwapFirstSource: swapFirstSource,
 		IsRefund:        isRefund,
+		Replacements:    replacements,
+	}
+	if tx.Confirmations == 0 && (chainType == mutilchain.TYPEBTC || chainType == mutilchain.TYPELTC) {
+		pageData.ConfirmationEstimate = exp.txConfirmationEstimate(chainType, hash)
 	}
 	// Get a fiat-converted value for the total and the fees.
 	if exp.xcBot != nil {
@@ -2532,6 +2665,7 @@ func (exp *ExplorerUI) TxPage(w http.ResponseWriter, r *http.Request) {
 		SwapFirstSource      *dbtypes.AtomicSwapForTokenData
 		TargetToken          string
 		IsRefund             bool
+		ConfirmationEstimate *txhelpers.ConfirmationEstimate
 		Conversions          struct {
 			Total *exchanges.Conversion
 			Fees  *exchanges.Conversion
@@ -2549,6 +2683,9 @@ func (exp *ExplorerUI) TxPage(w http.ResponseWriter, r *http.Request) {
 		TargetToken:          targetToken,
 		IsRefund:             isRefund,
 	}
+	if tx.BlockHeight == 0 {
+		pageData.ConfirmationEstimate = exp.txConfirmationEstimate(mutilchain.TYPEDCR, tx.TxID)
+	}
 
 	// Get a fiat-converted value for the total and the fees.
 	if exp.xcBot != nil {
@@ -4180,6 +4317,7 @@ func (exp *ExplorerUI) AgendaPage(w http.ResponseWriter, r *http.Request) {
 		TotalRealVote uint32
 		QuorumYes     bool
 		RCIBlocks     int64
+		Forecast      *agendas.AgendaForecast
 	}{
 		CommonPageData: exp.commonData(r),
 		Ai:             &agendaDetail,
@@ -4195,6 +4333,7 @@ func (exp *ExplorerUI) AgendaPage(w http.ResponseWriter, r *http.Request) {
 		QuorumYes:      totalRealVote >= ruleChangeQ,
 		PassRate:       float64(0.75),
 		RCIBlocks:      int64(voteSummary.RCIBlocks),
+		Forecast:       exp.voteTracker.Forecast(agendaId),
 	})
 
 	if err != nil {
@@ -4919,6 +5058,36 @@ func (exp *ExplorerUI) AttackCost(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, str)
 }
 
+// MutilchainAttackCost is the page handler for the "/{chaintype}/attack-cost"
+// path of the PoW chains. The estimate itself comes from the attack cost API.
+func (exp *ExplorerUI) MutilchainAttackCost(w http.ResponseWriter, r *http.Request) {
+	chainType := chi.URLParam(r, "chaintype")
+	cfg := mutilchain.DefaultAttackCostConfig(chainType)
+	if cfg == nil || exp.ChainDisabledMap[chainType] {
+		exp.StatusPage(w, defaultErrorCode, "attack cost is not available for this chain", "", ExpStatusNotFound)
+		return
+	}
+
+	str, err := exp.templates.exec("chain_attackcost", struct {
+		*CommonPageData
+		ChainType string
+		Config    *mutilchain.AttackCostConfig
+	}{
+		CommonPageData: exp.commonData(r),
+		ChainType:      chainType,
+		Config:         cfg,
+	})
+	if err != nil {
+		log.Errorf("Template execute failure: %v", err)
+		exp.StatusPage(w, defaultErrorCode, defaultErrorMessage, "", ExpStatusError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, str)
+}
+
 func (exp *ExplorerUI) FinanceDetailPage(w http.ResponseWriter, r *http.Request) {
 	rtype := r.URL.Query().Get("type")
 	time := r.URL.Query().Get("time")
@@ -16,6 +16,7 @@ import (
 	apitypes "github.com/decred/dcrdata/v8/api/types"
 	"github.com/decred/dcrdata/v8/db/dbtypes"
 	"github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
 	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
 	"golang.org/x/net/websocket"
 )
@@ -218,6 +219,34 @@ func (exp *ExplorerUI) RootWebsocket(w http.ResponseWriter, r *http.Request) {
 					}
 					webData.Message = string(msg)
 
+				case "gettxeta":
+					// The confirmation estimate of a mempool transaction, requested
+					// as "chain:txid". Used on the tx pages, and null once the
+					// transaction left mempool.
+					chainType, txid, found := strings.Cut(msg.Message, ":")
+					if !found || len(txid) != 64 {
+						webData.Message = "Error: invalid transaction"
+						break
+					}
+					if chainType != mutilchain.TYPEDCR && chainType != mutilchain.TYPEBTC &&
+						chainType != mutilchain.TYPELTC {
+						webData.Message = "Error: unsupported chain type"
+						break
+					}
+					est, err := exp.dataSource.GetTxConfirmationEstimate(chainType, txid)
+					if err != nil {
+						log.Warnf("GetTxConfirmationEstimate(%s, %s) error: %v", chainType, txid, err)
+						webData.Message = "Error: failed to estimate confirmation"
+						break
+					}
+					msg, err := json.Marshal(est)
+					if err != nil {
+						log.Warn("Invalid JSON message: ", err)
+						webData.Message = errMsgJSONEncode
+						break
+					}
+					webData.Message = string(msg)
+
 				case "ping":
 					log.Tracef("We've been pinged: %.40s...", msg.Message)
 					continue
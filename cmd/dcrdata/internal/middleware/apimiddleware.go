@@ -604,6 +604,22 @@ func GetIndentCtx(r *http.Request) string {
 	return indent
 }
 
+// RequestBaseURL is the scheme and host of the request, e.g.
+// https://dcrdata.org. Behind a trusted proxy, the scheme is the forwarded one
+// set by the explorer's ProxyHeaders middleware. Otherwise it is implied by the
+// connection.
+func RequestBaseURL(r *http.Request) string {
+	scheme := r.URL.Scheme
+	if scheme == "" {
+		if r.TLS == nil {
+			scheme = "http"
+		} else {
+			scheme = "https"
+		}
+	}
+	return scheme + "://" + r.Host // assumes not opaque url
+}
+
 // Server sets the Server header element.
 func Server(server string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -131,3 +131,16 @@ func TestGetAddressCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestBaseURL(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/calendar.ics", nil)
+	r.Host = "dcrdata.org"
+	if got := RequestBaseURL(r); got != "http://dcrdata.org" {
+		t.Errorf("wrong base URL %s", got)
+	}
+	// The scheme forwarded by a trusted proxy takes precedence.
+	r.URL.Scheme = "https"
+	if got := RequestBaseURL(r); got != "https://dcrdata.org" {
+		t.Errorf("wrong forwarded base URL %s", got)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package main
+
+import (
+	"github.com/decred/dcrdata/exchanges/v3"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+)
+
+// liquiditySnapshots makes the snapshot of the aggregated order book,
+// followed by the snapshots of each exchange, from the books of
+// exchanges.LiquidityBooks.
+func liquiditySnapshots(chainType string, states map[string]*exchanges.ExchangeState, stamp int64) []*dbtypes.LiquiditySnapshot {
+	books := exchanges.LiquidityBooks(chainType, states)
+	if len(books) == 0 {
+		return nil
+	}
+	mid := books[0].MidGap()
+	aggBids, aggAsks := books[0].BandVolumes(mid)
+	snapshots := make([]*dbtypes.LiquiditySnapshot, 0, len(books))
+	for i, book := range books {
+		snapshot := &dbtypes.LiquiditySnapshot{
+			ChainType: chainType,
+			Token:     book.Token,
+			Time:      stamp,
+			MidGap:    book.MidGap(),
+		}
+		snapshot.BidVolume, snapshot.AskVolume = book.BandVolumes(mid)
+		snapshot.BuySlippage, snapshot.SellSlippage = book.Slippage()
+		if i == 0 {
+			snapshot.Share = 100
+		} else {
+			snapshot.Share = exchanges.LiquidityShare(snapshot.BidVolume, snapshot.AskVolume, aggBids, aggAsks)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
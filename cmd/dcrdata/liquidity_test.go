@@ -0,0 +1,76 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrdata/exchanges/v3"
+)
+
+func testDepthState(bids, asks []exchanges.DepthPoint) *exchanges.ExchangeState {
+	return &exchanges.ExchangeState{
+		Depth: &exchanges.DepthData{
+			Time: time.Now().Unix(),
+			Bids: bids,
+			Asks: asks,
+		},
+	}
+}
+
+func TestLiquiditySnapshots(t *testing.T) {
+	states := map[string]*exchanges.ExchangeState{
+		"binance": testDepthState(
+			[]exchanges.DepthPoint{{Quantity: 10, Price: 99.5}, {Quantity: 20, Price: 98}, {Quantity: 100, Price: 90}},
+			[]exchanges.DepthPoint{{Quantity: 10, Price: 100.5}, {Quantity: 20, Price: 102}, {Quantity: 100, Price: 110}},
+		),
+		"kucoin": testDepthState(
+			[]exchanges.DepthPoint{{Quantity: 30, Price: 99}},
+			[]exchanges.DepthPoint{{Quantity: 30, Price: 101}},
+		),
+		// DCR-BTC markets are skipped.
+		"btc_binance": testDepthState(
+			[]exchanges.DepthPoint{{Quantity: 1, Price: 0.0002}},
+			[]exchanges.DepthPoint{{Quantity: 1, Price: 0.00021}},
+		),
+	}
+	snapshots := liquiditySnapshots(exchanges.TYPEDCR, states, 1000)
+	if len(snapshots) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(snapshots))
+	}
+	agg, binance, kucoin := snapshots[0], snapshots[1], snapshots[2]
+	if agg.Token != exchanges.AggregatedToken || binance.Token != "binance" || kucoin.Token != "kucoin" {
+		t.Fatalf("unexpected snapshot order %s, %s, %s", agg.Token, binance.Token, kucoin.Token)
+	}
+	if agg.MidGap != 100 || agg.Time != 1000 {
+		t.Fatalf("wrong aggregated mid-gap %f", agg.MidGap)
+	}
+	// 1%: 99.5, 99 | 100.5, 101. 2%: + 98 | 102. 5%: same as 2%.
+	if agg.BidVolume != [3]float64{40, 60, 60} || agg.AskVolume != [3]float64{40, 60, 60} {
+		t.Fatalf("wrong aggregated band volumes %v %v", agg.BidVolume, agg.AskVolume)
+	}
+	if binance.BidVolume[1]+kucoin.BidVolume[1] != agg.BidVolume[1] {
+		t.Fatalf("exchange volumes don't sum to the aggregated volume")
+	}
+	if agg.Share != 100 || math.Abs(binance.Share+kucoin.Share-100) > 1e-9 {
+		t.Fatalf("wrong shares %f, %f", binance.Share, kucoin.Share)
+	}
+	if math.Abs(kucoin.Share-50) > 1e-9 {
+		t.Fatalf("expected kucoin share 50, got %f", kucoin.Share)
+	}
+	// $1000 buy on binance fills 9.95 @ 100.5, 0.5% from mid-gap 100.
+	if math.Abs(binance.BuySlippage[0]-0.5) > 1e-9 {
+		t.Fatalf("wrong buy slippage %f", binance.BuySlippage[0])
+	}
+	// kucoin can't fill a $10000 order.
+	if kucoin.BuySlippage[1] != -1 || kucoin.SellSlippage[1] != -1 {
+		t.Fatalf("expected unfillable slippage, got %f, %f", kucoin.BuySlippage[1], kucoin.SellSlippage[1])
+	}
+
+	if liquiditySnapshots(exchanges.TYPEBTC, nil, 1000) != nil {
+		t.Fatalf("expected nil snapshots without depth data")
+	}
+}
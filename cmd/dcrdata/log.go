@@ -17,7 +17,9 @@ import (
 	"github.com/jrick/logrotate/rotator"
 
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/api"
+	"github.com/decred/dcrdata/cmd/dcrdata/internal/api/graphql"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/api/insight"
+	"github.com/decred/dcrdata/cmd/dcrdata/internal/calendar"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/explorer"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/middleware"
 	notify "github.com/decred/dcrdata/cmd/dcrdata/internal/notification"
@@ -36,6 +38,7 @@ import (
 	"github.com/decred/dcrdata/v8/pubsub"
 	"github.com/decred/dcrdata/v8/rpcutils"
 	"github.com/decred/dcrdata/v8/stakedb"
+	"github.com/decred/dcrdata/v8/webhooks"
 )
 
 var (
@@ -54,6 +57,7 @@ var (
 	apiLog          slog.Logger
 	log             slog.Logger
 	iapiLog         slog.Logger
+	gapiLog         slog.Logger
 	pubsubLog       slog.Logger
 	xcBotLog        slog.Logger
 	agendasLog      slog.Logger
@@ -62,6 +66,8 @@ var (
 	btcBlockdataLog slog.Logger
 	ltcBlockdataLog slog.Logger
 	xmrBlockdataLog slog.Logger
+	calendarLog     slog.Logger
+	webhooksLog     slog.Logger
 	// filled after init so setLogLevels works
 	subsystemLoggers map[string]slog.Logger
 )
@@ -238,6 +244,7 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 	apiLog = backendLog.Logger("JAPI")
 	log = backendLog.Logger("DATD")
 	iapiLog = backendLog.Logger("IAPI")
+	gapiLog = backendLog.Logger("GAPI")
 	pubsubLog = backendLog.Logger("PUBS")
 	xcBotLog = backendLog.Logger("XBOT")
 	agendasLog = backendLog.Logger("AGDB")
@@ -246,11 +253,13 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 	btcBlockdataLog = backendLog.Logger("BTCBLKD")
 	ltcBlockdataLog = backendLog.Logger("LTCBLKD")
 	xmrBlockdataLog = backendLog.Logger("XMRBLKD")
+	calendarLog = backendLog.Logger("CALR")
+	webhooksLog = backendLog.Logger("HOOK")
 	all := []slog.Logger{
 		notifyLog, postgresqlLog, stakedbLog, BlockdataLog, clientLog,
 		mempoolLog, expLog, apiLog, log, iapiLog, pubsubLog,
 		xcBotLog, agendasLog, proposalsLog, externalLog, btcBlockdataLog,
-		ltcBlockdataLog, xmrBlockdataLog,
+		ltcBlockdataLog, xmrBlockdataLog, calendarLog, webhooksLog, gapiLog,
 	}
 	for _, lg := range all {
 		lg.SetLevel(slog.LevelDebug)
@@ -266,6 +275,7 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 	explorer.UseLogger(expLog)
 	api.UseLogger(apiLog)
 	insight.UseLogger(iapiLog)
+	graphql.UseLogger(gapiLog)
 	middleware.UseLogger(apiLog)
 	notify.UseLogger(notifyLog)
 	pubsub.UseLogger(pubsubLog)
@@ -276,6 +286,8 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 	blockdatabtc.UseLogger(btcBlockdataLog)
 	blockdataltc.UseLogger(ltcBlockdataLog)
 	blockdataxmr.UseLogger(xmrBlockdataLog)
+	calendar.UseLogger(calendarLog)
+	webhooks.UseLogger(webhooksLog)
 
 	// Save map to use setLogLevels laters
 	subsystemLoggers = map[string]slog.Logger{
@@ -288,6 +300,7 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 		"EXPR":    expLog,
 		"JAPI":    apiLog,
 		"IAPI":    iapiLog,
+		"GAPI":    gapiLog,
 		"DATD":    log,
 		"PUBS":    pubsubLog,
 		"XBOT":    xcBotLog,
@@ -297,6 +310,8 @@ func initLogRotators(logPath, debugLogPath string, maxRolls int) {
 		"BTCBLKD": btcBlockdataLog,
 		"LTCBLKD": ltcBlockdataLog,
 		"XMRBLKD": xmrBlockdataLog,
+		"CALR":    calendarLog,
+		"HOOK":    webhooksLog,
 	}
 }
 
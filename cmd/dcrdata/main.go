@@ -39,6 +39,7 @@ import (
 	"github.com/decred/dcrdata/v8/db/cache"
 	"github.com/decred/dcrdata/v8/db/dbtypes"
 	"github.com/decred/dcrdata/v8/mempool"
+	"github.com/decred/dcrdata/v8/mempool/mempoolbtc"
 	"github.com/decred/dcrdata/v8/mempool/mempoolltc"
 	"github.com/decred/dcrdata/v8/mutilchain"
 	"github.com/decred/dcrdata/v8/mutilchain/btcrpcutils"
@@ -49,6 +50,8 @@ import (
 	"github.com/decred/dcrdata/v8/rpcutils"
 	"github.com/decred/dcrdata/v8/semver"
 	"github.com/decred/dcrdata/v8/stakedb"
+	"github.com/decred/dcrdata/v8/txhelpers"
+	"github.com/decred/dcrdata/v8/webhooks"
 	"github.com/decred/dcrdata/v8/xmr/xmrclient"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -57,7 +60,9 @@ import (
 	ltcClient "github.com/ltcsuite/ltcd/rpcclient"
 
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/api"
+	"github.com/decred/dcrdata/cmd/dcrdata/internal/api/graphql"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/api/insight"
+	"github.com/decred/dcrdata/cmd/dcrdata/internal/calendar"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/chainsocket"
 	"github.com/decred/dcrdata/cmd/dcrdata/internal/explorer"
 	mw "github.com/decred/dcrdata/cmd/dcrdata/internal/middleware"
@@ -286,6 +291,31 @@ func _main(ctx context.Context) error {
 		return fmt.Errorf("Check and create 24hblocks table failed: %w", create24hBlocksErr)
 	}
 
+	// Create liquidity snapshots table
+	if err = chainDB.CheckCreateLiquiditySnapshotsTable(); err != nil {
+		return fmt.Errorf("Check and create liquidity_snapshots table failed: %w", err)
+	}
+
+	// Create dcrdex market days table
+	if err = chainDB.CheckCreateDEXMarketDaysTable(); err != nil {
+		return fmt.Errorf("Check and create dex_market_days table failed: %w", err)
+	}
+
+	// Create VSP history tables
+	if err = chainDB.CheckCreateVSPTables(); err != nil {
+		return fmt.Errorf("Check and create vsp tables failed: %w", err)
+	}
+
+	// Create mixes table
+	if err = chainDB.CheckCreateMixesTable(); err != nil {
+		return fmt.Errorf("Check and create mixes table failed: %w", err)
+	}
+
+	// Create tspend tracker table
+	if err = chainDB.CheckCreateTSpendTrackerTable(); err != nil {
+		return fmt.Errorf("Check and create tspend tracker table failed: %w", err)
+	}
+
 	var barLoad chan *dbtypes.ProgressBarLoad
 	var ltcdClient *ltcClient.Client
 	var btcdClient *btcClient.Client
@@ -305,6 +335,16 @@ func _main(ctx context.Context) error {
 	chainDB.ChainDisabledMap[mutilchain.TYPEDCR] = dcrDisabled
 	chainDB.ChainDisabledMap[mutilchain.TYPEXMR] = xmrDisabled
 
+	// Create fee estimates tables
+	if err = chainDB.CheckCreateFeeEstimatesTables(); err != nil {
+		return fmt.Errorf("Check and create fee estimates tables failed: %w", err)
+	}
+
+	// Create mempool ledger tables
+	if err = chainDB.CheckCreateMempoolLedgerTables(); err != nil {
+		return fmt.Errorf("Check and create mempool ledger tables failed: %w", err)
+	}
+
 	//init mutilchain rpc client and set to chainDB
 	if !ltcDisabled {
 		//Start create rpcclient
@@ -608,10 +648,15 @@ func _main(ctx context.Context) error {
 			MasterBot:      cfg.RateMaster,
 			MasterCertFile: cfg.RateCertificate,
 			BinanceAPIURL:  cfg.BinanceAPI,
+			IndexMode:      cfg.IndexMode,
+			IndexExpiry:    cfg.IndexExpiry,
 		}
 		if cfg.DisabledExchanges != "" {
 			botCfg.Disabled = strings.Split(cfg.DisabledExchanges, ",")
 		}
+		if cfg.IndexSources != "" {
+			botCfg.IndexPriority = strings.Split(cfg.IndexSources, ",")
+		}
 		xcBot, err = exchanges.NewExchangeBot(&botCfg)
 		if err != nil {
 			log.Errorf("Could not create exchange monitor. Exchange info will be disabled: %v", err)
@@ -643,6 +688,49 @@ func _main(ctx context.Context) error {
 		}
 	}
 
+	// Periodically store order book liquidity snapshots for the market charts.
+	if xcBot != nil {
+		liquidityChains := make([]string, 0, 4)
+		for chainType, disabled := range map[string]bool{
+			mutilchain.TYPEDCR: dcrDisabled,
+			mutilchain.TYPEBTC: btcDisabled,
+			mutilchain.TYPELTC: ltcDisabled,
+			mutilchain.TYPEXMR: xmrDisabled,
+		} {
+			if !disabled {
+				liquidityChains = append(liquidityChains, chainType)
+			}
+		}
+		wg.Add(1)
+		go storeLiquiditySnapshots(ctx, &wg, xcBot, chainDB, liquidityChains)
+	}
+
+	// Collect the dcrdex market volumes for the Bison Wallet dashboard.
+	if cfg.DEXDataURL != "" && activeChain.Name == "mainnet" {
+		wg.Add(1)
+		go collectDEXMarketDays(ctx, &wg, exchanges.NewDEXCollector(cfg.DEXDataURL, nil), cfg.DEXHistoryURL, chainDB)
+	}
+
+	// Price and market alerts are fed by the ExchangeBot.
+	var alertEngine *exchanges.AlertEngine
+	if cfg.AlertRulesFile != "" {
+		if xcBot == nil {
+			log.Warnf("Market alerts require the exchange monitor. Alerts disabled.")
+		} else {
+			rules, err := exchanges.LoadAlertRules(cfg.AlertRulesFile)
+			if err == nil {
+				alertEngine, err = exchanges.NewAlertEngine(xcBot, &exchanges.AlertEngineConfig{Rules: rules})
+			}
+			if err != nil {
+				log.Errorf("Could not create alert engine. Market alerts will be disabled: %v", err)
+			} else {
+				log.Infof("Market alerts enabled with %d rules", len(rules))
+				wg.Add(1)
+				go alertEngine.Run(ctx, &wg)
+			}
+		}
+	}
+
 	// Creates a new or loads an existing agendas db instance that helps to
 	// store and retrieves agendas data. Agendas votes are On-Chain
 	// transactions that appear in the decred blockchain. If corrupted data is
@@ -673,6 +761,34 @@ func _main(ctx context.Context) error {
 			return fmt.Errorf("Unable to initialize vote tracker: %v", err)
 		}
 	}
+
+	// The governance calendar estimates the times of governance and block
+	// reward events from block heights. Its chain tips are set as blocks
+	// arrive.
+	calendarCfg := &calendar.Config{
+		Params:    activeChain,
+		Proposals: proposalsDB,
+		TSpends:   chainDB,
+		BlockTime: chainDB.BlockTimeByHeight,
+	}
+	if tracker != nil {
+		calendarCfg.Agendas = tracker
+	}
+	if !btcDisabled {
+		calendarCfg.Chains = append(calendarCfg.Chains, calendar.Chain{
+			Name:                     mutilchain.TYPEBTC,
+			BlockTime:                btcActiveChain.TargetTimePerBlock,
+			SubsidyReductionInterval: int64(btcActiveChain.SubsidyReductionInterval),
+		})
+	}
+	if !ltcDisabled {
+		calendarCfg.Chains = append(calendarCfg.Chains, calendar.Chain{
+			Name:                     mutilchain.TYPELTC,
+			BlockTime:                ltcActiveChain.TargetTimePerBlock,
+			SubsidyReductionInterval: int64(ltcActiveChain.SubsidyReductionInterval),
+		})
+	}
+	govCalendar := calendar.New(calendarCfg)
 	chainDisabledMap := make(map[string]bool)
 	chainDisabledMap[mutilchain.TYPEBTC] = btcDisabled
 	chainDisabledMap[mutilchain.TYPELTC] = ltcDisabled
@@ -727,6 +843,56 @@ func _main(ctx context.Context) error {
 	blockDataSavers = append(blockDataSavers, psHub)
 	mempoolSavers = append(mempoolSavers, psHub) // individual transactions are from mempool monitor
 
+	// The chain event webhooks are fed by the same block savers and mempool
+	// signals as the pubsub hub.
+	var hookDispatcher *webhooks.Dispatcher
+	if cfg.EnableWebhooks {
+		var hookChains []string
+		for _, chainType := range []string{mutilchain.TYPEDCR, mutilchain.TYPEBTC,
+			mutilchain.TYPELTC, mutilchain.TYPEXMR} {
+			if !chainDisabledMap[chainType] {
+				hookChains = append(hookChains, chainType)
+			}
+		}
+		if err = chainDB.CheckCreateWebhookTables(); err == nil {
+			hookDispatcher, err = webhooks.NewDispatcher(ctx, &webhooks.Config{
+				Store:     chainDB,
+				Chains:    hookChains,
+				DCRParams: activeChain,
+				BTCParams: btcActiveChain,
+				LTCParams: ltcActiveChain,
+			})
+		}
+		if err != nil {
+			log.Errorf("Could not create webhook dispatcher. Webhooks will be disabled: %v", err)
+			hookDispatcher = nil
+		} else {
+			log.Infof("Chain event webhooks enabled for %s", strings.Join(hookChains, ", "))
+			wg.Add(1)
+			go hookDispatcher.Run(&wg)
+			blockDataSavers = append(blockDataSavers, hookDispatcher)
+		}
+	}
+
+	// Relay tspend lifecycle events to pubsub subscribers.
+	chainDB.SetTSpendEventHandler(func(ev *dbtypes.TSpendEvent) {
+		select {
+		case psHub.HubRelay() <- pstypes.HubMessage{Signal: pstypes.SigTSpend, Msg: ev}:
+		case <-time.After(time.Second * 10):
+			log.Errorf("SigTSpend send failed: Timeout waiting for WebsocketHub.")
+		}
+	})
+
+	// Relay the double spends seen in the DCR, BTC and LTC mempools to pubsub
+	// subscribers.
+	doubleSpendHandler := func(ds *txhelpers.DoubleSpend) {
+		select {
+		case psHub.HubRelay() <- pstypes.HubMessage{Signal: pstypes.SigDoubleSpend, Msg: ds}:
+		case <-time.After(time.Second * 10):
+			log.Errorf("SigDoubleSpend send failed: Timeout waiting for WebsocketHub.")
+		}
+	}
+
 	// Store explorerUI data after pubsubhub.
 	blockDataSavers = append(blockDataSavers, explore)
 	mempoolSavers = append(mempoolSavers, explore)
@@ -751,6 +917,9 @@ func _main(ctx context.Context) error {
 	signalToPSHub := psHub.HubRelay()
 	signalToExplorer := explore.MempoolSignal()
 	mempoolSigOuts := []chan<- pstypes.HubMessage{signalToPSHub, signalToExplorer}
+	if hookDispatcher != nil {
+		mempoolSigOuts = append(mempoolSigOuts, hookDispatcher.HubRelay())
+	}
 	mpm, err := mempool.NewMempoolMonitor(ctx, mpoolCollector, mempoolSavers,
 		activeChain, mempoolSigOuts, true)
 
@@ -763,6 +932,8 @@ func _main(ctx context.Context) error {
 
 	// Use the MempoolMonitor in DB to get unconfirmed transaction data.
 	chainDB.UseMempoolChecker(mpm)
+	chainDB.UseMempoolLedger(mutilchain.TYPEDCR, mpm.Ledger())
+	mpm.Ledger().SetDoubleSpendHandler(doubleSpendHandler)
 
 	// Prepare for sync by setting up the channels for status/progress updates
 	// (barLoad) or full explorer page updates (latestBlockHash).
@@ -851,6 +1022,21 @@ func _main(ctx context.Context) error {
 	defer insightSocketServer.Close()
 	blockDataSavers = append(blockDataSavers, insightSocketServer)
 
+	// The GraphQL API resolves from the same database as the JSON API, with
+	// the subscriptions backed by the pubsub hub.
+	gqlCfg := &graphql.Config{
+		DataSource:     chainDB,
+		Events:         psHub,
+		DisabledChains: chainDisabledMap,
+	}
+	if xcBot != nil {
+		gqlCfg.Prices = xcBot
+	}
+	gqlHandler, err := graphql.NewHandler(gqlCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create the GraphQL API: %v", err)
+	}
+
 	// Start dcrdata's JSON web API.
 	app := api.NewContext(&api.AppContextConfig{
 		Client:            dcrdClient,
@@ -859,13 +1045,20 @@ func _main(ctx context.Context) error {
 		Params:            activeChain,
 		DataSource:        chainDB,
 		XcBot:             xcBot,
+		AlertEngine:       alertEngine,
+		Webhooks:          hookDispatcher,
+		EventStream:       psHub.EventsHandler,
+		GraphQL:           gqlHandler,
 		AgendasDBInstance: agendaDB,
+		Tracker:           tracker,
+		Calendar:          govCalendar,
 		ProposalsDB:       proposalsDB,
 		MaxAddrs:          cfg.MaxCSVAddrs,
 		Charts:            charts,
 		ChainDisabledMap:  chainDisabledMap,
 		CoinCaps:          coinCaps,
 	})
+	app.MutilchainHashRate = explore.MutilchainHashRate
 	getMarketCapData := func() {
 		//get coin cap data from extenal api
 		coinCapData := externalapi.GetCoinigyCapData(coinCaps)
@@ -1032,6 +1225,9 @@ func _main(ctx context.Context) error {
 			rd.Get("/market", explore.MarketPage)
 			rd.Get("/marketlist", explore.CoinCapPage)
 			rd.Get("/bwdash", explore.BisonWalletDashboardPage)
+			rd.Get("/vsps", explore.VSPsPage)
+			rd.Get("/mixes", explore.MixesPage)
+			rd.Get("/stake-simulator", explore.StakeSimulatorPage)
 			rd.Get("/statistics", func(w http.ResponseWriter, r *http.Request) {
 				http.Redirect(w, r, "/decred/stats", http.StatusPermanentRedirect)
 			})
@@ -1147,6 +1343,7 @@ func _main(ctx context.Context) error {
 			rd.Get("/supply", explore.SupplyPage)
 			rd.Get("/visualblocks", explore.MultichainVisualBlocks)
 			rd.Get("/parameters", explore.MutilchainParametersPage)
+			rd.Get("/attack-cost", explore.MutilchainAttackCost)
 			rd.With(explorer.AddressPathCtx).Get("/address/{address}", explore.MutilchainAddressPage)
 			rd.With(explorer.AddressPathCtx).Get("/addresstable/{address}", explore.MutilchainAddressTable)
 		})
@@ -1579,6 +1776,9 @@ func _main(ctx context.Context) error {
 	notifier.RegisterBlockHandlerGroup(sdbChainMonitor.ConnectBlock)
 	notifier.RegisterBlockHandlerGroup(bdChainMonitor.ConnectBlock)
 	notifier.RegisterBlockHandlerLiteGroup(app.UpdateNodeHeight, mpm.BlockHandler)
+	notifier.RegisterBlockHandlerGroup(govCalendar.ConnectBlock)
+	// The fee estimates use the refreshed mempool.
+	notifier.RegisterBlockHandlerLiteGroup(feeEstimatesHandler(chainDB, mutilchain.TYPEDCR))
 	notifier.RegisterReorgHandlerGroup(sdbChainMonitor.ReorgHandler)
 	notifier.RegisterReorgHandlerGroup(bdChainMonitor.ReorgHandler, chainDBChainMonitor.ReorgHandler)
 	notifier.RegisterReorgHandlerGroup(charts.ReorgHandler) // snip charts data
@@ -1611,6 +1811,9 @@ func _main(ctx context.Context) error {
 	// that subsequent blocks are in the correct sequence.
 	bestHash, bestHeight := chainDB.BestBlock()
 	notifier.SetPreviousBlock(*bestHash, uint32(bestHeight))
+	if bestTime, err := chainDB.BlockTimeByHeight(bestHeight); err == nil {
+		govCalendar.Update(mutilchain.TYPEDCR, bestHeight, bestTime)
+	}
 
 	// Register for notifications from dcrd. This also sets the daemon RPC
 	// client used by other functions in the notify/notification package (i.e.
@@ -1781,6 +1984,9 @@ func _main(ctx context.Context) error {
 		xmrBlockDataSavers = append(xmrBlockDataSavers, chainDB)
 		xmrBlockDataSavers = append(xmrBlockDataSavers, psHub)
 		xmrBlockDataSavers = append(xmrBlockDataSavers, explore)
+		if hookDispatcher != nil {
+			xmrBlockDataSavers = append(xmrBlockDataSavers, hookDispatcher)
+		}
 		// Add charts saver method after explorer and database stores. This may run
 		// asynchronously.
 		xmrBlockDataSavers = append(xmrBlockDataSavers, blockdataxmr.BlockTrigger{
@@ -1799,7 +2005,7 @@ func _main(ctx context.Context) error {
 		if cerr != nil {
 			return fmt.Errorf("XMR RPC client error: %v", cerr)
 		}
-		go explore.UpdateXMRMempoolData(xmrClient, make(chan struct{}))
+		go explore.UpdateXMRMempoolData(xmrClient, make(chan struct{}), psHub)
 	}
 
 	// handler syncing for XMR blockchain on background
@@ -1816,37 +2022,52 @@ func _main(ctx context.Context) error {
 			return fmt.Errorf("Check and create table for blockchain %s errors: %w", mutilchain.TYPELTC, checkErr)
 		}
 		//first, use external socket api to get mempool info
-		mainSocket, err := chainsocket.NewMutilchainInfoSocket(explore, mutilchain.TYPELTC)
-		if err == nil {
-			err = mainSocket.StartMempoolConnectAndUpdate()
+		mainSocket, socketErr := chainsocket.NewMutilchainInfoSocket(explore, mutilchain.TYPELTC)
+		if socketErr == nil {
+			socketErr = mainSocket.StartMempoolConnectAndUpdate()
 		}
-		if err != nil {
+		if socketErr != nil {
 			log.Infof("Create external API socket failed. Start initialize mempool data with Mempool collector")
-			if !chainDB.ChainDBDisabled {
-				//handler mempool with Mempool monitor
-				ltcMempoolSavers := []mempoolltc.MempoolDataSaver{chainDB.LTCMPC}
+		}
+		var ltcMpm *mempoolltc.MempoolMonitor
+		if !chainDB.ChainDBDisabled {
+			// The mempool monitor also feeds the block projections, replacements,
+			// ledger and new transaction signals, so it runs even when the
+			// external API socket provides the mempool info of the explorer.
+			ltcMempoolSavers := []mempoolltc.MempoolDataSaver{chainDB.LTCMPC}
+			if socketErr != nil {
 				ltcMempoolSavers = append(ltcMempoolSavers, explore)
-				// Create the mempool data collector.
-				ltcMpoolCollector := mempoolltc.NewDataCollector(ltcdClient, ltcActiveChain)
-				if ltcMpoolCollector == nil {
-					// Shutdown goroutines.
-					requestShutdown()
-					return fmt.Errorf("Failed to create LTC mempool data collector")
-				}
-
-				mpm, err := mempoolltc.NewMempoolMonitor(ctx, ltcMpoolCollector, ltcMempoolSavers,
-					ltcActiveChain, true)
+			}
+			ltcMempoolSavers = append(ltcMempoolSavers, psHub)
+			// Create the mempool data collector.
+			ltcMpoolCollector := mempoolltc.NewDataCollector(ltcdClient, ltcActiveChain)
+			if ltcMpoolCollector == nil {
+				// Shutdown goroutines.
+				requestShutdown()
+				return fmt.Errorf("Failed to create LTC mempool data collector")
+			}
 
-				// Ensure the initial collect/store succeeded.
-				if err != nil {
-					// Shutdown goroutines.
-					requestShutdown()
-					return fmt.Errorf("NewMempoolMonitor: %v", err)
-				}
+			// New transactions are signaled to the pubsub hub.
+			ltcMempoolSigOuts := []chan<- pstypes.HubMessage{psHub.HubRelay()}
+			if hookDispatcher != nil {
+				ltcMempoolSigOuts = append(ltcMempoolSigOuts, hookDispatcher.HubRelay())
+			}
+			mpm, err := mempoolltc.NewMempoolMonitor(ctx, ltcMpoolCollector, ltcMempoolSavers,
+				ltcActiveChain, ltcMempoolSigOuts, true)
 
-				// Use the MempoolMonitor in DB to get unconfirmed transaction data.
-				chainDB.UseLTCMempoolChecker(mpm)
+			// Ensure the initial collect/store succeeded.
+			if err != nil {
+				// Shutdown goroutines.
+				requestShutdown()
+				return fmt.Errorf("NewMempoolMonitor: %v", err)
 			}
+
+			// Use the MempoolMonitor in DB to get unconfirmed transaction data.
+			chainDB.UseLTCMempoolChecker(mpm)
+			chainDB.UseMempoolReplacements(mutilchain.TYPELTC, mpm.Replacements())
+			chainDB.UseMempoolLedger(mutilchain.TYPELTC, mpm.Ledger())
+			mpm.Ledger().SetDoubleSpendHandler(doubleSpendHandler)
+			ltcMpm = mpm
 		}
 
 		//Start - LTC Sync handler
@@ -1961,10 +2182,31 @@ func _main(ctx context.Context) error {
 		ltcBlockDataSavers = append(ltcBlockDataSavers, chainDB)
 		ltcBlockDataSavers = append(ltcBlockDataSavers, psHub)
 		ltcBlockDataSavers = append(ltcBlockDataSavers, explore)
+		if hookDispatcher != nil {
+			ltcBlockDataSavers = append(ltcBlockDataSavers, hookDispatcher)
+		}
 		ltcBdChainMonitor := blockdataltc.NewChainMonitor(ctx, ltcCollector, ltcBlockDataSavers,
 			ltcReorgBlockDataSavers)
 
 		ltcNotifier.RegisterBlockHandlerGroup(ltcBdChainMonitor.ConnectBlock)
+		ltcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.LtcBlockHeader) error {
+			govCalendar.Update(mutilchain.TYPELTC, int64(bh.Height), bh.Time.Unix())
+			return nil
+		})
+		if ltcMpm != nil {
+			// Rebuild the mempool projection after each block.
+			ltcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.LtcBlockHeader) error {
+				return ltcMpm.BlockHandler(uint32(bh.Height), bh.Hash.String())
+			})
+			// Track the replacements of new mempool transactions.
+			ltcNotifier.RegisterTxHandlerGroup(ltcMpm.TxHandler)
+		}
+		ltcFeeEstimates := feeEstimatesHandler(chainDB, mutilchain.TYPELTC)
+		ltcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.LtcBlockHeader) error {
+			return ltcFeeEstimates(uint32(bh.Height), bh.Hash.String())
+		})
+		govCalendar.Update(mutilchain.TYPELTC, chainDB.MutilchainHeight(mutilchain.TYPELTC),
+			chainDB.MutilchainBestBlockTime(mutilchain.TYPELTC))
 		// Register for notifications from dcrd. This also sets the daemon RPC
 		// client used by other functions in the notify/notification package (i.e.
 		// common ancestor identification in processReorg).
@@ -1984,13 +2226,53 @@ func _main(ctx context.Context) error {
 		}
 
 		//first, use external socket api to get mempool info
-		mainSocket, err := chainsocket.NewMutilchainInfoSocket(explore, mutilchain.TYPEBTC)
-		if err == nil {
-			err = mainSocket.StartMempoolConnectAndUpdate()
+		mainSocket, socketErr := chainsocket.NewMutilchainInfoSocket(explore, mutilchain.TYPEBTC)
+		if socketErr == nil {
+			socketErr = mainSocket.StartMempoolConnectAndUpdate()
 		}
-		if err != nil {
+		if socketErr != nil {
 			log.Infof("Create external API socket failed. Start initialize mempool data with Mempool collector")
 		}
+		var btcMpm *mempoolbtc.MempoolMonitor
+		if !chainDB.ChainDBDisabled {
+			// The mempool monitor also feeds the block projections, replacements,
+			// ledger and new transaction signals, so it runs even when the
+			// external API socket provides the mempool info of the explorer.
+			btcMempoolSavers := []mempoolbtc.MempoolDataSaver{chainDB.BTCMPC}
+			if socketErr != nil {
+				btcMempoolSavers = append(btcMempoolSavers, explore)
+			}
+			btcMempoolSavers = append(btcMempoolSavers, psHub)
+			// Create the mempool data collector.
+			btcMpoolCollector := mempoolbtc.NewDataCollector(btcdClient, btcActiveChain)
+			if btcMpoolCollector == nil {
+				// Shutdown goroutines.
+				requestShutdown()
+				return fmt.Errorf("Failed to create BTC mempool data collector")
+			}
+
+			// New transactions are signaled to the pubsub hub.
+			btcMempoolSigOuts := []chan<- pstypes.HubMessage{psHub.HubRelay()}
+			if hookDispatcher != nil {
+				btcMempoolSigOuts = append(btcMempoolSigOuts, hookDispatcher.HubRelay())
+			}
+			mpm, err := mempoolbtc.NewMempoolMonitor(ctx, btcMpoolCollector, btcMempoolSavers,
+				btcActiveChain, btcMempoolSigOuts, true)
+
+			// Ensure the initial collect/store succeeded.
+			if err != nil {
+				// Shutdown goroutines.
+				requestShutdown()
+				return fmt.Errorf("NewMempoolMonitor: %v", err)
+			}
+
+			// Use the MempoolMonitor in DB to get unconfirmed transaction data.
+			chainDB.UseBTCMempoolChecker(mpm)
+			chainDB.UseMempoolReplacements(mutilchain.TYPEBTC, mpm.Replacements())
+			chainDB.UseMempoolLedger(mutilchain.TYPEBTC, mpm.Ledger())
+			mpm.Ledger().SetDoubleSpendHandler(doubleSpendHandler)
+			btcMpm = mpm
+		}
 
 		//Start - BTC Sync handler
 		btcHeightFromDB, err := chainDB.MutilchainHeightDB(mutilchain.TYPEBTC)
@@ -2104,11 +2386,32 @@ func _main(ctx context.Context) error {
 		btcBlockDataSavers = append(btcBlockDataSavers, chainDB)
 		btcBlockDataSavers = append(btcBlockDataSavers, psHub)
 		btcBlockDataSavers = append(btcBlockDataSavers, explore)
+		if hookDispatcher != nil {
+			btcBlockDataSavers = append(btcBlockDataSavers, hookDispatcher)
+		}
 		btcReorgBlockDataSavers := []blockdatabtc.BlockDataSaver{explore}
 		btcBdChainMonitor := blockdatabtc.NewChainMonitor(ctx, btcCollector, btcBlockDataSavers,
 			btcReorgBlockDataSavers)
 
 		btcNotifier.RegisterBlockHandlerGroup(btcBdChainMonitor.ConnectBlock)
+		btcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.BtcBlockHeader) error {
+			govCalendar.Update(mutilchain.TYPEBTC, int64(bh.Height), bh.Time.Unix())
+			return nil
+		})
+		if btcMpm != nil {
+			// Rebuild the mempool projection after each block.
+			btcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.BtcBlockHeader) error {
+				return btcMpm.BlockHandler(uint32(bh.Height), bh.Hash.String())
+			})
+			// Track the replacements of new mempool transactions.
+			btcNotifier.RegisterTxHandlerGroup(btcMpm.TxHandler)
+		}
+		btcFeeEstimates := feeEstimatesHandler(chainDB, mutilchain.TYPEBTC)
+		btcNotifier.RegisterBlockHandlerGroup(func(bh *mutilchain.BtcBlockHeader) error {
+			return btcFeeEstimates(uint32(bh.Height), bh.Hash.String())
+		})
+		govCalendar.Update(mutilchain.TYPEBTC, chainDB.MutilchainHeight(mutilchain.TYPEBTC),
+			chainDB.MutilchainBestBlockTime(mutilchain.TYPEBTC))
 		// Register for notifications from dcrd. This also sets the daemon RPC
 		// client used by other functions in the notify/notification package (i.e.
 		// common ancestor identification in processReorg).
@@ -2310,6 +2613,128 @@ func connectBTCNodeRPC(cfg *config, ntfnHandlers *btcClient.NotificationHandlers
 		cfg.BtcdCert, cfg.DisableDaemonTLS, true, ntfnHandlers)
 }
 
+const feeEstimatesRetention = 90 * 24 * time.Hour
+
+// feeEstimatesHandler returns a block handler that updates the fee estimates
+// of a chain, and deletes the estimates older than feeEstimatesRetention once
+// a day.
+func feeEstimatesHandler(chainDB *dcrpg.ChainDB, chainType string) func(uint32, string) error {
+	var lastPurge time.Time
+	return func(height uint32, _ string) error {
+		if _, err := chainDB.UpdateFeeEstimates(chainType); err != nil {
+			log.Errorf("Failed to update the %s fee estimates at height %d: %v", chainType, height, err)
+		}
+		if time.Since(lastPurge) > 24*time.Hour {
+			lastPurge = time.Now()
+			cutoff := lastPurge.Add(-feeEstimatesRetention).Unix()
+			if n, err := chainDB.PurgeFeeEstimates(chainType, cutoff); err != nil {
+				log.Errorf("Failed to purge the %s fee estimates: %v", chainType, err)
+			} else if n > 0 {
+				log.Debugf("Purged %d old %s fee estimates", n, chainType)
+			}
+		}
+		return nil
+	}
+}
+
+const (
+	liquiditySnapshotInterval  = 5 * time.Minute
+	liquiditySnapshotRetention = 90 * 24 * time.Hour
+)
+
+// storeLiquiditySnapshots stores a summary of each chain's order books every
+// liquiditySnapshotInterval, and deletes snapshots older than
+// liquiditySnapshotRetention once a day.
+func storeLiquiditySnapshots(ctx context.Context, wg *sync.WaitGroup, xcBot *exchanges.ExchangeBot,
+	chainDB *dcrpg.ChainDB, chainTypes []string) {
+	defer wg.Done()
+	ticker := time.NewTicker(liquiditySnapshotInterval)
+	defer ticker.Stop()
+	var lastPurge time.Time
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		var snapshots []*dbtypes.LiquiditySnapshot
+		if state := xcBot.State(); state != nil {
+			stamp := time.Now().Unix()
+			for _, chainType := range chainTypes {
+				snapshots = append(snapshots, liquiditySnapshots(chainType, state.GetMutilchainExchangeState(chainType), stamp)...)
+			}
+		}
+		if len(snapshots) > 0 {
+			if err := chainDB.InsertLiquiditySnapshots(snapshots); err != nil {
+				log.Errorf("Failed to store liquidity snapshots: %v", err)
+			}
+		}
+		if time.Since(lastPurge) > 24*time.Hour {
+			lastPurge = time.Now()
+			cutoff := lastPurge.Add(-liquiditySnapshotRetention).Unix()
+			if n, err := chainDB.PurgeLiquiditySnapshots(cutoff); err != nil {
+				log.Errorf("Failed to purge liquidity snapshots: %v", err)
+			} else if n > 0 {
+				log.Debugf("Purged %d old liquidity snapshots", n)
+			}
+		}
+	}
+}
+
+const dexDataInterval = time.Hour
+
+// collectDEXMarketDays stores the daily volume of the dcrdex markets on
+// startup and every dexDataInterval. The volume history at historyURL, if
+// set, is stored once for the days before the collected data.
+func collectDEXMarketDays(ctx context.Context, wg *sync.WaitGroup, collector *exchanges.DEXCollector,
+	historyURL string, chainDB *dcrpg.ChainDB) {
+	defer wg.Done()
+	ticker := time.NewTicker(dexDataInterval)
+	defer ticker.Stop()
+	haveHistory := historyURL == ""
+	for {
+		days, err := collector.Collect(ctx)
+		if err != nil {
+			log.Errorf("Failed to collect dcrdex market data: %v", err)
+		} else if err = chainDB.StoreDEXMarketDays(dbDEXMarketDays(days)); err != nil {
+			log.Errorf("Failed to store dcrdex market data: %v", err)
+		} else if !haveHistory {
+			// The collected days must be stored first, so that the history
+			// is only stored for the days before them.
+			days, err = collector.History(ctx, historyURL)
+			if err != nil {
+				log.Errorf("Failed to get the dcrdex volume history: %v", err)
+			} else if err = chainDB.StoreDEXHistoryDays(dbDEXMarketDays(days)); err != nil {
+				log.Errorf("Failed to store the dcrdex volume history: %v", err)
+			} else {
+				haveHistory = true
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dbDEXMarketDays converts the collected dcrdex market days for storage.
+func dbDEXMarketDays(days []*exchanges.DEXMarketDay) []*dbtypes.DEXMarketDay {
+	dbDays := make([]*dbtypes.DEXMarketDay, 0, len(days))
+	for _, d := range days {
+		dbDays = append(dbDays, &dbtypes.DEXMarketDay{
+			Market:          d.Market,
+			Day:             d.Day,
+			BaseVolume:      d.BaseVolume,
+			QuoteVolume:     d.QuoteVolume,
+			USDVolume:       d.USDVolume,
+			ActiveIntervals: d.ActiveIntervals,
+			Rate:            d.Rate,
+		})
+	}
+	return dbDays
+}
+
 func listenAndServeProto(ctx context.Context, wg *sync.WaitGroup, listen, proto string, mux http.Handler) {
 	// Try to bind web server
 	server := http.Server{
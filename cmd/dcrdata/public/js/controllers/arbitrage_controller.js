@@ -0,0 +1,128 @@
+import { Controller } from '@hotwired/stimulus'
+import { requestJSON } from '../helpers/http.js'
+import humanize from '../helpers/humanize_helper.js'
+import globalEventBus from '../services/event_bus_service.js'
+
+const printNames = {
+  dcrdex: 'Dcrdex',
+  btc_coinex: 'Coinex',
+  btc_binance: 'Binance'
+  // default is capitalize
+}
+
+function printName (token) {
+  const name = printNames[token]
+  if (name) return name
+  return humanize.capitalize(token)
+}
+
+function fmtPrice (price, quote) {
+  if (quote === 'btc') return price.toFixed(8)
+  return humanize.threeSigFigs(price)
+}
+
+function fmtPct (pct) {
+  const cls = pct > 0 ? 'text-green' : (pct < 0 ? 'text-danger' : '')
+  return `<span class="${cls}">${pct.toFixed(2)}%</span>`
+}
+
+export default class extends Controller {
+  static get targets () {
+    return ['quote', 'books', 'summary', 'size', 'trade']
+  }
+
+  connect () {
+    this.chainType = this.data.get('chainType')
+    this.quote = 'usdt'
+    this.size = 1
+    this.processXcUpdate = this._processXcUpdate.bind(this)
+    globalEventBus.on('EXCHANGE_UPDATE', this.processXcUpdate)
+    this.fetchArbitrage()
+  }
+
+  disconnect () {
+    globalEventBus.off('EXCHANGE_UPDATE', this.processXcUpdate)
+  }
+
+  setQuote (e) {
+    const btn = e.target
+    if (btn.nodeName !== 'BUTTON' || btn.name === this.quote) return
+    this.quoteTargets.forEach(b => b.classList.remove('btn-selected'))
+    btn.classList.add('btn-selected')
+    this.quote = btn.name
+    this.fetchArbitrage()
+  }
+
+  setSize () {
+    const size = parseFloat(this.sizeTarget.value)
+    if (isNaN(size) || size < 0) return
+    this.size = size
+    this.fetchArbitrage()
+  }
+
+  async fetchArbitrage () {
+    // Exchange updates can arrive in bursts. Requests made while another is
+    // in flight are merged into a single follow-up request.
+    if (this.fetching) {
+      this.pending = true
+      return
+    }
+    this.fetching = true
+    const url = `/api/exchanges/${this.chainType}/arbitrage?quote=${this.quote}&size=${this.size}`
+    try {
+      this.drawBooks(await requestJSON(url))
+    } catch (err) {
+      // Keep the last books. The next exchange update retries.
+    } finally {
+      this.fetching = false
+      if (this.pending) {
+        this.pending = false
+        this.fetchArbitrage()
+      }
+    }
+  }
+
+  drawBooks (view) {
+    const quote = view.quote
+    let rows = ''
+    view.books.forEach(book => {
+      const bidCls = book.token === view.best_bid_exchange ? 'fw-bold text-green' : ''
+      const askCls = book.token === view.best_ask_exchange ? 'fw-bold text-danger' : ''
+      rows += `<tr>
+        <td class="py-1"><div class="exchange-logo ${book.token} me-2"></div>${printName(book.token)}</td>
+        <td class="text-end py-1 ${bidCls}">${fmtPrice(book.best_bid, quote)}</td>
+        <td class="text-end py-1 ${askCls}">${fmtPrice(book.best_ask, quote)}</td>
+        <td class="text-end py-1">${book.spread.toFixed(2)}%</td>
+      </tr>`
+    })
+    if (rows === '') {
+      rows = '<tr><td colspan="4" class="text-center py-2">No order book data</td></tr>'
+    }
+    this.booksTarget.innerHTML = rows
+    if (view.books.length < 2) {
+      this.summaryTarget.innerHTML = ''
+      this.tradeTarget.innerHTML = ''
+      return
+    }
+    this.summaryTarget.innerHTML = `Cross-exchange spread: ${fmtPct(view.spread)}
+      (bid ${printName(view.best_bid_exchange)}, ask ${printName(view.best_ask_exchange)})`
+    if (!view.trades || view.trades.length === 0) {
+      this.tradeTarget.innerHTML = ''
+      return
+    }
+    const trade = view.trades[0]
+    const unit = quote.toUpperCase()
+    let filled = ''
+    if (trade.filled < trade.size) {
+      filled = ` (only ${humanize.threeSigFigs(trade.filled)} fillable)`
+    }
+    this.tradeTarget.innerHTML = `Buy on ${printName(trade.buy_exchange)} @ ${fmtPrice(trade.avg_buy_price, quote)},
+      sell on ${printName(trade.sell_exchange)} @ ${fmtPrice(trade.avg_sell_price, quote)}${filled}:
+      <span class="fw-bold">${fmtPrice(trade.profit, quote)} ${unit}</span> ${fmtPct(trade.profit_percent)}`
+  }
+
+  _processXcUpdate (update) {
+    if (update.fiat || update.updater.chain_type !== this.chainType) return
+    this.fetchArbitrage()
+  }
+}
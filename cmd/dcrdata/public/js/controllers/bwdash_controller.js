@@ -1,11 +1,9 @@
 import { Controller } from '@hotwired/stimulus'
 import * as Plotly from 'plotly.js-dist-min'
 import humanize from '../helpers/humanize_helper'
+import { requestJSON } from '../helpers/http.js'
 
-const pairList = ['btc_usdc.eth', 'btc_usdt.polygon', 'dcr_btc', 'dcr_ltc', 'dcr_polygon', 'dcr_usdc.eth',
-  'dcr_usdc.polygon', 'dcr_usdt.polygon', 'dgb_btc', 'dgb_usdt.polygon', 'doge_btc', 'eth_btc', 'ltc_btc',
-  'ltc_usdt.polygon', 'usdc.polygon_usdt.polygon', 'zec_btc', 'zec_usdc.eth', 'zec_usdt.polygon'
-]
+let pairList = []
 
 const pairColor = ['#690e20', '#38b6ba', '#0f103f', '#2fc399', '#289f87', '#434c9a', '#1c5863', '#153451',
   '#3169e1', '#82ae14', '#92780b', '#92510b', '#3ca2ca', '#34cbaa', '#227b75', '#408ddb', '#75cda1', '#cd759e'
@@ -85,7 +83,7 @@ export default class extends Controller {
       legend: { orientation: 'h', xanchor: 'center', x: 0.5, traceorder: 'normal' }
     }
     // check currency pair with data
-    this.dailyData = await this.fetchCsvDataFromUrl()
+    this.dailyData = await this.fetchDailyData()
     const monthlyData = this.groupMonthlyData(this.dailyData)
     // weekly data
     const weeklyData = this.groupWeeklyData(this.dailyData)
@@ -167,7 +165,7 @@ export default class extends Controller {
           y: yLabel,
           name: pair,
           marker: {
-            color: pairColor[index],
+            color: pairColor[index % pairColor.length],
             width: 1
           },
           type: 'bar',
@@ -188,7 +186,7 @@ export default class extends Controller {
           y: yWeekLabel,
           name: pair,
           marker: {
-            color: pairColor[index],
+            color: pairColor[index % pairColor.length],
             width: 1
           },
           type: 'bar',
@@ -209,7 +207,7 @@ export default class extends Controller {
           y: yDailyLabel,
           name: pair,
           marker: {
-            color: pairColor[index],
+            color: pairColor[index % pairColor.length],
             width: 1
           },
           type: 'bar',
@@ -220,14 +218,14 @@ export default class extends Controller {
         if (curValueFloat > 0) {
           curLabels.push(pair)
           curPercentArr.push(curValueFloat)
-          curColors.push(pairColor[index])
+          curColors.push(pairColor[index % pairColor.length])
         }
         // init for current month breakdown
         const prevValueFloat = Number(prevMonthData[index + 1])
         if (prevValueFloat > 0) {
           prevLabels.push(pair)
           prevPercentArr.push(prevValueFloat)
-          prevColors.push(pairColor[index])
+          prevColors.push(pairColor[index % pairColor.length])
         }
       }
     })
@@ -283,32 +281,23 @@ export default class extends Controller {
     return Number(monthStr)
   }
 
-  async fetchCsvDataFromUrl () {
-    const data = await this.getCsvContent()
-    const csvData = []
-    const jsonObject = data.split(/\r?\n|\r/)
-    if (jsonObject.length < 2) {
-      return csvData
-    }
-    // Skip first header line
-    for (let i = 1; i < jsonObject.length; i++) {
-      if (jsonObject[i] === '' || jsonObject[i].trim() === '') {
-        continue
+  // fetchDailyData gets the collected market days and pivots them into rows
+  // of [date, volume of each pair in pairList] in USD.
+  async fetchDailyData () {
+    const marketDays = await requestJSON('/api/bwdash')
+    pairList = [...new Set(marketDays.map(d => d.market))].sort()
+    const rows = []
+    let row
+    let lastDay
+    marketDays.forEach((d) => {
+      if (d.day !== lastDay) {
+        lastDay = d.day
+        row = [humanize.date(d.day * 1000, false, true)].concat(pairList.map(() => '0'))
+        rows.push(row)
       }
-      csvData.push(jsonObject[i].split(','))
-    }
-    return csvData
-  }
-
-  async getCsvContent () {
-    return $.ajax({
-      url: 'https://raw.githubusercontent.com/bochinchero/dcrsnapcsv/main/data/stream/dex_decred_org_VolUSD.csv',
-      type: 'get',
-      dataType: 'text'
+      row[pairList.indexOf(d.market) + 1] = d.usd_volume + ''
     })
-      .then(function (res) {
-        return res
-      })
+    return rows
   }
 
   groupWeeklyData (records) {
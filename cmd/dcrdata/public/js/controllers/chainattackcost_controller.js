@@ -0,0 +1,71 @@
+import { Controller } from '@hotwired/stimulus'
+import { requestJSON } from '../helpers/http.js'
+import humanize from '../helpers/humanize_helper.js'
+
+function usd (v) {
+  return humanize.formatNumber(v, 0)
+}
+
+function coins (v) {
+  return humanize.formatNumber(v, 2)
+}
+
+function cell (text, start) {
+  const td = document.createElement('td')
+  td.className = start ? 'text-start' : 'text-end'
+  td.textContent = text
+  return td
+}
+
+function row (cells) {
+  const tr = document.createElement('tr')
+  cells.forEach((c, i) => tr.appendChild(cell(c, i === 0)))
+  return tr
+}
+
+export default class extends Controller {
+  static get targets () {
+    return ['form', 'message', 'periods']
+  }
+
+  connect () {
+    this.chainType = this.data.get('chainType')
+    this.unit = this.data.get('unit')
+    this.unitHashes = parseFloat(this.data.get('unitHashes'))
+    this.run()
+  }
+
+  estimate (e) {
+    e.preventDefault()
+    this.run()
+  }
+
+  async run () {
+    const params = new URLSearchParams()
+    new FormData(this.formTarget).forEach((v, k) => {
+      if (v !== '') params.set(k, v)
+    })
+    this.messageTarget.textContent = 'Estimating...'
+    let est
+    try {
+      est = await requestJSON(`/api/attack-cost/${this.chainType}?${params.toString()}`)
+    } catch (err) {
+      console.error(err)
+      this.messageTarget.textContent = 'The estimate failed. Check the inputs, or wait for the chain to sync.'
+      return
+    }
+    const cfg = est.config
+    const hashrate = (h) => `${humanize.formatNumber(h / this.unitHashes, 2)} ${this.unit}`
+    this.messageTarget.textContent = `Network hash rate ${hashrate(cfg.hashrate)}, price $${humanize.formatNumber(cfg.price, 2)}. ` +
+      `The attack needs ${hashrate(est.attack_hashrate)}, or ${est.devices.toLocaleString()} devices. ` +
+      `The rental market covers ${est.rental_coverage.toFixed(1)}% of it.`
+    this.periodsTarget.innerHTML = ''
+    ;[['1 hour', est.hour], ['1 day', est.day]].forEach(([name, p]) => {
+      this.periodsTarget.appendChild(row([
+        name, usd(p.hardware_cost), usd(p.electricity_kwh), usd(p.electricity_cost), usd(p.other_costs),
+        usd(p.buy_cost), coins(p.buy_cost_coins), usd(p.rental_cost), usd(p.remainder_cost), usd(p.rent_cost),
+        coins(p.rent_cost_coins)
+      ]))
+    })
+  }
+}
@@ -0,0 +1,95 @@
+import { Controller } from '@hotwired/stimulus'
+import * as Plotly from 'plotly.js-dist-min'
+import { requestJSON } from '../helpers/http.js'
+
+const atomsToDCR = 1e-8
+const dayMs = 86400000
+
+const layout = {
+  autosize: true,
+  height: 320,
+  margin: { l: 50, r: 20, t: 10, b: 40 },
+  showlegend: true,
+  legend: { orientation: 'h' },
+  xaxis: { type: 'date' },
+  yaxis: { rangemode: 'tozero' }
+}
+
+function denomLabel (atoms) {
+  return `${parseFloat((atoms * atomsToDCR).toFixed(8))} DCR`
+}
+
+export default class extends Controller {
+  static get targets () {
+    return ['zoom', 'message', 'volumeChart', 'participantsChart', 'changeChart']
+  }
+
+  connect () {
+    const active = this.zoomTarget.querySelector('button.active')
+    this.fetchDays(active ? parseInt(active.name) : 90)
+  }
+
+  setZoom (e) {
+    const btn = e.target.closest('button')
+    if (!btn) return
+    this.zoomTarget.querySelectorAll('button').forEach((b) => b.classList.remove('active'))
+    btn.classList.add('active')
+    this.fetchDays(parseInt(btn.name))
+  }
+
+  async fetchDays (days) {
+    this.messageTarget.textContent = ''
+    const from = days > 0 ? Math.floor((Date.now() - days * dayMs) / 1000) : 0
+    const url = `/api/mixes/daily?from=${from}`
+    let mixDays
+    try {
+      mixDays = await requestJSON(url)
+    } catch (err) {
+      console.error(err)
+      this.messageTarget.textContent = 'Failed to load the mixing history.'
+      return
+    }
+    if (!mixDays || mixDays.length === 0) {
+      this.messageTarget.textContent = 'No mixes in this period.'
+    }
+    this.plot(mixDays || [])
+  }
+
+  plot (mixDays) {
+    const byDenom = {}
+    const byDay = {}
+    mixDays.forEach((d) => {
+      const t = new Date(d.day * 1000)
+      if (!byDenom[d.denomination]) byDenom[d.denomination] = { x: [], y: [] }
+      byDenom[d.denomination].x.push(t)
+      byDenom[d.denomination].y.push(d.mixed_amount * atomsToDCR)
+      if (!byDay[d.day]) byDay[d.day] = { t: t, mixes: 0, participants: 0, change: 0 }
+      const day = byDay[d.day]
+      day.mixes += d.mixes
+      day.participants += d.avg_participants * d.mixes
+      day.change += d.change_amount * atomsToDCR
+    })
+    const volume = Object.keys(byDenom).sort((a, b) => b - a).map((denom) => ({
+      x: byDenom[denom].x,
+      y: byDenom[denom].y,
+      name: denomLabel(denom),
+      type: 'bar'
+    }))
+    const days = Object.keys(byDay).sort((a, b) => a - b).map((k) => byDay[k])
+    const x = days.map((d) => d.t)
+    Plotly.newPlot(this.volumeChartTarget, volume, { ...layout, barmode: 'stack' }, { responsive: true })
+    Plotly.newPlot(this.participantsChartTarget, [{
+      x: x,
+      y: days.map((d) => d.participants / d.mixes),
+      name: 'participants',
+      type: 'scatter',
+      mode: 'lines'
+    }], layout, { responsive: true })
+    Plotly.newPlot(this.changeChartTarget, [{
+      x: x,
+      y: days.map((d) => d.change),
+      name: 'unmixed change',
+      type: 'bar'
+    }], layout, { responsive: true })
+  }
+}
@@ -0,0 +1,70 @@
+import { Controller } from '@hotwired/stimulus'
+import { requestJSON } from '../helpers/http.js'
+
+function dcr (v) {
+  return v.toFixed(2)
+}
+
+function cell (text, start) {
+  const td = document.createElement('td')
+  td.className = start ? 'text-start' : 'text-end'
+  td.textContent = text
+  return td
+}
+
+function row (cells) {
+  const tr = document.createElement('tr')
+  cells.forEach((c, i) => tr.appendChild(cell(c, i === 0)))
+  return tr
+}
+
+export default class extends Controller {
+  static get targets () {
+    return ['form', 'message', 'summary', 'periods']
+  }
+
+  connect () {
+    this.run()
+  }
+
+  simulate (e) {
+    e.preventDefault()
+    this.run()
+  }
+
+  async run () {
+    const params = new URLSearchParams()
+    new FormData(this.formTarget).forEach((v, k) => {
+      if (v !== '') params.set(k, v)
+    })
+    this.messageTarget.textContent = 'Simulating...'
+    let sim
+    try {
+      sim = await requestJSON(`/api/stake/simulate?${params.toString()}`)
+    } catch (err) {
+      console.error(err)
+      this.messageTarget.textContent = 'The simulation failed. Check the inputs.'
+      return
+    }
+    this.messageTarget.textContent = `From block ${sim.height}: ticket price ${dcr(sim.ticket_price)} DCR, ` +
+      `${(sim.staked_fraction * 100).toFixed(1)}% of ${Math.round(sim.coin_supply).toLocaleString()} DCR staked.`
+    this.render(sim)
+  }
+
+  render (sim) {
+    this.summaryTarget.innerHTML = ''
+    ;[sim.expected, sim.best, sim.worst].forEach((r) => {
+      this.summaryTarget.appendChild(row([
+        r.scenario, r.vote_blocks, r.periods.length, r.days.toFixed(1), dcr(r.reward), dcr(r.vsp_fees),
+        dcr(r.end_balance), `${r.roi.toFixed(2)}%`, `${r.annual_rate.toFixed(2)}%`
+      ]))
+    })
+    this.periodsTarget.innerHTML = ''
+    sim.expected.periods.forEach((p) => {
+      this.periodsTarget.appendChild(row([
+        p.period, p.buy_height, p.vote_height, p.days.toFixed(1), dcr(p.ticket_price),
+        p.tickets.toFixed(2), dcr(p.staked), dcr(p.reward), dcr(p.vsp_fee), dcr(p.balance), dcr(p.withdrawn)
+      ]))
+    })
+  }
+}
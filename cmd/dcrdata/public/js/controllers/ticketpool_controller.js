@@ -90,6 +90,26 @@ function populateOutputs (data) {
   return tbody
 }
 
+// The observed and expected fractions of the votes by days from ticket
+// maturity to vote. The histogram buckets are one day of blocks.
+function voteDelayGraphData (data) {
+  const observed = data.observed.histogram
+  const total = observed.reduce((a, n) => a + n, 0)
+  return data.expected.distribution.map((p, i) => {
+    const count = i < observed.length ? observed[i] : 0
+    return [i + 1, total > 0 ? count / total : 0, p]
+  })
+}
+
+function voteModelSummary (data) {
+  const m = data.expected
+  const days = (blocks) => (blocks / m.bucket_blocks).toFixed(1)
+  const median = m.median_blocks < 0 ? 'n/a' : days(m.median_blocks)
+  return `Pool size ${m.pool_size.toLocaleString()}: expected wait after maturity ${days(m.mean_blocks)} days (median ${median} days), ` +
+    `${(m.expiry_chance * 100).toFixed(2)}% of tickets expire. ` +
+    `Observed from ${data.observed.histogram.reduce((a, n) => a + n, 0).toLocaleString()} votes over ${data.observed.by_day.length} days.`
+}
+
 function getWindow (val) {
   switch (val) {
     case 'day': return [(ms - 8.64E+07) - 1000, ms]
@@ -114,7 +134,7 @@ const commonOptions = {
 
 export default class extends Controller {
   static get targets () {
-    return ['zoom', 'bars', 'age', 'wrapper', 'outputs']
+    return ['zoom', 'bars', 'age', 'wrapper', 'outputs', 'voteModel']
   }
 
   async initialize () {
@@ -122,6 +142,8 @@ export default class extends Controller {
     this.tipHeight = 0
     this.purchasesGraph = null
     this.priceGraph = null
+    this.voteDelayGraph = null
+    this.voteDelays = null
     this.graphData = {
       time_chart: null,
       price_chart: null
@@ -135,6 +157,7 @@ export default class extends Controller {
     this.chartCount += 2
     this.purchasesGraph = this.makePurchasesGraph()
     this.priceGraph = this.makePriceGraph()
+    this.voteDelayGraph = this.makeVoteDelayGraph()
   }
 
   connect () {
@@ -158,6 +181,11 @@ export default class extends Controller {
     const chartsResponse = await requestJSON('/api/ticketpool/charts')
     this.processData(chartsResponse)
     this.wrapperTarget.classList.remove('loading')
+    this.voteDelays = await requestJSON('/api/ticketpool/vote-delays')
+    this.voteModelTarget.textContent = voteModelSummary(this.voteDelays)
+    if (this.voteDelayGraph !== null) {
+      this.voteDelayGraph.updateOptions({ file: voteDelayGraphData(this.voteDelays) })
+    }
   }
 
   processData (data) {
@@ -190,6 +218,7 @@ export default class extends Controller {
   disconnect () {
     this.purchasesGraph.destroy()
     this.priceGraph.destroy()
+    this.voteDelayGraph.destroy()
 
     ws.deregisterEvtHandlers('ticketpool')
     ws.deregisterEvtHandlers('getticketpooldataResp')
@@ -259,4 +288,24 @@ export default class extends Controller {
       d, { ...commonOptions, ...p }
     )
   }
+
+  makeVoteDelayGraph () {
+    const d = this.voteDelays ? voteDelayGraphData(this.voteDelays) : [[0, 0, 0]]
+    const p = {
+      labels: ['Days After Maturity', 'Observed', 'Expected'],
+      colors: ['#2971FF', '#FF8C00'],
+      title: 'Ticket Vote Delay',
+      xlabel: 'Days After Maturity',
+      ylabel: 'Fraction of Votes',
+      digitsAfterDecimal: 4,
+      fillGraph: false,
+      stackedGraph: false,
+      showRangeSelector: false,
+      plotter: Dygraph.Plotters.linePlotter
+    }
+    return new Dygraph(
+      document.getElementById('tickets-by-vote-delay'),
+      d, { ...commonOptions, ...p }
+    )
+  }
 }
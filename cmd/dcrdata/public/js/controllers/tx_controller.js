@@ -7,6 +7,7 @@ import { MiniMeter } from '../helpers/meters.js'
 import { darkEnabled } from '../services/theme_service'
 import { getDefault } from '../helpers/module_helper'
 import { requestJSON } from '../helpers/http'
+import ws from '../services/messagesocket_service'
 
 const chartLayout = {
   showRangeSelector: true,
@@ -66,7 +67,7 @@ export default class extends Controller {
     return ['unconfirmed', 'confirmations', 'formattedAge', 'age', 'progressBar',
       'ticketStage', 'expiryChance', 'mempoolTd', 'ticketMsg',
       'expiryMsg', 'statusMsg', 'spendingTx', 'approvalMeter', 'cumulativeVoteChoices',
-      'voteChoicesByBlock', 'outputRow', 'showMoreText', 'showMoreIcon']
+      'voteChoicesByBlock', 'outputRow', 'showMoreText', 'showMoreIcon', 'eta']
   }
 
   initialize () {
@@ -83,6 +84,7 @@ export default class extends Controller {
     this.tspendExpend = false
     this.targetBlockTime = parseInt(document.getElementById('navBar').dataset.blocktime)
     globalEventBus.on('BLOCK_RECEIVED', this.processBlock)
+    this.connectEta()
 
     if (this.isTSpend) {
       this.Dygraph = await getDefault(
@@ -160,6 +162,52 @@ export default class extends Controller {
 
   disconnect () {
     globalEventBus.off('BLOCK_RECEIVED', this.processBlock)
+    this.disconnectEta()
+  }
+
+  // The confirmation estimate of a mempool transaction is requested again
+  // whenever the mempool or the chain tip of its chain changes.
+  connectEta () {
+    if (!this.hasEtaTarget) return
+    const d = this.etaTarget.dataset
+    this.etaMessage = d.chain + ':' + d.txid
+    this.requestEta = () => { ws.send('gettxeta', this.etaMessage) }
+    switch (d.chain) {
+      case 'btc':
+        this.etaEvents = ['BTC_BLOCK_RECEIVED', 'MEMPOOL_BTC_RECEIVED']
+        break
+      case 'ltc':
+        this.etaEvents = ['LTC_BLOCK_RECEIVED', 'MEMPOOL_LTC_RECEIVED']
+        break
+      default:
+        this.etaEvents = ['BLOCK_RECEIVED']
+        ws.registerEvtHandler('mempool', this.requestEta)
+    }
+    this.etaEvents.forEach(evt => globalEventBus.on(evt, this.requestEta))
+    ws.registerEvtHandler('gettxetaResp', (evt) => { this._updateEta(evt) })
+  }
+
+  disconnectEta () {
+    if (!this.etaEvents) return
+    this.etaEvents.forEach(evt => globalEventBus.off(evt, this.requestEta))
+    ws.deregisterEvtHandlers('mempool')
+    ws.deregisterEvtHandlers('gettxetaResp')
+    this.etaEvents = null
+  }
+
+  _updateEta (evt) {
+    if (!this.hasEtaTarget || evt.startsWith('Error')) return
+    const est = JSON.parse(evt)
+    if (est === null) {
+      // The transaction left mempool.
+      this.etaTarget.classList.add('d-none')
+      return
+    }
+    if (est.txid !== this.etaTarget.dataset.txid) return
+    const blocks = `${est.at_least ? 'over ' : ''}${est.blocks} block${est.blocks === 1 ? '' : 's'}`
+    this.etaTarget.innerHTML = `ETA ${est.at_least ? '&gt;' : '~'}${humanize.timeDuration(est.seconds * 1000, 'minute').trim()}
+<span class="fs13">(${blocks})</span>`
+    this.etaTarget.classList.remove('d-none')
   }
 
   drawChart (el, options, Dygraph) {
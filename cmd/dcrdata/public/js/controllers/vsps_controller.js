@@ -0,0 +1,68 @@
+import { Controller } from '@hotwired/stimulus'
+import * as Plotly from 'plotly.js-dist-min'
+import humanize from '../helpers/humanize_helper'
+import { requestJSON } from '../helpers/http.js'
+
+const layout = {
+  autosize: true,
+  height: 320,
+  margin: { l: 50, r: 20, t: 10, b: 40 },
+  showlegend: true,
+  legend: { orientation: 'h' },
+  xaxis: { type: 'date' },
+  yaxis: { rangemode: 'tozero' }
+}
+
+function ratio (a, b) {
+  return b === 0 ? 0 : a / b * 100
+}
+
+export default class extends Controller {
+  static get targets () {
+    return ['date', 'select', 'message', 'shareChart', 'ratioChart', 'feeChart', 'liveChart']
+  }
+
+  connect () {
+    this.dateTargets.forEach((el) => {
+      el.textContent = humanize.date(el.dataset.stamp * 1000, false, true)
+    })
+    if (this.hasSelectTarget) this.change()
+  }
+
+  select (e) {
+    this.selectTarget.value = e.target.dataset.vsp
+    this.change()
+  }
+
+  async change () {
+    const vsp = this.selectTarget.value
+    this.messageTarget.textContent = ''
+    let snapshots
+    try {
+      snapshots = await requestJSON(`/api/vsps/${encodeURIComponent(vsp)}/history`)
+    } catch (err) {
+      console.error(err)
+      this.messageTarget.textContent = 'Failed to load the VSP history.'
+      return
+    }
+    if (!snapshots || snapshots.length === 0) {
+      this.messageTarget.textContent = 'No history is stored for this VSP.'
+      return
+    }
+    const t = snapshots.map((s) => new Date(s.time * 1000))
+    const line = (name, y) => ({ x: t, y: y, name: name, type: 'scatter', mode: 'lines', line: { shape: 'hv' } })
+    Plotly.newPlot(this.shareChartTarget, [
+      line('network share %', snapshots.map((s) => s.network_proportion * 100))
+    ], layout, { responsive: true })
+    Plotly.newPlot(this.ratioChartTarget, [
+      line('missed %', snapshots.map((s) => ratio(s.missed, s.voted + s.missed))),
+      line('expired %', snapshots.map((s) => ratio(s.expired, s.voted + s.missed + s.expired)))
+    ], layout, { responsive: true })
+    Plotly.newPlot(this.feeChartTarget, [
+      line('fee %', snapshots.map((s) => s.fee_percentage))
+    ], layout, { responsive: true })
+    Plotly.newPlot(this.liveChartTarget, [
+      line('live tickets', snapshots.map((s) => s.voting))
+    ], layout, { responsive: true })
+  }
+}
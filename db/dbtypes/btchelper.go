@@ -93,6 +93,7 @@ func processBTCTransactions(client *btcClient.Client, block *Block, msgBlock *wi
 			BlockIndex:  uint32(txIndex),
 			Locktime:    tx.LockTime,
 			Size:        uint32(tx.SerializeSize()),
+			VSize:       btcTxVSize(tx),
 			Sent:        sent,
 			NumVin:      uint32(len(tx.TxIn)),
 			NumVout:     uint32(len(tx.TxOut)),
@@ -196,6 +197,7 @@ func processBTCTransactionsSimpleInfo(client *btcClient.Client, block *Block, ms
 			BlockIndex:  uint32(txIndex),
 			Locktime:    tx.LockTime,
 			Size:        uint32(tx.SerializeSize()),
+			VSize:       btcTxVSize(tx),
 			Sent:        sent,
 			NumVin:      uint32(len(tx.TxIn)),
 			NumVout:     uint32(len(tx.TxOut)),
@@ -225,3 +227,10 @@ func processBTCTransactionsSimpleInfo(client *btcClient.Client, block *Block, ms
 	}
 	return dbTransactions
 }
+
+// btcTxVSize returns the virtual size of a BTC transaction, its weight divided by
+// the witness scale factor of 4, rounded up.
+func btcTxVSize(tx *wire.MsgTx) uint32 {
+	weight := tx.SerializeSizeStripped()*3 + tx.SerializeSize()
+	return uint32((weight + 3) / 4)
+}
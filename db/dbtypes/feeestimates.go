@@ -0,0 +1,17 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+// FeeEstimateStat is a stored fee estimate of a chain. The fee rates of the
+// low, medium and high priority targets are in atoms/B for DCR and atoms/vB
+// for BTC and LTC.
+type FeeEstimateStat struct {
+	Time         int64   `json:"time"`
+	Height       int64   `json:"height"`
+	Low          float64 `json:"low"`
+	Medium       float64 `json:"medium"`
+	High         float64 `json:"high"`
+	MempoolTxs   int64   `json:"mempool_txs"`
+	MempoolVSize int64   `json:"mempool_vsize"`
+}
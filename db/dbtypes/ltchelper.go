@@ -94,6 +94,7 @@ func processLTCTransactions(client *ltcClient.Client, block *Block, msgBlock *wi
 			BlockIndex:  uint32(txIndex),
 			Locktime:    tx.LockTime,
 			Size:        uint32(tx.SerializeSize()),
+			VSize:       ltcTxVSize(tx),
 			Sent:        sent,
 			NumVin:      uint32(len(tx.TxIn)),
 			NumVout:     uint32(len(tx.TxOut)),
@@ -198,6 +199,7 @@ func processLTCTransactionsSimpleInfo(client *ltcClient.Client, block *Block, ms
 			BlockIndex:  uint32(txIndex),
 			Locktime:    tx.LockTime,
 			Size:        uint32(tx.SerializeSize()),
+			VSize:       ltcTxVSize(tx),
 			Sent:        sent,
 			NumVin:      uint32(len(tx.TxIn)),
 			NumVout:     uint32(len(tx.TxOut)),
@@ -227,3 +229,10 @@ func processLTCTransactionsSimpleInfo(client *ltcClient.Client, block *Block, ms
 	}
 	return dbTransactions
 }
+
+// ltcTxVSize returns the virtual size of a LTC transaction, its weight divided by
+// the witness scale factor of 4, rounded up.
+func ltcTxVSize(tx *wire.MsgTx) uint32 {
+	weight := tx.SerializeSizeStripped()*3 + tx.SerializeSize()
+	return uint32((weight + 3) / 4)
+}
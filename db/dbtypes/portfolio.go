@@ -0,0 +1,150 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+import (
+	"github.com/decred/dcrd/chaincfg/v3"
+
+	"github.com/decred/dcrdata/v8/txhelpers"
+)
+
+// PortfolioTicket is a mainchain ticket paying to one of the addresses of an
+// extended public key. Price, Fee and VoteReward are in DCR. The fields
+// following MissHeight are set by NewTicketPortfolio.
+type PortfolioTicket struct {
+	TxHash      string           `json:"txid"`
+	Height      int64            `json:"height"`
+	Price       float64          `json:"price"`
+	Fee         float64          `json:"fee"`
+	SpendType   TicketSpendType  `json:"-"`
+	PoolStatus  TicketPoolStatus `json:"-"`
+	SpendHeight int64            `json:"spend_height,omitempty"`
+	VoteReward  float64          `json:"vote_reward,omitempty"`
+	MissHeight  int64            `json:"miss_height,omitempty"`
+
+	// Status is immature, live, voted, missed or expired.
+	Status  string `json:"status"`
+	Revoked bool   `json:"revoked"`
+	// ROI is the realised return of a spent ticket relative to its price:
+	// the vote reward less the purchase fee, or the loss of the fee if the
+	// ticket was revoked.
+	ROI float64 `json:"roi"`
+	// VoteBlocks is the number of blocks from maturity to the vote or miss.
+	VoteBlocks int64 `json:"vote_blocks,omitempty"`
+	// Luck is the probability that a ticket waits longer than VoteBlocks to
+	// be called, so 0.5 is average and higher is luckier. Only voted tickets
+	// have a luck.
+	Luck float64 `json:"luck,omitempty"`
+}
+
+// TicketPortfolio summarizes the tickets of an extended public key.
+type TicketPortfolio struct {
+	Addresses int                `json:"addresses"`
+	Tickets   []*PortfolioTicket `json:"tickets"`
+	Immature  int                `json:"immature"`
+	Live      int                `json:"live"`
+	Voted     int                `json:"voted"`
+	Missed    int                `json:"missed"`
+	Expired   int                `json:"expired"`
+	Revoked   int                `json:"revoked"`
+	// Invested is the total price of all tickets, and Locked the price of the
+	// unspent immature and live tickets.
+	Invested float64 `json:"invested"`
+	Locked   float64 `json:"locked"`
+	Rewards  float64 `json:"rewards"`
+	Fees     float64 `json:"fees"`
+	// ROI is the realised return of the spent tickets relative to their
+	// total price.
+	ROI float64 `json:"roi"`
+	// MeanVoteBlocks is the mean VoteBlocks of the called tickets, and
+	// ExpectedVoteBlocks is the mean for a ticket that is called before expiry
+	// in a ticket pool of the target size.
+	MeanVoteBlocks     float64 `json:"mean_vote_blocks"`
+	ExpectedVoteBlocks float64 `json:"expected_vote_blocks"`
+	// Luck is the mean Luck of the voted tickets.
+	Luck float64 `json:"luck"`
+}
+
+// NewTicketPortfolio sets the status, return and luck of the tickets at the
+// tip height, and summarizes them.
+func NewTicketPortfolio(tickets []*PortfolioTicket, addresses int, tip int64, params *chaincfg.Params) *TicketPortfolio {
+	poolSize := int64(params.TicketPoolSize) * int64(params.TicketsPerBlock)
+	p := &TicketPortfolio{
+		Addresses:          addresses,
+		Tickets:            tickets,
+		ExpectedVoteBlocks: txhelpers.ExpectedVoteBlocks(poolSize, params),
+	}
+	maturity := int64(params.TicketMaturity)
+
+	var spentPrice, spentReturn float64
+	var called, voted int
+	for _, t := range tickets {
+		p.Invested += t.Price
+		p.Fees += t.Fee
+		t.Revoked = t.SpendType == TicketRevoked
+
+		switch t.PoolStatus {
+		case PoolStatusVoted:
+			t.Status = "voted"
+			p.Voted++
+		case PoolStatusMissed:
+			t.Status = "missed"
+			p.Missed++
+		case PoolStatusExpired:
+			t.Status = "expired"
+			p.Expired++
+		default:
+			if tip < t.Height+maturity {
+				t.Status = "immature"
+				p.Immature++
+			} else {
+				t.Status = "live"
+				p.Live++
+			}
+			p.Locked += t.Price
+		}
+		if t.Revoked {
+			p.Revoked++
+		}
+
+		switch t.SpendType {
+		case TicketVoted:
+			t.ROI = (t.VoteReward - t.Fee) / t.Price
+			p.Rewards += t.VoteReward
+		case TicketRevoked:
+			t.ROI = -t.Fee / t.Price
+		}
+		if t.SpendType != TicketUnspent {
+			spentPrice += t.Price
+			spentReturn += t.ROI * t.Price
+		}
+
+		// Voted and missed tickets were called by the lottery.
+		calledHeight := t.SpendHeight
+		if t.PoolStatus == PoolStatusMissed {
+			calledHeight = t.MissHeight
+		}
+		if (t.PoolStatus == PoolStatusVoted || t.PoolStatus == PoolStatusMissed) && calledHeight > 0 {
+			t.VoteBlocks = calledHeight - t.Height - maturity
+			p.MeanVoteBlocks += float64(t.VoteBlocks)
+			called++
+			// A missed vote is no luck, however soon the ticket was called.
+			if t.PoolStatus == PoolStatusVoted {
+				t.Luck = 1 - txhelpers.ProbVoteWithin(t.VoteBlocks, poolSize, params)
+				p.Luck += t.Luck
+				voted++
+			}
+		}
+	}
+	if spentPrice > 0 {
+		p.ROI = spentReturn / spentPrice
+	}
+	if called > 0 {
+		p.MeanVoteBlocks /= float64(called)
+	}
+	if voted > 0 {
+		p.Luck /= float64(voted)
+	}
+	return p
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestNewTicketPortfolio(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	// Mainnet: 256 block maturity and a pool of 8192 blocks worth of tickets.
+	tickets := []*PortfolioTicket{
+		// Voted after exactly the expected wait.
+		{TxHash: "voted", Height: 1000, Price: 100, Fee: 0.01, SpendType: TicketVoted,
+			PoolStatus: PoolStatusVoted, SpendHeight: 1000 + 256 + 8192, VoteReward: 0.51},
+		// Missed right after maturity, then revoked.
+		{TxHash: "missed", Height: 2000, Price: 100, Fee: 0.01, SpendType: TicketRevoked,
+			PoolStatus: PoolStatusMissed, SpendHeight: 2300, MissHeight: 2256},
+		// Expired, not revoked yet.
+		{TxHash: "expired", Height: 3000, Price: 100, Fee: 0.01, PoolStatus: PoolStatusExpired},
+		{TxHash: "live", Height: 50000, Price: 200, Fee: 0.02},
+		{TxHash: "immature", Height: 59900, Price: 200, Fee: 0.02},
+	}
+	p := NewTicketPortfolio(tickets, 40, 60000, params)
+
+	if p.Voted != 1 || p.Missed != 1 || p.Expired != 1 || p.Live != 1 || p.Immature != 1 || p.Revoked != 1 {
+		t.Errorf("wrong counts: %+v", p)
+	}
+	for _, tk := range tickets {
+		if tk.Status != tk.TxHash {
+			t.Errorf("ticket %s has status %s", tk.TxHash, tk.Status)
+		}
+	}
+	if p.Invested != 700 || p.Locked != 400 || p.Rewards != 0.51 {
+		t.Errorf("wrong totals: invested %v, locked %v, rewards %v", p.Invested, p.Locked, p.Rewards)
+	}
+	if roi := tickets[0].ROI; math.Abs(roi-0.005) > 1e-12 {
+		t.Errorf("wrong voted ROI %v", roi)
+	}
+	if roi := tickets[1].ROI; math.Abs(roi+0.0001) > 1e-12 {
+		t.Errorf("wrong revoked ROI %v", roi)
+	}
+	if math.Abs(p.ROI-(0.5-0.01)/200) > 1e-12 {
+		t.Errorf("wrong portfolio ROI %v", p.ROI)
+	}
+
+	// The average wait is as lucky as a ticket gets 1/e of the time.
+	if tickets[0].VoteBlocks != 8192 || math.Abs(tickets[0].Luck-math.Exp(-1)) > 1e-4 {
+		t.Errorf("wrong voted luck: %d blocks, %v", tickets[0].VoteBlocks, tickets[0].Luck)
+	}
+	// Missed and expired tickets have no luck, and only the voted ticket
+	// counts toward the portfolio's luck.
+	if tickets[1].VoteBlocks != 0 || tickets[1].Luck != 0 || tickets[2].Luck != 0 {
+		t.Errorf("wrong missed luck: %d blocks, %v", tickets[1].VoteBlocks, tickets[1].Luck)
+	}
+	if p.Luck != tickets[0].Luck {
+		t.Errorf("wrong portfolio luck %v", p.Luck)
+	}
+	// The tickets that expire are excluded from the expected wait.
+	if math.Abs(p.ExpectedVoteBlocks-7914) > 1 || p.MeanVoteBlocks != 4096 {
+		t.Errorf("wrong vote blocks: expected %v, mean %v", p.ExpectedVoteBlocks, p.MeanVoteBlocks)
+	}
+}
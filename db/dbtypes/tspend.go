@@ -0,0 +1,128 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+import (
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// The lifecycle statuses of a treasury spend.
+const (
+	// TSpendPending is a tspend waiting for its voting window to start.
+	TSpendPending = "pending"
+	// TSpendVoting is a tspend in its voting window that is neither approved
+	// nor rejected yet.
+	TSpendVoting = "voting"
+	// TSpendApproved is a tspend with enough votes to be mined at the next
+	// treasury vote interval.
+	TSpendApproved = "approved"
+	// TSpendRejected is a tspend that can no longer be approved, because the
+	// voting window ended or because even an all-yes vote in the rest of the
+	// window could not meet the quorum or approval thresholds.
+	TSpendRejected = "rejected"
+	// TSpendMined is a tspend that was mined in a mainchain block.
+	TSpendMined = "mined"
+)
+
+// TSpendLifecycle is a treasury spend tracked from when it was first seen in
+// the mempool until it was mined or rejected. Heights and times are 0 if not
+// applicable. The fields following MinedHeight are not stored, and are set by
+// Update.
+type TSpendLifecycle struct {
+	TxHash       string `json:"txid"`
+	Amount       int64  `json:"amount"`
+	Expiry       int64  `json:"expiry"`
+	VoteStart    int64  `json:"vote_start"`
+	VoteEnd      int64  `json:"vote_end"`
+	FirstSeen    int64  `json:"first_seen"`
+	Status       string `json:"status"`
+	StatusHeight int64  `json:"status_height"`
+	YesVotes     int64  `json:"yes_votes"`
+	NoVotes      int64  `json:"no_votes"`
+	MinedHeight  int64  `json:"mined_height"`
+
+	// Quorum is the number of votes that must be cast for approval.
+	Quorum int64 `json:"quorum"`
+	// RequiredYes is the number of yes votes needed for approval at the tip,
+	// which counts every vote that could still be cast in the window as a no.
+	RequiredYes int64 `json:"required_yes"`
+	// RemainingBlocks is the number of blocks left in the voting window.
+	RemainingBlocks int64 `json:"remaining_blocks"`
+	// ProjectedYes and ProjectedNo extrapolate the tallies to the end of the
+	// voting window at the vote rate and approval seen so far.
+	ProjectedYes      int64 `json:"projected_yes"`
+	ProjectedNo       int64 `json:"projected_no"`
+	ProjectedQuorum   bool  `json:"projected_quorum"`
+	ProjectedApproval bool  `json:"projected_approval"`
+}
+
+// Update sets the tspend's status and projection for the tip height, using
+// the consensus quorum and approval rules of the network. StatusHeight is set
+// to the tip if the status changes. Update reports whether the status changed.
+func (t *TSpendLifecycle) Update(tip int64, params *chaincfg.Params) bool {
+	votesPerBlock := int64(params.TicketsPerBlock)
+	window := t.VoteEnd - t.VoteStart
+	maxVotes := votesPerBlock * int64(params.TreasuryVoteInterval*params.TreasuryVoteIntervalMultiplier)
+	reqMul, reqDiv := int64(params.TreasuryVoteRequiredMultiplier), int64(params.TreasuryVoteRequiredDivisor)
+
+	t.Quorum = maxVotes * int64(params.TreasuryVoteQuorumMultiplier) / int64(params.TreasuryVoteQuorumDivisor)
+	t.RemainingBlocks = t.VoteEnd - tip
+	if t.RemainingBlocks > window {
+		t.RemainingBlocks = window
+	} else if t.RemainingBlocks < 0 {
+		t.RemainingBlocks = 0
+	}
+
+	cast := t.YesVotes + t.NoVotes
+	maxRemaining := t.RemainingBlocks * votesPerBlock
+	t.RequiredYes = (cast + maxRemaining) * reqMul / reqDiv
+	approved := cast >= t.Quorum && t.YesVotes >= t.RequiredYes
+	// An all-yes vote for the rest of the window is the best case.
+	possible := cast+maxRemaining >= t.Quorum &&
+		t.YesVotes+maxRemaining >= t.RequiredYes
+
+	t.ProjectedYes, t.ProjectedNo = t.YesVotes, t.NoVotes
+	if elapsed := window - t.RemainingBlocks; elapsed > 0 && cast > 0 {
+		moreVotes := cast * t.RemainingBlocks / elapsed
+		moreYes := moreVotes * t.YesVotes / cast
+		t.ProjectedYes += moreYes
+		t.ProjectedNo += moreVotes - moreYes
+	}
+	projectedCast := t.ProjectedYes + t.ProjectedNo
+	t.ProjectedQuorum = projectedCast >= t.Quorum
+	t.ProjectedApproval = t.ProjectedQuorum && t.ProjectedYes >= projectedCast*reqMul/reqDiv
+
+	var status string
+	switch {
+	case t.MinedHeight > 0:
+		status = TSpendMined
+	case approved:
+		status = TSpendApproved
+	case !possible:
+		status = TSpendRejected
+	case tip < t.VoteStart:
+		status = TSpendPending
+	default:
+		status = TSpendVoting
+	}
+	if status == t.Status {
+		return false
+	}
+	t.Status = status
+	t.StatusHeight = tip
+	return true
+}
+
+// IsFinal checks whether the tspend is mined or rejected.
+func (t *TSpendLifecycle) IsFinal() bool {
+	return t.Status == TSpendMined || t.Status == TSpendRejected
+}
+
+// TSpendEvent is a change in a tracked tspend's lifecycle status.
+type TSpendEvent struct {
+	// Event is the new status: TSpendApproved, TSpendRejected or TSpendMined.
+	Event  string           `json:"event"`
+	Height int64            `json:"height"`
+	TSpend *TSpendLifecycle `json:"tspend"`
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestTSpendLifecycleUpdate(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	// Mainnet: 5 votes per block, a 3456 block window, and a quorum of 3456.
+	newTSpend := func(yes, no int64) *TSpendLifecycle {
+		return &TSpendLifecycle{VoteStart: 10000, VoteEnd: 13456, YesVotes: yes, NoVotes: no}
+	}
+
+	ts := newTSpend(0, 0)
+	if !ts.Update(9000, params) || ts.Status != TSpendPending || ts.StatusHeight != 9000 {
+		t.Fatalf("expected pending, got %s", ts.Status)
+	}
+	if ts.Quorum != 3456 || ts.RemainingBlocks != 3456 {
+		t.Fatalf("wrong quorum %d or remaining blocks %d", ts.Quorum, ts.RemainingBlocks)
+	}
+	if ts.Update(9001, params) {
+		t.Fatalf("status should not have changed")
+	}
+
+	// Halfway through the window with a strong yes vote. Not approved yet,
+	// since the rest of the window could still vote no, but projected to be.
+	ts = newTSpend(4000, 200)
+	ts.Update(11728, params)
+	if ts.Status != TSpendVoting {
+		t.Fatalf("expected voting, got %s", ts.Status)
+	}
+	if ts.ProjectedYes != 8000 || ts.ProjectedNo != 400 || !ts.ProjectedQuorum || !ts.ProjectedApproval {
+		t.Fatalf("wrong projection %+v", ts)
+	}
+
+	// Early approval: the yes votes are a supermajority of every vote that
+	// could be cast in the window.
+	ts = newTSpend(7000, 100)
+	ts.Update(13000, params)
+	if ts.Status != TSpendApproved {
+		t.Fatalf("expected approved, got %s (required %d)", ts.Status, ts.RequiredYes)
+	}
+
+	// Too many no votes to ever reach 60% yes.
+	ts = newTSpend(100, 8000)
+	ts.Update(11728, params)
+	if ts.Status != TSpendRejected || ts.ProjectedApproval {
+		t.Fatalf("expected rejected, got %s", ts.Status)
+	}
+
+	// The window ended without quorum.
+	ts = newTSpend(1000, 0)
+	ts.Update(13456, params)
+	if ts.Status != TSpendRejected || ts.RemainingBlocks != 0 {
+		t.Fatalf("expected rejected at the end of the window, got %s", ts.Status)
+	}
+
+	ts = newTSpend(7000, 100)
+	ts.MinedHeight = 13248
+	ts.Update(13248, params)
+	if ts.Status != TSpendMined || !ts.IsFinal() {
+		t.Fatalf("expected mined, got %s", ts.Status)
+	}
+}
@@ -2161,6 +2161,7 @@ type Tx struct {
 	Locktime    uint32  `json:"locktime"`
 	Expiry      uint32  `json:"expiry"`
 	Size        uint32  `json:"size"`
+	VSize       uint32  `json:"vsize"` // BTC and LTC only
 	Spent       int64   `json:"spent"`
 	Sent        int64   `json:"sent"`
 	Fees        int64   `json:"fees"`
@@ -2381,6 +2382,127 @@ type DailyMarket struct {
 
 type MexcMonthlyPriceResponse [][]interface{}
 
+// LiquiditySnapshot is a summary of an exchange's order book, or of the
+// aggregated order book, at a point in time. Band volumes are in units of the
+// base asset, measured around the mid-gap of the aggregated order book so that
+// every exchange's volumes sum to the aggregated volumes. Slippage is the
+// percent difference between the average fill price of a market order and the
+// book's own mid-gap, or -1 if the book is too thin to fill the order.
+type LiquiditySnapshot struct {
+	ChainType string  `json:"chain"`
+	Token     string  `json:"token"`
+	Time      int64   `json:"time"`
+	MidGap    float64 `json:"mid_gap"`
+	// BidVolume[i] and AskVolume[i] are the volumes within
+	// exchanges.LiquidityBands[i].
+	BidVolume [3]float64 `json:"bid_volume"`
+	AskVolume [3]float64 `json:"ask_volume"`
+	// BuySlippage[i] and SellSlippage[i] are the slippages of orders of
+	// exchanges.SlippageSizes[i].
+	BuySlippage  [3]float64 `json:"buy_slippage"`
+	SellSlippage [3]float64 `json:"sell_slippage"`
+	// Share is the percentage of the aggregated volume within the middle
+	// band that this book contributes. Always 100 for the aggregated book.
+	Share float64 `json:"share"`
+}
+
+// DEXMarketDay is the stored trading activity of a dcrdex market over a UTC
+// day. Day is the UNIX time of the start of the day. Volumes are in
+// conventional units. ActiveIntervals is the number of 5-minute intervals with
+// at least one match. Only the USDVolume is known for the days of the volume
+// history.
+type DEXMarketDay struct {
+	Market          string  `json:"market"`
+	Day             int64   `json:"day"`
+	BaseVolume      float64 `json:"base_volume"`
+	QuoteVolume     float64 `json:"quote_volume"`
+	USDVolume       float64 `json:"usd_volume"`
+	ActiveIntervals int64   `json:"active_intervals"`
+	Rate            float64 `json:"rate"`
+}
+
+// VSPSnapshot is a VSP's listing in the VSP API at a point in time. Time is
+// when the listing was fetched and LastUpdated is when the VSP API last
+// refreshed the VSP's data. NetworkProportion is the VSP's estimated share
+// of the live tickets.
+type VSPSnapshot struct {
+	VSP               string  `json:"vsp"`
+	Time              int64   `json:"time"`
+	LastUpdated       int64   `json:"last_updated"`
+	FeePercentage     float64 `json:"fee_percentage"`
+	Voting            int64   `json:"voting"`
+	Voted             int64   `json:"voted"`
+	Missed            int64   `json:"missed"`
+	Expired           int64   `json:"expired"`
+	NetworkProportion float64 `json:"network_proportion"`
+	VspdVersion       string  `json:"vspd_version"`
+}
+
+// MissedRatio is the fraction of the VSP's tickets that were called to vote
+// that missed.
+func (s *VSPSnapshot) MissedRatio() float64 {
+	if s.Voted+s.Missed == 0 {
+		return 0
+	}
+	return float64(s.Missed) / float64(s.Voted+s.Missed)
+}
+
+// ExpiredRatio is the fraction of the VSP's spent or expired tickets that
+// expired.
+func (s *VSPSnapshot) ExpiredRatio() float64 {
+	if s.Voted+s.Missed+s.Expired == 0 {
+		return 0
+	}
+	return float64(s.Expired) / float64(s.Voted+s.Missed+s.Expired)
+}
+
+// VSPStatus is a VSP that has been listed by the VSP API, with its latest
+// snapshot. RemovedAt is when the VSP dropped off the list, or 0 if it is
+// still listed.
+type VSPStatus struct {
+	VSP       string       `json:"vsp"`
+	FirstSeen int64        `json:"first_seen"`
+	LastSeen  int64        `json:"last_seen"`
+	RemovedAt int64        `json:"removed_at"`
+	Latest    *VSPSnapshot `json:"latest"`
+}
+
+// MixTx is a mixing transaction: a CSPP mix, or a ticket split transaction
+// with mixed ticket outputs if IsSplit. MixCount is the number of mixed
+// outputs, one for each participant in the round. The outputs that are not of
+// the mix denomination are the participants' unmixed change.
+type MixTx struct {
+	TxHash       string `json:"txid"`
+	BlockHeight  int64  `json:"block_height"`
+	BlockTime    int64  `json:"block_time"`
+	Denomination int64  `json:"denomination"`
+	MixCount     int32  `json:"mix_count"`
+	NumVin       int32  `json:"num_vin"`
+	NumVout      int32  `json:"num_vout"`
+	ChangeCount  int32  `json:"change_count"`
+	ChangeAmount int64  `json:"change_amount"`
+	Fees         int64  `json:"fees"`
+	IsSplit      bool   `json:"is_split"`
+}
+
+// MixedAmount is the total value of the mixed outputs.
+func (m *MixTx) MixedAmount() int64 {
+	return int64(m.MixCount) * m.Denomination
+}
+
+// MixDay is the CSPP mixing activity of a denomination over a UTC day.
+// Amounts are in atoms.
+type MixDay struct {
+	Day             int64   `json:"day"`
+	Denomination    int64   `json:"denomination"`
+	Mixes           int64   `json:"mixes"`
+	MixedAmount     int64   `json:"mixed_amount"`
+	AvgParticipants float64 `json:"avg_participants"`
+	ChangeAmount    int64   `json:"change_amount"`
+	// MixesWithChange is the number of mixes with unmixed change outputs.
+	MixesWithChange int64 `json:"mixes_with_change"`
+}
+
 // TreasuryBalance is the current balance, spent amount, and tx count for the
 // treasury.
 type TreasuryBalance struct {
@@ -0,0 +1,28 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+// VoteDelayStats are statistics of the number of blocks from maturity to vote
+// of the tickets that voted in a day or a block. Time is the start of the day
+// for the daily statistics, and Height is the block height for the block
+// statistics.
+type VoteDelayStats struct {
+	Time   int64   `json:"time,omitempty"`
+	Height int64   `json:"height,omitempty"`
+	Votes  int64   `json:"votes"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    int64   `json:"min"`
+	Max    int64   `json:"max"`
+}
+
+// VoteDelays are the observed vote delays of the votes in a time range, by day
+// and by block. Histogram counts the votes by the number of blocks from
+// maturity to vote, in buckets of BucketBlocks blocks starting at 1 block.
+type VoteDelays struct {
+	ByDay        []*VoteDelayStats `json:"by_day"`
+	ByBlock      []*VoteDelayStats `json:"by_block"`
+	BucketBlocks int64             `json:"bucket_blocks"`
+	Histogram    []int64           `json:"histogram"`
+}
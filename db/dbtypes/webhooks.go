@@ -0,0 +1,48 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package dbtypes
+
+// The chain events that a Webhook may be registered for.
+const (
+	WebhookBlock         = "block"
+	WebhookReorg         = "reorg"
+	WebhookAddress       = "address"
+	WebhookConfirmations = "confirmations"
+)
+
+// Webhook is a registered HTTP callback for the events of a chain. Address is
+// set for the address activity event, and TxID and Depth for the tx
+// confirmation depth event. A confirmations hook fires once, and is then
+// inactive. The Secret keys the HMAC signature of the payloads. The hook is
+// managed with its Token, which is only returned when the hook is registered,
+// and stored as the hex encoded SHA-256 Owner.
+type Webhook struct {
+	ID        int64  `json:"id"`
+	ChainType string `json:"chain"`
+	Event     string `json:"event"`
+	URL       string `json:"url"`
+	Secret    string `json:"secret,omitempty"`
+	Token     string `json:"token,omitempty"`
+	Owner     string `json:"-"`
+	Address   string `json:"address,omitempty"`
+	TxID      string `json:"txid,omitempty"`
+	Depth     int64  `json:"depth,omitempty"`
+	Active    bool   `json:"active"`
+	Created   int64  `json:"created"`
+}
+
+// WebhookDelivery is the delivery log entry of an attempt to post an event to
+// a Webhook. The attempts of a delivery share the DeliveryID, and the Payload
+// is only logged with the first attempt.
+type WebhookDelivery struct {
+	HookID     int64  `json:"hook_id"`
+	DeliveryID string `json:"delivery_id"`
+	Event      string `json:"event"`
+	Payload    string `json:"payload"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Success    bool   `json:"success"`
+	Time       int64  `json:"time"`
+}
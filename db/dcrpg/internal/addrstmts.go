@@ -268,6 +268,16 @@ const (
 			matching_tx_hash=''  -- separate spent and unspent
 		ORDER BY count, is_funding;`
 
+	// SelectAddressesFundingCountAndValue gets the number and combined value of
+	// the spent and unspent outpoints of each address in the array $1.
+	SelectAddressesFundingCountAndValue = `SELECT address,
+			(matching_tx_hash = '') AS unspent,
+			COUNT(*),
+			SUM(value)
+		FROM addresses
+		WHERE address = ANY($1) AND valid_mainchain AND is_funding
+		GROUP BY address, matching_tx_hash = '';`
+
 	SelectAddressSpentUnspentCountAndValueYear = `SELECT
 		(tx_type = 0) AS is_regular,
 		COUNT(*),
@@ -105,6 +105,19 @@ const (
 	SelectBlockHashByHeight = `SELECT hash FROM blocks WHERE height = $1 AND is_mainchain = true;`
 	SelectBlockHeightByHash = `SELECT height FROM blocks WHERE hash = $1;`
 
+	// SelectBlocksByHashes selects the blocks with the hashes in the array $1.
+	SelectBlocksByHashes = `SELECT hash, height, size, version, numtx, time,
+			difficulty, previous_hash
+		FROM blocks
+		WHERE hash = ANY($1);`
+
+	// SelectMainchainBlocksByHeights selects the main chain blocks at the
+	// heights in the array $1.
+	SelectMainchainBlocksByHeights = `SELECT hash, height, size, version, numtx,
+			time, difficulty, previous_hash
+		FROM blocks
+		WHERE height = ANY($1) AND is_mainchain = true;`
+
 	SelectBlockTimeByHeight = `SELECT time FROM blocks
 		WHERE height = $1 AND is_mainchain = true;`
 
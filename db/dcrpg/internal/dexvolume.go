@@ -0,0 +1,60 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "dex_market_days" table.
+const (
+	CreateDEXMarketDaysTable = `CREATE TABLE IF NOT EXISTS dex_market_days (
+		market TEXT NOT NULL,
+		day INT8 NOT NULL,
+		base_volume FLOAT8,
+		quote_volume FLOAT8,
+		usd_volume FLOAT8,
+		active_intervals INT8,
+		rate FLOAT8,
+		PRIMARY KEY (market, day)
+	);`
+
+	// UpsertDEXMarketDayRow inserts or updates a market day. The server only
+	// keeps the most recent 5-minute candles, so the active intervals of a day
+	// that has partly aged out of the server's cache are not lowered.
+	UpsertDEXMarketDayRow = `INSERT INTO dex_market_days (market, day,
+		base_volume, quote_volume, usd_volume, active_intervals, rate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (market, day) DO UPDATE SET
+		base_volume = EXCLUDED.base_volume,
+		quote_volume = EXCLUDED.quote_volume,
+		usd_volume = EXCLUDED.usd_volume,
+		active_intervals = GREATEST(dex_market_days.active_intervals, EXCLUDED.active_intervals),
+		rate = EXCLUDED.rate;`
+
+	// InsertDEXHistoryDayRow inserts a day of the volume history, unless the
+	// market day was already collected from the server.
+	InsertDEXHistoryDayRow = `INSERT INTO dex_market_days (market, day, usd_volume)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (market, day) DO NOTHING;`
+
+	// SelectEarliestDEXMarketDay selects the first day collected from the
+	// server, which has base volumes unlike the days of the volume history.
+	SelectEarliestDEXMarketDay = `SELECT COALESCE(MIN(day), 0) FROM dex_market_days
+		WHERE base_volume IS NOT NULL;`
+
+	SelectDEXMarketDays = `SELECT market, day, COALESCE(base_volume, 0), COALESCE(quote_volume, 0),
+		usd_volume, COALESCE(active_intervals, 0), COALESCE(rate, 0)
+		FROM dex_market_days
+		WHERE day >= $1 AND day <= $2
+		ORDER BY day, market;`
+
+	// SelectDEXVolumeSummary selects the total USD volume, the volume of the
+	// last 30 days with data, and the volume of the most recent day.
+	SelectDEXVolumeSummary = `WITH daily AS (
+			SELECT day, SUM(usd_volume) AS vol FROM dex_market_days GROUP BY day
+		), ranked AS (
+			SELECT vol, ROW_NUMBER() OVER (ORDER BY day DESC) AS n FROM daily
+		)
+		SELECT COALESCE(SUM(vol), 0),
+			COALESCE(SUM(vol) FILTER (WHERE n <= 30), 0),
+			COALESCE(SUM(vol) FILTER (WHERE n = 1), 0)
+		FROM ranked;`
+)
@@ -0,0 +1,14 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+const (
+	// SelectRegularTxFeeRates selects the block height and the fee rate in
+	// atoms/B of the fee paying regular transactions of the mainchain blocks
+	// above the height in $1.
+	SelectRegularTxFeeRates = `SELECT block_height, fees::FLOAT8 / size
+		FROM transactions
+		WHERE block_height > $1 AND is_mainchain AND tree = 0 AND fees > 0 AND size > 0
+		ORDER BY block_height;`
+)
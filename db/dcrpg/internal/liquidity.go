@@ -0,0 +1,46 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "liquidity_snapshots" table.
+const (
+	CreateLiquiditySnapshotsTable = `CREATE TABLE IF NOT EXISTS liquidity_snapshots (
+		id SERIAL8 PRIMARY KEY,
+		chain_type TEXT NOT NULL,
+		token TEXT NOT NULL,
+		time INT8 NOT NULL,
+		mid_gap FLOAT8,
+		bid_1 FLOAT8,
+		bid_2 FLOAT8,
+		bid_5 FLOAT8,
+		ask_1 FLOAT8,
+		ask_2 FLOAT8,
+		ask_5 FLOAT8,
+		buy_slip_1k FLOAT8,
+		buy_slip_10k FLOAT8,
+		buy_slip_100k FLOAT8,
+		sell_slip_1k FLOAT8,
+		sell_slip_10k FLOAT8,
+		sell_slip_100k FLOAT8,
+		share FLOAT8,
+		UNIQUE (chain_type, token, time)
+	);`
+
+	InsertLiquiditySnapshotRow = `INSERT INTO liquidity_snapshots (chain_type, token, time, mid_gap,
+		bid_1, bid_2, bid_5, ask_1, ask_2, ask_5,
+		buy_slip_1k, buy_slip_10k, buy_slip_100k, sell_slip_1k, sell_slip_10k, sell_slip_100k, share)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (chain_type, token, time) DO NOTHING;`
+
+	// SelectLiquiditySnapshots selects the snapshots for a chain in a time
+	// range. An empty token selects every token.
+	SelectLiquiditySnapshots = `SELECT chain_type, token, time, mid_gap,
+		bid_1, bid_2, bid_5, ask_1, ask_2, ask_5,
+		buy_slip_1k, buy_slip_10k, buy_slip_100k, sell_slip_1k, sell_slip_10k, sell_slip_100k, share
+		FROM liquidity_snapshots
+		WHERE chain_type = $1 AND ($2 = '' OR token = $2) AND time >= $3 AND time <= $4
+		ORDER BY time, token;`
+
+	DeleteLiquiditySnapshotsBefore = `DELETE FROM liquidity_snapshots WHERE time < $1;`
+)
@@ -0,0 +1,65 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "mixes" table.
+const (
+	// CreateMixesTable stores the mixing transactions: CSPP mixes and mixed
+	// ticket split transactions. Rows are keyed by block as well as tx hash
+	// so that side chain copies do not need to be removed on reorg. The
+	// transactions table is joined to filter for valid mainchain mixes.
+	CreateMixesTable = `CREATE TABLE IF NOT EXISTS mixes (
+		tx_hash TEXT NOT NULL,
+		block_hash TEXT NOT NULL,
+		block_height INT8 NOT NULL,
+		block_time INT8 NOT NULL,
+		mix_denom INT8 NOT NULL,
+		mix_count INT4 NOT NULL,
+		num_vin INT4 NOT NULL,
+		num_vout INT4 NOT NULL,
+		change_count INT4 NOT NULL,
+		change_amount INT8 NOT NULL,
+		fees INT8 NOT NULL,
+		is_split BOOLEAN NOT NULL,
+		UNIQUE (tx_hash, block_hash)
+	);
+	CREATE INDEX IF NOT EXISTS idx_mixes_block_time ON mixes(block_time);`
+
+	// SyncMixes copies the mixing transactions of the blocks from $1 blocks
+	// below the highest stored mix into the mixes table. Outputs that are
+	// not of the mix denomination are the participants' unmixed change. $2 is
+	// the array of CSPP denominations, which tells CSPP mixes from split
+	// transactions.
+	SyncMixes = `INSERT INTO mixes (tx_hash, block_hash, block_height, block_time,
+			mix_denom, mix_count, num_vin, num_vout, change_count, change_amount, fees, is_split)
+		SELECT tx_hash, block_hash, block_height, EXTRACT(EPOCH FROM block_time)::INT8,
+			mix_denom, mix_count, num_vin, num_vout, num_vout - mix_count,
+			sent - mix_count * mix_denom, fees, mix_denom <> ALL($2::INT8[])
+		FROM transactions
+		WHERE mix_count > 0
+			AND block_height > (SELECT COALESCE(MAX(block_height), -1) FROM mixes) - $1
+		ON CONFLICT (tx_hash, block_hash) DO NOTHING;`
+
+	// mainchainMixes restricts the mixes to valid mainchain transactions.
+	mainchainMixes = `FROM mixes
+		JOIN transactions ON transactions.tx_hash = mixes.tx_hash
+			AND transactions.block_hash = mixes.block_hash
+		WHERE transactions.is_mainchain AND transactions.is_valid
+			AND mixes.block_time >= $1 AND mixes.block_time <= $2`
+
+	SelectMixes = `SELECT mixes.tx_hash, mixes.block_height, mixes.block_time,
+			mixes.mix_denom, mixes.mix_count, mixes.num_vin, mixes.num_vout,
+			mixes.change_count, mixes.change_amount, mixes.fees, mixes.is_split
+		` + mainchainMixes + `
+		ORDER BY mixes.block_height DESC, mixes.tx_hash
+		LIMIT $3;`
+
+	// SelectMixDays aggregates the CSPP mixes by UTC day and denomination.
+	SelectMixDays = `SELECT (mixes.block_time / 86400) * 86400 AS day, mixes.mix_denom,
+			COUNT(*), SUM(mixes.mix_count * mixes.mix_denom), AVG(mixes.mix_count),
+			SUM(mixes.change_amount), COUNT(*) FILTER (WHERE mixes.change_count > 0)
+		` + mainchainMixes + ` AND NOT mixes.is_split
+		GROUP BY day, mixes.mix_denom
+		ORDER BY day, mixes.mix_denom DESC;`
+)
@@ -168,6 +168,16 @@ LIMIT 1;`
 	SelectAddressIDByVoutIDAddress = `SELECT id FROM %saddresses
 		WHERE address=$1 and vout_row_id=$2;`
 
+	// SelectAddressesFundingCountAndValue gets the number and combined value of
+	// the spent and unspent outpoints of each address in the array $1.
+	SelectAddressesFundingCountAndValue = `SELECT address,
+			(spending_tx_row_id IS NULL) AS unspent,
+			COUNT(*),
+			SUM(value)
+		FROM %saddresses
+		WHERE address = ANY($1)
+		GROUP BY address, spending_tx_row_id IS NULL;`
+
 	SetAddressSpendingForID = `UPDATE %saddresses SET spending_tx_row_id = $2, 
 		spending_tx_hash = $3, spending_tx_vin_index = $4, vin_row_id = $5 
 		WHERE id=$1;`
@@ -232,6 +242,10 @@ func MakeSelectAddressSpentCountAndValue(chainType string) string {
 	return fmt.Sprintf(SelectAddressSpentCountAndValue, chainType)
 }
 
+func MakeSelectAddressesFundingCountAndValue(chainType string) string {
+	return fmt.Sprintf(SelectAddressesFundingCountAndValue, chainType)
+}
+
 func MakeSelectAddressLimitNByAddress(chainType string) string {
 	return fmt.Sprintf(SelectAddressLimitNByAddress, chainType)
 }
@@ -199,6 +199,24 @@ ORDER BY a.height ASC;`
 	CheckExistBLockAll         = `SELECT EXISTS(SELECT 1 FROM %sblocks_all WHERE height = $1);`
 	SelectBlockAllHeightByHash = `SELECT height FROM %sblocks_all WHERE hash = $1 LIMIT 1;`
 	SelectBlockAllHashByHeight = `SELECT hash FROM %sblocks_all WHERE height = $1;`
+
+	// SelectBlocksAllByHashes selects the blocks with the hashes in the array
+	// $1.
+	SelectBlocksAllByHashes = `SELECT DISTINCT ON (hash) hash, height,
+			COALESCE(size, 0), COALESCE(version, 0), COALESCE(numtx, 0), time,
+			COALESCE(difficulty, 0), COALESCE(previous_hash, '')
+		FROM %sblocks_all
+		WHERE hash = ANY($1)
+		ORDER BY hash, id DESC;`
+
+	// SelectBlocksAllByHeights selects the blocks at the heights in the array
+	// $1.
+	SelectBlocksAllByHeights = `SELECT DISTINCT ON (height) hash, height,
+			COALESCE(size, 0), COALESCE(version, 0), COALESCE(numtx, 0), time,
+			COALESCE(difficulty, 0), COALESCE(previous_hash, '')
+		FROM %sblocks_all
+		WHERE height = ANY($1)
+		ORDER BY height, id DESC;`
 	SelectMinBlockAllHeight    = `SELECT min(height) FROM %sblocks_all;`
 	DeleteBlocksWithMinHeight  = `DELETE FROM %sblocks_all WHERE height > $1`
 	CheckAndRemoveDuplicateRow = `WITH duplicates AS (
@@ -267,6 +285,14 @@ func MakeSelectBlockAllHashByHeight(chainType string) string {
 	return fmt.Sprintf(SelectBlockAllHashByHeight, chainType)
 }
 
+func MakeSelectBlocksAllByHashes(chainType string) string {
+	return fmt.Sprintf(SelectBlocksAllByHashes, chainType)
+}
+
+func MakeSelectBlocksAllByHeights(chainType string) string {
+	return fmt.Sprintf(SelectBlocksAllByHeights, chainType)
+}
+
 func MakeSelectBlockAllDiffByTime(chainType string) string {
 	return fmt.Sprintf(SelectBlockAllDiffByTime, chainType)
 }
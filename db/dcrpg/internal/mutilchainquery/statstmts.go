@@ -22,6 +22,59 @@ const (
 
 	RetrieveLast90FeesStat = `SELECT time, fees, fees_rewards, fees_perkb FROM %sfees_stat ORDER BY time LIMIT 90;`
 
+	// The fee estimates of a chain, with the fee rates in atoms/B for DCR and
+	// atoms/vB for BTC and LTC. The DCR table has no prefix.
+	CreateFeeEstimatesTable = `CREATE TABLE IF NOT EXISTS %[1]sfee_estimates (
+		id SERIAL PRIMARY KEY,
+		time INT8,
+		height INT8,
+		low FLOAT8,
+		medium FLOAT8,
+		high FLOAT8,
+		mempool_txs INT8,
+		mempool_vsize INT8
+	);
+	CREATE INDEX IF NOT EXISTS idx_%[1]sfee_estimates_time ON %[1]sfee_estimates(time);`
+
+	InsertFeeEstimate = `INSERT INTO %sfee_estimates (time, height, low, medium, high, mempool_txs, mempool_vsize)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`
+
+	SelectFeeEstimates = `SELECT time, height, low, medium, high, mempool_txs, mempool_vsize
+		FROM %sfee_estimates ORDER BY time DESC LIMIT $1;`
+
+	DeleteFeeEstimatesBefore = `DELETE FROM %sfee_estimates WHERE time < $1;`
+
+	// SelectTxFeeRates selects the block height and the fee rate in atoms/vB
+	// of the fee paying transactions of the blocks above the height in $1.
+	// Transactions stored before the vsize column was added fall back to their
+	// size, which is their virtual size without witness data and an upper
+	// bound on it otherwise.
+	SelectTxFeeRates = `SELECT block_height, fees::FLOAT8 / COALESCE(vsize, size) FROM %stransactions
+		WHERE block_height > $1 AND fees > 0 AND COALESCE(vsize, size) > 0 ORDER BY block_height;`
+
+	// The record of the transactions that left a mempool without being
+	// mined, or were mined after leaving it. The DCR table has no prefix.
+	CreateMempoolLedgerTable = `CREATE TABLE IF NOT EXISTS %smempool_ledger (
+		txid TEXT PRIMARY KEY,
+		first_seen INT8,
+		last_seen INT8,
+		fate TEXT,
+		fate_time INT8,
+		height INT8,
+		replaced_by TEXT,
+		conflicted_by TEXT
+	);`
+
+	UpsertMempoolLedgerEntry = `INSERT INTO %smempool_ledger (txid, first_seen, last_seen, fate,
+			fate_time, height, replaced_by, conflicted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (txid) DO UPDATE SET last_seen = EXCLUDED.last_seen, fate = EXCLUDED.fate,
+			fate_time = EXCLUDED.fate_time, height = EXCLUDED.height,
+			replaced_by = EXCLUDED.replaced_by, conflicted_by = EXCLUDED.conflicted_by;`
+
+	SelectMempoolLedgerEntry = `SELECT first_seen, last_seen, fate, fate_time, height, replaced_by, conflicted_by
+		FROM %smempool_ledger WHERE txid = $1;`
+
 	CreateMempoolHistory = `CREATE TABLE IF NOT EXISTS %smempool_history (
 		id SERIAL PRIMARY KEY,
 		time INT8, -- UNIQUE
@@ -58,6 +111,14 @@ func CreateFeesStatTableTableFunc(chainType string) string {
 	return fmt.Sprintf(CreateFeesStatTable, chainType)
 }
 
+func CreateFeeEstimatesTableFunc(chainType string) string {
+	return fmt.Sprintf(CreateFeeEstimatesTable, chainType)
+}
+
+func CreateMempoolLedgerTableFunc(chainType string) string {
+	return fmt.Sprintf(CreateMempoolLedgerTable, chainType)
+}
+
 func CreateMempoolHistoryFunc(chainType string) string {
 	return fmt.Sprintf(CreateMempoolHistory, chainType)
 }
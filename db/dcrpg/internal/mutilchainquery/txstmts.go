@@ -12,11 +12,11 @@ const (
 		block_hash, block_height, block_time, time,
 		tx_type, version, tx_hash, block_index, 
 		lock_time, size, spent, sent, fees, 
-		num_vin, num_vout)
+		num_vin, num_vout, vsize)
 	VALUES (
 		$1, $2, $3, $4, 
 		$5, $6, $7, $8, $9,
-		$10, $11, $12, $13, $14, $15) `
+		$10, $11, $12, $13, $14, $15, $16) `
 	insertXmrTxRow0 = `INSERT INTO xmrtransactions (
 		block_hash, block_height, block_time, time,
 		tx_type, version, tx_hash, block_index, is_ringct, rct_type,
@@ -72,9 +72,14 @@ const (
 		sent INT8,
 		fees INT8,
 		num_vin INT4,
-		num_vout INT4
+		num_vout INT4,
+		vsize INT4
 	);`
 
+	// AddTransactionVSizeColumn adds the virtual size column, in vbytes, to
+	// the transactions tables created without it.
+	AddTransactionVSizeColumn = `ALTER TABLE %stransactions ADD COLUMN IF NOT EXISTS vsize INT4;`
+
 	SelectTotalTransaction    = `SELECT count(*) FROM %stransactions;`
 	SelectTxByHash            = `SELECT id, block_hash, block_index FROM %stransactions WHERE tx_hash = $1;`
 	SelectTxsByBlockHash      = `SELECT id, tx_hash, block_index FROM %stransactions WHERE block_hash = $1;`
@@ -85,6 +90,23 @@ const (
 		size, spent, sent, fees, num_vin, num_vout 
 		FROM %stransactions WHERE tx_hash = $1;`
 
+	// SelectTxsByHashes selects the transactions with the hashes in the array
+	// $1.
+	SelectTxsByHashes = `SELECT DISTINCT ON (tx_hash) tx_hash, block_hash,
+			block_height, block_time, COALESCE(tx_type, 0), block_index,
+			COALESCE(size, 0), COALESCE(sent, 0), COALESCE(fees, 0),
+			COALESCE(num_vin, 0), COALESCE(num_vout, 0)
+		FROM %stransactions
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, block_height DESC;`
+
+	// SelectTxHashesByBlockHashes selects the transactions of the blocks with
+	// the hashes in the array $1, in block order.
+	SelectTxHashesByBlockHashes = `SELECT block_hash, tx_hash
+		FROM %stransactions
+		WHERE block_hash = ANY($1)
+		ORDER BY block_hash, block_index;`
+
 	SelectRegularTxByHash = `SELECT id, block_hash, block_index FROM %stransactions WHERE tx_hash = $1;`
 
 	SelectTxsBlocks = `SELECT block_height, block_hash, block_index
@@ -161,6 +183,14 @@ func MakeSelectTxsBlocks(chainType string) string {
 func MakeSelectFullTxByHash(chainType string) string {
 	return fmt.Sprintf(SelectFullTxByHash, chainType)
 }
+
+func MakeSelectTxsByHashes(chainType string) string {
+	return fmt.Sprintf(SelectTxsByHashes, chainType)
+}
+
+func MakeSelectTxHashesByBlockHashes(chainType string) string {
+	return fmt.Sprintf(SelectTxHashesByBlockHashes, chainType)
+}
 func MakeIndexTransactionTableOnTxHash(chainType string) string {
 	return fmt.Sprintf(IndexTransactionTableOnTxHash, chainType, chainType)
 }
@@ -213,6 +243,10 @@ func MakeTxInsertStatement(checked bool, chainType string) string {
 	}
 }
 
+func AddTransactionVSizeColumnFunc(chainType string) string {
+	return fmt.Sprintf(AddTransactionVSizeColumn, chainType)
+}
+
 func CreateTransactionTableFunc(chainType string) string {
 	return fmt.Sprintf(CreateTransactionTable, chainType, chainType)
 }
@@ -85,6 +85,24 @@ const (
 	SelectVoutAllIDByOutpoint      = `SELECT id FROM %svouts_all WHERE tx_hash=$1 and tx_index=$2;`
 	SelectVoutAllByID              = `SELECT * FROM %svouts_all WHERE id=$1;`
 
+	// SelectVoutsAllByTxHashes selects the outputs of the transactions with the
+	// hashes in the array $1.
+	SelectVoutsAllByTxHashes = `SELECT tx_hash, tx_index, tx_tree, COALESCE(value, 0),
+			COALESCE(version, 0), COALESCE(script_req_sigs, 0),
+			COALESCE(script_type, ''), script_addresses
+		FROM %svouts_all
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, tx_index;`
+
+	// SelectVinsAllByTxHashes selects the inputs of the transactions with the
+	// hashes in the array $1.
+	SelectVinsAllByTxHashes = `SELECT tx_hash, tx_index, COALESCE(tx_tree, 0),
+			COALESCE(prev_tx_hash, ''), COALESCE(prev_tx_index, 0),
+			COALESCE(prev_tx_tree, 0), COALESCE(value_in, 0)
+		FROM %svins_all
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, tx_index;`
+
 	RetrieveVoutAllValue  = `SELECT value FROM %svouts_all WHERE tx_hash=$1 and tx_index=$2;`
 	RetrieveVoutAllValues = `SELECT value, tx_index, tx_tree FROM %svouts_all WHERE tx_hash=$1;`
 
@@ -129,6 +147,14 @@ func MakeSelectVoutAllByID(chainType string) string {
 	return fmt.Sprintf(SelectVoutAllByID, chainType)
 }
 
+func MakeSelectVoutsAllByTxHashes(chainType string) string {
+	return fmt.Sprintf(SelectVoutsAllByTxHashes, chainType)
+}
+
+func MakeSelectVinsAllByTxHashes(chainType string) string {
+	return fmt.Sprintf(SelectVinsAllByTxHashes, chainType)
+}
+
 func MakeVoutAllInsertStatement(checked bool, chainType string) string {
 	if checked {
 		return fmt.Sprintf(insertVoutAllRowChecked, chainType)
@@ -0,0 +1,26 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the tickets of a set of addresses.
+const (
+	// SelectPortfolioTickets selects the mainchain tickets with an output
+	// paying to any of the addresses in $1, with the reward of their vote and
+	// the height of their first miss, if any. $2 is the ticket purchase
+	// transaction type. The vote is joined through the spending transaction
+	// to use the votes tx_hash index.
+	SelectPortfolioTickets = `SELECT tickets.tx_hash, tickets.block_height,
+			tickets.price, tickets.fee, tickets.spend_type, tickets.pool_status,
+			COALESCE(tickets.spend_height, 0), COALESCE(votes.vote_reward, 0),
+			COALESCE((SELECT MIN(misses.height) FROM misses
+				WHERE misses.ticket_hash = tickets.tx_hash), 0)
+		FROM tickets
+		LEFT JOIN transactions spends ON spends.id = tickets.spend_tx_db_id
+		LEFT JOIN votes ON votes.tx_hash = spends.tx_hash AND votes.is_mainchain
+		WHERE tickets.is_mainchain AND tickets.tx_hash IN (
+			SELECT tx_hash FROM addresses
+			WHERE address = ANY($1) AND tx_type = $2 AND is_funding
+				AND valid_mainchain)
+		ORDER BY tickets.block_height DESC;`
+)
@@ -0,0 +1,54 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "tspend_tracker" table.
+const (
+	// CreateTSpendTrackerTable stores the lifecycle of the treasury spends
+	// seen in the mempool. The tallies are as of status_height, or the last
+	// tracked block for a tspend that is not final.
+	CreateTSpendTrackerTable = `CREATE TABLE IF NOT EXISTS tspend_tracker (
+		tx_hash TEXT PRIMARY KEY,
+		amount INT8 NOT NULL,
+		expiry INT8 NOT NULL,
+		vote_start INT8 NOT NULL,
+		vote_end INT8 NOT NULL,
+		first_seen INT8 NOT NULL,
+		status TEXT NOT NULL,
+		status_height INT8 NOT NULL,
+		yes_votes INT8 NOT NULL,
+		no_votes INT8 NOT NULL,
+		mined_height INT8 NOT NULL DEFAULT 0
+	);`
+
+	// UpsertTSpendTrackerRow inserts a newly seen tspend, or updates the
+	// status and tallies of a tracked one. first_seen is kept.
+	UpsertTSpendTrackerRow = `INSERT INTO tspend_tracker (tx_hash, amount, expiry,
+			vote_start, vote_end, first_seen, status, status_height, yes_votes, no_votes, mined_height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (tx_hash) DO UPDATE SET status = $7, status_height = $8,
+			yes_votes = $9, no_votes = $10, mined_height = $11;`
+
+	selectTSpendTrackerRows = `SELECT tx_hash, amount, expiry, vote_start, vote_end,
+			first_seen, status, status_height, yes_votes, no_votes, mined_height
+		FROM tspend_tracker`
+
+	// SelectTSpendTrackerActive selects the tspends that are not mined or
+	// rejected. $1 and $2 are the final statuses.
+	SelectTSpendTrackerActive = selectTSpendTrackerRows + `
+		WHERE status <> $1 AND status <> $2;`
+
+	SelectTSpendTrackerAll = selectTSpendTrackerRows + `
+		ORDER BY first_seen DESC
+		LIMIT $1;`
+
+	SelectTSpendTrackerByHash = selectTSpendTrackerRows + `
+		WHERE tx_hash = $1;`
+
+	// SelectTreasuryTxMinedHeight selects the height of the mainchain block
+	// that mined a treasury transaction.
+	SelectTreasuryTxMinedHeight = `SELECT block_height FROM treasury
+		WHERE tx_hash = $1 AND is_mainchain
+		LIMIT 1;`
+)
@@ -128,6 +128,23 @@ const (
 	SelectTxsByBlockHash = `SELECT id, tx_hash, block_index, tree, block_time
 		FROM transactions WHERE block_hash = $1;`
 
+	// SelectTxsByHashes selects the transactions with the hashes in the array
+	// $1, preferring the main chain row of a transaction mined in several
+	// blocks.
+	SelectTxsByHashes = `SELECT DISTINCT ON (tx_hash) tx_hash, block_hash,
+			block_height, block_time, tx_type, tree, block_index, size, sent,
+			fees, num_vin, num_vout, is_mainchain
+		FROM transactions
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, is_mainchain DESC, is_valid DESC;`
+
+	// SelectTxHashesByBlockHashes selects the transactions of the blocks with
+	// the hashes in the array $1, in block order.
+	SelectTxHashesByBlockHashes = `SELECT block_hash, tx_hash
+		FROM transactions
+		WHERE block_hash = ANY($1)
+		ORDER BY block_hash, tree, block_index;`
+
 	SelectTxBlockTimeByHash = `SELECT block_time
 		FROM transactions
 		WHERE tx_hash = $1
@@ -119,6 +119,14 @@ const (
 		prev_tx_hash, prev_tx_index, prev_tx_tree, value_in, tx_type FROM vins WHERE id = $1;`
 	SelectVinVoutPairByID = `SELECT tx_hash, tx_index, prev_tx_hash, prev_tx_index FROM vins WHERE id = $1;`
 
+	// SelectVinsByTxHashes selects the inputs of the transactions with the
+	// hashes in the array $1.
+	SelectVinsByTxHashes = `SELECT tx_hash, tx_index, tx_tree, prev_tx_hash,
+			prev_tx_index, prev_tx_tree, value_in
+		FROM vins
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, tx_index;`
+
 	SelectUTXOsViaVinsMatch = `SELECT vouts.id, vouts.tx_hash, vouts.tx_index,   -- row ID and outpoint
 			vouts.script_addresses, vouts.value, vouts.mixed         -- value, addresses, and mixed flag of output
 		FROM vouts
@@ -277,6 +285,14 @@ const (
 	SelectVoutIDByOutpoint = `SELECT id FROM vouts WHERE tx_hash=$1 and tx_index=$2;`
 	SelectVoutByID         = `SELECT * FROM vouts WHERE id=$1;`
 
+	// SelectVoutsByTxHashes selects the outputs of the transactions with the
+	// hashes in the array $1.
+	SelectVoutsByTxHashes = `SELECT tx_hash, tx_index, tx_tree, value, version,
+			script_req_sigs, script_type, script_addresses, mixed
+		FROM vouts
+		WHERE tx_hash = ANY($1)
+		ORDER BY tx_hash, tx_index;`
+
 	RetrieveVoutValue  = `SELECT value FROM vouts WHERE tx_hash=$1 and tx_index=$2;`
 	RetrieveVoutValues = `SELECT value, tx_index, tx_tree FROM vouts WHERE tx_hash=$1;`
 )
@@ -0,0 +1,47 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the number of blocks tickets wait from
+// maturity to vote. The delay of a vote is its height less the height of the
+// ticket and the ticket maturity in $3. Votes are selected by the block time
+// range [$1, $2) in UNIX seconds, using the votes block_time index, and joined
+// to their mainchain tickets with the tickets tx_hash index.
+const (
+	voteDelaysFrom = `FROM votes
+		JOIN tickets ON tickets.tx_hash = votes.ticket_hash AND tickets.is_mainchain
+		WHERE votes.is_mainchain
+			AND votes.block_time >= to_timestamp($1)
+			AND votes.block_time < to_timestamp($2)`
+
+	voteDelay = `(votes.height - tickets.block_height - $3)`
+
+	// SelectVoteDelaysByDay selects the vote delay statistics of each UTC day.
+	SelectVoteDelaysByDay = `SELECT
+			EXTRACT(EPOCH FROM date_trunc('day', votes.block_time AT TIME ZONE 'UTC'))::INT8 AS day,
+			COUNT(*), AVG(` + voteDelay + `)::FLOAT8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + voteDelay + `)::FLOAT8,
+			MIN(` + voteDelay + `), MAX(` + voteDelay + `)
+		` + voteDelaysFrom + `
+		GROUP BY day
+		ORDER BY day;`
+
+	// SelectVoteDelaysByBlock selects the vote delay statistics of the $4 most
+	// recent blocks.
+	SelectVoteDelaysByBlock = `SELECT votes.height,
+			COUNT(*), AVG(` + voteDelay + `)::FLOAT8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + voteDelay + `)::FLOAT8,
+			MIN(` + voteDelay + `), MAX(` + voteDelay + `)
+		` + voteDelaysFrom + `
+		GROUP BY votes.height
+		ORDER BY votes.height DESC
+		LIMIT $4;`
+
+	// SelectVoteDelayHistogram counts the votes in buckets of $4 blocks of
+	// delay. The first bucket is delays of 1 to $4 blocks.
+	SelectVoteDelayHistogram = `SELECT GREATEST((` + voteDelay + ` - 1) / $4, 0) AS bucket, COUNT(*)
+		` + voteDelaysFrom + `
+		GROUP BY bucket
+		ORDER BY bucket;`
+)
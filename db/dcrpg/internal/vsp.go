@@ -0,0 +1,65 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "vsps" and "vsp_snapshots" tables.
+const (
+	// CreateVSPsTable tracks when each VSP was first and last listed by the
+	// VSP API. removed_at is set when a VSP drops off the list, and cleared if
+	// it returns.
+	CreateVSPsTable = `CREATE TABLE IF NOT EXISTS vsps (
+		vsp TEXT PRIMARY KEY,
+		first_seen INT8 NOT NULL,
+		last_seen INT8 NOT NULL,
+		removed_at INT8
+	);`
+
+	CreateVSPSnapshotsTable = `CREATE TABLE IF NOT EXISTS vsp_snapshots (
+		id SERIAL8 PRIMARY KEY,
+		vsp TEXT NOT NULL,
+		time INT8 NOT NULL,
+		last_updated INT8 NOT NULL,
+		fee_percentage FLOAT8,
+		voting INT8,
+		voted INT8,
+		missed INT8,
+		expired INT8,
+		network_proportion FLOAT8,
+		vspd_version TEXT,
+		UNIQUE (vsp, last_updated)
+	);`
+
+	UpsertVSPSeen = `INSERT INTO vsps (vsp, first_seen, last_seen)
+		VALUES ($1, $2, $2)
+		ON CONFLICT (vsp) DO UPDATE SET last_seen = EXCLUDED.last_seen, removed_at = NULL;`
+
+	// MarkVSPsRemoved marks the VSPs that were not seen in the latest listing,
+	// at time $1, as removed.
+	MarkVSPsRemoved = `UPDATE vsps SET removed_at = $1
+		WHERE removed_at IS NULL AND last_seen < $1;`
+
+	// InsertVSPSnapshotRow stores a snapshot. The VSP API only refreshes its
+	// data periodically, so a snapshot with an already stored last_updated
+	// time is ignored.
+	InsertVSPSnapshotRow = `INSERT INTO vsp_snapshots (vsp, time, last_updated, fee_percentage,
+		voting, voted, missed, expired, network_proportion, vspd_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (vsp, last_updated) DO NOTHING;`
+
+	// SelectVSPs selects every VSP ever listed with its latest snapshot.
+	SelectVSPs = `SELECT vsps.vsp, vsps.first_seen, vsps.last_seen, COALESCE(vsps.removed_at, 0),
+		s.time, s.last_updated, s.fee_percentage, s.voting, s.voted, s.missed, s.expired,
+		s.network_proportion, s.vspd_version
+		FROM vsps
+		JOIN (
+			SELECT DISTINCT ON (vsp) * FROM vsp_snapshots ORDER BY vsp, last_updated DESC
+		) s ON s.vsp = vsps.vsp
+		ORDER BY vsps.removed_at IS NOT NULL, s.voting DESC, vsps.vsp;`
+
+	SelectVSPSnapshots = `SELECT vsp, time, last_updated, fee_percentage,
+		voting, voted, missed, expired, network_proportion, vspd_version
+		FROM vsp_snapshots
+		WHERE vsp = $1 AND last_updated >= $2 AND last_updated <= $3
+		ORDER BY last_updated;`
+)
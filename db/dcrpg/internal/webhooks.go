@@ -0,0 +1,76 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package internal
+
+// These queries relate primarily to the "webhooks" and "webhook_deliveries"
+// tables.
+const (
+	// CreateWebhooksTable stores the registered chain event webhooks.
+	CreateWebhooksTable = `CREATE TABLE IF NOT EXISTS webhooks (
+		id SERIAL8 PRIMARY KEY,
+		chain TEXT NOT NULL,
+		event TEXT NOT NULL,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL,
+		owner TEXT NOT NULL,
+		address TEXT NOT NULL DEFAULT '',
+		txid TEXT NOT NULL DEFAULT '',
+		depth INT8 NOT NULL DEFAULT 0,
+		active BOOLEAN NOT NULL DEFAULT TRUE,
+		created INT8 NOT NULL
+	);`
+
+	InsertWebhookRow = `INSERT INTO webhooks (chain, event, url, secret, owner,
+			address, txid, depth, active, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;`
+
+	SelectWebhooks = `SELECT id, chain, event, url, secret, owner, address, txid,
+			depth, active, created
+		FROM webhooks
+		ORDER BY id;`
+
+	DeactivateWebhook = `UPDATE webhooks SET active = FALSE WHERE id = $1;`
+
+	DeleteWebhook = `DELETE FROM webhooks WHERE id = $1;`
+
+	// CreateWebhookDeliveriesTable stores every delivery attempt of the
+	// webhooks. The payload is only stored with the first attempt of a
+	// delivery.
+	CreateWebhookDeliveriesTable = `CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		id SERIAL8 PRIMARY KEY,
+		hook_id INT8 NOT NULL,
+		delivery_id TEXT NOT NULL,
+		event TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		attempt INT4 NOT NULL,
+		status_code INT4 NOT NULL,
+		error TEXT NOT NULL,
+		success BOOLEAN NOT NULL,
+		time INT8 NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_hook_id
+		ON webhook_deliveries(hook_id);
+	CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_time
+		ON webhook_deliveries(time);`
+
+	InsertWebhookDeliveryRow = `INSERT INTO webhook_deliveries (hook_id, delivery_id,
+			event, payload, attempt, status_code, error, success, time)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	// SelectWebhookDeliveries selects the most recent delivery attempts of a
+	// webhook, up to the limit $2.
+	SelectWebhookDeliveries = `SELECT hook_id, delivery_id, event, payload, attempt,
+			status_code, error, success, time
+		FROM webhook_deliveries
+		WHERE hook_id = $1
+		ORDER BY id DESC
+		LIMIT $2;`
+
+	DeleteWebhookDeliveries = `DELETE FROM webhook_deliveries WHERE hook_id = $1;`
+
+	// DeleteWebhookDeliveriesBefore deletes the delivery attempts older than
+	// the time $1.
+	DeleteWebhookDeliveriesBefore = `DELETE FROM webhook_deliveries WHERE time < $1;`
+)
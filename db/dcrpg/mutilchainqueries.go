@@ -190,7 +190,7 @@ func InsertMutilchainTxns(sqlTx *sql.Tx, dbTxns []*dbtypes.Tx, checked bool, cha
 			tx.BlockHash, tx.BlockHeight, tx.BlockTime.UNIX(), tx.Time.UNIX(),
 			tx.TxType, tx.Version, tx.TxID, tx.BlockIndex,
 			tx.Locktime, tx.Size, tx.Spent, tx.Sent, tx.Fees,
-			tx.NumVin, tx.NumVout).Scan(&id)
+			tx.NumVin, tx.NumVout, tx.VSize).Scan(&id)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				log.Errorf("%s: Insert to transactions table unsuccessfully. Height: %d", chainType, tx.BlockHeight)
@@ -1117,3 +1117,106 @@ func RetrieveMutilchainAddressesCount(ctx context.Context, db *sql.DB, chainType
 	err := db.QueryRowContext(ctx, mutilchainquery.MakeSelectCountTotalAddress(chainType)).Scan(&count)
 	return count, err
 }
+
+// RetrieveMutilchainBlocksByHashes retrieves the blocks of the chain with the
+// given hashes. The blocks that are not found are omitted.
+func RetrieveMutilchainBlocksByHashes(ctx context.Context, db *sql.DB, hashes []string, chainType string) ([]*dbtypes.Block, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectBlocksAllByHashes(chainType), pq.Array(hashes))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlocks(rows, true)
+}
+
+// RetrieveMutilchainBlocksByHeights retrieves the blocks of the chain at the
+// given heights.
+func RetrieveMutilchainBlocksByHeights(ctx context.Context, db *sql.DB, heights []int64, chainType string) ([]*dbtypes.Block, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectBlocksAllByHeights(chainType), pq.Array(heights))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlocks(rows, true)
+}
+
+// RetrieveMutilchainTxsByHashes retrieves the transactions of the chain with
+// the given hashes. The transactions that are not found are omitted.
+func RetrieveMutilchainTxsByHashes(ctx context.Context, db *sql.DB, txids []string, chainType string) ([]*dbtypes.Tx, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectTxsByHashes(chainType), pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var txs []*dbtypes.Tx
+	for rows.Next() {
+		var tx dbtypes.Tx
+		var blockTime int64
+		err = rows.Scan(&tx.TxID, &tx.BlockHash, &tx.BlockHeight, &blockTime,
+			&tx.TxType, &tx.BlockIndex, &tx.Size, &tx.Sent, &tx.Fees,
+			&tx.NumVin, &tx.NumVout)
+		if err != nil {
+			return nil, err
+		}
+		tx.BlockTime = dbtypes.NewTimeDefFromUNIX(blockTime)
+		tx.IsMainchainBlock = true
+		txs = append(txs, &tx)
+	}
+	return txs, rows.Err()
+}
+
+// RetrieveMutilchainTxHashesByBlockHashes retrieves the transaction hashes of
+// the blocks of the chain with the given hashes, in block order, keyed by
+// block hash.
+func RetrieveMutilchainTxHashesByBlockHashes(ctx context.Context, db *sql.DB, blockHashes []string, chainType string) (map[string][]string, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectTxHashesByBlockHashes(chainType), pq.Array(blockHashes))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlockTxHashes(rows)
+}
+
+// RetrieveMutilchainVinsByTxHashes retrieves the inputs of the transactions of
+// the chain with the given hashes.
+func RetrieveMutilchainVinsByTxHashes(ctx context.Context, db *sql.DB, txids []string, chainType string) ([]*dbtypes.VinTxProperty, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectVinsAllByTxHashes(chainType), pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	return scanVins(rows)
+}
+
+// RetrieveMutilchainVoutsByTxHashes retrieves the outputs of the transactions
+// of the chain with the given hashes.
+func RetrieveMutilchainVoutsByTxHashes(ctx context.Context, db *sql.DB, txids []string, chainType string) ([]*dbtypes.Vout, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectVoutsAllByTxHashes(chainType), pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var vouts []*dbtypes.Vout
+	for rows.Next() {
+		var vout dbtypes.Vout
+		var scriptType string
+		err = rows.Scan(&vout.TxHash, &vout.TxIndex, &vout.TxTree, &vout.Value,
+			&vout.Version, &vout.ScriptPubKeyData.ReqSigs, &scriptType,
+			pq.Array(&vout.ScriptPubKeyData.Addresses))
+		if err != nil {
+			return nil, err
+		}
+		vout.ScriptPubKeyData.Type = dbtypes.NewScriptClassFromString(scriptType)
+		vouts = append(vouts, &vout)
+	}
+	return vouts, rows.Err()
+}
+
+// RetrieveMutilchainAddressBalances gets the numbers and amounts of the spent
+// and unspent outpoints of the given addresses of the chain. The addresses
+// without outpoints are omitted.
+func RetrieveMutilchainAddressBalances(ctx context.Context, db *sql.DB, addresses []string, chainType string) ([]*dbtypes.AddressBalance, error) {
+	rows, err := db.QueryContext(ctx, mutilchainquery.MakeSelectAddressesFundingCountAndValue(chainType), pq.Array(addresses))
+	if err != nil {
+		return nil, err
+	}
+	return scanAddressBalances(rows)
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	btc_blockchain "github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
 	btc_chaincfg "github.com/btcsuite/btcd/chaincfg"
@@ -60,6 +61,7 @@ import (
 	"github.com/decred/dcrdata/v8/txhelpers/ltctxhelper"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/lib/pq"
+	ltc_blockchain "github.com/ltcsuite/ltcd/blockchain"
 	ltcjson "github.com/ltcsuite/ltcd/btcjson"
 	ltc_chaincfg "github.com/ltcsuite/ltcd/chaincfg"
 	ltc_chainhash "github.com/ltcsuite/ltcd/chaincfg/chainhash"
@@ -282,6 +284,11 @@ type ChainDB struct {
 	mp                 rpcutils.MempoolAddressChecker
 	ltcMp              ltcrpcutils.MempoolAddressChecker
 	btcMp              btcrpcutils.MempoolAddressChecker
+	ltcReplacements    *txhelpers.ReplacementTracker
+	btcReplacements    *txhelpers.ReplacementTracker
+	dcrLedger          *txhelpers.MempoolLedger
+	ltcLedger          *txhelpers.MempoolLedger
+	btcLedger          *txhelpers.MempoolLedger
 	chainParams        *chaincfg.Params
 	ltcChainParams     *ltc_chaincfg.Params
 	btcChainParams     *btc_chaincfg.Params
@@ -315,6 +322,10 @@ type ChainDB struct {
 	MPC                *mempool.DataCache
 	LTCMPC             *mempoolltc.DataCache
 	BTCMPC             *mempoolbtc.DataCache
+	feeEstimatesMtx    sync.RWMutex
+	feeEstimates       map[string]*txhelpers.FeeEstimates
+	confEstimatesMtx   sync.Mutex
+	confEstimates      map[string]*confirmationEstimates
 	// BlockCache stores apitypes.BlockDataBasic and apitypes.StakeInfoExtended
 	// in StoreBlock for quick retrieval without a DB query.
 	BlockCache             *apitypes.APICache
@@ -380,6 +391,8 @@ type ChainDB struct {
 	xmrWholeSyncMtx           sync.Mutex
 	btc20BlocksSyncMtx        sync.Mutex
 	ltc20BlocksSyncMtx        sync.Mutex
+	tspendTrackMtx            sync.Mutex
+	tspendEventHandler        func(*dbtypes.TSpendEvent)
 }
 
 // ChainDeployments is mutex-protected blockchain deployment data.
@@ -914,6 +927,38 @@ func (pgb *ChainDB) UseBTCMempoolChecker(mp btcrpcutils.MempoolAddressChecker) {
 	pgb.btcMp = mp
 }
 
+// UseMempoolReplacements assigns the tracker of the replaced transactions of
+// the BTC or LTC mempool.
+func (pgb *ChainDB) UseMempoolReplacements(chainType string, rt *txhelpers.ReplacementTracker) {
+	switch chainType {
+	case mutilchain.TYPEBTC:
+		pgb.btcReplacements = rt
+	case mutilchain.TYPELTC:
+		pgb.ltcReplacements = rt
+	}
+}
+
+// UseMempoolLedger assigns the ledger of the transactions seen in the DCR, BTC
+// or LTC mempool, and stores the fates of the transactions that leave it other
+// than by being mined.
+func (pgb *ChainDB) UseMempoolLedger(chainType string, ledger *txhelpers.MempoolLedger) {
+	switch chainType {
+	case mutilchain.TYPEDCR:
+		pgb.dcrLedger = ledger
+	case mutilchain.TYPEBTC:
+		pgb.btcLedger = ledger
+	case mutilchain.TYPELTC:
+		pgb.ltcLedger = ledger
+	default:
+		return
+	}
+	ledger.SetResolvedHandler(func(entries []*txhelpers.LedgerEntry) {
+		if err := pgb.storeMempoolLedgerEntries(chainType, entries); err != nil {
+			log.Errorf("Failed to store the %s mempool ledger entries: %v", chainType, err)
+		}
+	})
+}
+
 // EnableDuplicateCheckOnInsert specifies whether SQL insertions should check
 // for row conflicts (duplicates), and avoid adding or updating.
 func (pgb *ChainDB) EnableDuplicateCheckOnInsert(dupCheck bool) {
@@ -1014,6 +1059,12 @@ func (pgb *ChainDB) MutilchainCheckAndCreateTable(chainType string) error {
 		if err = CreateMutilchainTables(pgb.db, chainType); err != nil {
 			return fmt.Errorf("failed to create tables: %w", err)
 		}
+	} else if chainType != mutilchain.TYPEXMR {
+		// The fee estimates need the virtual sizes of the transactions.
+		_, err = pgb.db.Exec(mutilchainquery.AddTransactionVSizeColumnFunc(chainType))
+		if err != nil {
+			return fmt.Errorf("failed to add the vsize column of %stransactions: %w", chainType, err)
+		}
 	}
 	return nil
 }
@@ -1940,6 +1991,32 @@ func (pgb *ChainDB) CheckCreateDailyMarketTable() (err error) {
 	return checkExistAndCreateDailyMarketTable(pgb.db)
 }
 
+// Check exist or create a new liquidity_snapshots table
+func (pgb *ChainDB) CheckCreateLiquiditySnapshotsTable() (err error) {
+	return checkExistAndCreateLiquiditySnapshotsTable(pgb.db)
+}
+
+// Check exist or create a new dex_market_days table
+func (pgb *ChainDB) CheckCreateDEXMarketDaysTable() (err error) {
+	return checkExistAndCreateDEXMarketDaysTable(pgb.db)
+}
+
+// Check exist or create the vsps and vsp_snapshots tables
+func (pgb *ChainDB) CheckCreateVSPTables() (err error) {
+	return checkExistAndCreateVSPTables(pgb.db)
+}
+
+// CheckCreateMixesTable creates the mixes table if it does not exist.
+func (pgb *ChainDB) CheckCreateMixesTable() (err error) {
+	return checkExistAndCreateMixesTable(pgb.db)
+}
+
+// CheckCreateTSpendTrackerTable creates the tspend_tracker table if it does
+// not exist.
+func (pgb *ChainDB) CheckCreateTSpendTrackerTable() (err error) {
+	return checkExistAndCreateTSpendTrackerTable(pgb.db)
+}
+
 // Add proposal meta data to table
 func (pgb *ChainDB) AddProposalMeta(proposalMetaData []map[string]string) (err error) {
 	return addNewProposalMetaData(pgb.db, proposalMetaData)
@@ -5582,6 +5659,11 @@ func (pgb *ChainDB) Store(blockData *blockdata.BlockData, msgBlock *wire.MsgBloc
 	pgb.SignalHeight(msgBlock.Header.Height)
 	log.Infof("Start syncing coin age bands/mean coin age data in the background. Height: %d.", msgBlock.Header.Height)
 	go pgb.SyncCoinAgeDataAllSet(int64(msgBlock.Header.Height))
+	go func() {
+		if err := pgb.TrackTSpends(int64(msgBlock.Header.Height)); err != nil {
+			log.Errorf("TrackTSpends failed: %v", err)
+		}
+	}()
 	return nil
 }
 
@@ -6894,6 +6976,9 @@ func (pgb *ChainDB) StoreBlock(msgBlock *wire.MsgBlock, isValid, isMainchain,
 		if err = pgb.FreshenAddressCaches(true, addresses); err != nil {
 			log.Warnf("FreshenAddressCaches: %v", err)
 		}
+		if err = pgb.syncMixes(); err != nil {
+			log.Warnf("syncMixes: %v", err)
+		}
 	}
 
 	return
@@ -8812,6 +8897,149 @@ func (pgb *ChainDB) GetMempoolSSTxDetails(N int) *apitypes.MempoolTicketDetails
 	return &mpTicketDetails
 }
 
+// GetMutilchainMempoolProjection returns the projected blocks and the fee rate
+// histogram of the BTC or LTC mempool cache. It is nil until the mempool
+// monitor of the chain has stored the mempool.
+func (pgb *ChainDB) GetMutilchainMempoolProjection(chainType string) *exptypes.MempoolProjection {
+	switch chainType {
+	case mutilchain.TYPEBTC:
+		return pgb.BTCMPC.GetProjection()
+	case mutilchain.TYPELTC:
+		return pgb.LTCMPC.GetProjection()
+	default:
+		return nil
+	}
+}
+
+// mempoolReplacements is the replacement tracker of the BTC or LTC mempool, or
+// nil if there is none.
+func (pgb *ChainDB) mempoolReplacements(chainType string) *txhelpers.ReplacementTracker {
+	switch chainType {
+	case mutilchain.TYPEBTC:
+		return pgb.btcReplacements
+	case mutilchain.TYPELTC:
+		return pgb.ltcReplacements
+	default:
+		return nil
+	}
+}
+
+// GetMutilchainTxReplacements returns the replacement history and the CPFP
+// package of a BTC or LTC transaction, which may have been replaced and never
+// mined. It is nil if the replacements of the chain are not tracked.
+func (pgb *ChainDB) GetMutilchainTxReplacements(chainType, txid string) *exptypes.TxReplacementInfo {
+	rt := pgb.mempoolReplacements(chainType)
+	if rt == nil {
+		return nil
+	}
+	return &exptypes.TxReplacementInfo{
+		ChainType: chainType,
+		TxID:      txid,
+		InMempool: rt.Tracked(txid),
+		History:   rt.History(txid),
+		Package:   rt.Package(txid),
+	}
+}
+
+// GetMutilchainRecentReplacements returns up to n of the most recent
+// replacements in the BTC or LTC mempool, most recent first.
+func (pgb *ChainDB) GetMutilchainRecentReplacements(chainType string, n int) []*txhelpers.TxReplacement {
+	rt := pgb.mempoolReplacements(chainType)
+	if rt == nil {
+		return nil
+	}
+	return rt.Recent(n)
+}
+
+// mempoolLedger is the ledger of the DCR, BTC or LTC mempool, or nil if there is
+// none.
+func (pgb *ChainDB) mempoolLedger(chainType string) *txhelpers.MempoolLedger {
+	switch chainType {
+	case mutilchain.TYPEDCR:
+		return pgb.dcrLedger
+	case mutilchain.TYPEBTC:
+		return pgb.btcLedger
+	case mutilchain.TYPELTC:
+		return pgb.ltcLedger
+	default:
+		return nil
+	}
+}
+
+// CheckCreateMempoolLedgerTables creates the mempool_ledger tables of DCR, BTC
+// and LTC if they do not exist.
+func (pgb *ChainDB) CheckCreateMempoolLedgerTables() error {
+	for _, chainType := range []string{mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPELTC} {
+		if pgb.ChainDisabledMap[chainType] {
+			continue
+		}
+		if err := checkExistAndCreateMempoolLedgerTable(pgb.db, chainTablePrefix(chainType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeMempoolLedgerEntries upserts the entries of the mempool ledger of a
+// chain in one database transaction.
+func (pgb *ChainDB) storeMempoolLedgerEntries(chainType string, entries []*txhelpers.LedgerEntry) error {
+	dbTx, err := pgb.db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", err)
+	}
+	stmt, err := dbTx.Prepare(fmt.Sprintf(mutilchainquery.UpsertMempoolLedgerEntry, chainTablePrefix(chainType)))
+	if err != nil {
+		_ = dbTx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, e := range entries {
+		_, err = stmt.Exec(e.TxID, e.FirstSeen, e.LastSeen, e.Fate, e.FateTime, e.Height,
+			e.ReplacedBy, e.ConflictedBy)
+		if err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// GetTxFate returns the ledger entry of a transaction seen in the DCR, BTC or
+// LTC mempool, from the ledger in memory or from the stored fates of the
+// transactions that left mempool other than by being mined. The entry is nil
+// if the transaction was not seen.
+func (pgb *ChainDB) GetTxFate(chainType, txid string) (*txhelpers.LedgerEntry, error) {
+	ledger := pgb.mempoolLedger(chainType)
+	if ledger == nil {
+		return nil, fmt.Errorf("the %s mempool ledger is not enabled", chainType)
+	}
+	if e := ledger.Entry(txid); e != nil {
+		return e, nil
+	}
+	ctx, cancel := context.WithTimeout(pgb.ctx, pgb.queryTimeout)
+	defer cancel()
+	e := &txhelpers.LedgerEntry{TxID: txid}
+	err := pgb.db.QueryRowContext(ctx, fmt.Sprintf(mutilchainquery.SelectMempoolLedgerEntry, chainTablePrefix(chainType)),
+		txid).Scan(&e.FirstSeen, &e.LastSeen, &e.Fate, &e.FateTime, &e.Height, &e.ReplacedBy, &e.ConflictedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, pgb.replaceCancelError(err)
+	}
+	return e, nil
+}
+
+// GetRecentDoubleSpends returns up to n of the most recent double spends in the
+// DCR, BTC or LTC mempool, most recent first.
+func (pgb *ChainDB) GetRecentDoubleSpends(chainType string, n int) []*txhelpers.DoubleSpend {
+	ledger := pgb.mempoolLedger(chainType)
+	if ledger == nil {
+		return nil
+	}
+	return ledger.RecentDoubleSpends(n)
+}
+
 func decPkScript(ver uint16, pkScript []byte, isTicketCommit bool, chainParams *chaincfg.Params) (spkDec apitypes.ScriptPubKey) {
 	scriptType, addrs := stdscript.ExtractAddrs(ver, pkScript, chainParams)
 	reqSigs := stdscript.DetermineRequiredSigs(ver, pkScript)
@@ -12358,98 +12586,1116 @@ func (pgb *ChainDB) GetAvgTxFee() (int64, error) {
 	return avgTxFee, nil
 }
 
-// GetBwDashData get total bison wallet vol (By USD). From dcrsnapcsv (in the future, save to DB)
-// return (total vol : int64, last 30 days vol : int64)
+// GetBwDashData returns the total Bison Wallet (dex.decred.org) USD volume,
+// the volume of the last 30 days and the volume of the most recent day, from
+// the collected dcrdex market data and the volume history before it.
 func (pgb *ChainDB) GetBwDashData() (int64, int64, int64) {
-	dailyData := utils.ReadCsvFileFromUrl("https://raw.githubusercontent.com/bochinchero/dcrsnapcsv/main/data/stream/dex_decred_org_VolUSD.csv")
-	if len(dailyData) < 2 {
+	var volSum, last30days, vol24h float64
+	err := pgb.db.QueryRowContext(pgb.ctx, internal.SelectDEXVolumeSummary).Scan(&volSum, &last30days, &vol24h)
+	if err != nil {
+		log.Errorf("GetBwDashData: %v", err)
 		return 0, 0, 0
 	}
-	dailyData = dailyData[1:]
-	var volSum, last30days, vol24h float64
-	count := 0
-	for i := len(dailyData) - 1; i >= 0; i-- {
-		dailyItem := dailyData[i]
-		dailySum := utils.SumVolOfBwRow(dailyItem)
-		volSum += dailySum
-		if i == len(dailyData)-1 {
-			vol24h = dailySum
+	return int64(math.Round(volSum)), int64(math.Round(last30days)), int64(math.Round(vol24h))
+}
+
+// StoreDEXMarketDays inserts or updates the daily dcrdex market data.
+func (pgb *ChainDB) StoreDEXMarketDays(days []*dbtypes.DEXMarketDay) error {
+	dbTx, err := pgb.db.BeginTx(pgb.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", err)
+	}
+	stmt, err := dbTx.Prepare(internal.UpsertDEXMarketDayRow)
+	if err != nil {
+		_ = dbTx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, d := range days {
+		_, err = stmt.Exec(d.Market, d.Day, d.BaseVolume, d.QuoteVolume, d.USDVolume, d.ActiveIntervals, d.Rate)
+		if err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// StoreDEXHistoryDays stores the days of the dcrdex volume history that are
+// before the first day collected from the server. The history is only
+// inserted for the days that were not collected.
+func (pgb *ChainDB) StoreDEXHistoryDays(days []*dbtypes.DEXMarketDay) error {
+	var earliest int64
+	err := pgb.db.QueryRowContext(pgb.ctx, internal.SelectEarliestDEXMarketDay).Scan(&earliest)
+	if err != nil {
+		return err
+	}
+	dbTx, err := pgb.db.BeginTx(pgb.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", err)
+	}
+	stmt, err := dbTx.Prepare(internal.InsertDEXHistoryDayRow)
+	if err != nil {
+		_ = dbTx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, d := range days {
+		if earliest > 0 && d.Day >= earliest {
+			continue
 		}
-		if count < 30 {
-			last30days += dailySum
-			count++
+		if _, err = stmt.Exec(d.Market, d.Day, d.USDVolume); err != nil {
+			_ = dbTx.Rollback()
+			return err
 		}
 	}
-	return int64(math.Round(volSum)), int64(math.Round(last30days)), int64(math.Round(vol24h))
+	return dbTx.Commit()
 }
 
-// GetTicketsSummaryInfo return summary information of tickets vote
-func (pgb *ChainDB) GetTicketsSummaryInfo() (*dbtypes.TicketsSummaryInfo, error) {
-	bestBlockHeight := pgb.bestBlock.Height()
-	// get summary info
-	result := dbtypes.TicketsSummaryInfo{}
-	err := pgb.db.QueryRow(internal.SelectTicketSummaryInfo, bestBlockHeight).Scan(&result.MissedTickets, &result.Last1000BlocksMissed, &result.Last1000BlocksTicketFeeAvg)
+// GetDEXMarketDays returns the daily dcrdex market data for the days starting
+// between the from and to times, ordered by day, then market.
+func (pgb *ChainDB) GetDEXMarketDays(from, to int64) ([]*dbtypes.DEXMarketDay, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectDEXMarketDays, from, to)
 	if err != nil {
 		return nil, err
 	}
-	// calculator for ticket maturity
-	result.TicketMaturity = uint64(pgb.chainParams.TicketMaturity)
-	// avg block time
-	timePerBlock := pgb.chainParams.TargetTimePerBlock
-	// calculate ticket maturity duration (by seconds)
-	result.TicketMaturityDuration = uint64(timePerBlock.Seconds()) * result.TicketMaturity
-	// ticket expiration
-	result.TicketExpiration = uint64(pgb.chainParams.TicketExpiry)
-	// calculate to ticket expiration duration (by seconds)
-	result.TicketExpirationDuration = uint64(timePerBlock.Seconds()) * result.TicketExpiration
-	// Calculate Win probability on 1 block
-	result.WinProbability = float64(pgb.chainParams.TicketsPerBlock) / float64(pgb.chainParams.TicketPoolSize*pgb.chainParams.TicketsPerBlock)
-	// Calculate the expected block number to ensure 100% selection
-	threshold := 0.0001
-	blocksNeed := uint64(math.Round(math.Log(threshold) / math.Log(1-result.WinProbability)))
-	result.BlocksNeedToWin = blocksNeed
-	result.TimeToBlocksNeedToWin = uint64(timePerBlock.Seconds()) * result.BlocksNeedToWin
-	return &result, nil
+	defer rows.Close()
+	days := make([]*dbtypes.DEXMarketDay, 0)
+	for rows.Next() {
+		var d dbtypes.DEXMarketDay
+		err = rows.Scan(&d.Market, &d.Day, &d.BaseVolume, &d.QuoteVolume, &d.USDVolume, &d.ActiveIntervals, &d.Rate)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, &d)
+	}
+	return days, rows.Err()
 }
 
-// Get24hActiveAddressesCount return active addresses count in 24h
-func (pgb *ChainDB) Get24hActiveAddressesCount() int64 {
-	var activeAddr int64
-	pgb.db.QueryRow(internal.SelectCount24hUniqueAddress).Scan(&activeAddr)
-	return activeAddr
+// StoreVSPSnapshots stores the VSPs listed by the VSP API at the given time.
+// VSPs that were listed before but are missing from the snapshots are marked
+// as removed. An empty list is ignored, since it is more likely a failed
+// request than every VSP disappearing.
+func (pgb *ChainDB) StoreVSPSnapshots(snapshots []*dbtypes.VSPSnapshot, stamp int64) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	dbTx, err := pgb.db.BeginTx(pgb.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", err)
+	}
+	for _, s := range snapshots {
+		if _, err = dbTx.Exec(internal.UpsertVSPSeen, s.VSP, stamp); err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+		_, err = dbTx.Exec(internal.InsertVSPSnapshotRow, s.VSP, s.Time, s.LastUpdated, s.FeePercentage,
+			s.Voting, s.Voted, s.Missed, s.Expired, s.NetworkProportion, s.VspdVersion)
+		if err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+	}
+	if _, err = dbTx.Exec(internal.MarkVSPsRemoved, stamp); err != nil {
+		_ = dbTx.Rollback()
+		return err
+	}
+	return dbTx.Commit()
 }
 
-// Get24hStakingInfo return 24h staking info
-func (pgb *ChainDB) Get24hStakingInfo() (poolvalue, missed int64, err error) {
-	err = pgb.db.QueryRow(internal.SelectStakingSummaryIn24h).Scan(&poolvalue, &missed)
-	return
+// GetVSPs returns every VSP that has been listed by the VSP API, with its
+// latest snapshot. Listed VSPs come first, by number of live tickets.
+func (pgb *ChainDB) GetVSPs() ([]*dbtypes.VSPStatus, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectVSPs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	vsps := make([]*dbtypes.VSPStatus, 0)
+	for rows.Next() {
+		v := dbtypes.VSPStatus{Latest: new(dbtypes.VSPSnapshot)}
+		s := v.Latest
+		err = rows.Scan(&v.VSP, &v.FirstSeen, &v.LastSeen, &v.RemovedAt,
+			&s.Time, &s.LastUpdated, &s.FeePercentage, &s.Voting, &s.Voted, &s.Missed, &s.Expired,
+			&s.NetworkProportion, &s.VspdVersion)
+		if err != nil {
+			return nil, err
+		}
+		s.VSP = v.VSP
+		vsps = append(vsps, &v)
+	}
+	return vsps, rows.Err()
 }
 
-// Get24hTreasuryBalanceChange return 24h treasury balance change
-func (pgb *ChainDB) Get24hTreasuryBalanceChange() (treasuryBalanceChange int64, err error) {
-	err = pgb.db.QueryRow(internal.SelectTreasuryBalanceChangeIn24h).Scan(&treasuryBalanceChange)
-	return
+// GetVSPSnapshots returns the stored snapshots of the VSP with VSP API update
+// times between from and to, in time order.
+func (pgb *ChainDB) GetVSPSnapshots(vsp string, from, to int64) ([]*dbtypes.VSPSnapshot, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectVSPSnapshots, vsp, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	snapshots := make([]*dbtypes.VSPSnapshot, 0)
+	for rows.Next() {
+		var s dbtypes.VSPSnapshot
+		err = rows.Scan(&s.VSP, &s.Time, &s.LastUpdated, &s.FeePercentage,
+			&s.Voting, &s.Voted, &s.Missed, &s.Expired, &s.NetworkProportion, &s.VspdVersion)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &s)
+	}
+	return snapshots, rows.Err()
 }
 
-// InsertToBlackList insert to black list
-func (pgb *ChainDB) InsertToBlackList(agent, ip, note string) error {
-	_, err := pgb.db.Exec(internal.UpsertBlackList, agent, ip, note)
+// mixReorgDepth is how many blocks below the highest stored mix are
+// rescanned by syncMixes, so that mixes in blocks that replaced side chain
+// blocks are picked up.
+const mixReorgDepth = 16
+
+// syncMixes copies the mixing transactions stored since the last sync into
+// the mixes table. The first sync after the table is created copies every
+// stored mix.
+func (pgb *ChainDB) syncMixes() error {
+	_, err := pgb.db.ExecContext(pgb.ctx, internal.SyncMixes, mixReorgDepth,
+		pq.Array(txhelpers.MixDenominations()))
 	return err
 }
 
-// CheckOnBlackList return true if exist on black list
-func (pgb *ChainDB) CheckOnBlackList(agent, ip string) (bool, error) {
-	var onBlacklist bool
-	err := pgb.db.QueryRow(internal.CheckExistOnBlackList, agent, ip).Scan(&onBlacklist)
-	return onBlacklist, err
+// GetMixes returns up to limit valid mainchain mixing transactions mined
+// between the from and to UNIX times, most recent first.
+func (pgb *ChainDB) GetMixes(from, to int64, limit int) ([]*dbtypes.MixTx, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectMixes, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	mixes := make([]*dbtypes.MixTx, 0)
+	for rows.Next() {
+		var mix dbtypes.MixTx
+		err = rows.Scan(&mix.TxHash, &mix.BlockHeight, &mix.BlockTime, &mix.Denomination,
+			&mix.MixCount, &mix.NumVin, &mix.NumVout, &mix.ChangeCount, &mix.ChangeAmount,
+			&mix.Fees, &mix.IsSplit)
+		if err != nil {
+			return nil, err
+		}
+		mixes = append(mixes, &mix)
+	}
+	return mixes, rows.Err()
 }
 
-func (pgb *ChainDB) GetMultichain24hSumAndAvgTxFee(chainType string) (int64, int64, error) {
-	var txFeeSum, txFeeAvg int64
-	err := pgb.db.QueryRow(mutilchainquery.CreateSelect24hAvgAndSumTxFee(chainType)).Scan(&txFeeSum, &txFeeAvg)
-	return txFeeSum, txFeeAvg, err
+// GetMixDays returns the daily CSPP mix volume, participation and change of
+// each denomination between the from and to UNIX times.
+func (pgb *ChainDB) GetMixDays(from, to int64) ([]*dbtypes.MixDay, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectMixDays, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	days := make([]*dbtypes.MixDay, 0)
+	for rows.Next() {
+		var d dbtypes.MixDay
+		err = rows.Scan(&d.Day, &d.Denomination, &d.Mixes, &d.MixedAmount,
+			&d.AvgParticipants, &d.ChangeAmount, &d.MixesWithChange)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, &d)
+	}
+	return days, rows.Err()
 }
 
-func (pgb *ChainDB) GetMultichainStats(chainType string) (*externalapi.ChainStatsData, error) {
-	return externalapi.GetBlockchainStats(chainType)
+// GetTicketPortfolio summarizes the mainchain tickets with an output paying
+// to any of the addresses, such as those derived from an extended public key.
+func (pgb *ChainDB) GetTicketPortfolio(addresses []string) (*dbtypes.TicketPortfolio, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectPortfolioTickets,
+		pq.Array(addresses), int(stake.TxTypeSStx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tickets := make([]*dbtypes.PortfolioTicket, 0)
+	for rows.Next() {
+		var t dbtypes.PortfolioTicket
+		err = rows.Scan(&t.TxHash, &t.Height, &t.Price, &t.Fee, &t.SpendType,
+			&t.PoolStatus, &t.SpendHeight, &t.VoteReward, &t.MissHeight)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, &t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return dbtypes.NewTicketPortfolio(tickets, len(addresses), pgb.Height(), pgb.chainParams), nil
+}
+
+// GetVoteDelays retrieves the number of blocks from maturity to vote of the
+// mainchain votes between the from and to UNIX times, by day, by block for the
+// most recent blocks, and as a histogram with buckets of bucketBlocks blocks.
+func (pgb *ChainDB) GetVoteDelays(from, to int64, blocks int, bucketBlocks int64) (*dbtypes.VoteDelays, error) {
+	maturity := int64(pgb.chainParams.TicketMaturity)
+	scanStats := func(byDay bool, query string, args ...interface{}) ([]*dbtypes.VoteDelayStats, error) {
+		rows, err := pgb.db.QueryContext(pgb.ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		stats := make([]*dbtypes.VoteDelayStats, 0)
+		for rows.Next() {
+			var s dbtypes.VoteDelayStats
+			var key int64
+			err = rows.Scan(&key, &s.Votes, &s.Mean, &s.Median, &s.Min, &s.Max)
+			if err != nil {
+				return nil, err
+			}
+			if byDay {
+				s.Time = key
+			} else {
+				s.Height = key
+			}
+			stats = append(stats, &s)
+		}
+		return stats, rows.Err()
+	}
+
+	delays := &dbtypes.VoteDelays{
+		BucketBlocks: bucketBlocks,
+		Histogram:    make([]int64, 0),
+	}
+	var err error
+	delays.ByDay, err = scanStats(true, internal.SelectVoteDelaysByDay, from, to, maturity)
+	if err != nil {
+		return nil, err
+	}
+	delays.ByBlock, err = scanStats(false, internal.SelectVoteDelaysByBlock, from, to, maturity, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectVoteDelayHistogram,
+		from, to, maturity, bucketBlocks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket, count int64
+		if err = rows.Scan(&bucket, &count); err != nil {
+			return nil, err
+		}
+		for int64(len(delays.Histogram)) <= bucket {
+			delays.Histogram = append(delays.Histogram, 0)
+		}
+		delays.Histogram[bucket] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return delays, nil
+}
+
+// SetTSpendEventHandler sets the function that TrackTSpends calls when a
+// tracked tspend is approved, rejected or mined.
+func (pgb *ChainDB) SetTSpendEventHandler(handler func(*dbtypes.TSpendEvent)) {
+	pgb.tspendTrackMtx.Lock()
+	pgb.tspendEventHandler = handler
+	pgb.tspendTrackMtx.Unlock()
+}
+
+// queryTSpendLifecycles runs a tspend_tracker query.
+func (pgb *ChainDB) queryTSpendLifecycles(query string, args ...interface{}) ([]*dbtypes.TSpendLifecycle, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tspends := make([]*dbtypes.TSpendLifecycle, 0)
+	for rows.Next() {
+		var ts dbtypes.TSpendLifecycle
+		err = rows.Scan(&ts.TxHash, &ts.Amount, &ts.Expiry, &ts.VoteStart, &ts.VoteEnd,
+			&ts.FirstSeen, &ts.Status, &ts.StatusHeight, &ts.YesVotes, &ts.NoVotes, &ts.MinedHeight)
+		if err != nil {
+			return nil, err
+		}
+		tspends = append(tspends, &ts)
+	}
+	return tspends, rows.Err()
+}
+
+// tspendAmount is the amount that the tspend spends from the treasury.
+func (pgb *ChainDB) tspendAmount(txid string) (int64, error) {
+	txHash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := pgb.Client.GetRawTransaction(pgb.ctx, txHash)
+	if err != nil {
+		return 0, err
+	}
+	if len(tx.MsgTx().TxIn) == 0 {
+		return 0, fmt.Errorf("tspend %s has no inputs", txid)
+	}
+	return tx.MsgTx().TxIn[0].ValueIn, nil
+}
+
+// TrackTSpends updates the tracked tspends for a new tip. New tspends are
+// picked up from the dcrd mempool, along with dcrd's vote tallies for the
+// tspends that are still in the mempool. The tallies of tracked tspends that
+// have left the mempool are taken from the stored votes, and such tspends are
+// checked for having been mined. The event handler is called for each tspend
+// that became approved, rejected or mined, after the tracker is unlocked.
+func (pgb *ChainDB) TrackTSpends(tip int64) error {
+	pgb.tspendTrackMtx.Lock()
+	events, err := pgb.trackTSpends(tip)
+	handler := pgb.tspendEventHandler
+	pgb.tspendTrackMtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, ev := range events {
+		log.Infof("Tspend %s is %s at height %d.", ev.TSpend.TxHash, ev.Event, ev.Height)
+		if handler != nil {
+			handler(ev)
+		}
+	}
+	return nil
+}
+
+// trackTSpends updates the tracked tspends for a new tip, and returns the
+// events of the tspends that became approved, rejected or mined. The caller
+// must hold the tspendTrackMtx.
+func (pgb *ChainDB) trackTSpends(tip int64) ([]*dbtypes.TSpendEvent, error) {
+	active, err := pgb.queryTSpendLifecycles(internal.SelectTSpendTrackerActive,
+		dbtypes.TSpendMined, dbtypes.TSpendRejected)
+	if err != nil {
+		return nil, err
+	}
+	tspends := make(map[string]*dbtypes.TSpendLifecycle, len(active))
+	for _, ts := range active {
+		tspends[ts.TxHash] = ts
+	}
+
+	res, err := pgb.Client.GetTreasurySpendVotes(pgb.ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetTreasurySpendVotes: %w", err)
+	}
+	inMempool := make(map[string]bool, len(res.Votes))
+	for _, v := range res.Votes {
+		inMempool[v.Hash] = true
+		ts, found := tspends[v.Hash]
+		if !found {
+			// A final tspend can linger in the mempool until it expires.
+			final, err := pgb.queryTSpendLifecycles(internal.SelectTSpendTrackerByHash, v.Hash)
+			if err != nil {
+				return nil, err
+			}
+			if len(final) > 0 {
+				continue
+			}
+			ts = &dbtypes.TSpendLifecycle{
+				TxHash:    v.Hash,
+				Expiry:    v.Expiry,
+				VoteStart: v.VoteStart,
+				VoteEnd:   v.VoteEnd,
+				FirstSeen: time.Now().Unix(),
+			}
+			if ts.Amount, err = pgb.tspendAmount(v.Hash); err != nil {
+				log.Warnf("Unable to get the amount of tspend %s: %v", v.Hash, err)
+			}
+			tspends[v.Hash] = ts
+			log.Infof("Tracking new tspend %s.", v.Hash)
+		}
+		ts.YesVotes, ts.NoVotes = v.YesVotes, v.NoVotes
+	}
+
+	var events []*dbtypes.TSpendEvent
+	for txid, ts := range tspends {
+		if !inMempool[txid] {
+			var minedHeight int64
+			err = pgb.db.QueryRowContext(pgb.ctx, internal.SelectTreasuryTxMinedHeight, txid).Scan(&minedHeight)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			ts.MinedHeight = minedHeight
+			var total int64
+			err = pgb.db.QueryRowContext(pgb.ctx, internal.SelectTSpendVoteTotals, dbtypes.TSpendYes,
+				dbtypes.TSpendNo, txid).Scan(&ts.YesVotes, &ts.NoVotes, &total)
+			if err != nil {
+				return nil, err
+			}
+		}
+		prevStatus := ts.Status
+		if ts.Update(tip, pgb.chainParams) {
+			// A tspend that is mined without having been seen as approved was
+			// approved in the block before it was mined.
+			if ts.Status == dbtypes.TSpendMined && prevStatus != dbtypes.TSpendApproved {
+				events = append(events, &dbtypes.TSpendEvent{Event: dbtypes.TSpendApproved, Height: tip, TSpend: ts})
+			}
+			switch ts.Status {
+			case dbtypes.TSpendApproved, dbtypes.TSpendRejected, dbtypes.TSpendMined:
+				events = append(events, &dbtypes.TSpendEvent{Event: ts.Status, Height: tip, TSpend: ts})
+			}
+		}
+		_, err = pgb.db.ExecContext(pgb.ctx, internal.UpsertTSpendTrackerRow, ts.TxHash, ts.Amount,
+			ts.Expiry, ts.VoteStart, ts.VoteEnd, ts.FirstSeen, ts.Status, ts.StatusHeight,
+			ts.YesVotes, ts.NoVotes, ts.MinedHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// GetTSpendLifecycles returns up to limit tracked tspends, most recently seen
+// first, with their status and projection at the best block.
+func (pgb *ChainDB) GetTSpendLifecycles(limit int) ([]*dbtypes.TSpendLifecycle, error) {
+	tspends, err := pgb.queryTSpendLifecycles(internal.SelectTSpendTrackerAll, limit)
+	if err != nil {
+		return nil, err
+	}
+	tip := pgb.bestBlock.Height()
+	for _, ts := range tspends {
+		pgb.projectTSpend(ts, tip)
+	}
+	return tspends, nil
+}
+
+// GetTSpendLifecycle returns the tracked tspend with its status and
+// projection at the best block, or nil if the tspend is not tracked.
+func (pgb *ChainDB) GetTSpendLifecycle(txid string) (*dbtypes.TSpendLifecycle, error) {
+	tspends, err := pgb.queryTSpendLifecycles(internal.SelectTSpendTrackerByHash, txid)
+	if err != nil || len(tspends) == 0 {
+		return nil, err
+	}
+	pgb.projectTSpend(tspends[0], pgb.bestBlock.Height())
+	return tspends[0], nil
+}
+
+// projectTSpend sets the projection of a stored tspend. The stored status of
+// a final tspend is kept, since its tallies are as of its last status change.
+func (pgb *ChainDB) projectTSpend(ts *dbtypes.TSpendLifecycle, tip int64) {
+	status, statusHeight := ts.Status, ts.StatusHeight
+	if ts.IsFinal() {
+		tip = statusHeight
+	}
+	ts.Update(tip, pgb.chainParams)
+	ts.Status, ts.StatusHeight = status, statusHeight
+}
+
+// GetTicketsSummaryInfo return summary information of tickets vote
+func (pgb *ChainDB) GetTicketsSummaryInfo() (*dbtypes.TicketsSummaryInfo, error) {
+	bestBlockHeight := pgb.bestBlock.Height()
+	// get summary info
+	result := dbtypes.TicketsSummaryInfo{}
+	err := pgb.db.QueryRow(internal.SelectTicketSummaryInfo, bestBlockHeight).Scan(&result.MissedTickets, &result.Last1000BlocksMissed, &result.Last1000BlocksTicketFeeAvg)
+	if err != nil {
+		return nil, err
+	}
+	// calculator for ticket maturity
+	result.TicketMaturity = uint64(pgb.chainParams.TicketMaturity)
+	// avg block time
+	timePerBlock := pgb.chainParams.TargetTimePerBlock
+	// calculate ticket maturity duration (by seconds)
+	result.TicketMaturityDuration = uint64(timePerBlock.Seconds()) * result.TicketMaturity
+	// ticket expiration
+	result.TicketExpiration = uint64(pgb.chainParams.TicketExpiry)
+	// calculate to ticket expiration duration (by seconds)
+	result.TicketExpirationDuration = uint64(timePerBlock.Seconds()) * result.TicketExpiration
+	// Calculate Win probability on 1 block
+	result.WinProbability = float64(pgb.chainParams.TicketsPerBlock) / float64(pgb.chainParams.TicketPoolSize*pgb.chainParams.TicketsPerBlock)
+	// Calculate the expected block number to ensure 100% selection
+	threshold := 0.0001
+	blocksNeed := uint64(math.Round(math.Log(threshold) / math.Log(1-result.WinProbability)))
+	result.BlocksNeedToWin = blocksNeed
+	result.TimeToBlocksNeedToWin = uint64(timePerBlock.Seconds()) * result.BlocksNeedToWin
+	return &result, nil
+}
+
+// Get24hActiveAddressesCount return active addresses count in 24h
+func (pgb *ChainDB) Get24hActiveAddressesCount() int64 {
+	var activeAddr int64
+	pgb.db.QueryRow(internal.SelectCount24hUniqueAddress).Scan(&activeAddr)
+	return activeAddr
+}
+
+// Get24hStakingInfo return 24h staking info
+func (pgb *ChainDB) Get24hStakingInfo() (poolvalue, missed int64, err error) {
+	err = pgb.db.QueryRow(internal.SelectStakingSummaryIn24h).Scan(&poolvalue, &missed)
+	return
+}
+
+// Get24hTreasuryBalanceChange return 24h treasury balance change
+func (pgb *ChainDB) Get24hTreasuryBalanceChange() (treasuryBalanceChange int64, err error) {
+	err = pgb.db.QueryRow(internal.SelectTreasuryBalanceChangeIn24h).Scan(&treasuryBalanceChange)
+	return
+}
+
+// InsertToBlackList insert to black list
+func (pgb *ChainDB) InsertToBlackList(agent, ip, note string) error {
+	_, err := pgb.db.Exec(internal.UpsertBlackList, agent, ip, note)
+	return err
+}
+
+// CheckOnBlackList return true if exist on black list
+func (pgb *ChainDB) CheckOnBlackList(agent, ip string) (bool, error) {
+	var onBlacklist bool
+	err := pgb.db.QueryRow(internal.CheckExistOnBlackList, agent, ip).Scan(&onBlacklist)
+	return onBlacklist, err
+}
+
+// InsertLiquiditySnapshots stores order book liquidity snapshots. Snapshots
+// already stored for the same chain, token and time are ignored.
+func (pgb *ChainDB) InsertLiquiditySnapshots(snapshots []*dbtypes.LiquiditySnapshot) error {
+	dbTx, err := pgb.db.BeginTx(pgb.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", err)
+	}
+	stmt, err := dbTx.Prepare(internal.InsertLiquiditySnapshotRow)
+	if err != nil {
+		_ = dbTx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, s := range snapshots {
+		_, err = stmt.Exec(s.ChainType, s.Token, s.Time, s.MidGap,
+			s.BidVolume[0], s.BidVolume[1], s.BidVolume[2],
+			s.AskVolume[0], s.AskVolume[1], s.AskVolume[2],
+			s.BuySlippage[0], s.BuySlippage[1], s.BuySlippage[2],
+			s.SellSlippage[0], s.SellSlippage[1], s.SellSlippage[2], s.Share)
+		if err != nil {
+			_ = dbTx.Rollback()
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// GetLiquiditySnapshots returns the stored liquidity snapshots for the chain
+// between the from and to times, in time order. An empty token returns the
+// snapshots for every exchange and the aggregated order book.
+func (pgb *ChainDB) GetLiquiditySnapshots(chainType, token string, from, to int64) ([]*dbtypes.LiquiditySnapshot, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectLiquiditySnapshots, chainType, token, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	snapshots := make([]*dbtypes.LiquiditySnapshot, 0)
+	for rows.Next() {
+		var s dbtypes.LiquiditySnapshot
+		err = rows.Scan(&s.ChainType, &s.Token, &s.Time, &s.MidGap,
+			&s.BidVolume[0], &s.BidVolume[1], &s.BidVolume[2],
+			&s.AskVolume[0], &s.AskVolume[1], &s.AskVolume[2],
+			&s.BuySlippage[0], &s.BuySlippage[1], &s.BuySlippage[2],
+			&s.SellSlippage[0], &s.SellSlippage[1], &s.SellSlippage[2], &s.Share)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &s)
+	}
+	return snapshots, rows.Err()
+}
+
+// PurgeLiquiditySnapshots deletes the liquidity snapshots older than the
+// given time, returning the number of rows deleted.
+func (pgb *ChainDB) PurgeLiquiditySnapshots(before int64) (int64, error) {
+	res, err := pgb.db.ExecContext(pgb.ctx, internal.DeleteLiquiditySnapshotsBefore, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (pgb *ChainDB) GetMultichain24hSumAndAvgTxFee(chainType string) (int64, int64, error) {
+	var txFeeSum, txFeeAvg int64
+	err := pgb.db.QueryRow(mutilchainquery.CreateSelect24hAvgAndSumTxFee(chainType)).Scan(&txFeeSum, &txFeeAvg)
+	return txFeeSum, txFeeAvg, err
+}
+
+func (pgb *ChainDB) GetMultichainStats(chainType string) (*externalapi.ChainStatsData, error) {
+	return externalapi.GetBlockchainStats(chainType)
+}
+
+// The minimum relay fee rates in atoms/vB, and the confidence of the fee
+// estimates.
+const (
+	dcrMinFeeRate         = 10 // 1e4 atoms/kB
+	btcMinFeeRate         = 1
+	ltcMinFeeRate         = 1
+	feeEstimateConfidence = 0.9
+)
+
+// chainTablePrefix is the table prefix of a chain for the tables that DCR has
+// too, without a prefix, such as fee_estimates and mempool_ledger.
+func chainTablePrefix(chainType string) string {
+	if chainType == mutilchain.TYPEDCR {
+		return ""
+	}
+	return chainType
+}
+
+// CheckCreateFeeEstimatesTables creates the fee_estimates tables of DCR, BTC
+// and LTC if they do not exist.
+func (pgb *ChainDB) CheckCreateFeeEstimatesTables() error {
+	for _, chainType := range []string{mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPELTC} {
+		if pgb.ChainDisabledMap[chainType] {
+			continue
+		}
+		if err := checkExistAndCreateFeeEstimatesTable(pgb.db, chainTablePrefix(chainType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feeEstimatorParams returns the fee estimator parameters of a chain, the
+// unit of its fee rates and its mempool transactions.
+func (pgb *ChainDB) feeEstimatorParams(chainType string) (*txhelpers.FeeEstimatorParams, string, []exptypes.MempoolTx, error) {
+	switch chainType {
+	case mutilchain.TYPEDCR:
+		return &txhelpers.FeeEstimatorParams{
+			MaxBlockWeight: 4 * int64(pgb.chainParams.MaximumBlockSizes[0]),
+			MinFeeRate:     dcrMinFeeRate,
+			BlockTime:      int64(pgb.chainParams.TargetTimePerBlock / time.Second),
+			Confidence:     feeEstimateConfidence,
+		}, "atoms/B", pgb.MPC.GetTxns(), nil
+	case mutilchain.TYPEBTC:
+		return &txhelpers.FeeEstimatorParams{
+			MaxBlockWeight: btc_blockchain.MaxBlockWeight,
+			MinFeeRate:     btcMinFeeRate,
+			BlockTime:      int64(pgb.btcChainParams.TargetTimePerBlock / time.Second),
+			Confidence:     feeEstimateConfidence,
+		}, "sat/vB", pgb.BTCMPC.GetTxns(), nil
+	case mutilchain.TYPELTC:
+		return &txhelpers.FeeEstimatorParams{
+			MaxBlockWeight: ltc_blockchain.MaxBlockWeight,
+			MinFeeRate:     ltcMinFeeRate,
+			BlockTime:      int64(pgb.ltcChainParams.TargetTimePerBlock / time.Second),
+			Confidence:     feeEstimateConfidence,
+		}, "lit/vB", pgb.LTCMPC.GetTxns(), nil
+	default:
+		return nil, "", nil, fmt.Errorf("fee estimates are not supported for %s", chainType)
+	}
+}
+
+// recentBlockFeeRates retrieves the fee rates of the fee paying transactions
+// of the last txhelpers.FeeEstimateWindow blocks of a chain up to height,
+// oldest first. Blocks without fee paying transactions have no fee rates.
+func (pgb *ChainDB) recentBlockFeeRates(chainType string, height int64) ([]*txhelpers.BlockFeeRates, error) {
+	from := height - txhelpers.FeeEstimateWindow
+	if from < -1 {
+		from = -1
+	}
+	query := internal.SelectRegularTxFeeRates
+	if chainType != mutilchain.TYPEDCR {
+		query = fmt.Sprintf(mutilchainquery.SelectTxFeeRates, chainType)
+	}
+	rows, err := pgb.db.QueryContext(pgb.ctx, query, from)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	blocks := make([]*txhelpers.BlockFeeRates, 0, height-from)
+	for h := from + 1; h <= height; h++ {
+		blocks = append(blocks, &txhelpers.BlockFeeRates{Height: h})
+	}
+	for rows.Next() {
+		var h int64
+		var feeRate float64
+		if err = rows.Scan(&h, &feeRate); err != nil {
+			return nil, err
+		}
+		if h <= from || h > height {
+			continue
+		}
+		b := blocks[h-from-1]
+		b.FeeRates = append(b.FeeRates, feeRate)
+	}
+	return blocks, rows.Err()
+}
+
+// UpdateFeeEstimates estimates the fees of a chain from its recent blocks and
+// its mempool, and stores the estimates in the fee_estimates table of the
+// chain.
+func (pgb *ChainDB) UpdateFeeEstimates(chainType string) (*txhelpers.FeeEstimates, error) {
+	params, unit, mempoolTxs, err := pgb.feeEstimatorParams(chainType)
+	if err != nil {
+		return nil, err
+	}
+	height := pgb.MutilchainHeight(chainType)
+	blocks, err := pgb.recentBlockFeeRates(chainType, height)
+	if err != nil {
+		return nil, err
+	}
+	est := txhelpers.EstimateFees(blocks, exptypes.MempoolTemplateTxs(mempoolTxs), params)
+	est.ChainType = chainType
+	est.Height = height
+	est.Time = time.Now().Unix()
+	est.Unit = unit
+
+	_, err = pgb.db.ExecContext(pgb.ctx, fmt.Sprintf(mutilchainquery.InsertFeeEstimate, chainTablePrefix(chainType)),
+		est.Time, est.Height, est.Low.FeeRate, est.Medium.FeeRate, est.High.FeeRate, est.MempoolTxs, est.MempoolVSize)
+	if err != nil {
+		return nil, err
+	}
+
+	pgb.feeEstimatesMtx.Lock()
+	if pgb.feeEstimates == nil {
+		pgb.feeEstimates = make(map[string]*txhelpers.FeeEstimates)
+	}
+	pgb.feeEstimates[chainType] = est
+	pgb.feeEstimatesMtx.Unlock()
+	return est, nil
+}
+
+// GetFeeEstimates returns the latest fee estimates of a chain, estimating
+// them if they were not estimated since the start.
+func (pgb *ChainDB) GetFeeEstimates(chainType string) (*txhelpers.FeeEstimates, error) {
+	pgb.feeEstimatesMtx.RLock()
+	est := pgb.feeEstimates[chainType]
+	pgb.feeEstimatesMtx.RUnlock()
+	if est != nil {
+		return est, nil
+	}
+	return pgb.UpdateFeeEstimates(chainType)
+}
+
+// confirmationEstimates are the confirmation estimates of the transactions of
+// a mempool snapshot.
+type confirmationEstimates struct {
+	mempoolTime time.Time
+	estimates   map[string]*txhelpers.ConfirmationEstimate
+}
+
+// mempoolTime is the time of the last mempool snapshot of a chain.
+func (pgb *ChainDB) mempoolTime(chainType string) time.Time {
+	switch chainType {
+	case mutilchain.TYPEDCR:
+		return pgb.MPC.GetTimestamp()
+	case mutilchain.TYPEBTC:
+		return pgb.BTCMPC.GetTimestamp()
+	case mutilchain.TYPELTC:
+		return pgb.LTCMPC.GetTimestamp()
+	default:
+		return time.Time{}
+	}
+}
+
+// mempoolConfirmationEstimates returns the confirmation estimates of the
+// transactions of the last mempool snapshot of a chain, estimating them from
+// the recent blocks once per snapshot. DCR stake transactions are left out, as
+// the stake rules rather than the fee rate set when they confirm.
+func (pgb *ChainDB) mempoolConfirmationEstimates(chainType string) (map[string]*txhelpers.ConfirmationEstimate, error) {
+	params, _, mempoolTxs, err := pgb.feeEstimatorParams(chainType)
+	if err != nil {
+		return nil, err
+	}
+	mempoolTime := pgb.mempoolTime(chainType)
+
+	pgb.confEstimatesMtx.Lock()
+	defer pgb.confEstimatesMtx.Unlock()
+	if cached := pgb.confEstimates[chainType]; cached != nil && cached.mempoolTime.Equal(mempoolTime) {
+		return cached.estimates, nil
+	}
+
+	blocks, err := pgb.recentBlockFeeRates(chainType, pgb.MutilchainHeight(chainType))
+	if err != nil {
+		return nil, err
+	}
+	if chainType == mutilchain.TYPEDCR {
+		regular := make([]exptypes.MempoolTx, 0, len(mempoolTxs))
+		for _, tx := range mempoolTxs {
+			if tx.TypeID == int(stake.TxTypeRegular) {
+				regular = append(regular, tx)
+			}
+		}
+		mempoolTxs = regular
+	}
+	estimates := txhelpers.EstimateConfirmations(exptypes.MempoolTemplateTxs(mempoolTxs), blocks, params)
+	if pgb.confEstimates == nil {
+		pgb.confEstimates = make(map[string]*confirmationEstimates)
+	}
+	pgb.confEstimates[chainType] = &confirmationEstimates{
+		mempoolTime: mempoolTime,
+		estimates:   estimates,
+	}
+	return estimates, nil
+}
+
+// GetTxConfirmationEstimate returns the confirmation estimate of a transaction
+// in the DCR, BTC or LTC mempool. It is nil if the transaction is not in the
+// last mempool snapshot, or is a DCR stake transaction.
+func (pgb *ChainDB) GetTxConfirmationEstimate(chainType, txid string) (*txhelpers.ConfirmationEstimate, error) {
+	estimates, err := pgb.mempoolConfirmationEstimates(chainType)
+	if err != nil {
+		return nil, err
+	}
+	return estimates[txid], nil
+}
+
+// GetFeeEstimateHistory retrieves up to limit stored fee estimates of a chain,
+// most recent first.
+func (pgb *ChainDB) GetFeeEstimateHistory(chainType string, limit int) ([]*dbtypes.FeeEstimateStat, error) {
+	if _, _, _, err := pgb.feeEstimatorParams(chainType); err != nil {
+		return nil, err
+	}
+	rows, err := pgb.db.QueryContext(pgb.ctx, fmt.Sprintf(mutilchainquery.SelectFeeEstimates,
+		chainTablePrefix(chainType)), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	stats := make([]*dbtypes.FeeEstimateStat, 0, limit)
+	for rows.Next() {
+		var s dbtypes.FeeEstimateStat
+		if err = rows.Scan(&s.Time, &s.Height, &s.Low, &s.Medium, &s.High, &s.MempoolTxs, &s.MempoolVSize); err != nil {
+			return nil, err
+		}
+		stats = append(stats, &s)
+	}
+	return stats, rows.Err()
+}
+
+// PurgeFeeEstimates deletes the stored fee estimates of a chain older than the
+// given time, returning the number of rows deleted.
+func (pgb *ChainDB) PurgeFeeEstimates(chainType string, before int64) (int64, error) {
+	res, err := pgb.db.ExecContext(pgb.ctx, fmt.Sprintf(mutilchainquery.DeleteFeeEstimatesBefore,
+		chainTablePrefix(chainType)), before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// CheckCreateWebhookTables creates the webhooks and webhook_deliveries tables
+// if they do not exist.
+func (pgb *ChainDB) CheckCreateWebhookTables() error {
+	return checkExistAndCreateWebhookTables(pgb.db)
+}
+
+// Webhooks retrieves all the registered webhooks, active or not.
+func (pgb *ChainDB) Webhooks() ([]*dbtypes.Webhook, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	hooks := make([]*dbtypes.Webhook, 0)
+	for rows.Next() {
+		var h dbtypes.Webhook
+		err = rows.Scan(&h.ID, &h.ChainType, &h.Event, &h.URL, &h.Secret, &h.Owner,
+			&h.Address, &h.TxID, &h.Depth, &h.Active, &h.Created)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, &h)
+	}
+	return hooks, rows.Err()
+}
+
+// InsertWebhook stores a new webhook, and returns its ID.
+func (pgb *ChainDB) InsertWebhook(h *dbtypes.Webhook) (int64, error) {
+	var id int64
+	err := pgb.db.QueryRowContext(pgb.ctx, internal.InsertWebhookRow, h.ChainType, h.Event,
+		h.URL, h.Secret, h.Owner, h.Address, h.TxID, h.Depth, h.Active, h.Created).Scan(&id)
+	return id, err
+}
+
+// DeactivateWebhook marks a webhook inactive, e.g. once a confirmations hook
+// has fired.
+func (pgb *ChainDB) DeactivateWebhook(id int64) error {
+	_, err := pgb.db.ExecContext(pgb.ctx, internal.DeactivateWebhook, id)
+	return err
+}
+
+// DeleteWebhook deletes a webhook and its delivery log.
+func (pgb *ChainDB) DeleteWebhook(id int64) error {
+	dbtx, err := pgb.db.BeginTx(pgb.ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err = dbtx.Exec(internal.DeleteWebhookDeliveries, id); err != nil {
+		_ = dbtx.Rollback()
+		return err
+	}
+	if _, err = dbtx.Exec(internal.DeleteWebhook, id); err != nil {
+		_ = dbtx.Rollback()
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// InsertWebhookDelivery stores a delivery attempt of a webhook.
+func (pgb *ChainDB) InsertWebhookDelivery(d *dbtypes.WebhookDelivery) error {
+	_, err := pgb.db.ExecContext(pgb.ctx, internal.InsertWebhookDeliveryRow, d.HookID,
+		d.DeliveryID, d.Event, d.Payload, d.Attempt, d.StatusCode, d.Error, d.Success, d.Time)
+	return err
+}
+
+// WebhookDeliveries retrieves up to limit delivery attempts of a webhook, most
+// recent first.
+func (pgb *ChainDB) WebhookDeliveries(hookID int64, limit int) ([]*dbtypes.WebhookDelivery, error) {
+	rows, err := pgb.db.QueryContext(pgb.ctx, internal.SelectWebhookDeliveries, hookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	deliveries := make([]*dbtypes.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		var d dbtypes.WebhookDelivery
+		err = rows.Scan(&d.HookID, &d.DeliveryID, &d.Event, &d.Payload, &d.Attempt,
+			&d.StatusCode, &d.Error, &d.Success, &d.Time)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
+
+// DeleteWebhookDeliveriesBefore deletes the webhook delivery attempts older
+// than the given time, and returns the number deleted.
+func (pgb *ChainDB) DeleteWebhookDeliveriesBefore(t int64) (int64, error) {
+	res, err := pgb.db.ExecContext(pgb.ctx, internal.DeleteWebhookDeliveriesBefore, t)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// TxBlockHeight returns the height of the mainchain block that mined a
+// transaction of a chain, or dbtypes.ErrNoResult if it is not mined.
+func (pgb *ChainDB) TxBlockHeight(chainType, txid string) (int64, error) {
+	if chainType == mutilchain.TYPEDCR {
+		txs, err := pgb.Transaction(txid)
+		if err != nil {
+			return 0, err
+		}
+		for _, tx := range txs {
+			if tx.IsMainchainBlock {
+				return tx.BlockHeight, nil
+			}
+		}
+		return 0, dbtypes.ErrNoResult
+	}
+	txs, err := pgb.MutilchainTransaction(txid, chainType)
+	if err != nil {
+		return 0, err
+	}
+	if len(txs) == 0 {
+		return 0, dbtypes.ErrNoResult
+	}
+	return txs[0].BlockHeight, nil
+}
+
+// BlocksByHashes retrieves the blocks of a chain with the given hashes in one
+// query. The blocks that are not found are omitted.
+func (pgb *ChainDB) BlocksByHashes(ctx context.Context, chainType string, hashes []string) ([]*dbtypes.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var blocks []*dbtypes.Block
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		blocks, err = RetrieveBlocksByHashes(ctx, pgb.db, hashes)
+	} else {
+		blocks, err = RetrieveMutilchainBlocksByHashes(ctx, pgb.db, hashes, chainType)
+	}
+	return blocks, pgb.replaceCancelError(err)
+}
+
+// BlocksByHeights retrieves the main chain blocks of a chain at the given
+// heights in one query. The heights that are not found are omitted.
+func (pgb *ChainDB) BlocksByHeights(ctx context.Context, chainType string, heights []int64) ([]*dbtypes.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var blocks []*dbtypes.Block
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		blocks, err = RetrieveMainchainBlocksByHeights(ctx, pgb.db, heights)
+	} else {
+		blocks, err = RetrieveMutilchainBlocksByHeights(ctx, pgb.db, heights, chainType)
+	}
+	return blocks, pgb.replaceCancelError(err)
+}
+
+// TxIDsByBlockHashes retrieves the transaction IDs of the blocks of a chain
+// with the given hashes in one query. The IDs are in block order, keyed by
+// block hash.
+func (pgb *ChainDB) TxIDsByBlockHashes(ctx context.Context, chainType string, blockHashes []string) (map[string][]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var txids map[string][]string
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		txids, err = RetrieveTxHashesByBlockHashes(ctx, pgb.db, blockHashes)
+	} else {
+		txids, err = RetrieveMutilchainTxHashesByBlockHashes(ctx, pgb.db, blockHashes, chainType)
+	}
+	return txids, pgb.replaceCancelError(err)
+}
+
+// TransactionsByHashes retrieves the transactions of a chain with the given
+// hashes in one query. The transactions that are not found are omitted.
+func (pgb *ChainDB) TransactionsByHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.Tx, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var txs []*dbtypes.Tx
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		txs, err = RetrieveTxsByHashes(ctx, pgb.db, txids)
+	} else {
+		txs, err = RetrieveMutilchainTxsByHashes(ctx, pgb.db, txids, chainType)
+	}
+	return txs, pgb.replaceCancelError(err)
+}
+
+// VinsByTxHashes retrieves the inputs of the transactions of a chain with the
+// given hashes in one query.
+func (pgb *ChainDB) VinsByTxHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.VinTxProperty, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var vins []*dbtypes.VinTxProperty
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		vins, err = RetrieveVinsByTxHashes(ctx, pgb.db, txids)
+	} else {
+		vins, err = RetrieveMutilchainVinsByTxHashes(ctx, pgb.db, txids, chainType)
+	}
+	return vins, pgb.replaceCancelError(err)
+}
+
+// VoutsByTxHashes retrieves the outputs of the transactions of a chain with the
+// given hashes in one query.
+func (pgb *ChainDB) VoutsByTxHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.Vout, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var vouts []*dbtypes.Vout
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		vouts, err = RetrieveVoutsByTxHashes(ctx, pgb.db, txids)
+	} else {
+		vouts, err = RetrieveMutilchainVoutsByTxHashes(ctx, pgb.db, txids, chainType)
+	}
+	return vouts, pgb.replaceCancelError(err)
+}
+
+// AddressBalances retrieves the balances of the addresses of a chain in one
+// query. The addresses without outpoints are omitted.
+func (pgb *ChainDB) AddressBalances(ctx context.Context, chainType string, addresses []string) ([]*dbtypes.AddressBalance, error) {
+	ctx, cancel := context.WithTimeout(ctx, pgb.queryTimeout)
+	defer cancel()
+	var balances []*dbtypes.AddressBalance
+	var err error
+	if chainType == mutilchain.TYPEDCR {
+		balances, err = RetrieveAddressBalances(ctx, pgb.db, addresses)
+	} else {
+		balances, err = RetrieveMutilchainAddressBalances(ctx, pgb.db, addresses, chainType)
+	}
+	return balances, pgb.replaceCancelError(err)
 }
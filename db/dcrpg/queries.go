@@ -872,6 +872,58 @@ func checkExistAndCreateDailyMarketTable(db *sql.DB) error {
 	return err
 }
 
+// Check exist and create liquidity_snapshots table
+func checkExistAndCreateLiquiditySnapshotsTable(db *sql.DB) error {
+	err := createTable(db, "liquidity_snapshots", internal.CreateLiquiditySnapshotsTable)
+	return err
+}
+
+// Check exist and create dex_market_days table
+func checkExistAndCreateDEXMarketDaysTable(db *sql.DB) error {
+	err := createTable(db, "dex_market_days", internal.CreateDEXMarketDaysTable)
+	return err
+}
+
+// Check exist and create vsps and vsp_snapshots tables
+func checkExistAndCreateVSPTables(db *sql.DB) error {
+	err := createTable(db, "vsps", internal.CreateVSPsTable)
+	if err != nil {
+		return err
+	}
+	return createTable(db, "vsp_snapshots", internal.CreateVSPSnapshotsTable)
+}
+
+// Check exist and create mixes table
+func checkExistAndCreateMixesTable(db *sql.DB) error {
+	return createTable(db, "mixes", internal.CreateMixesTable)
+}
+
+// Check exist and create tspend_tracker table
+func checkExistAndCreateTSpendTrackerTable(db *sql.DB) error {
+	return createTable(db, "tspend_tracker", internal.CreateTSpendTrackerTable)
+}
+
+// Check exist and create webhooks and webhook_deliveries tables
+func checkExistAndCreateWebhookTables(db *sql.DB) error {
+	err := createTable(db, "webhooks", internal.CreateWebhooksTable)
+	if err != nil {
+		return err
+	}
+	return createTable(db, "webhook_deliveries", internal.CreateWebhookDeliveriesTable)
+}
+
+// Check exist and create the fee_estimates table of a chain. The DCR table
+// has no prefix.
+func checkExistAndCreateFeeEstimatesTable(db *sql.DB, prefix string) error {
+	return createTable(db, prefix+"fee_estimates", mutilchainquery.CreateFeeEstimatesTableFunc(prefix))
+}
+
+// Check exist and create the mempool_ledger table of a chain. The DCR table
+// has no prefix.
+func checkExistAndCreateMempoolLedgerTable(db *sql.DB, prefix string) error {
+	return createTable(db, prefix+"mempool_ledger", mutilchainquery.CreateMempoolLedgerTableFunc(prefix))
+}
+
 // Get proposal Meta by owner
 func getProposalMetasByOwner(db *sql.DB, name string) ([]map[string]string, error) {
 	return queryProposalMetaList(db, internal.SelectProposalMetasByOwner, name)
@@ -6314,3 +6366,187 @@ func retrieveMultichainBasicBlockInfo(ctx context.Context, db *sql.DB, blHeight
 		FormattedBytes: humanize.Bytes(uint64(size)),
 	}, nil
 }
+
+// RetrieveBlocksByHashes retrieves the blocks with the given hashes. The blocks
+// that are not found are omitted.
+func RetrieveBlocksByHashes(ctx context.Context, db *sql.DB, hashes []string) ([]*dbtypes.Block, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectBlocksByHashes, pq.Array(hashes))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlocks(rows, false)
+}
+
+// RetrieveMainchainBlocksByHeights retrieves the main chain blocks at the given
+// heights. The heights above the best block are omitted.
+func RetrieveMainchainBlocksByHeights(ctx context.Context, db *sql.DB, heights []int64) ([]*dbtypes.Block, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectMainchainBlocksByHeights, pq.Array(heights))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlocks(rows, false)
+}
+
+// scanBlocks scans and closes the rows of the blocks batch queries. The times
+// of the mutilchain tables are UNIX timestamps.
+func scanBlocks(rows *sql.Rows, unixTime bool) ([]*dbtypes.Block, error) {
+	defer closeRows(rows)
+	var blocks []*dbtypes.Block
+	for rows.Next() {
+		var b dbtypes.Block
+		var err error
+		if unixTime {
+			var t int64
+			err = rows.Scan(&b.Hash, &b.Height, &b.Size, &b.Version, &b.NumTx,
+				&t, &b.Difficulty, &b.PreviousHash)
+			b.Time = dbtypes.NewTimeDefFromUNIX(t)
+		} else {
+			err = rows.Scan(&b.Hash, &b.Height, &b.Size, &b.Version, &b.NumTx,
+				&b.Time, &b.Difficulty, &b.PreviousHash)
+		}
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, &b)
+	}
+	return blocks, rows.Err()
+}
+
+// RetrieveTxsByHashes retrieves the transactions with the given hashes. The
+// transactions that are not found are omitted.
+func RetrieveTxsByHashes(ctx context.Context, db *sql.DB, txids []string) ([]*dbtypes.Tx, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectTxsByHashes, pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var txs []*dbtypes.Tx
+	for rows.Next() {
+		var tx dbtypes.Tx
+		err = rows.Scan(&tx.TxID, &tx.BlockHash, &tx.BlockHeight, &tx.BlockTime,
+			&tx.TxType, &tx.Tree, &tx.BlockIndex, &tx.Size, &tx.Sent, &tx.Fees,
+			&tx.NumVin, &tx.NumVout, &tx.IsMainchainBlock)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &tx)
+	}
+	return txs, rows.Err()
+}
+
+// RetrieveTxHashesByBlockHashes retrieves the transaction hashes of the blocks
+// with the given hashes, in block order, keyed by block hash.
+func RetrieveTxHashesByBlockHashes(ctx context.Context, db *sql.DB, blockHashes []string) (map[string][]string, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectTxHashesByBlockHashes, pq.Array(blockHashes))
+	if err != nil {
+		return nil, err
+	}
+	return scanBlockTxHashes(rows)
+}
+
+// scanBlockTxHashes scans and closes the rows of (block_hash, tx_hash).
+func scanBlockTxHashes(rows *sql.Rows) (map[string][]string, error) {
+	defer closeRows(rows)
+	txids := make(map[string][]string)
+	for rows.Next() {
+		var blockHash, txid string
+		if err := rows.Scan(&blockHash, &txid); err != nil {
+			return nil, err
+		}
+		txids[blockHash] = append(txids[blockHash], txid)
+	}
+	return txids, rows.Err()
+}
+
+// RetrieveVinsByTxHashes retrieves the inputs of the transactions with the
+// given hashes.
+func RetrieveVinsByTxHashes(ctx context.Context, db *sql.DB, txids []string) ([]*dbtypes.VinTxProperty, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectVinsByTxHashes, pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	return scanVins(rows)
+}
+
+// scanVins scans and closes the rows of the inputs batch queries.
+func scanVins(rows *sql.Rows) ([]*dbtypes.VinTxProperty, error) {
+	defer closeRows(rows)
+	var vins []*dbtypes.VinTxProperty
+	for rows.Next() {
+		var vin dbtypes.VinTxProperty
+		err := rows.Scan(&vin.TxID, &vin.TxIndex, &vin.TxTree, &vin.PrevTxHash,
+			&vin.PrevTxIndex, &vin.PrevTxTree, &vin.ValueIn)
+		if err != nil {
+			return nil, err
+		}
+		vins = append(vins, &vin)
+	}
+	return vins, rows.Err()
+}
+
+// RetrieveVoutsByTxHashes retrieves the outputs of the transactions with the
+// given hashes.
+func RetrieveVoutsByTxHashes(ctx context.Context, db *sql.DB, txids []string) ([]*dbtypes.Vout, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectVoutsByTxHashes, pq.Array(txids))
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var vouts []*dbtypes.Vout
+	for rows.Next() {
+		var vout dbtypes.Vout
+		err = rows.Scan(&vout.TxHash, &vout.TxIndex, &vout.TxTree, &vout.Value,
+			&vout.Version, &vout.ScriptPubKeyData.ReqSigs, &vout.ScriptPubKeyData.Type,
+			pq.Array(&vout.ScriptPubKeyData.Addresses), &vout.Mixed)
+		if err != nil {
+			return nil, err
+		}
+		vouts = append(vouts, &vout)
+	}
+	return vouts, rows.Err()
+}
+
+// RetrieveAddressBalances gets the numbers and amounts of the spent and unspent
+// outpoints of the given addresses. The addresses without outpoints are
+// omitted.
+func RetrieveAddressBalances(ctx context.Context, db *sql.DB, addresses []string) ([]*dbtypes.AddressBalance, error) {
+	rows, err := db.QueryContext(ctx, internal.SelectAddressesFundingCountAndValue, pq.Array(addresses))
+	if err != nil {
+		return nil, err
+	}
+	return scanAddressBalances(rows)
+}
+
+// scanAddressBalances scans and closes the rows of (address, unspent, count,
+// sum) of the address balances batch queries.
+func scanAddressBalances(rows *sql.Rows) ([]*dbtypes.AddressBalance, error) {
+	defer closeRows(rows)
+	balances := make(map[string]*dbtypes.AddressBalance)
+	var ordered []*dbtypes.AddressBalance
+	for rows.Next() {
+		var addr string
+		var unspent bool
+		var count int64
+		var value sql.NullInt64
+		if err := rows.Scan(&addr, &unspent, &count, &value); err != nil {
+			return nil, err
+		}
+		bal := balances[addr]
+		if bal == nil {
+			bal = &dbtypes.AddressBalance{Address: addr}
+			balances[addr] = bal
+			ordered = append(ordered, bal)
+		}
+		if unspent {
+			bal.NumUnspent += count
+			bal.TotalUnspent += value.Int64
+		} else {
+			bal.NumSpent += count
+			bal.TotalSpent += value.Int64
+		}
+		bal.TotalReceived = bal.TotalSpent + bal.TotalUnspent
+	}
+	return ordered, rows.Err()
+}
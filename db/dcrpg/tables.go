@@ -45,6 +45,16 @@ var createTableStatements = [][2]string{
 	{"blocks24h", internal.Create24hBlocksTable},
 	{"tspend_votes", internal.CreateTSpendVotesTable},
 	{"black_list", internal.CreateBlackListTable},
+	{"liquidity_snapshots", internal.CreateLiquiditySnapshotsTable},
+	{"dex_market_days", internal.CreateDEXMarketDaysTable},
+	{"vsps", internal.CreateVSPsTable},
+	{"vsp_snapshots", internal.CreateVSPSnapshotsTable},
+	{"mixes", internal.CreateMixesTable},
+	{"tspend_tracker", internal.CreateTSpendTrackerTable},
+	{"fee_estimates", mutilchainquery.CreateFeeEstimatesTableFunc("")},
+	{"mempool_ledger", mutilchainquery.CreateMempoolLedgerTableFunc("")},
+	{"webhooks", internal.CreateWebhooksTable},
+	{"webhook_deliveries", internal.CreateWebhookDeliveriesTable},
 }
 
 func GetCreateDBTables() [][2]string {
@@ -56,6 +66,8 @@ func GetCreateDBTables() [][2]string {
 		result = append(result, [2]string{fmt.Sprintf("%sblocks_all", chainType), mutilchainquery.CreateBlockAllTableFunc(chainType)})
 		result = append(result, [2]string{fmt.Sprintf("%sblock_chain", chainType), mutilchainquery.CreateBlockPrevNextTableFunc(chainType)})
 		result = append(result, [2]string{fmt.Sprintf("%sfees_stat", chainType), mutilchainquery.CreateFeesStatTableTableFunc(chainType)})
+		result = append(result, [2]string{fmt.Sprintf("%sfee_estimates", chainType), mutilchainquery.CreateFeeEstimatesTableFunc(chainType)})
+		result = append(result, [2]string{fmt.Sprintf("%smempool_ledger", chainType), mutilchainquery.CreateMempoolLedgerTableFunc(chainType)})
 		result = append(result, [2]string{fmt.Sprintf("%smempool_history", chainType), mutilchainquery.CreateMempoolHistoryFunc(chainType)})
 		result = append(result, [2]string{fmt.Sprintf("%snodes", chainType), mutilchainquery.CreateNodesTableFunc(chainType)})
 		result = append(result, [2]string{fmt.Sprintf("%stransactions", chainType), mutilchainquery.CreateTransactionTableFunc(chainType)})
@@ -80,6 +92,8 @@ func GetMutilchainTables(chainType string) [][2]string {
 	result = append(result, [2]string{fmt.Sprintf("%sblocks_all", chainType), mutilchainquery.CreateBlockAllTableFunc(chainType)})
 	result = append(result, [2]string{fmt.Sprintf("%sblock_chain", chainType), mutilchainquery.CreateBlockPrevNextTableFunc(chainType)})
 	result = append(result, [2]string{fmt.Sprintf("%sfees_stat", chainType), mutilchainquery.CreateFeesStatTableTableFunc(chainType)})
+	result = append(result, [2]string{fmt.Sprintf("%sfee_estimates", chainType), mutilchainquery.CreateFeeEstimatesTableFunc(chainType)})
+	result = append(result, [2]string{fmt.Sprintf("%smempool_ledger", chainType), mutilchainquery.CreateMempoolLedgerTableFunc(chainType)})
 	result = append(result, [2]string{fmt.Sprintf("%smempool_history", chainType), mutilchainquery.CreateMempoolHistoryFunc(chainType)})
 	result = append(result, [2]string{fmt.Sprintf("%snodes", chainType), mutilchainquery.CreateNodesTableFunc(chainType)})
 	result = append(result, [2]string{fmt.Sprintf("%stransactions", chainType), mutilchainquery.CreateTransactionTableFunc(chainType)})
@@ -0,0 +1,623 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAlertRetries is the number of times a failed webhook delivery is
+	// retried before it is abandoned.
+	DefaultAlertRetries = 4
+	// DefaultAlertBackoff is the delay before the first retry of a failed
+	// webhook delivery. The delay doubles on each subsequent retry.
+	DefaultAlertBackoff = 5 * time.Second
+	// DefaultAlertLogSize is the number of delivery attempts retained in the
+	// delivery log.
+	DefaultAlertLogSize = 500
+
+	maxAlertBackoff = 10 * time.Minute
+)
+
+// AlertKind is the type of condition that an AlertRule checks.
+type AlertKind string
+
+const (
+	// AlertPriceCross triggers when the price moves across Threshold. With
+	// Direction "above", the rule fires when the price rises to or above the
+	// threshold, and with "below" when it falls to or below it. An empty
+	// Direction fires on a cross in either direction.
+	AlertPriceCross AlertKind = "price_cross"
+	// AlertPercentChange triggers when the price has changed by at least
+	// Threshold percent over Window. Direction restricts the rule to rises
+	// ("above") or falls ("below").
+	AlertPercentChange AlertKind = "percent_change"
+	// AlertSpread triggers when the percentage difference between the highest
+	// and lowest exchange prices for the chain reaches Threshold.
+	AlertSpread AlertKind = "spread"
+	// AlertVolumeSpike triggers when the aggregate volume reaches Threshold
+	// times its average over Window.
+	AlertVolumeSpike AlertKind = "volume_spike"
+)
+
+// AlertRule is a single user-defined threshold. Rules are evaluated on every
+// exchange and index update received from the ExchangeBot for the rule's
+// chain. A rule fires once when its condition becomes true, and is re-armed
+// when the condition clears and the Cooldown has passed.
+type AlertRule struct {
+	ID        string    `json:"id"`
+	Kind      AlertKind `json:"kind"`
+	ChainType string    `json:"chain"`
+	// Index is the 3-letter fiat currency code that prices are converted to
+	// before comparison. Defaults to the ExchangeBot's BtcIndex.
+	Index     string   `json:"index,omitempty"`
+	Threshold float64  `json:"threshold"`
+	Direction string   `json:"direction,omitempty"`
+	Window    string   `json:"window,omitempty"`
+	Cooldown  string   `json:"cooldown,omitempty"`
+	Webhooks  []string `json:"webhooks"`
+
+	window   time.Duration
+	cooldown time.Duration
+}
+
+// validate checks the rule fields and parses the durations.
+func (rule *AlertRule) validate() error {
+	if rule.ID == "" {
+		return fmt.Errorf("alert rule has no id")
+	}
+	switch rule.ChainType {
+	case TYPEDCR, TYPEBTC, TYPELTC, TYPEXMR:
+	default:
+		return fmt.Errorf("alert rule %s: unknown chain %q", rule.ID, rule.ChainType)
+	}
+	switch rule.Direction {
+	case "", "above", "below":
+	default:
+		return fmt.Errorf("alert rule %s: unknown direction %q", rule.ID, rule.Direction)
+	}
+	if rule.Threshold <= 0 {
+		return fmt.Errorf("alert rule %s: threshold must be positive", rule.ID)
+	}
+	if len(rule.Webhooks) == 0 {
+		return fmt.Errorf("alert rule %s: no webhooks", rule.ID)
+	}
+	switch rule.Kind {
+	case AlertPriceCross, AlertSpread:
+	case AlertPercentChange, AlertVolumeSpike:
+		if rule.Window == "" {
+			return fmt.Errorf("alert rule %s: %s requires a window", rule.ID, rule.Kind)
+		}
+	default:
+		return fmt.Errorf("alert rule %s: unknown kind %q", rule.ID, rule.Kind)
+	}
+	var err error
+	if rule.Window != "" {
+		rule.window, err = time.ParseDuration(rule.Window)
+		if err != nil || rule.window <= 0 {
+			return fmt.Errorf("alert rule %s: invalid window %q", rule.ID, rule.Window)
+		}
+	}
+	if rule.Cooldown != "" {
+		rule.cooldown, err = time.ParseDuration(rule.Cooldown)
+		if err != nil || rule.cooldown < 0 {
+			return fmt.Errorf("alert rule %s: invalid cooldown %q", rule.ID, rule.Cooldown)
+		}
+	}
+	return nil
+}
+
+// LoadAlertRules reads a JSON array of AlertRule from the file at path.
+func LoadAlertRules(path string) ([]*AlertRule, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read alert rules file: %w", err)
+	}
+	var rules []*AlertRule
+	if err = json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("unable to decode alert rules file %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// AlertEvent is the JSON payload posted to a rule's webhooks when it fires.
+type AlertEvent struct {
+	RuleID    string    `json:"rule_id"`
+	Kind      AlertKind `json:"kind"`
+	ChainType string    `json:"chain"`
+	Index     string    `json:"index"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Price     float64   `json:"price"`
+	Message   string    `json:"message"`
+	Time      int64     `json:"time"`
+}
+
+// AlertDelivery is a delivery log entry for a single webhook request. The URL
+// is reduced to its scheme and host, see RedactURL.
+type AlertDelivery struct {
+	RuleID     string `json:"rule_id"`
+	URL        string `json:"url"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Success    bool   `json:"success"`
+	Time       int64  `json:"time"`
+}
+
+// AlertEngineConfig is the configuration for an AlertEngine.
+type AlertEngineConfig struct {
+	Rules []*AlertRule
+	// Client is used for webhook requests. Defaults to a client with a 10
+	// second timeout.
+	Client Doer
+	// Retries is the number of retries after a failed delivery. Defaults to
+	// DefaultAlertRetries. A negative value disables retries.
+	Retries int
+	// Backoff is the initial retry delay. Defaults to DefaultAlertBackoff.
+	Backoff time.Duration
+	// LogSize is the capacity of the delivery log. Defaults to
+	// DefaultAlertLogSize.
+	LogSize int
+}
+
+// A time-stamped value, used to track price and volume history for the
+// windowed rules.
+type alertSample struct {
+	stamp time.Time
+	price float64
+	vol   float64
+}
+
+// The trigger state of a rule.
+type alertRuleState struct {
+	active    bool
+	lastFired time.Time
+}
+
+// AlertEngine evaluates AlertRules against ExchangeBot updates and delivers
+// AlertEvents to webhooks. Make an AlertEngine with NewAlertEngine.
+type AlertEngine struct {
+	bot     *ExchangeBot
+	client  Doer
+	retries int
+	backoff time.Duration
+
+	mtx       sync.RWMutex
+	rules     []*AlertRule
+	ruleState map[string]*alertRuleState
+	samples   map[string][]alertSample
+	maxWindow time.Duration
+
+	logMtx  sync.RWMutex
+	log     []*AlertDelivery
+	logSize int
+
+	wg sync.WaitGroup
+}
+
+// NewAlertEngine validates the rules and constructs a new AlertEngine.
+func NewAlertEngine(bot *ExchangeBot, cfg *AlertEngineConfig) (*AlertEngine, error) {
+	if bot == nil {
+		return nil, fmt.Errorf("no ExchangeBot provided")
+	}
+	engine := &AlertEngine{
+		bot:       bot,
+		client:    cfg.Client,
+		retries:   cfg.Retries,
+		backoff:   cfg.Backoff,
+		ruleState: make(map[string]*alertRuleState),
+		samples:   make(map[string][]alertSample),
+		logSize:   cfg.LogSize,
+	}
+	if engine.client == nil {
+		engine.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if engine.retries == 0 {
+		engine.retries = DefaultAlertRetries
+	} else if engine.retries < 0 {
+		engine.retries = 0
+	}
+	if engine.backoff <= 0 {
+		engine.backoff = DefaultAlertBackoff
+	}
+	if engine.logSize <= 0 {
+		engine.logSize = DefaultAlertLogSize
+	}
+	for _, rule := range cfg.Rules {
+		if err := engine.AddRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	return engine, nil
+}
+
+// AddRule validates and adds a rule. Rule IDs must be unique.
+func (engine *AlertEngine) AddRule(rule *AlertRule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	engine.mtx.Lock()
+	defer engine.mtx.Unlock()
+	if _, found := engine.ruleState[rule.ID]; found {
+		return fmt.Errorf("duplicate alert rule id %s", rule.ID)
+	}
+	engine.rules = append(engine.rules, rule)
+	engine.ruleState[rule.ID] = new(alertRuleState)
+	if rule.window > engine.maxWindow {
+		engine.maxWindow = rule.window
+	}
+	return nil
+}
+
+// RemoveRule removes the rule with the given ID, if it exists.
+func (engine *AlertEngine) RemoveRule(id string) bool {
+	engine.mtx.Lock()
+	defer engine.mtx.Unlock()
+	for i, rule := range engine.rules {
+		if rule.ID == id {
+			engine.rules = append(engine.rules[:i], engine.rules[i+1:]...)
+			delete(engine.ruleState, id)
+			return true
+		}
+	}
+	return false
+}
+
+// Rules is a copy of the current rule set, with the webhook URLs reduced to
+// their scheme and host by RedactURL.
+func (engine *AlertEngine) Rules() []AlertRule {
+	engine.mtx.RLock()
+	defer engine.mtx.RUnlock()
+	rules := make([]AlertRule, 0, len(engine.rules))
+	for _, rule := range engine.rules {
+		r := *rule
+		r.Webhooks = make([]string, len(rule.Webhooks))
+		for i, hook := range rule.Webhooks {
+			r.Webhooks[i] = RedactURL(hook)
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// RedactURL reduces a webhook URL to its scheme and host. The user info, path
+// and query of a webhook URL often carry its secret token, e.g. the Slack and
+// Discord webhook URLs.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "[redacted]"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// redactError is the message of a delivery error without the URL of the
+// request, which an *url.Error would include.
+func redactError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Sprintf("%s: %v", urlErr.Op, urlErr.Err)
+	}
+	return err.Error()
+}
+
+// Deliveries is a copy of the delivery log, most recent attempt last.
+func (engine *AlertEngine) Deliveries() []AlertDelivery {
+	engine.logMtx.RLock()
+	defer engine.logMtx.RUnlock()
+	deliveries := make([]AlertDelivery, 0, len(engine.log))
+	for _, d := range engine.log {
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries
+}
+
+// Run is the AlertEngine loop. It evaluates the rules on every update from
+// the ExchangeBot until the context is cancelled or the bot quits. Pending
+// deliveries are abandoned on shutdown.
+func (engine *AlertEngine) Run(ctx context.Context, wg *sync.WaitGroup) {
+	if wg != nil {
+		defer wg.Done()
+	}
+	xcChans := engine.bot.UpdateChannels()
+	for {
+		select {
+		case update := <-xcChans.Exchange:
+			engine.evaluate(ctx, engine.bot.State(), chainTypeForSymbol(update.State.Symbol), time.Now())
+		case <-xcChans.Index:
+			// Index updates change the fiat conversion for every chain.
+			state := engine.bot.State()
+			now := time.Now()
+			for _, chainType := range []string{TYPEDCR, TYPEBTC, TYPELTC, TYPEXMR} {
+				engine.evaluate(ctx, state, chainType, now)
+			}
+		case <-xcChans.Quit:
+			log.Warnf("ExchangeBot has quit. Stopping alert engine.")
+			engine.wg.Wait()
+			return
+		case <-ctx.Done():
+			engine.wg.Wait()
+			return
+		}
+	}
+}
+
+// chainTypeForSymbol is the chain type for an ExchangeState Symbol.
+func chainTypeForSymbol(symbol string) string {
+	switch symbol {
+	case BTCSYMBOL:
+		return TYPEBTC
+	case LTCSYMBOL:
+		return TYPELTC
+	case XMRSYMBOL:
+		return TYPEXMR
+	default:
+		return TYPEDCR
+	}
+}
+
+// chainSpread is the percentage difference between the highest and lowest
+// exchange prices for the chain. DCR-BTC markets are priced in BTC and are
+// skipped.
+func chainSpread(state *ExchangeBotState, chainType string) float64 {
+	low, high := math.MaxFloat64, 0.0
+	for token, xcState := range state.GetMutilchainExchangeState(chainType) {
+		if chainType == TYPEDCR && IsDCRBTCExchange(token) {
+			continue
+		}
+		if xcState.Price <= 0 {
+			continue
+		}
+		low = math.Min(low, xcState.Price)
+		high = math.Max(high, xcState.Price)
+	}
+	if high == 0 || low == high {
+		return 0
+	}
+	return (high - low) / low * 100
+}
+
+// record adds a sample to the chain's history and drops samples older than
+// the longest rule window. The previous sample is always kept for the price
+// cross rules. The caller must hold the engine mutex.
+func (engine *AlertEngine) record(chainType string, sample alertSample) []alertSample {
+	samples := append(engine.samples[chainType], sample)
+	cutoff := sample.stamp.Add(-engine.maxWindow)
+	var i int
+	for i < len(samples)-2 && samples[i].stamp.Before(cutoff) {
+		i++
+	}
+	samples = samples[i:]
+	engine.samples[chainType] = samples
+	return samples
+}
+
+// checkRule evaluates a single rule against the state, returning the value
+// that was compared with the threshold and whether the condition is met.
+func checkRule(rule *AlertRule, state *ExchangeBotState, samples []alertSample, factor float64, now time.Time) (float64, bool) {
+	price := state.GetMutilchainPrice(rule.ChainType) * factor
+	switch rule.Kind {
+	case AlertPriceCross:
+		switch rule.Direction {
+		case "above":
+			return price, price >= rule.Threshold
+		case "below":
+			return price, price <= rule.Threshold
+		}
+		// Either direction. Compare with the previous sample.
+		if len(samples) < 2 {
+			return price, false
+		}
+		prev := samples[len(samples)-2].price * factor
+		return price, (prev < rule.Threshold) != (price < rule.Threshold)
+	case AlertPercentChange:
+		// The oldest sample inside the window is the reference price.
+		cutoff := now.Add(-rule.window)
+		var ref float64
+		for _, s := range samples {
+			if !s.stamp.Before(cutoff) {
+				ref = s.price
+				break
+			}
+		}
+		if ref == 0 {
+			return 0, false
+		}
+		change := (state.GetMutilchainPrice(rule.ChainType) - ref) / ref * 100
+		switch rule.Direction {
+		case "above":
+			return change, change >= rule.Threshold
+		case "below":
+			return change, -change >= rule.Threshold
+		}
+		return change, math.Abs(change) >= rule.Threshold
+	case AlertSpread:
+		spread := chainSpread(state, rule.ChainType)
+		return spread, spread >= rule.Threshold
+	case AlertVolumeSpike:
+		// Average the samples in the window, not counting the newest.
+		cutoff := now.Add(-rule.window)
+		var sum float64
+		var n int
+		for _, s := range samples[:len(samples)-1] {
+			if s.stamp.Before(cutoff) {
+				continue
+			}
+			sum += s.vol
+			n++
+		}
+		if n == 0 || sum == 0 {
+			return 0, false
+		}
+		ratio := state.GetMutilchainVolumn(rule.ChainType) / (sum / float64(n))
+		return ratio, ratio >= rule.Threshold
+	}
+	return 0, false
+}
+
+// evaluate checks all rules for the chain against the state and dispatches
+// webhook deliveries for the rules that fire.
+func (engine *AlertEngine) evaluate(ctx context.Context, state *ExchangeBotState, chainType string, now time.Time) {
+	if state == nil || state.GetMutilchainPrice(chainType) == 0 {
+		return
+	}
+	var events []*AlertEvent
+	var hooks [][]string
+	engine.mtx.Lock()
+	samples := engine.record(chainType, alertSample{
+		stamp: now,
+		price: state.GetMutilchainPrice(chainType),
+		vol:   state.GetMutilchainVolumn(chainType),
+	})
+	for _, rule := range engine.rules {
+		if rule.ChainType != chainType {
+			continue
+		}
+		index := rule.Index
+		if index == "" {
+			index = state.BtcIndex
+		}
+		factor := 1.0
+		if index != state.BtcIndex {
+			var err error
+			factor, err = engine.bot.FiatConversion(index)
+			if err != nil {
+				log.Debugf("Alert rule %s: %v", rule.ID, err)
+				continue
+			}
+		}
+		value, met := checkRule(rule, state, samples, factor, now)
+		ruleState := engine.ruleState[rule.ID]
+		if !met {
+			ruleState.active = false
+			continue
+		}
+		if ruleState.active || now.Sub(ruleState.lastFired) < rule.cooldown {
+			continue
+		}
+		ruleState.active = true
+		ruleState.lastFired = now
+		events = append(events, &AlertEvent{
+			RuleID:    rule.ID,
+			Kind:      rule.Kind,
+			ChainType: chainType,
+			Index:     index,
+			Value:     value,
+			Threshold: rule.Threshold,
+			Price:     state.GetMutilchainPrice(chainType) * factor,
+			Message:   alertMessage(rule, value, index),
+			Time:      now.Unix(),
+		})
+		hooks = append(hooks, rule.Webhooks)
+	}
+	engine.mtx.Unlock()
+
+	for i, event := range events {
+		log.Infof("Alert %s: %s", event.RuleID, event.Message)
+		for _, url := range hooks[i] {
+			engine.wg.Add(1)
+			go engine.deliver(ctx, url, event)
+		}
+	}
+}
+
+// alertMessage is a human-readable description of a triggered rule.
+func alertMessage(rule *AlertRule, value float64, index string) string {
+	switch rule.Kind {
+	case AlertPriceCross:
+		return fmt.Sprintf("%s price %.4f %s crossed %.4f", rule.ChainType, value, index, rule.Threshold)
+	case AlertPercentChange:
+		return fmt.Sprintf("%s price changed %.2f%% over %s", rule.ChainType, value, rule.window)
+	case AlertSpread:
+		return fmt.Sprintf("%s exchange spread is %.2f%%", rule.ChainType, value)
+	case AlertVolumeSpike:
+		return fmt.Sprintf("%s volume is %.2fx the %s average", rule.ChainType, value, rule.window)
+	}
+	return string(rule.Kind)
+}
+
+// deliver posts the event to the webhook URL, retrying with exponential
+// backoff on failure. Every attempt is recorded in the delivery log.
+func (engine *AlertEngine) deliver(ctx context.Context, url string, event *AlertEvent) {
+	defer engine.wg.Done()
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("Failed to encode alert event: %v", err)
+		return
+	}
+	delay := engine.backoff
+	for attempt := 1; ; attempt++ {
+		entry := &AlertDelivery{
+			RuleID:  event.RuleID,
+			URL:     RedactURL(url),
+			Attempt: attempt,
+			Time:    time.Now().Unix(),
+		}
+		entry.StatusCode, err = engine.post(ctx, url, body)
+		if err == nil {
+			entry.Success = true
+		} else {
+			entry.Error = redactError(err)
+		}
+		engine.logDelivery(entry)
+		if entry.Success {
+			return
+		}
+		if attempt > engine.retries {
+			log.Errorf("Alert %s delivery to %s failed after %d attempts: %v", event.RuleID, url, attempt, err)
+			return
+		}
+		log.Debugf("Alert %s delivery to %s failed. Retrying in %s: %v", event.RuleID, url, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay *= 2
+		if delay > maxAlertBackoff {
+			delay = maxAlertBackoff
+		}
+	}
+}
+
+// post sends a single webhook request. Any non-2xx response is an error.
+func (engine *AlertEngine) post(ctx context.Context, url string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := engine.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// logDelivery appends to the delivery log, discarding the oldest entries
+// beyond the log size.
+func (engine *AlertEngine) logDelivery(entry *AlertDelivery) {
+	engine.logMtx.Lock()
+	defer engine.logMtx.Unlock()
+	engine.log = append(engine.log, entry)
+	if len(engine.log) > engine.logSize {
+		engine.log = engine.log[len(engine.log)-engine.logSize:]
+	}
+}
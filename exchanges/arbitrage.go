@@ -0,0 +1,229 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// QuoteUSDT is the quote asset of the USD-pegged markets.
+	QuoteUSDT = "usdt"
+	// QuoteBTC is the quote asset of the DCR-BTC markets.
+	QuoteBTC = "btc"
+	// AggregatedToken is the token of the aggregated order book of the
+	// USD-pegged markets.
+	AggregatedToken = aggregatedOrderbookKey
+)
+
+// BookTop is the best bid and ask of a single exchange's order book.
+type BookTop struct {
+	Token       string  `json:"token"`
+	BestBid     float64 `json:"best_bid"`
+	BidQuantity float64 `json:"bid_quantity"`
+	BestAsk     float64 `json:"best_ask"`
+	AskQuantity float64 `json:"ask_quantity"`
+	// Spread is the exchange's bid-ask spread, as a percentage of the best
+	// bid.
+	Spread float64 `json:"spread"`
+	Time   int64   `json:"time"`
+}
+
+// ArbitrageTrade is the result of buying Size on the BuyExchange and selling
+// it on the SellExchange, walking both order books. If either book is too
+// thin, Filled will be less than Size.
+type ArbitrageTrade struct {
+	BuyExchange   string  `json:"buy_exchange"`
+	SellExchange  string  `json:"sell_exchange"`
+	Size          float64 `json:"size"`
+	Filled        float64 `json:"filled"`
+	Cost          float64 `json:"cost"`
+	Proceeds      float64 `json:"proceeds"`
+	AvgBuyPrice   float64 `json:"avg_buy_price"`
+	AvgSellPrice  float64 `json:"avg_sell_price"`
+	Profit        float64 `json:"profit"`
+	ProfitPercent float64 `json:"profit_percent"`
+}
+
+// ArbitrageView compares the order books of every exchange with fresh depth
+// data for a chain and quote asset. Prices are in units of the quote asset.
+type ArbitrageView struct {
+	ChainType string     `json:"chain"`
+	Quote     string     `json:"quote"`
+	Books     []*BookTop `json:"books"`
+	// BestBid is the highest bid across all exchanges, and BestAsk the lowest
+	// ask.
+	BestBid         float64 `json:"best_bid"`
+	BestBidExchange string  `json:"best_bid_exchange"`
+	BestAsk         float64 `json:"best_ask"`
+	BestAskExchange string  `json:"best_ask_exchange"`
+	// Spread is the cross-exchange spread, (BestBid - BestAsk) / BestAsk, as
+	// a percentage. A positive spread means an arbitrage opportunity.
+	Spread float64 `json:"spread"`
+	// Trades are the executable trades of the requested size for every
+	// exchange pair where the best bid on one exchange is higher than the best
+	// ask on another, most profitable first. If there are no such pairs, the
+	// trade between the BestAskExchange and BestBidExchange is reported.
+	Trades []*ArbitrageTrade `json:"trades"`
+	Time   int64             `json:"time"`
+}
+
+// BookQuote is the quote asset of the exchange's market for the chain.
+func BookQuote(chainType, token string) string {
+	if chainType == TYPEDCR && IsDCRBTCExchange(token) {
+		return QuoteBTC
+	}
+	return QuoteUSDT
+}
+
+// walkBook fills up to size from the price-ordered depth points, returning
+// the quantity filled and its total value in the quote asset.
+func walkBook(pts []DepthPoint, size float64) (filled, value float64) {
+	for _, pt := range pts {
+		if filled >= size {
+			break
+		}
+		qty := pt.Quantity
+		if filled+qty > size {
+			qty = size - filled
+		}
+		filled += qty
+		value += qty * pt.Price
+	}
+	return
+}
+
+// arbitrageTrade buys size from the asks of buy and sells it into the bids of
+// sell.
+func arbitrageTrade(buyToken string, buy *DepthData, sellToken string, sell *DepthData, size float64) *ArbitrageTrade {
+	// Only trade the size that both books can fill.
+	bought, _ := walkBook(buy.Asks, size)
+	sold, _ := walkBook(sell.Bids, size)
+	filled := bought
+	if sold < filled {
+		filled = sold
+	}
+	_, cost := walkBook(buy.Asks, filled)
+	_, proceeds := walkBook(sell.Bids, filled)
+	trade := &ArbitrageTrade{
+		BuyExchange:  buyToken,
+		SellExchange: sellToken,
+		Size:         size,
+		Filled:       filled,
+		Cost:         cost,
+		Proceeds:     proceeds,
+		Profit:       proceeds - cost,
+	}
+	if filled > 0 {
+		trade.AvgBuyPrice = cost / filled
+		trade.AvgSellPrice = proceeds / filled
+	}
+	if cost > 0 {
+		trade.ProfitPercent = trade.Profit / cost * 100
+	}
+	return trade
+}
+
+// arbitrageView builds the ArbitrageView from the exchange states. Exchanges
+// with stale or one-sided depth data are skipped.
+func arbitrageView(chainType, quote string, states map[string]*ExchangeState, size float64) *ArbitrageView {
+	view := &ArbitrageView{
+		ChainType: chainType,
+		Quote:     quote,
+		Books:     make([]*BookTop, 0),
+		Trades:    make([]*ArbitrageTrade, 0),
+	}
+	depths := make(map[string]*DepthData)
+	for token, state := range states {
+		if BookQuote(chainType, token) != quote || !state.HasDepth() {
+			continue
+		}
+		depth := state.Depth
+		if !depth.IsFresh() || len(depth.Bids) == 0 || len(depth.Asks) == 0 {
+			continue
+		}
+		depths[token] = depth
+		bid, ask := depth.Bids[0], depth.Asks[0]
+		view.Books = append(view.Books, &BookTop{
+			Token:       token,
+			BestBid:     bid.Price,
+			BidQuantity: bid.Quantity,
+			BestAsk:     ask.Price,
+			AskQuantity: ask.Quantity,
+			Spread:      (ask.Price - bid.Price) / bid.Price * 100,
+			Time:        depth.Time,
+		})
+		if depth.Time > view.Time {
+			view.Time = depth.Time
+		}
+	}
+	if len(view.Books) == 0 {
+		return view
+	}
+	sort.Slice(view.Books, func(i, j int) bool {
+		return view.Books[i].Token < view.Books[j].Token
+	})
+
+	for _, book := range view.Books {
+		if book.BestBid > view.BestBid {
+			view.BestBid = book.BestBid
+			view.BestBidExchange = book.Token
+		}
+		if view.BestAsk == 0 || book.BestAsk < view.BestAsk {
+			view.BestAsk = book.BestAsk
+			view.BestAskExchange = book.Token
+		}
+	}
+	view.Spread = (view.BestBid - view.BestAsk) / view.BestAsk * 100
+
+	if size <= 0 {
+		return view
+	}
+	for _, buy := range view.Books {
+		for _, sell := range view.Books {
+			if buy == sell || sell.BestBid <= buy.BestAsk {
+				continue
+			}
+			view.Trades = append(view.Trades, arbitrageTrade(buy.Token, depths[buy.Token], sell.Token, depths[sell.Token], size))
+		}
+	}
+	if len(view.Trades) == 0 && view.BestAskExchange != view.BestBidExchange {
+		view.Trades = append(view.Trades, arbitrageTrade(view.BestAskExchange, depths[view.BestAskExchange],
+			view.BestBidExchange, depths[view.BestBidExchange], size))
+	}
+	sort.Slice(view.Trades, func(i, j int) bool {
+		return view.Trades[i].Profit > view.Trades[j].Profit
+	})
+	return view
+}
+
+// Arbitrage compares the best bid and ask of every exchange for the chain and
+// quote asset, and calculates the profit of buying size on one exchange and
+// selling on another. The quote asset is QuoteUSDT for every chain, or
+// QuoteBTC for the DCR-BTC markets.
+func (bot *ExchangeBot) Arbitrage(chainType, quote string, size float64) (*ArbitrageView, error) {
+	switch chainType {
+	case TYPEDCR:
+		if quote != QuoteUSDT && quote != QuoteBTC {
+			return nil, fmt.Errorf("unknown quote asset %s for %s", quote, chainType)
+		}
+	case TYPEBTC, TYPELTC, TYPEXMR:
+		if quote != QuoteUSDT {
+			return nil, fmt.Errorf("unknown quote asset %s for %s", quote, chainType)
+		}
+	default:
+		return nil, fmt.Errorf("unknown chain type %s", chainType)
+	}
+	state := bot.State()
+	if state == nil {
+		return nil, fmt.Errorf("no exchange data available")
+	}
+	view := arbitrageView(chainType, quote, state.GetMutilchainExchangeState(chainType), size)
+	if view.Time == 0 {
+		view.Time = time.Now().Unix()
+	}
+	return view, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func testDepthState(bids, asks []DepthPoint) *ExchangeState {
+	return &ExchangeState{
+		Depth: &DepthData{
+			Time: time.Now().Unix(),
+			Bids: bids,
+			Asks: asks,
+		},
+	}
+}
+
+func TestArbitrageView(t *testing.T) {
+	states := map[string]*ExchangeState{
+		// Cheapest asks.
+		"binance": testDepthState(
+			[]DepthPoint{{Quantity: 1, Price: 99}, {Quantity: 5, Price: 98}},
+			[]DepthPoint{{Quantity: 1, Price: 100}, {Quantity: 2, Price: 101}},
+		),
+		// Highest bids.
+		"kucoin": testDepthState(
+			[]DepthPoint{{Quantity: 2, Price: 103}, {Quantity: 5, Price: 100}},
+			[]DepthPoint{{Quantity: 3, Price: 104}},
+		),
+		// Stale books are skipped.
+		"coinex": {Depth: &DepthData{
+			Time: time.Now().Add(-2 * time.Hour).Unix(),
+			Bids: []DepthPoint{{Quantity: 1, Price: 200}},
+			Asks: []DepthPoint{{Quantity: 1, Price: 201}},
+		}},
+		// DCR-BTC market.
+		"btc_binance": testDepthState(
+			[]DepthPoint{{Quantity: 1, Price: 0.0002}},
+			[]DepthPoint{{Quantity: 1, Price: 0.00021}},
+		),
+	}
+
+	view := arbitrageView(TYPEDCR, QuoteUSDT, states, 3)
+	if len(view.Books) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(view.Books))
+	}
+	if view.Books[0].Token != "binance" || view.Books[1].Token != "kucoin" {
+		t.Fatalf("unexpected book order %s, %s", view.Books[0].Token, view.Books[1].Token)
+	}
+	if view.BestBidExchange != "kucoin" || view.BestBid != 103 {
+		t.Fatalf("wrong best bid %s %f", view.BestBidExchange, view.BestBid)
+	}
+	if view.BestAskExchange != "binance" || view.BestAsk != 100 {
+		t.Fatalf("wrong best ask %s %f", view.BestAskExchange, view.BestAsk)
+	}
+	if math.Abs(view.Spread-3) > 1e-9 {
+		t.Fatalf("wrong spread %f", view.Spread)
+	}
+	if len(view.Trades) != 1 {
+		t.Fatalf("expected 1 trade, got %d", len(view.Trades))
+	}
+	// Buy 1 @ 100 + 2 @ 101, sell 2 @ 103 + 1 @ 100.
+	trade := view.Trades[0]
+	if trade.Filled != 3 || trade.Cost != 302 || trade.Proceeds != 306 || trade.Profit != 4 {
+		t.Fatalf("unexpected trade %+v", trade)
+	}
+
+	// The binance asks can only fill 3.
+	trade = arbitrageView(TYPEDCR, QuoteUSDT, states, 10).Trades[0]
+	if trade.Filled != 3 || trade.Size != 10 {
+		t.Fatalf("unexpected partial trade %+v", trade)
+	}
+
+	view = arbitrageView(TYPEDCR, QuoteBTC, states, 1)
+	if len(view.Books) != 1 || view.Books[0].Token != "btc_binance" {
+		t.Fatalf("wrong books for BTC quote")
+	}
+	if len(view.Trades) != 0 {
+		t.Fatalf("expected no trades for a single exchange")
+	}
+}
+
+func TestArbitrageNoOpportunity(t *testing.T) {
+	states := map[string]*ExchangeState{
+		"binance": testDepthState(
+			[]DepthPoint{{Quantity: 1, Price: 99}},
+			[]DepthPoint{{Quantity: 1, Price: 100}},
+		),
+		"kucoin": testDepthState(
+			[]DepthPoint{{Quantity: 1, Price: 99.5}},
+			[]DepthPoint{{Quantity: 1, Price: 101}},
+		),
+	}
+	view := arbitrageView(TYPEBTC, QuoteUSDT, states, 1)
+	if view.Spread >= 0 {
+		t.Fatalf("expected negative spread, got %f", view.Spread)
+	}
+	// The best pair is still reported, at a loss.
+	if len(view.Trades) != 1 || view.Trades[0].Profit != -0.5 {
+		t.Fatalf("unexpected trades %+v", view.Trades)
+	}
+	if view.Trades[0].BuyExchange != "binance" || view.Trades[0].SellExchange != "kucoin" {
+		t.Fatalf("wrong exchanges in trade %+v", view.Trades[0])
+	}
+}
@@ -34,6 +34,9 @@ const (
 
 	defaultDCRRatesPort = "7778"
 
+	// marketIndex is the currency of the prices of the USD-pegged markets.
+	marketIndex = "USD"
+
 	aggregatedOrderbookKey    = "aggregated"
 	aggregatedBTCOrderbookKey = "btc_aggregated"
 	orderbookKey              = "depth"
@@ -63,6 +66,14 @@ type ExchangeBotConfig struct {
 	MasterBot      string
 	MasterCertFile string
 	BinanceAPIURL  string
+	// IndexPriority is the order in which the BTC index sources are tried.
+	// Defaults to DefaultIndexPriority.
+	IndexPriority []string
+	// IndexMode is IndexModePriority (the default) or IndexModeMedian.
+	IndexMode string
+	// IndexExpiry is the age after which an index source's price for a
+	// currency is no longer used. Defaults to RequestExpiry.
+	IndexExpiry string
 }
 
 // ExchangeBot monitors exchanges and processes updates. When an update is
@@ -85,12 +96,19 @@ type ExchangeBot struct {
 	// converted by default. Other conversions are available via a lookup in
 	// indexMap, but with slightly lower performance.
 	// 3-letter currency code, e.g. USD.
-	BtcIndex     string
-	BTCIndex     string
-	LTCIndex     string
-	XMRIndex     string
-	indexMap     map[string]FiatIndices
-	currentState ExchangeBotState
+	BtcIndex string
+	BTCIndex string
+	// LTCIndex and XMRIndex are the currencies of the LTC and XMR prices,
+	// which are converted from the USD-pegged markets with the index sources.
+	LTCIndex string
+	XMRIndex string
+	indexMap map[string]FiatIndices
+	// indexStamps is when each index source last reported each currency.
+	indexStamps   map[string]map[string]time.Time
+	indexPriority []string
+	indexMode     string
+	indexExpiry   time.Duration
+	currentState  ExchangeBotState
 	// Both currentState and stateCopy hold the same information. currentState
 	// is updated by ExchangeBot, and a copy stored in stateCopy. After creation,
 	// stateCopy will never be updated, so can be used read-only by multiple
@@ -122,6 +140,8 @@ type ExchangeBot struct {
 // base currency, and a volume-averaged price and total volume in DCR.
 type ExchangeBotState struct {
 	BtcIndex          string  `json:"btc_index"`
+	LTCIndex          string  `json:"ltc_index"`
+	XMRIndex          string  `json:"xmr_index"`
 	BtcPrice          float64 `json:"btc_fiat_price"`
 	Price             float64 `json:"price"`
 	DCRUSD24hChange   float64 `json:"dcr_usd_24h_change"`
@@ -154,7 +174,9 @@ type ExchangeBotState struct {
 	XmrUsd map[string]*ExchangeState `json:"xmr_usd_exchanges"`
 	// FiatIndices:
 	// TODO: We only really need the BaseState for the fiat indices.
-	FiatIndices     map[string]*ExchangeState `json:"btc_indices"`
+	FiatIndices map[string]*ExchangeState `json:"btc_indices"`
+	// IndexSources are the BTC index sources that backed BtcPrice.
+	IndexSources    []string `json:"index_sources"`
 	VolumnOrdered   []*TokenedExchange
 	ExchangeOrdered []*TokenedExchange
 }
@@ -464,6 +486,40 @@ func NewExchangeBot(config *ExchangeBotConfig) (*ExchangeBot, error) {
 	if config.BtcIndex == "" {
 		config.BtcIndex = DefaultCurrency
 	}
+	if config.LTCIndex == "" {
+		config.LTCIndex = DefaultCurrency
+	}
+	if config.XmrIndex == "" {
+		config.XmrIndex = DefaultCurrency
+	}
+	indexExpiry := requestExpiry
+	if config.IndexExpiry != "" {
+		indexExpiry, err = time.ParseDuration(config.IndexExpiry)
+		if err != nil || indexExpiry <= 0 {
+			return nil, fmt.Errorf("Unable to parse index expiration from %s", config.IndexExpiry)
+		}
+	}
+	switch config.IndexMode {
+	case "":
+		config.IndexMode = IndexModePriority
+	case IndexModePriority, IndexModeMedian:
+	default:
+		return nil, fmt.Errorf("Unknown index mode %q", config.IndexMode)
+	}
+	if len(config.IndexPriority) == 0 {
+		config.IndexPriority = DefaultIndexPriority
+	}
+	priority := make([]string, 0, len(config.IndexPriority))
+	for _, token := range config.IndexPriority {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		priority = append(priority, token)
+		if !IsBtcIndex(token) {
+			return nil, fmt.Errorf("Unknown BTC index source %q", token)
+		}
+	}
 
 	bot := &ExchangeBot{
 		DcrBtcExchanges: make(map[string]Exchange),
@@ -478,9 +534,17 @@ func NewExchangeBot(config *ExchangeBotConfig) (*ExchangeBot, error) {
 		versionedCharts: make(map[string]*versionedChart),
 		chartVersions:   make(map[string]int),
 		BtcIndex:        config.BtcIndex,
+		LTCIndex:        config.LTCIndex,
+		XMRIndex:        config.XmrIndex,
 		indexMap:        make(map[string]FiatIndices),
+		indexStamps:     make(map[string]map[string]time.Time),
+		indexPriority:   priority,
+		indexMode:       config.IndexMode,
+		indexExpiry:     indexExpiry,
 		currentState: ExchangeBotState{
 			BtcIndex:    config.BtcIndex,
+			LTCIndex:    config.LTCIndex,
+			XMRIndex:    config.XmrIndex,
 			Price:       0,
 			LTCPrice:    0,
 			BTCPrice:    0,
@@ -917,27 +981,25 @@ func (bot *ExchangeBot) State() *ExchangeBotState {
 func (bot *ExchangeBot) ConvertedState(code string) (*ExchangeBotState, error) {
 	bot.mtx.RLock()
 	defer bot.mtx.RUnlock()
-	fiatIndices := make(map[string]*ExchangeState)
-	for token, indices := range bot.indexMap {
-		for symbol, price := range indices {
-			if symbol == code {
-				fiatIndices[token] = &ExchangeState{BaseState: BaseState{Price: price}}
-			}
-		}
-	}
+	fiatIndices := bot.fiatIndexStates(code)
 
 	dcrPrice, dcrChange, volume, low, high := bot.processState(bot.currentState.DcrBtc, true)
 	dcrBtcPrice, dcrBtcChange, dcrBtcvolume := bot.processDCRBTCState(bot.currentState.DcrBtc, true)
 	ltcPrice, ltcChange, ltcVolumn, ltcLow, ltcHigh := bot.processMutilchainState(bot.currentState.LtcUsd, bot.LTCExchanges, true)
 	btcExchangePrice, btcUsdChange, btcVolumn, btcLow, btcHigh := bot.processMutilchainState(bot.currentState.BtcUsd, bot.BTCExchanges, true)
 	xmrPrice, xmrChange, xmrVolumn, xmrLow, xmrHigh := bot.processMutilchainState(bot.currentState.XmrUsd, bot.XMRExchanges, true)
-	btcPrice, _, _, _, _ := bot.processState(fiatIndices, false)
-	if dcrPrice == 0 || btcPrice == 0 {
+	btcPrice, indexSources := bot.indexPrice(code)
+	factor, _ := bot.crossRate(marketIndex, code)
+	if dcrPrice == 0 || btcPrice == 0 || factor == 0 {
 		bot.failed = true
 		return nil, fmt.Errorf("Unable to process price for currency %s", code)
 	}
+	ltcPrice, ltcLow, ltcHigh = ltcPrice*factor, ltcLow*factor, ltcHigh*factor
+	xmrPrice, xmrLow, xmrHigh = xmrPrice*factor, xmrLow*factor, xmrHigh*factor
 	state := ExchangeBotState{
 		BtcIndex:          code,
+		LTCIndex:          code,
+		XMRIndex:          code,
 		Volume:            volume * btcPrice,
 		Price:             dcrPrice,
 		LowPrice:          low,
@@ -962,6 +1024,7 @@ func (bot *ExchangeBot) ConvertedState(code string) (*ExchangeBotState, error) {
 		XmrUsd:            bot.currentState.XmrUsd,
 		BTCUSDPriceChange: btcUsdChange,
 		FiatIndices:       fiatIndices,
+		IndexSources:      indexSources,
 		DCRBTCPrice:       dcrBtcPrice,
 		DCRBTCVolume:      dcrBtcvolume,
 		DCRUSD24hChange:   dcrChange,
@@ -972,12 +1035,15 @@ func (bot *ExchangeBot) ConvertedState(code string) (*ExchangeBotState, error) {
 	return state.copy(), nil
 }
 
-// ExchangeRates is the dcr and btc prices converted to fiat.
+// ExchangeRates is the dcr and btc prices converted to fiat. IndexSources are
+// the BTC index sources that backed BtcPrice, combined according to IndexMode.
 type ExchangeRates struct {
-	BtcIndex  string               `json:"btcIndex"`
-	DcrPrice  float64              `json:"dcrPrice"`
-	BtcPrice  float64              `json:"btcPrice"`
-	Exchanges map[string]BaseState `json:"exchanges"`
+	BtcIndex     string               `json:"btcIndex"`
+	DcrPrice     float64              `json:"dcrPrice"`
+	BtcPrice     float64              `json:"btcPrice"`
+	IndexSources []string             `json:"indexSources"`
+	IndexMode    string               `json:"indexMode"`
+	Exchanges    map[string]BaseState `json:"exchanges"`
 }
 
 // Rates is the current exchange rates for dcr and btc.
@@ -992,10 +1058,12 @@ func (bot *ExchangeBot) Rates() *ExchangeRates {
 	}
 
 	return &ExchangeRates{
-		BtcIndex:  s.BtcIndex,
-		DcrPrice:  s.Price,
-		BtcPrice:  s.BtcPrice,
-		Exchanges: xcs,
+		BtcIndex:     s.BtcIndex,
+		DcrPrice:     s.Price,
+		BtcPrice:     s.BtcPrice,
+		IndexSources: s.IndexSources,
+		IndexMode:    bot.indexMode,
+		Exchanges:    xcs,
 	}
 }
 
@@ -1004,25 +1072,19 @@ func (bot *ExchangeBot) Rates() *ExchangeRates {
 func (bot *ExchangeBot) ConvertedRates(code string) (*ExchangeRates, error) {
 	bot.mtx.RLock()
 	defer bot.mtx.RUnlock()
-	fiatIndices := make(map[string]*ExchangeState)
-	for token, indices := range bot.indexMap {
-		for symbol, price := range indices {
-			if symbol == code {
-				fiatIndices[token] = &ExchangeState{BaseState: BaseState{Price: price}}
-			}
-		}
-	}
 
 	dcrPrice, _, _, _, _ := bot.processState(bot.currentState.DcrBtc, true)
-	btcPrice, _, _, _, _ := bot.processState(fiatIndices, false)
+	btcPrice, indexSources := bot.indexPrice(code)
 	if dcrPrice == 0 || btcPrice == 0 {
 		bot.failed = true
 		return nil, fmt.Errorf("Unable to process price for currency %s", code)
 	}
 	return &ExchangeRates{
-		BtcIndex: code,
-		DcrPrice: dcrPrice,
-		BtcPrice: btcPrice,
+		BtcIndex:     code,
+		DcrPrice:     dcrPrice,
+		BtcPrice:     btcPrice,
+		IndexSources: indexSources,
+		IndexMode:    bot.indexMode,
 	}, nil
 }
 
@@ -1084,6 +1146,19 @@ func (bot *ExchangeBot) Indices(token string) FiatIndices {
 	return indices
 }
 
+// FiatConversion is the factor that converts a price in the bot's BtcIndex
+// currency to the given currency code, from the index sources that quote both
+// currencies.
+func (bot *ExchangeBot) FiatConversion(code string) (float64, error) {
+	bot.mtx.RLock()
+	defer bot.mtx.RUnlock()
+	factor, _ := bot.crossRate(bot.BtcIndex, code)
+	if factor == 0 {
+		return 0, fmt.Errorf("no index data for currency %s", code)
+	}
+	return factor, nil
+}
+
 func (bot *ExchangeBot) incrementChart(chartId string) {
 	_, found := bot.chartVersions[chartId]
 	if found {
@@ -1248,15 +1323,8 @@ func (bot *ExchangeBot) updateExchange(update *ExchangeUpdate) error {
 func (bot *ExchangeBot) updateIndices(update *IndexUpdate) error {
 	bot.mtx.Lock()
 	defer bot.mtx.Unlock()
-	bot.indexMap[update.Token] = update.Indices
-	price, hasCode := update.Indices[bot.config.BtcIndex]
-	if hasCode {
-		bot.currentState.FiatIndices[update.Token] = &ExchangeState{
-			BaseState: BaseState{
-				Price: price,
-				Stamp: time.Now().Unix(),
-			},
-		}
+	bot.setIndices(update.Token, update.Indices, time.Now())
+	if _, hasCode := update.Indices[bot.config.BtcIndex]; hasCode {
 		return bot.updateState()
 	}
 	log.Warnf("Default currency code, %s, not contained in update from %s", bot.BtcIndex, update.Token)
@@ -1267,14 +1335,15 @@ func (bot *ExchangeBot) updateMutilchainState(chainType string) error {
 	switch chainType {
 	case TYPELTC:
 		ltcPrice, ltcChange, ltcVolumn, ltcLow, ltcHigh := bot.processMutilchainState(bot.currentState.LtcUsd, bot.LTCExchanges, true)
-		if ltcPrice == 0 {
+		factor, _ := bot.crossRate(marketIndex, bot.LTCIndex)
+		if ltcPrice == 0 || factor == 0 {
 			bot.failed = true
 		} else {
 			bot.failed = false
-			bot.currentState.LTCPrice = ltcPrice
+			bot.currentState.LTCPrice = ltcPrice * factor
 			bot.currentState.LTCVolume = ltcVolumn
-			bot.currentState.LTCLowPrice = ltcLow
-			bot.currentState.LTCHighPrice = ltcHigh
+			bot.currentState.LTCLowPrice = ltcLow * factor
+			bot.currentState.LTCHighPrice = ltcHigh * factor
 			bot.currentState.LTCPriceChange = ltcChange
 		}
 	case TYPEBTC:
@@ -1291,26 +1360,29 @@ func (bot *ExchangeBot) updateMutilchainState(chainType string) error {
 		}
 	case TYPEXMR:
 		xmrPrice, xmrChange, xmrVolumn, xmrLow, xmrHigh := bot.processMutilchainState(bot.currentState.XmrUsd, bot.XMRExchanges, true)
-		if xmrPrice == 0 {
+		factor, _ := bot.crossRate(marketIndex, bot.XMRIndex)
+		if xmrPrice == 0 || factor == 0 {
 			bot.failed = true
 		} else {
 			bot.failed = false
-			bot.currentState.XMRPrice = xmrPrice
+			bot.currentState.XMRPrice = xmrPrice * factor
 			bot.currentState.XMRVolume = xmrVolumn
-			bot.currentState.XMRLowPrice = xmrLow
-			bot.currentState.XMRHighPrice = xmrHigh
+			bot.currentState.XMRLowPrice = xmrLow * factor
+			bot.currentState.XMRHighPrice = xmrHigh * factor
 			bot.currentState.XMRPriceChange = xmrChange
 		}
 	default:
 		dcrPrice, dcrChange, volume, lowPrice, highPrice := bot.processState(bot.currentState.DcrBtc, true)
 		dcrBtcPrice, dcrBtcChange, dcrBtcVolume := bot.processDCRBTCState(bot.currentState.DcrBtc, true)
-		btcPrice, _, _, _, _ := bot.processState(bot.currentState.FiatIndices, false)
+		bot.currentState.FiatIndices = bot.fiatIndexStates(bot.BtcIndex)
+		btcPrice, indexSources := bot.indexPrice(bot.BtcIndex)
 		if dcrPrice == 0 || btcPrice == 0 {
 			bot.failed = true
 		} else {
 			bot.failed = false
 			bot.currentState.Price = dcrPrice
 			bot.currentState.BtcPrice = btcPrice
+			bot.currentState.IndexSources = indexSources
 			bot.currentState.Volume = volume
 			bot.currentState.DCRBTCPrice = dcrBtcPrice
 			bot.currentState.DCRBTCVolume = dcrBtcVolume
@@ -1341,13 +1413,15 @@ func (bot *ExchangeBot) updateMutilchainState(chainType string) error {
 func (bot *ExchangeBot) updateState() error {
 	dcrPrice, dcrChange, volume, lowPrice, highPrice := bot.processState(bot.currentState.DcrBtc, true)
 	dcrBtcPrice, dcrBtcChange, dcrBtcVolume := bot.processDCRBTCState(bot.currentState.DcrBtc, true)
-	btcPrice, _, _, _, _ := bot.processState(bot.currentState.FiatIndices, false)
+	bot.currentState.FiatIndices = bot.fiatIndexStates(bot.BtcIndex)
+	btcPrice, indexSources := bot.indexPrice(bot.BtcIndex)
 	if dcrPrice == 0 || btcPrice == 0 {
 		bot.failed = true
 	} else {
 		bot.failed = false
 		bot.currentState.Price = dcrPrice
 		bot.currentState.BtcPrice = btcPrice
+		bot.currentState.IndexSources = indexSources
 		bot.currentState.Volume = volume
 		bot.currentState.LowPrice = lowPrice
 		bot.currentState.HighPrice = highPrice
@@ -1375,7 +1449,8 @@ func (bot *ExchangeBot) updateState() error {
 
 // IsFailed is whether the failed flag was set during the last IndexUpdate
 // or ExchangeUpdate. The failed flag is set when either no Bitcoin Index
-// sources or no Decred Exchanges are up-to-date. Individual exchanges can
+// source has a fresh price for the BtcIndex currency or no Decred Exchanges
+// are up-to-date. Individual exchanges can
 // be outdated/failed without IsFailed being false, as long as there is at least
 // one Bitcoin index and one Decred exchange.
 func (bot *ExchangeBot) IsFailed() bool {
@@ -0,0 +1,311 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/candles"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+const (
+	dayMs       = uint64(24 * time.Hour / time.Millisecond)
+	fiveMinutes = "5m"
+	oneDay      = "24h"
+)
+
+// usdStablecoins are the assets that are valued at 1 USD. Token symbols are
+// matched without their network suffix, e.g. usdc.eth and usdc.polygon.
+var usdStablecoins = map[string]bool{
+	"usdc": true,
+	"usdt": true,
+	"dai":  true,
+	"busd": true,
+}
+
+// DEXMarketDay is the trading activity of a dcrdex market over a UTC day.
+// Volumes are in conventional units, e.g. DCR rather than atoms.
+type DEXMarketDay struct {
+	Market string `json:"market"`
+	// Day is the UNIX timestamp of the start of the day.
+	Day         int64   `json:"day"`
+	BaseVolume  float64 `json:"base_volume"`
+	QuoteVolume float64 `json:"quote_volume"`
+	// USDVolume is the volume valued with the USD prices implied by the DEX's
+	// own stablecoin markets for the day. It is 0 if no price is available
+	// for either asset.
+	USDVolume float64 `json:"usd_volume"`
+	// ActiveIntervals is the number of 5-minute intervals of the day that had
+	// at least one match. The candles API does not report individual matches,
+	// so the number of trades is not known. It is only available for the most
+	// recent days, for which the server still has 5-minute candles, and is 0
+	// for the days of the volume history.
+	ActiveIntervals int64 `json:"active_intervals"`
+	// Rate is the closing rate of the day, in units of the quote asset.
+	Rate float64 `json:"rate"`
+
+	base, quote string
+}
+
+// DEXCollector polls the public HTTP API of a dcrdex server for the daily
+// volume of its markets.
+type DEXCollector struct {
+	url    string
+	client Doer
+}
+
+// NewDEXCollector is the constructor for a DEXCollector. The url is the
+// server's HTTP API root, e.g. https://dex.decred.org:7232.
+func NewDEXCollector(url string, client Doer) *DEXCollector {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &DEXCollector{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+	}
+}
+
+// get requests the path from the server and decodes the JSON response.
+func (c *DEXCollector) get(ctx context.Context, path string, thing interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed: %s", path, resp.Status)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(thing); err != nil {
+		return fmt.Errorf("failed to decode json from %s: %w", path, err)
+	}
+	return nil
+}
+
+// candles gets as many candles of the bin size as the server has cached.
+func (c *DEXCollector) candles(ctx context.Context, base, quote, binSize string) (*msgjson.WireCandles, error) {
+	wc := new(msgjson.WireCandles)
+	path := fmt.Sprintf("/api/candles/%s/%s/%s/%d", base, quote, binSize, candles.CacheSize)
+	if err := c.get(ctx, path, wc); err != nil {
+		return nil, err
+	}
+	return wc, nil
+}
+
+// Collect gets the daily volume of every market on the server, for as many
+// days as the server has cached. The returned days are sorted by day, then
+// market.
+func (c *DEXCollector) Collect(ctx context.Context) ([]*DEXMarketDay, error) {
+	cfg := new(msgjson.ConfigResult)
+	if err := c.get(ctx, "/api/config", cfg); err != nil {
+		return nil, err
+	}
+	assets := make(map[uint32]*msgjson.Asset, len(cfg.Assets))
+	for _, a := range cfg.Assets {
+		assets[a.ID] = a
+	}
+	var days []*DEXMarketDay
+	for _, mkt := range cfg.Markets {
+		base, quote := assets[mkt.Base], assets[mkt.Quote]
+		if base == nil || quote == nil {
+			log.Warnf("Skipping dcrdex market %s with unknown assets", mkt.Name)
+			continue
+		}
+		daily, err := c.candles(ctx, base.Symbol, quote.Symbol, oneDay)
+		if err != nil {
+			return nil, err
+		}
+		recent, err := c.candles(ctx, base.Symbol, quote.Symbol, fiveMinutes)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, dexMarketDays(mkt.Name, base, quote, daily, recent)...)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Day == days[j].Day {
+			return days[i].Market < days[j].Market
+		}
+		return days[i].Day < days[j].Day
+	})
+	valueDEXMarketDays(days)
+	return days, nil
+}
+
+// History gets the daily USD volume of the markets from a CSV file with a
+// header row, a date column formatted as 2006-01-02, and a USD volume column
+// for each market, such as the dex.decred.org volume history of dcrsnapcsv.
+// The server's own candles do not go back to its launch, so the history covers
+// the earlier days. Only the Market, Day and USDVolume of the returned days
+// are set. Days are sorted by day, then market.
+func (c *DEXCollector) History(ctx context.Context, url string) ([]*DEXMarketDay, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed: %s", url, resp.Status)
+	}
+	days, err := parseDEXVolumeHistory(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	return days, nil
+}
+
+// parseDEXVolumeHistory parses a volume history CSV file. Empty and zero
+// volumes are skipped.
+func parseDEXVolumeHistory(r io.Reader) ([]*DEXMarketDay, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no header")
+	}
+	markets := records[0]
+	var days []*DEXMarketDay
+	for _, record := range records[1:] {
+		date, err := time.Parse("2006-01-02", record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", record[0], err)
+		}
+		for i := 1; i < len(record) && i < len(markets); i++ {
+			if record[i] == "" {
+				continue
+			}
+			vol, err := strconv.ParseFloat(record[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s volume %q on %s: %w", markets[i], record[i], record[0], err)
+			}
+			if vol <= 0 {
+				continue
+			}
+			days = append(days, &DEXMarketDay{
+				Market:    markets[i],
+				Day:       date.Unix(),
+				USDVolume: vol,
+			})
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Day == days[j].Day {
+			return days[i].Market < days[j].Market
+		}
+		return days[i].Day < days[j].Day
+	})
+	return days, nil
+}
+
+// dexMarketDays converts the market's 24h candles to DEXMarketDays, with the
+// active intervals counted from the 5m candles.
+func dexMarketDays(market string, base, quote *msgjson.Asset, daily, recent *msgjson.WireCandles) []*DEXMarketDay {
+	baseFactor := float64(base.UnitInfo.Conventional.ConversionFactor)
+	quoteFactor := float64(quote.UnitInfo.Conventional.ConversionFactor)
+	if baseFactor == 0 || quoteFactor == 0 {
+		return nil
+	}
+	active := make(map[uint64]int64)
+	for i, stamp := range recent.StartStamps {
+		if i < len(recent.MatchVolumes) && recent.MatchVolumes[i] > 0 {
+			active[stamp-stamp%dayMs]++
+		}
+	}
+	n := len(daily.StartStamps)
+	if len(daily.MatchVolumes) < n || len(daily.QuoteVolumes) < n || len(daily.EndRates) < n {
+		return nil
+	}
+	days := make([]*DEXMarketDay, 0, n)
+	for i, stamp := range daily.StartStamps {
+		day := stamp - stamp%dayMs
+		days = append(days, &DEXMarketDay{
+			Market:          market,
+			Day:             int64(day / 1000),
+			BaseVolume:      float64(daily.MatchVolumes[i]) / baseFactor,
+			QuoteVolume:     float64(daily.QuoteVolumes[i]) / quoteFactor,
+			ActiveIntervals: active[day],
+			Rate: calc.ConventionalRateAlt(daily.EndRates[i], base.UnitInfo.Conventional.ConversionFactor,
+				quote.UnitInfo.Conventional.ConversionFactor),
+			base:  base.Symbol,
+			quote: quote.Symbol,
+		})
+	}
+	return days
+}
+
+// isUSDStablecoin checks whether the asset symbol is a USD stablecoin.
+func isUSDStablecoin(symbol string) bool {
+	return usdStablecoins[strings.Split(symbol, ".")[0]]
+}
+
+// valueDEXMarketDays sets the USDVolume of the day-sorted DEXMarketDays. Each
+// day's asset prices are derived from that day's closing rates, starting from
+// the stablecoins and following the markets to other assets. Prices carry
+// forward to days without trades.
+func valueDEXMarketDays(days []*DEXMarketDay) {
+	prices := make(map[string]float64)
+	for start := 0; start < len(days); {
+		end := start
+		for end < len(days) && days[end].Day == days[start].Day {
+			end++
+		}
+		today := days[start:end]
+		known := make(map[string]bool)
+		for _, d := range today {
+			for _, symbol := range []string{d.base, d.quote} {
+				if isUSDStablecoin(symbol) {
+					prices[symbol] = 1
+					known[symbol] = true
+				}
+			}
+		}
+		// Each pass can only price assets one market away from a priced
+		// asset, so stop when a pass adds nothing.
+		for added := true; added; {
+			added = false
+			for _, d := range today {
+				if d.Rate <= 0 || d.BaseVolume == 0 {
+					continue
+				}
+				switch {
+				case known[d.quote] && !known[d.base]:
+					prices[d.base] = d.Rate * prices[d.quote]
+					known[d.base] = true
+					added = true
+				case known[d.base] && !known[d.quote]:
+					prices[d.quote] = prices[d.base] / d.Rate
+					known[d.quote] = true
+					added = true
+				}
+			}
+		}
+		for _, d := range today {
+			if p, found := prices[d.quote]; found {
+				d.USDVolume = d.QuoteVolume * p
+			} else if p, found = prices[d.base]; found {
+				d.USDVolume = d.BaseVolume * p
+			}
+		}
+		start = end
+	}
+}
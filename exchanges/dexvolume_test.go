@@ -0,0 +1,149 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+func testDEXAsset(symbol string, id uint32, factor uint64) *msgjson.Asset {
+	return &msgjson.Asset{
+		Symbol: symbol,
+		ID:     id,
+		UnitInfo: dex.UnitInfo{
+			Conventional: dex.Denomination{ConversionFactor: factor},
+		},
+	}
+}
+
+func TestDEXCollector(t *testing.T) {
+	enableTestLog()
+
+	const day1, day2 = uint64(1700006400000), uint64(1700092800000)
+	cfg := &msgjson.ConfigResult{
+		Assets: []*msgjson.Asset{
+			testDEXAsset("dcr", 42, 1e8),
+			testDEXAsset("btc", 0, 1e8),
+			testDEXAsset("usdt.polygon", 966001, 1e6),
+		},
+		Markets: []*msgjson.Market{
+			{Name: "dcr_btc", Base: 42, Quote: 0},
+			{Name: "btc_usdt.polygon", Base: 0, Quote: 966001},
+		},
+	}
+	// Message rates are encoded as conventional rate * 1e8 * quote factor /
+	// base factor.
+	daily := map[string]*msgjson.WireCandles{
+		"dcr_btc": {
+			StartStamps:  []uint64{day1, day2},
+			EndStamps:    []uint64{day2, day2 + dayMs},
+			MatchVolumes: []uint64{100e8, 50e8},
+			QuoteVolumes: []uint64{2e6, 1e6},
+			EndRates:     []uint64{2e4, 2e4},
+		},
+		"btc_usdt.polygon": {
+			// No trades on day2, so day1's BTC price is carried forward.
+			StartStamps:  []uint64{day1},
+			EndStamps:    []uint64{day2},
+			MatchVolumes: []uint64{1e8},
+			QuoteVolumes: []uint64{50000e6},
+			EndRates:     []uint64{50000e6},
+		},
+	}
+	recent := map[string]*msgjson.WireCandles{
+		"dcr_btc": {
+			StartStamps:  []uint64{day2, day2 + 300000, day2 + 600000},
+			MatchVolumes: []uint64{1e8, 0, 2e8},
+		},
+		"btc_usdt.polygon": {},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/config", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(cfg)
+	})
+	mux.HandleFunc("/api/candles/", func(w http.ResponseWriter, r *http.Request) {
+		// /api/candles/{base}/{quote}/{binSize}/{count}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/candles/"), "/")
+		if len(parts) != 4 {
+			http.Error(w, "bad candles path", http.StatusBadRequest)
+			return
+		}
+		base, quote, binSize := parts[0], parts[1], parts[2]
+		candles := daily
+		if binSize == fiveMinutes {
+			candles = recent
+		}
+		wc := candles[base+"_"+quote]
+		if wc == nil {
+			http.Error(w, "market not known", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(wc)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	days, err := NewDEXCollector(srv.URL+"/", nil).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+	if len(days) != 3 {
+		t.Fatalf("expected 3 market days, got %d", len(days))
+	}
+	btc1, dcr1, dcr2 := days[0], days[1], days[2]
+	if btc1.Market != "btc_usdt.polygon" || dcr1.Market != "dcr_btc" || dcr2.Day != int64(day2/1000) {
+		t.Fatalf("unexpected market day order")
+	}
+	if btc1.USDVolume != 50000 || btc1.Rate != 50000 {
+		t.Fatalf("wrong btc_usdt.polygon volume %f or rate %f", btc1.USDVolume, btc1.Rate)
+	}
+	if dcr1.BaseVolume != 100 || dcr1.QuoteVolume != 0.02 || dcr1.Rate != 0.0002 {
+		t.Fatalf("wrong dcr_btc volumes %f, %f or rate %f", dcr1.BaseVolume, dcr1.QuoteVolume, dcr1.Rate)
+	}
+	if math.Abs(dcr1.USDVolume-1000) > 1e-6 || math.Abs(dcr2.USDVolume-500) > 1e-6 {
+		t.Fatalf("wrong dcr_btc USD volumes %f, %f", dcr1.USDVolume, dcr2.USDVolume)
+	}
+	if dcr1.ActiveIntervals != 0 || dcr2.ActiveIntervals != 2 {
+		t.Fatalf("wrong active intervals %d, %d", dcr1.ActiveIntervals, dcr2.ActiveIntervals)
+	}
+
+	srv.Close()
+	if _, err = NewDEXCollector(srv.URL, nil).Collect(context.Background()); err == nil {
+		t.Fatalf("expected an error from a closed server")
+	}
+}
+
+func TestParseDEXVolumeHistory(t *testing.T) {
+	const history = `date,dcr_btc,btc_usdt
+2022-01-02,10.5,
+2022-01-01,100,0
+`
+	days, err := parseDEXVolumeHistory(strings.NewReader(history))
+	if err != nil {
+		t.Fatalf("parseDEXVolumeHistory error: %v", err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("expected 2 market days, got %d", len(days))
+	}
+	if days[0].Market != "dcr_btc" || days[0].Day != 1640995200 || days[0].USDVolume != 100 {
+		t.Fatalf("wrong first day %+v", days[0])
+	}
+	if days[1].Day != 1641081600 || days[1].USDVolume != 10.5 {
+		t.Fatalf("wrong second day %+v", days[1])
+	}
+
+	if _, err = parseDEXVolumeHistory(strings.NewReader("date,dcr_btc\n2022-01-01,x\n")); err == nil {
+		t.Fatalf("expected an error for an invalid volume")
+	}
+}
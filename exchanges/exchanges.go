@@ -30,6 +30,8 @@ import (
 const (
 	Coinbase     = "coinbase"
 	Coindesk     = "coindesk"
+	CoinGecko    = "coingecko"
+	Blockchain   = "blockchain"
 	Binance      = "binance"
 	Coinex       = "coinex"
 	Mexc         = "mexc"
@@ -116,6 +118,12 @@ var (
 	CoindeskURLs = URLs{
 		Price: "https://api.coindesk.com/v2/bpi/currentprice.json",
 	}
+	CoinGeckoURLs = URLs{
+		Price: "https://api.coingecko.com/api/v3/simple/price?ids=bitcoin&vs_currencies=usd,eur,gbp,jpy,cny,cad,aud,chf,krw,inr,brl,rub,hkd,sgd",
+	}
+	BlockchainURLs = URLs{
+		Price: "https://blockchain.info/ticker",
+	}
 	BinanceURLs = URLs{
 		Price: "%s/api/v3/ticker/24hr?symbol=DCR%s",
 		// Binance returns a maximum of 5000 depth chart points. This seems like it
@@ -401,8 +409,10 @@ var (
 
 // BtcIndices maps tokens to constructors for BTC-fiat exchanges.
 var BtcIndices = map[string]func(*http.Client, *BotChannels, string) (Exchange, error){
-	Coinbase: NewCoinbase,
-	Coindesk: NewCoindesk,
+	Coinbase:   NewCoinbase,
+	Coindesk:   NewCoindesk,
+	CoinGecko:  NewIndexSource(CoinGecko, &CoinGeckoIndex{URL: CoinGeckoURLs.Price}),
+	Blockchain: NewIndexSource(Blockchain, &BlockchainIndex{URL: BlockchainURLs.Price}),
 }
 
 // DcrExchanges maps tokens to constructors for DCR-BTC exchanges.
@@ -1181,117 +1191,6 @@ func newCommonExchange(token string, client *http.Client,
 	}
 }
 
-// CoinbaseExchange provides tons of bitcoin-fiat exchange pairs.
-type CoinbaseExchange struct {
-	*CommonExchange
-}
-
-// NewCoinbase constructs a CoinbaseExchange.
-func NewCoinbase(client *http.Client, channels *BotChannels, _ string) (coinbase Exchange, err error) {
-	reqs := newRequests()
-	reqs.price, err = http.NewRequest(http.MethodGet, CoinbaseURLs.Price, nil)
-	if err != nil {
-		return
-	}
-	coinbase = &CoinbaseExchange{
-		CommonExchange: newCommonExchange(Coinbase, client, reqs, channels),
-	}
-	return
-}
-
-// CoinbaseResponse models the JSON data returned from the Coinbase API.
-type CoinbaseResponse struct {
-	Data CoinbaseResponseData `json:"data"`
-}
-
-// CoinbaseResponseData models the "data" field of the Coinbase API response.
-type CoinbaseResponseData struct {
-	Currency string            `json:"currency"`
-	Rates    map[string]string `json:"rates"`
-}
-
-// Refresh retrieves and parses API data from Coinbase.
-func (coinbase *CoinbaseExchange) Refresh() {
-	coinbase.LogRequest()
-	response := new(CoinbaseResponse)
-	err := coinbase.fetch(coinbase.requests.price, response)
-	if err != nil {
-		coinbase.fail("Fetch", err)
-		return
-	}
-
-	indices := make(FiatIndices)
-	for code, floatStr := range response.Data.Rates {
-		price, err := strconv.ParseFloat(floatStr, 64)
-		if err != nil {
-			coinbase.fail(fmt.Sprintf("Failed to parse float for index %s. Given %s", code, floatStr), err)
-			continue
-		}
-		indices[code] = price
-	}
-	coinbase.UpdateIndices(indices)
-}
-
-// CoindeskExchange provides Bitcoin indices for USD, GBP, and EUR by default.
-// Others are available, but custom requests would need to be implemented.
-type CoindeskExchange struct {
-	*CommonExchange
-}
-
-// NewCoindesk constructs a CoindeskExchange.
-func NewCoindesk(client *http.Client, channels *BotChannels, _ string) (coindesk Exchange, err error) {
-	reqs := newRequests()
-	reqs.price, err = http.NewRequest(http.MethodGet, CoindeskURLs.Price, nil)
-	if err != nil {
-		return
-	}
-	coindesk = &CoindeskExchange{
-		CommonExchange: newCommonExchange(Coindesk, client, reqs, channels),
-	}
-	return
-}
-
-// CoindeskResponse models the JSON data returned from the Coindesk API.
-type CoindeskResponse struct {
-	Time       CoindeskResponseTime           `json:"time"`
-	Disclaimer string                         `json:"disclaimer"`
-	ChartName  string                         `json:"chartName"`
-	Bpi        map[string]CoindeskResponseBpi `json:"bpi"`
-}
-
-// CoindeskResponseTime models the "time" field of the Coindesk API response.
-type CoindeskResponseTime struct {
-	Updated    string    `json:"updated"`
-	UpdatedIso time.Time `json:"updatedISO"`
-	Updateduk  string    `json:"updateduk"`
-}
-
-// CoindeskResponseBpi models the "bpi" field of the Coindesk API response.
-type CoindeskResponseBpi struct {
-	Code        string  `json:"code"`
-	Symbol      string  `json:"symbol"`
-	Rate        string  `json:"rate"`
-	Description string  `json:"description"`
-	RateFloat   float64 `json:"rate_float"`
-}
-
-// Refresh retrieves and parses API data from Coindesk.
-func (coindesk *CoindeskExchange) Refresh() {
-	coindesk.LogRequest()
-	response := new(CoindeskResponse)
-	err := coindesk.fetch(coindesk.requests.price, response)
-	if err != nil {
-		coindesk.fail("Fetch", err)
-		return
-	}
-
-	indices := make(FiatIndices)
-	for code, bpi := range response.Bpi {
-		indices[code] = bpi.RateFloat
-	}
-	coindesk.UpdateIndices(indices)
-}
-
 // BinanceExchange is a high-volume and well-respected crypto exchange.
 type BinanceExchange struct {
 	*CommonExchange
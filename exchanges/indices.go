@@ -0,0 +1,396 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// IndexModePriority resolves a fiat price from the first source in the
+	// priority order with fresh data for the currency.
+	IndexModePriority = "priority"
+	// IndexModeMedian resolves a fiat price as the median of every source with
+	// fresh data for the currency.
+	IndexModeMedian = "median"
+)
+
+// DefaultIndexPriority is the order in which the BTC index sources are tried
+// if ExchangeBotConfig.IndexPriority is not set. Sources not in the priority
+// order are tried last, in alphabetical order.
+var DefaultIndexPriority = []string{Coinbase, CoinGecko, Blockchain, Coindesk}
+
+// IndexProvider is a source of Bitcoin prices in fiat currencies. Wrap an
+// IndexProvider with NewIndexSource to register it in BtcIndices.
+type IndexProvider interface {
+	// FetchIndices gets the current Bitcoin price in each currency that the
+	// provider supports, keyed by upper-case currency code.
+	FetchIndices(client Doer) (FiatIndices, error)
+}
+
+// IndexSource is the Exchange that polls an IndexProvider.
+type IndexSource struct {
+	*CommonExchange
+	provider IndexProvider
+}
+
+// NewIndexSource creates a constructor for an IndexSource, suitable for
+// BtcIndices.
+func NewIndexSource(token string, provider IndexProvider) func(*http.Client, *BotChannels, string) (Exchange, error) {
+	return func(client *http.Client, channels *BotChannels, _ string) (Exchange, error) {
+		return &IndexSource{
+			CommonExchange: newCommonExchange(token, client, newRequests(), channels),
+			provider:       provider,
+		}, nil
+	}
+}
+
+// Refresh retrieves the indices from the provider.
+func (src *IndexSource) Refresh() {
+	src.LogRequest()
+	indices, err := src.provider.FetchIndices(src.client)
+	if err != nil {
+		src.fail("Fetch", err)
+		return
+	}
+	if len(indices) == 0 {
+		src.fail("Fetch", fmt.Errorf("no indices received"))
+		return
+	}
+	src.UpdateIndices(indices)
+}
+
+// getIndexJSON requests the url and decodes the JSON response.
+func getIndexJSON(client Doer, url string, response interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed: %s", url, resp.Status)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode json from %s: %w", url, err)
+	}
+	return nil
+}
+
+// NewCoinbase constructs the Coinbase index source, which provides tons of
+// bitcoin-fiat exchange pairs.
+func NewCoinbase(client *http.Client, channels *BotChannels, _ string) (Exchange, error) {
+	return NewIndexSource(Coinbase, &CoinbaseIndex{URL: CoinbaseURLs.Price})(client, channels, "")
+}
+
+// CoinbaseIndex is the IndexProvider for the Coinbase exchange rates API.
+type CoinbaseIndex struct {
+	URL string
+}
+
+// CoinbaseResponse models the JSON data returned from the Coinbase API.
+type CoinbaseResponse struct {
+	Data CoinbaseResponseData `json:"data"`
+}
+
+// CoinbaseResponseData models the "data" field of the Coinbase API response.
+type CoinbaseResponseData struct {
+	Currency string            `json:"currency"`
+	Rates    map[string]string `json:"rates"`
+}
+
+// FetchIndices retrieves and parses API data from Coinbase. Rates that can't
+// be parsed are skipped.
+func (cb *CoinbaseIndex) FetchIndices(client Doer) (FiatIndices, error) {
+	response := new(CoinbaseResponse)
+	if err := getIndexJSON(client, cb.URL, response); err != nil {
+		return nil, err
+	}
+	indices := make(FiatIndices)
+	for code, floatStr := range response.Data.Rates {
+		price, err := strconv.ParseFloat(floatStr, 64)
+		if err != nil {
+			log.Debugf("Failed to parse float for Coinbase index %s. Given %s", code, floatStr)
+			continue
+		}
+		indices[code] = price
+	}
+	return indices, nil
+}
+
+// NewCoindesk constructs the Coindesk index source, which provides Bitcoin
+// indices for USD, GBP, and EUR.
+func NewCoindesk(client *http.Client, channels *BotChannels, _ string) (Exchange, error) {
+	return NewIndexSource(Coindesk, &CoindeskIndex{URL: CoindeskURLs.Price})(client, channels, "")
+}
+
+// CoindeskIndex is the IndexProvider for the Coindesk Bitcoin price index.
+type CoindeskIndex struct {
+	URL string
+}
+
+// CoindeskResponse models the JSON data returned from the Coindesk API.
+type CoindeskResponse struct {
+	Time       CoindeskResponseTime           `json:"time"`
+	Disclaimer string                         `json:"disclaimer"`
+	ChartName  string                         `json:"chartName"`
+	Bpi        map[string]CoindeskResponseBpi `json:"bpi"`
+}
+
+// CoindeskResponseTime models the "time" field of the Coindesk API response.
+type CoindeskResponseTime struct {
+	Updated    string    `json:"updated"`
+	UpdatedIso time.Time `json:"updatedISO"`
+	Updateduk  string    `json:"updateduk"`
+}
+
+// CoindeskResponseBpi models the "bpi" field of the Coindesk API response.
+type CoindeskResponseBpi struct {
+	Code        string  `json:"code"`
+	Symbol      string  `json:"symbol"`
+	Rate        string  `json:"rate"`
+	Description string  `json:"description"`
+	RateFloat   float64 `json:"rate_float"`
+}
+
+// FetchIndices retrieves and parses API data from Coindesk.
+func (cd *CoindeskIndex) FetchIndices(client Doer) (FiatIndices, error) {
+	response := new(CoindeskResponse)
+	if err := getIndexJSON(client, cd.URL, response); err != nil {
+		return nil, err
+	}
+	indices := make(FiatIndices)
+	for code, bpi := range response.Bpi {
+		indices[code] = bpi.RateFloat
+	}
+	return indices, nil
+}
+
+// CoinGeckoIndex is the IndexProvider for the CoinGecko simple price API. The
+// currencies are set by the vs_currencies parameter of the URL.
+type CoinGeckoIndex struct {
+	URL string
+}
+
+// FetchIndices retrieves and parses API data from CoinGecko.
+func (cg *CoinGeckoIndex) FetchIndices(client Doer) (FiatIndices, error) {
+	var response map[string]map[string]float64
+	if err := getIndexJSON(client, cg.URL, &response); err != nil {
+		return nil, err
+	}
+	indices := make(FiatIndices)
+	for code, price := range response["bitcoin"] {
+		indices[strings.ToUpper(code)] = price
+	}
+	return indices, nil
+}
+
+// BlockchainIndex is the IndexProvider for the Blockchain.com ticker API.
+type BlockchainIndex struct {
+	URL string
+}
+
+// BlockchainTicker models a currency in the Blockchain.com ticker response.
+type BlockchainTicker struct {
+	Last   float64 `json:"last"`
+	Buy    float64 `json:"buy"`
+	Sell   float64 `json:"sell"`
+	Symbol string  `json:"symbol"`
+}
+
+// FetchIndices retrieves and parses API data from Blockchain.com.
+func (bc *BlockchainIndex) FetchIndices(client Doer) (FiatIndices, error) {
+	var response map[string]BlockchainTicker
+	if err := getIndexJSON(client, bc.URL, &response); err != nil {
+		return nil, err
+	}
+	indices := make(FiatIndices)
+	for code, ticker := range response {
+		indices[code] = ticker.Last
+	}
+	return indices, nil
+}
+
+// setIndices merges an index source's prices into the indexMap. Each price is
+// stamped so that a currency the source stops reporting goes stale on its
+// own. The caller must hold the mtx.
+func (bot *ExchangeBot) setIndices(token string, indices FiatIndices, stamp time.Time) {
+	if bot.indexMap == nil {
+		bot.indexMap = make(map[string]FiatIndices)
+	}
+	if bot.indexStamps == nil {
+		bot.indexStamps = make(map[string]map[string]time.Time)
+	}
+	prices, stamps := bot.indexMap[token], bot.indexStamps[token]
+	if prices == nil {
+		prices = make(FiatIndices)
+		bot.indexMap[token] = prices
+	}
+	if stamps == nil {
+		stamps = make(map[string]time.Time)
+		bot.indexStamps[token] = stamps
+	}
+	for code, price := range indices {
+		if price <= 0 {
+			continue
+		}
+		prices[code] = price
+		stamps[code] = stamp
+	}
+}
+
+// indexOrder is the order in which the index sources are tried: the
+// configured priority order, followed by any other sources alphabetically.
+// The caller must hold the mtx.
+func (bot *ExchangeBot) indexOrder() []string {
+	order := make([]string, 0, len(bot.indexMap))
+	listed := make(map[string]bool, len(bot.indexPriority))
+	for _, token := range bot.indexPriority {
+		listed[token] = true
+		if _, found := bot.indexMap[token]; found {
+			order = append(order, token)
+		}
+	}
+	var rest []string
+	for token := range bot.indexMap {
+		if !listed[token] {
+			rest = append(rest, token)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
+
+// resolveIndex combines the values of the index sources according to the
+// index mode: the value of the first source in the order the sources are
+// tried, or the median of every source's value. value reports whether the
+// source has fresh data. The sources that backed the result are returned in
+// priority order. A zero value is returned if no source has fresh data. The
+// caller must hold the mtx.
+func (bot *ExchangeBot) resolveIndex(value func(token string, oldestValid time.Time) (float64, bool)) (float64, []string) {
+	oldestValid := time.Now().Add(-bot.indexExpiry)
+	var values []float64
+	var sources []string
+	for _, token := range bot.indexOrder() {
+		v, ok := value(token, oldestValid)
+		if !ok {
+			continue
+		}
+		if bot.indexMode != IndexModeMedian {
+			return v, []string{token}
+		}
+		values = append(values, v)
+		sources = append(sources, token)
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2, sources
+	}
+	return values[mid], sources
+}
+
+// freshIndex is the source's Bitcoin price in the currency, if it is no older
+// than oldestValid. The caller must hold the mtx.
+func (bot *ExchangeBot) freshIndex(token, code string, oldestValid time.Time) (float64, bool) {
+	price := bot.indexMap[token][code]
+	if price <= 0 || bot.indexStamps[token][code].Before(oldestValid) {
+		return 0, false
+	}
+	return price, true
+}
+
+// indexPrice resolves the Bitcoin price in the currency from the index
+// sources with a price for the currency no older than the index expiry. The
+// caller must hold the mtx.
+func (bot *ExchangeBot) indexPrice(code string) (float64, []string) {
+	return bot.resolveIndex(func(token string, oldestValid time.Time) (float64, bool) {
+		return bot.freshIndex(token, code, oldestValid)
+	})
+}
+
+// crossRate resolves the factor that converts a price in the from currency to
+// the to currency. Each source's factor is the ratio of its own Bitcoin prices
+// in the two currencies, so only the sources with fresh prices in both
+// currencies are used. The caller must hold the mtx.
+func (bot *ExchangeBot) crossRate(from, to string) (float64, []string) {
+	if from == to {
+		return 1, nil
+	}
+	return bot.resolveIndex(func(token string, oldestValid time.Time) (float64, bool) {
+		base, ok := bot.freshIndex(token, from, oldestValid)
+		if !ok {
+			return 0, false
+		}
+		target, ok := bot.freshIndex(token, to, oldestValid)
+		if !ok {
+			return 0, false
+		}
+		return target / base, true
+	})
+}
+
+// IndexStatus is the state of a BTC index source's price for a currency.
+type IndexStatus struct {
+	Token string  `json:"token"`
+	Price float64 `json:"price"`
+	Stamp int64   `json:"stamp"`
+	Stale bool    `json:"stale"`
+}
+
+// IndexStatuses lists every index source's price for the currency, in the
+// order the sources are tried.
+func (bot *ExchangeBot) IndexStatuses(code string) []IndexStatus {
+	bot.mtx.RLock()
+	defer bot.mtx.RUnlock()
+	oldestValid := time.Now().Add(-bot.indexExpiry)
+	statuses := make([]IndexStatus, 0, len(bot.indexMap))
+	for _, token := range bot.indexOrder() {
+		price, found := bot.indexMap[token][code]
+		if !found {
+			continue
+		}
+		stamp := bot.indexStamps[token][code]
+		statuses = append(statuses, IndexStatus{
+			Token: token,
+			Price: price,
+			Stamp: stamp.Unix(),
+			Stale: stamp.Before(oldestValid),
+		})
+	}
+	return statuses
+}
+
+// fiatIndexStates are the index sources' fresh prices for the currency, as
+// ExchangeStates for the ExchangeBotState. The caller must hold the mtx.
+func (bot *ExchangeBot) fiatIndexStates(code string) map[string]*ExchangeState {
+	oldestValid := time.Now().Add(-bot.indexExpiry)
+	states := make(map[string]*ExchangeState)
+	for token, indices := range bot.indexMap {
+		price, found := indices[code]
+		stamp := bot.indexStamps[token][code]
+		if !found || stamp.Before(oldestValid) {
+			continue
+		}
+		states[token] = &ExchangeState{
+			BaseState: BaseState{
+				Price: price,
+				Stamp: stamp.Unix(),
+			},
+		}
+	}
+	return states
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIndexProviders(t *testing.T) {
+	responses := map[string]string{
+		"/coinbase":   `{"data":{"currency":"BTC","rates":{"USD":"50000.5","EUR":"45000","XYZ":"n/a"}}}`,
+		"/coindesk":   `{"bpi":{"USD":{"code":"USD","rate_float":50001}}}`,
+		"/coingecko":  `{"bitcoin":{"usd":50002,"eur":45002}}`,
+		"/blockchain": `{"USD":{"last":50003,"buy":50003,"sell":50003,"symbol":"$"}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, found := responses[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		provider IndexProvider
+		usd      float64
+		codes    int
+	}{
+		{&CoinbaseIndex{URL: srv.URL + "/coinbase"}, 50000.5, 2},
+		{&CoindeskIndex{URL: srv.URL + "/coindesk"}, 50001, 1},
+		{&CoinGeckoIndex{URL: srv.URL + "/coingecko"}, 50002, 2},
+		{&BlockchainIndex{URL: srv.URL + "/blockchain"}, 50003, 1},
+	}
+	client := new(http.Client)
+	for _, tt := range tests {
+		indices, err := tt.provider.FetchIndices(client)
+		if err != nil {
+			t.Fatalf("%T: FetchIndices error: %v", tt.provider, err)
+		}
+		if indices["USD"] != tt.usd || len(indices) != tt.codes {
+			t.Fatalf("%T: wrong indices %v", tt.provider, indices)
+		}
+	}
+
+	if _, err := (&CoinGeckoIndex{URL: srv.URL + "/missing"}).FetchIndices(client); err == nil {
+		t.Fatalf("expected an error for a missing endpoint")
+	}
+}
+
+func TestIndexPrice(t *testing.T) {
+	now := time.Now()
+	bot := &ExchangeBot{
+		BtcIndex:      "USD",
+		indexPriority: []string{Coindesk, Coinbase},
+		indexMode:     IndexModePriority,
+		indexExpiry:   time.Hour,
+	}
+	bot.setIndices(Coinbase, FiatIndices{"USD": 100, "EUR": 90}, now)
+	bot.setIndices(Coindesk, FiatIndices{"USD": 110, "EUR": 99}, now.Add(-2*time.Hour))
+	bot.setIndices(Blockchain, FiatIndices{"USD": 130}, now)
+
+	// Coindesk's prices are stale.
+	price, sources := bot.indexPrice("USD")
+	if price != 100 || !reflect.DeepEqual(sources, []string{Coinbase}) {
+		t.Fatalf("expected a fallback to coinbase, got %f from %v", price, sources)
+	}
+
+	// Coindesk stops reporting EUR. Its USD price is fresh again, but EUR
+	// still falls back to Coinbase.
+	bot.setIndices(Coindesk, FiatIndices{"USD": 111}, now)
+	price, sources = bot.indexPrice("USD")
+	if price != 111 || !reflect.DeepEqual(sources, []string{Coindesk}) {
+		t.Fatalf("expected the first priority source, got %f from %v", price, sources)
+	}
+	price, sources = bot.indexPrice("EUR")
+	if price != 90 || !reflect.DeepEqual(sources, []string{Coinbase}) {
+		t.Fatalf("expected a fallback to coinbase, got %f from %v", price, sources)
+	}
+
+	// Unlisted sources are tried last.
+	if order := bot.indexOrder(); !reflect.DeepEqual(order, []string{Coindesk, Coinbase, Blockchain}) {
+		t.Fatalf("wrong index order %v", order)
+	}
+
+	bot.indexMode = IndexModeMedian
+	price, sources = bot.indexPrice("USD")
+	if price != 111 || len(sources) != 3 {
+		t.Fatalf("wrong median %f from %v", price, sources)
+	}
+	bot.indexStamps[Blockchain]["USD"] = now.Add(-2 * time.Hour)
+	price, _ = bot.indexPrice("USD")
+	if price != 105.5 {
+		t.Fatalf("wrong two-source median %f", price)
+	}
+
+	if price, _ = bot.indexPrice("JPY"); price != 0 {
+		t.Fatalf("expected no price for an unknown currency")
+	}
+	// Only Coinbase has fresh USD and EUR prices.
+	if conv, err := bot.FiatConversion("EUR"); err != nil || conv != 0.9 {
+		t.Fatalf("wrong fiat conversion %f, %v", conv, err)
+	}
+	bot.setIndices(Coindesk, FiatIndices{"EUR": 88.8}, now)
+	rate, sources := bot.crossRate("USD", "EUR")
+	if math.Abs(rate-0.85) > 1e-9 || !reflect.DeepEqual(sources, []string{Coindesk, Coinbase}) {
+		t.Fatalf("wrong cross rate median %f from %v", rate, sources)
+	}
+	bot.indexMode = IndexModePriority
+	if rate, _ = bot.crossRate("USD", "EUR"); math.Abs(rate-0.8) > 1e-9 {
+		t.Fatalf("wrong cross rate %f", rate)
+	}
+	if _, err := bot.FiatConversion("JPY"); err == nil {
+		t.Fatalf("expected no conversion for an unknown currency")
+	}
+
+	statuses := bot.IndexStatuses("USD")
+	if len(statuses) != 3 || !statuses[2].Stale || statuses[0].Stale {
+		t.Fatalf("wrong index statuses %+v", statuses)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"sort"
+)
+
+// LiquidityBands are the distances from the mid-gap price, in percent, within
+// which the order book volume is measured.
+var LiquidityBands = [3]float64{1, 2, 5}
+
+// SlippageSizes are the market order sizes, in units of the quote asset, for
+// which slippage is measured.
+var SlippageSizes = [3]float64{1000, 10000, 100000}
+
+// LiquidityBook is the price-ordered depth of the USD-pegged order book of an
+// exchange, or of the aggregated order book.
+type LiquidityBook struct {
+	Token string
+	DepthData
+}
+
+// LiquidityBooks gets the aggregated order book of the USD-pegged markets of
+// the chain, followed by the books of each exchange sorted by token. Books
+// with stale or one-sided depth data, and the DCR-BTC markets, are skipped.
+// nil is returned if there are no books.
+func LiquidityBooks(chainType string, states map[string]*ExchangeState) []*LiquidityBook {
+	var books []*LiquidityBook
+	agg := &LiquidityBook{Token: AggregatedToken}
+	for token, state := range states {
+		if BookQuote(chainType, token) != QuoteUSDT || !state.HasDepth() {
+			continue
+		}
+		depth := state.Depth
+		if !depth.IsFresh() || len(depth.Bids) == 0 || len(depth.Asks) == 0 {
+			continue
+		}
+		books = append(books, &LiquidityBook{Token: token, DepthData: *depth})
+		agg.Bids = append(agg.Bids, depth.Bids...)
+		agg.Asks = append(agg.Asks, depth.Asks...)
+		if depth.Time > agg.Time {
+			agg.Time = depth.Time
+		}
+	}
+	if len(books) == 0 {
+		return nil
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].Token < books[j].Token
+	})
+	sort.Slice(agg.Bids, func(i, j int) bool {
+		return agg.Bids[i].Price > agg.Bids[j].Price
+	})
+	sort.Slice(agg.Asks, func(i, j int) bool {
+		return agg.Asks[i].Price < agg.Asks[j].Price
+	})
+	return append([]*LiquidityBook{agg}, books...)
+}
+
+// BandVolumes sums the bid and ask volume within each of the LiquidityBands
+// of refMid. Measured around the mid-gap of the aggregated order book, the
+// volumes of the exchanges sum to the aggregated volumes.
+func (book *LiquidityBook) BandVolumes(refMid float64) (bids, asks [3]float64) {
+	return bandVolumes(book.Bids, refMid), bandVolumes(book.Asks, refMid)
+}
+
+// Slippage is the slippage of buy and sell market orders of each of the
+// SlippageSizes, measured from the book's own mid-gap. See slippage.
+func (book *LiquidityBook) Slippage() (buy, sell [3]float64) {
+	mid := book.MidGap()
+	for i, size := range SlippageSizes {
+		buy[i] = slippage(book.Asks, mid, size)
+		sell[i] = slippage(book.Bids, mid, size)
+	}
+	return
+}
+
+// LiquidityShare is the percentage of the aggregated volume within the middle
+// band that a book contributes, given the band volumes of the book and of the
+// aggregated order book.
+func LiquidityShare(bids, asks, aggBids, aggAsks [3]float64) float64 {
+	aggVol := aggBids[1] + aggAsks[1]
+	if aggVol <= 0 {
+		return 0
+	}
+	return (bids[1] + asks[1]) / aggVol * 100
+}
+
+// bandVolumes sums the volume of the points within each of the
+// LiquidityBands of the mid-gap.
+func bandVolumes(pts []DepthPoint, mid float64) (vols [3]float64) {
+	for _, pt := range pts {
+		dist := (pt.Price - mid) / mid * 100
+		if dist < 0 {
+			dist = -dist
+		}
+		for i, band := range LiquidityBands {
+			if dist <= band {
+				vols[i] += pt.Quantity
+			}
+		}
+	}
+	return
+}
+
+// slippage is the percent difference between the average price of spending
+// quoteSize on the price-ordered points and the mid-gap. -1 is returned if the
+// points can't fill the order.
+func slippage(pts []DepthPoint, mid, quoteSize float64) float64 {
+	var spent, qty float64
+	for _, pt := range pts {
+		value := pt.Quantity * pt.Price
+		if spent+value >= quoteSize {
+			qty += (quoteSize - spent) / pt.Price
+			avg := quoteSize / qty
+			s := (avg - mid) / mid * 100
+			if s < 0 {
+				s = -s
+			}
+			return s
+		}
+		spent += value
+		qty += pt.Quantity
+	}
+	return -1
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package exchanges
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLiquidityBooks(t *testing.T) {
+	now := time.Now().Unix()
+	state := func(bids, asks []DepthPoint) *ExchangeState {
+		return &ExchangeState{Depth: &DepthData{Time: now, Bids: bids, Asks: asks}}
+	}
+	states := map[string]*ExchangeState{
+		"kucoin": state(
+			[]DepthPoint{{Quantity: 30, Price: 99}},
+			[]DepthPoint{{Quantity: 30, Price: 101}},
+		),
+		"binance": state(
+			[]DepthPoint{{Quantity: 10, Price: 99.5}, {Quantity: 20, Price: 98}},
+			[]DepthPoint{{Quantity: 10, Price: 100.5}, {Quantity: 20, Price: 102}},
+		),
+		// One-sided and stale books are skipped.
+		"bittrex": state(nil, []DepthPoint{{Quantity: 1, Price: 100}}),
+		"huobi": {Depth: &DepthData{
+			Time: now - 2*3600,
+			Bids: []DepthPoint{{Quantity: 1, Price: 99}},
+			Asks: []DepthPoint{{Quantity: 1, Price: 101}},
+		}},
+		// DCR-BTC markets are skipped.
+		"btc_binance": state(
+			[]DepthPoint{{Quantity: 1, Price: 0.0002}},
+			[]DepthPoint{{Quantity: 1, Price: 0.00021}},
+		),
+	}
+	books := LiquidityBooks(TYPEDCR, states)
+	if len(books) != 3 {
+		t.Fatalf("expected 3 books, got %d", len(books))
+	}
+	agg := books[0]
+	if agg.Token != AggregatedToken || books[1].Token != "binance" || books[2].Token != "kucoin" {
+		t.Fatalf("unexpected book order %s, %s, %s", agg.Token, books[1].Token, books[2].Token)
+	}
+	if len(agg.Bids) != 3 || agg.Bids[0].Price != 99.5 || agg.Bids[2].Price != 98 ||
+		len(agg.Asks) != 3 || agg.Asks[0].Price != 100.5 || agg.Asks[2].Price != 102 {
+		t.Fatalf("aggregated book not price-ordered: %v %v", agg.Bids, agg.Asks)
+	}
+	if agg.MidGap() != 100 {
+		t.Fatalf("wrong aggregated mid-gap %f", agg.MidGap())
+	}
+
+	// 1%: 99.5, 99 | 100.5, 101. 2% and 5%: + 98 | 102.
+	aggBids, aggAsks := agg.BandVolumes(agg.MidGap())
+	if aggBids != [3]float64{40, 60, 60} || aggAsks != [3]float64{40, 60, 60} {
+		t.Fatalf("wrong aggregated band volumes %v %v", aggBids, aggAsks)
+	}
+	bids, asks := books[2].BandVolumes(agg.MidGap())
+	if share := LiquidityShare(bids, asks, aggBids, aggAsks); math.Abs(share-50) > 1e-9 {
+		t.Fatalf("expected kucoin share 50, got %f", share)
+	}
+	if share := LiquidityShare(bids, asks, [3]float64{}, [3]float64{}); share != 0 {
+		t.Fatalf("expected share 0 without aggregated volume, got %f", share)
+	}
+
+	if LiquidityBooks(TYPEBTC, nil) != nil {
+		t.Fatalf("expected nil books without depth data")
+	}
+}
+
+func TestSlippage(t *testing.T) {
+	asks := []DepthPoint{{Quantity: 1, Price: 100}, {Quantity: 1, Price: 200}}
+	// 100 + 100 buys 1.5 at an average of 133.33.
+	s := slippage(asks, 100, 200)
+	if math.Abs(s-100.0/3) > 1e-9 {
+		t.Fatalf("wrong slippage %f", s)
+	}
+	if slippage(asks, 100, 301) != -1 {
+		t.Fatalf("expected unfillable order")
+	}
+
+	// $1000 orders fill @ 100.5 and 99.5, 0.5% from the mid-gap of 100.
+	// $10000 orders can't be filled.
+	book := &LiquidityBook{DepthData: DepthData{
+		Bids: []DepthPoint{{Quantity: 20, Price: 99.5}},
+		Asks: []DepthPoint{{Quantity: 20, Price: 100.5}},
+	}}
+	buy, sell := book.Slippage()
+	if math.Abs(buy[0]-0.5) > 1e-9 || math.Abs(sell[0]-0.5) > 1e-9 {
+		t.Fatalf("wrong slippage %v %v", buy, sell)
+	}
+	if buy[1] != -1 || sell[1] != -1 {
+		t.Fatalf("expected unfillable orders, got %v %v", buy, sell)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -866,6 +867,185 @@ type MutilchainMempoolInfo struct {
 	InputsCount        int64               `json:"inputsCount"`
 	BlockReward        int64               `json:"blockReward"`
 	TotalTransactions  int64               `json:"totalTransactions"`
+	Projection         *MempoolProjection  `json:"projection,omitempty"`
+}
+
+// MempoolProjection is the projection of the next blocks from a BTC or LTC
+// mempool, and the fee rate histogram of the mempool, with fee rates in
+// atoms/vB.
+type MempoolProjection struct {
+	ChainType       string                      `json:"chain"`
+	Height          int64                       `json:"height"`
+	Time            int64                       `json:"time"`
+	ProjectedBlocks []*txhelpers.ProjectedBlock `json:"projected_blocks"`
+	FeeHistogram    []*txhelpers.FeeRateBucket  `json:"fee_histogram"`
+}
+
+// MutilchainMempoolShort is the summary of a BTC, LTC or XMR mempool with its
+// latest transactions, sent as the chain-scoped mempool update. Latest has the
+// BTC or LTC transactions, and XmrLatest the XMR transactions.
+type MutilchainMempoolShort struct {
+	ChainType          string              `json:"chain"`
+	LastBlockHeight    int64               `json:"block_height,omitempty"`
+	LastBlockHash      string              `json:"block_hash,omitempty"`
+	LastBlockTime      int64               `json:"block_time,omitempty"`
+	Time               int64               `json:"time"`
+	TotalOut           float64             `json:"total,omitempty"`
+	TotalSize          int64               `json:"size"`
+	FormattedTotalSize string              `json:"formatted_size"`
+	TotalFee           float64             `json:"total_fee"`
+	MinFeeRate         float64             `json:"min_fee_rate"`
+	MaxFeeRate         float64             `json:"max_fee_rate"`
+	NumAll             int                 `json:"num_all"`
+	Latest             []MempoolTx         `json:"latest,omitempty"`
+	XmrLatest          []xmrutil.MempoolTx `json:"xmr_latest,omitempty"`
+}
+
+// Short summarizes a BTC or LTC mempool with its numLatest latest
+// transactions.
+func (mpi *MutilchainMempoolInfo) Short(chainType string, numLatest int) *MutilchainMempoolShort {
+	mpi.RLock()
+	defer mpi.RUnlock()
+	latest := make([]MempoolTx, len(mpi.Transactions))
+	copy(latest, mpi.Transactions)
+	sort.Sort(MPTxsByTime(latest))
+	if len(latest) > numLatest {
+		latest = latest[:numLatest]
+	}
+	return &MutilchainMempoolShort{
+		ChainType:          chainType,
+		LastBlockHeight:    mpi.LastBlockHeight,
+		LastBlockHash:      mpi.LastBlockHash,
+		LastBlockTime:      mpi.LastBlockTime,
+		Time:               mpi.Time,
+		TotalOut:           mpi.TotalOut,
+		TotalSize:          int64(mpi.TotalSize),
+		FormattedTotalSize: mpi.FormattedTotalSize,
+		TotalFee:           mpi.TotalFee,
+		MinFeeRate:         mpi.MinFeeRatevB,
+		MaxFeeRate:         mpi.MaxFeeRatevB,
+		NumAll:             len(mpi.Transactions),
+		Latest:             latest,
+	}
+}
+
+// XMRMempoolShort summarizes an XMR mempool, collected at time t, with its
+// numLatest latest transactions. The transaction JSON is left out.
+func XMRMempoolShort(mp *xmrutil.Mempool, t int64, numLatest int) *MutilchainMempoolShort {
+	latest := make([]xmrutil.MempoolTx, len(mp.Transactions))
+	copy(latest, mp.Transactions)
+	sort.Slice(latest, func(i, j int) bool {
+		return latest[i].ReceiveTime > latest[j].ReceiveTime
+	})
+	if len(latest) > numLatest {
+		latest = latest[:numLatest]
+	}
+	for i := range latest {
+		latest[i].TxJSON = ""
+	}
+	return &MutilchainMempoolShort{
+		ChainType:          "xmr",
+		Time:               t,
+		TotalSize:          mp.BytesTotal,
+		FormattedTotalSize: BytesString(uint64(mp.BytesTotal)),
+		TotalFee:           AtomicToXMR(mp.TotalFee),
+		MinFeeRate:         mp.MinFeeRate,
+		MaxFeeRate:         mp.MaxFeeRate,
+		NumAll:             mp.TxCount,
+		XmrLatest:          latest,
+	}
+}
+
+// MempoolTemplateTxs converts mempool transactions with fees in coins of 1e8
+// atoms for block projection. Transactions without a weight, as on chains
+// without segwit, weigh four times their size.
+func MempoolTemplateTxs(txs []MempoolTx) []*txhelpers.TemplateTx {
+	tmplTxs := make([]*txhelpers.TemplateTx, 0, len(txs))
+	for i := range txs {
+		tx := &txs[i]
+		weight := int64(tx.Weight)
+		if weight == 0 {
+			weight = 4 * int64(tx.Size)
+		}
+		tmplTxs = append(tmplTxs, &txhelpers.TemplateTx{
+			TxID:    tx.TxID,
+			Weight:  weight,
+			Fee:     int64(math.Round(tx.Fees * 1e8)),
+			Depends: tx.Depends,
+		})
+	}
+	return tmplTxs
+}
+
+// TxReplacementInfo is the replacement history and the CPFP package of a BTC
+// or LTC transaction. Package is set while the transaction is in mempool, and
+// History if it replaced or was replaced by another transaction.
+type TxReplacementInfo struct {
+	ChainType string                        `json:"chain"`
+	TxID      string                        `json:"txid"`
+	InMempool bool                          `json:"in_mempool"`
+	History   *txhelpers.ReplacementHistory `json:"history,omitempty"`
+	Package   *txhelpers.CPFPPackage        `json:"package,omitempty"`
+}
+
+// MempoolReplaceableTxs converts mempool transactions with fees in coins of
+// 1e8 atoms for replacement tracking.
+func MempoolReplaceableTxs(txs []MempoolTx) []*txhelpers.ReplaceableTx {
+	rTxs := make([]*txhelpers.ReplaceableTx, 0, len(txs))
+	for i := range txs {
+		tx := &txs[i]
+		vsize := int64(tx.Size)
+		if tx.Weight > 0 {
+			vsize = (int64(tx.Weight) + 3) / 4
+		}
+		outpoints := make([]string, 0, len(tx.Vin))
+		for _, vin := range tx.Vin {
+			outpoints = append(outpoints, txhelpers.Outpoint(vin.TxId, vin.Outdex))
+		}
+		rTxs = append(rTxs, &txhelpers.ReplaceableTx{
+			TxID:      tx.TxID,
+			Fee:       int64(math.Round(tx.Fees * 1e8)),
+			VSize:     vsize,
+			Time:      tx.Time,
+			Outpoints: outpoints,
+		})
+	}
+	return rTxs
+}
+
+// MempoolLedgerTxs converts mempool transactions for a txhelpers.MempoolLedger.
+// DCR votes are left out, since the votes of a ticket on competing blocks all
+// spend the ticket without replacing each other.
+func MempoolLedgerTxs(txs []MempoolTx) []*txhelpers.LedgerTx {
+	lTxs := make([]*txhelpers.LedgerTx, 0, len(txs))
+	for i := range txs {
+		tx := &txs[i]
+		if tx.TypeID == int(stake.TxTypeSSGen) {
+			continue
+		}
+		outpoints := make([]string, 0, len(tx.Vin))
+		for _, vin := range tx.Vin {
+			if txhelpers.IsZeroHashStr(vin.TxId) {
+				continue
+			}
+			outpoints = append(outpoints, txhelpers.Outpoint(vin.TxId, vin.Outdex))
+		}
+		lTxs = append(lTxs, &txhelpers.LedgerTx{TxID: tx.TxID, Outpoints: outpoints})
+	}
+	return lTxs
+}
+
+// NewMempoolProjection projects up to numBlocks blocks of maxWeight from the
+// mempool transactions of a BTC or LTC mempool.
+func NewMempoolProjection(chainType string, txs []MempoolTx, height, timestamp, maxWeight int64, numBlocks int) *MempoolProjection {
+	tmplTxs := MempoolTemplateTxs(txs)
+	return &MempoolProjection{
+		ChainType:       chainType,
+		Height:          height,
+		Time:            timestamp,
+		ProjectedBlocks: txhelpers.ProjectBlocks(tmplTxs, maxWeight, numBlocks),
+		FeeHistogram:    txhelpers.FeeRateHistogram(tmplTxs),
+	}
 }
 
 // DeepCopy makes a deep copy of MempoolInfo, where all the slice and map data
@@ -1345,6 +1525,9 @@ type MempoolTx struct {
 	Type     string    `json:"Type"`
 	TypeID   int       `json:"typeID"` // stake package types
 	VoteInfo *VoteInfo `json:"vote_info,omitempty"`
+	// Weight and Depends, the unconfirmed parents, are set for BTC and LTC.
+	Weight  int32    `json:"weight,omitempty"`
+	Depends []string `json:"depends,omitempty"`
 }
 
 type MoneroSimpleSummaryInfo struct {
@@ -1363,6 +1546,10 @@ func (mpt *MempoolTx) DeepCopy() *MempoolTx {
 	out.Vin = make([]MempoolInput, len(mpt.Vin))
 	copy(out.Vin, mpt.Vin)
 	out.VoteInfo = mpt.VoteInfo.DeepCopy()
+	if mpt.Depends != nil {
+		out.Depends = make([]string, len(mpt.Depends))
+		copy(out.Depends, mpt.Depends)
+	}
 	return &out
 }
 
@@ -0,0 +1,233 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package agendas
+
+import (
+	"math"
+)
+
+// RCIForecast is the projected outcome of voting on an agenda at the end of a
+// rule change interval.
+type RCIForecast struct {
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+	// Aye, Nay and Abstain are the projected vote counts at EndHeight.
+	Aye     uint32 `json:"aye"`
+	Nay     uint32 `json:"nay"`
+	Abstain uint32 `json:"abstain"`
+	// YesPercent and NoPercent are the projected shares of the non-abstaining
+	// votes, in percent.
+	YesPercent     float32 `json:"yes_percent"`
+	NoPercent      float32 `json:"no_percent"`
+	QuorumAchieved bool    `json:"quorum_achieved"`
+	// LockInProbability is the estimated probability that the agenda locks in
+	// at the end of the interval.
+	LockInProbability float32 `json:"lockin_probability"`
+}
+
+// AgendaForecast projects where voting on an agenda is heading, based on the
+// votes cast in the most recent stake version interval and on the progress of
+// the stake version upgrade.
+type AgendaForecast struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// RecentApproval and RecentParticipation are the share of yes votes among
+	// the non-abstaining votes and the share of votes counted for the agenda
+	// per possible vote, over the blocks used for the trend.
+	RecentApproval      float32 `json:"recent_approval"`
+	RecentParticipation float32 `json:"recent_participation"`
+	RecentAbstainRate   float32 `json:"recent_abstain_rate"`
+	TrendBlocks         int64   `json:"trend_blocks"`
+	// Current and Next are the projections at the end of the current and the
+	// following rule change intervals. Either is nil if the agenda can not be
+	// voted on in that interval.
+	Current *RCIForecast `json:"current"`
+	Next    *RCIForecast `json:"next"`
+	// LockInProbability is the estimated probability that the agenda locks in
+	// within the current or the next rule change interval.
+	LockInProbability float32 `json:"lockin_probability"`
+	// EarliestActivation is the earliest height at which the agenda can
+	// activate, or 0 if it is already active, failed, or can not activate
+	// within the next two rule change intervals.
+	EarliestActivation int64 `json:"earliest_activation"`
+}
+
+// voteSample is a snapshot of the vote counts of the started agendas at a
+// height. The tracker keeps one per block for the current rule change interval.
+type voteSample struct {
+	height int64
+	counts map[string]voteCount
+}
+
+// forecastInput is the agenda state that a forecast is made from.
+type forecastInput struct {
+	status     string
+	expireTime int64
+	// aye, nay and abstain are the counts in the current rule change interval
+	// at height, and base is the sample at the start of the trend window.
+	aye, nay, abstain uint32
+	base              voteCount
+	baseHeight        int64
+	height            int64
+	// The current rule change interval, and the time at its tip.
+	rciStart, rciEnd int64
+	tipTime          int64
+	// voterProgress is the share of voters on the new stake version in the
+	// current stake version interval.
+	voterProgress  float32
+	upgradeReached bool
+}
+
+// forecastParams are the consensus parameters used by forecasts.
+type forecastParams struct {
+	rciBlocks      int64
+	votesPerBlock  int64
+	quorum         uint32
+	passThreshold  float64
+	blockTime      int64
+	voterThreshold float32
+}
+
+// newAgendaForecast projects the outcome of voting on an agenda.
+func newAgendaForecast(id string, in *forecastInput, p *forecastParams) *AgendaForecast {
+	f := &AgendaForecast{
+		ID:     id,
+		Status: in.status,
+	}
+	nextStart := in.rciEnd + 1
+	nextEnd := in.rciEnd + p.rciBlocks
+	// Voting in the next interval requires the agenda not to expire before it
+	// starts, estimated from the target block time.
+	nextVotable := in.tipTime+(nextStart-in.height)*p.blockTime < in.expireTime
+
+	switch in.status {
+	case statusActive, statusFailed:
+		return f
+	case statusLocked:
+		f.LockInProbability = 1
+		f.EarliestActivation = nextStart
+		return f
+	case statusDefined:
+		// Voting starts at the next interval if the stake version upgrade
+		// completes in this one. There are no votes to project from, so only
+		// the timing is known.
+		if nextVotable && (in.upgradeReached || in.voterProgress >= p.voterThreshold) {
+			f.EarliestActivation = nextEnd + 1 + p.rciBlocks
+		}
+		return f
+	}
+
+	// The agenda is being voted on. Use the votes since the base sample for
+	// the trend, falling back to the stake version progress when there are no
+	// votes yet.
+	dAye := voteDelta(in.aye, in.base.yes)
+	dNay := voteDelta(in.nay, in.base.no)
+	dAbstain := voteDelta(in.abstain, in.base.abstain)
+	f.TrendBlocks = in.height - in.baseHeight
+	if dAye+dNay > 0 {
+		f.RecentApproval = float32(dAye) / float32(dAye+dNay)
+	} else if in.aye+in.nay > 0 {
+		f.RecentApproval = float32(in.aye) / float32(in.aye+in.nay)
+	}
+	if cast := dAye + dNay + dAbstain; cast > 0 {
+		f.RecentAbstainRate = float32(dAbstain) / float32(cast)
+		if f.TrendBlocks > 0 {
+			f.RecentParticipation = float32(cast) / float32(f.TrendBlocks*p.votesPerBlock)
+		}
+	} else {
+		f.RecentParticipation = in.voterProgress
+	}
+	if f.RecentParticipation > 1 {
+		f.RecentParticipation = 1
+	}
+
+	// The current interval adds the remaining blocks' votes at the recent
+	// rates to the counts so far.
+	remaining := in.rciEnd - in.height
+	if remaining < 0 {
+		remaining = 0
+	}
+	f.Current = projectRCI(in.rciStart, in.rciEnd, in.aye, in.nay, in.abstain,
+		remaining, f.RecentParticipation, f.RecentAbstainRate, f.RecentApproval, p)
+	if f.Current.LockInProbability > 0 {
+		f.EarliestActivation = nextStart + p.rciBlocks
+	}
+
+	// The next interval starts from zero. All voters are expected to have the
+	// new stake version by then, so participation is at least the current
+	// voter progress.
+	if nextVotable {
+		participation := f.RecentParticipation
+		if in.voterProgress > participation {
+			participation = in.voterProgress
+		}
+		f.Next = projectRCI(nextStart, nextEnd, 0, 0, 0, p.rciBlocks,
+			participation, f.RecentAbstainRate, f.RecentApproval, p)
+		if f.EarliestActivation == 0 && f.Next.LockInProbability > 0 {
+			f.EarliestActivation = nextEnd + 1 + p.rciBlocks
+		}
+	}
+
+	f.LockInProbability = f.Current.LockInProbability
+	if f.Next != nil {
+		f.LockInProbability += (1 - f.Current.LockInProbability) * f.Next.LockInProbability
+	}
+	return f
+}
+
+// voteDelta is the number of votes cast since the base count. A reorg can
+// leave the base sample ahead of the current count, in which case no votes are
+// counted.
+func voteDelta(count, base uint32) int64 {
+	d := int64(count) - int64(base)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// projectRCI projects the counts at the end of an interval from the counts so
+// far and the expected votes in the remaining blocks. The lock-in probability
+// treats each remaining non-abstaining vote as an independent yes with
+// probability approval, using the normal approximation of the binomial.
+func projectRCI(start, end int64, aye, nay, abstain uint32, remaining int64,
+	participation, abstainRate, approval float32, p *forecastParams) *RCIForecast {
+	moreVotes := float64(remaining*p.votesPerBlock) * float64(participation)
+	moreAbstain := moreVotes * float64(abstainRate)
+	moreCast := moreVotes - moreAbstain
+	moreAye := moreCast * float64(approval)
+
+	fc := &RCIForecast{
+		StartHeight: start,
+		EndHeight:   end,
+		Aye:         aye + uint32(math.Round(moreAye)),
+		Nay:         nay + uint32(math.Round(moreCast-moreAye)),
+		Abstain:     abstain + uint32(math.Round(moreAbstain)),
+	}
+	if cast := fc.Aye + fc.Nay; cast > 0 {
+		fc.YesPercent = 100 * float32(fc.Aye) / float32(cast)
+		fc.NoPercent = 100 - fc.YesPercent
+	}
+	fc.QuorumAchieved = fc.Aye+fc.Nay >= p.quorum
+	if !fc.QuorumAchieved {
+		return fc
+	}
+
+	// The yes votes still needed for the pass threshold.
+	total := float64(aye+nay) + moreCast
+	needed := math.Ceil(total*p.passThreshold) - float64(aye)
+	variance := moreCast * float64(approval) * float64(1-approval)
+	switch {
+	case needed <= 0:
+		fc.LockInProbability = 1
+	case variance == 0:
+		if moreAye >= needed {
+			fc.LockInProbability = 1
+		}
+	default:
+		z := (needed - 0.5 - moreAye) / math.Sqrt(variance)
+		fc.LockInProbability = float32(0.5 * math.Erfc(z/math.Sqrt2))
+	}
+	return fc
+}
@@ -0,0 +1,126 @@
+package agendas
+
+import (
+	"testing"
+)
+
+var testForecastParams = &forecastParams{
+	rciBlocks:      8064,
+	votesPerBlock:  5,
+	quorum:         4032,
+	passThreshold:  0.75,
+	blockTime:      300,
+	voterThreshold: 0.75,
+}
+
+func startedInput(aye, nay, abstain uint32) *forecastInput {
+	return &forecastInput{
+		status:        statusStarted,
+		expireTime:    1e10,
+		aye:           aye,
+		nay:           nay,
+		abstain:       abstain,
+		baseHeight:    99,
+		height:        4131, // 4032 blocks into the interval
+		rciStart:      100,
+		rciEnd:        8163,
+		tipTime:       1e9,
+		voterProgress: 1,
+	}
+}
+
+func TestAgendaForecastStarted(t *testing.T) {
+	// 90% approval with every ticket voting is headed for lock-in this
+	// interval.
+	in := startedInput(18144, 2016, 0)
+	f := newAgendaForecast("a", in, testForecastParams)
+	if f.Current == nil || f.Next == nil {
+		t.Fatalf("missing interval forecasts: %+v", f)
+	}
+	if f.RecentParticipation != 1 || f.RecentApproval != 0.9 {
+		t.Errorf("wrong recent rates: participation %v, approval %v", f.RecentParticipation, f.RecentApproval)
+	}
+	if f.Current.Aye != 36288 || f.Current.Nay != 4032 || f.Current.YesPercent != 90 {
+		t.Errorf("wrong current projection: %+v", f.Current)
+	}
+	if !f.Current.QuorumAchieved || f.Current.LockInProbability < 0.999 {
+		t.Errorf("expected lock-in in the current interval: %+v", f.Current)
+	}
+	if f.EarliestActivation != 8164+8064 {
+		t.Errorf("wrong earliest activation %d", f.EarliestActivation)
+	}
+
+	// A recent swing to 50% approval rules out lock-in.
+	in = startedInput(18144, 2016, 0)
+	in.base, in.baseHeight = voteCount{yes: 16128, no: 0}, 3123
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.RecentApproval != 0.5 {
+		t.Errorf("wrong recent approval %v", f.RecentApproval)
+	}
+	if f.LockInProbability > 0.001 || f.EarliestActivation != 0 {
+		t.Errorf("expected no lock-in: probability %v, activation %d", f.LockInProbability, f.EarliestActivation)
+	}
+
+	// A reorg can leave the base sample ahead of the current counts. The
+	// trend then falls back to the counts so far.
+	in = startedInput(18144, 2016, 0)
+	in.base, in.baseHeight = voteCount{yes: 20000, no: 3000, abstain: 10}, 3123
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.RecentApproval != 0.9 || f.RecentAbstainRate != 0 || f.RecentParticipation != 1 {
+		t.Errorf("wrong recent rates after a reorg: %+v", f)
+	}
+
+	// Approval right at the threshold is a coin flip.
+	in = startedInput(15120, 5040, 0)
+	f = newAgendaForecast("a", in, testForecastParams)
+	if p := f.Current.LockInProbability; p < 0.4 || p > 0.6 {
+		t.Errorf("expected a lock-in probability near 0.5, got %v", p)
+	}
+	if f.LockInProbability <= f.Current.LockInProbability {
+		t.Errorf("the next interval should add to the lock-in probability")
+	}
+
+	// Low participation misses the quorum this interval.
+	in = startedInput(900, 100, 9000)
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.Current.QuorumAchieved || f.Current.LockInProbability != 0 {
+		t.Errorf("expected the quorum to be missed: %+v", f.Current)
+	}
+
+	// An agenda expiring before the next interval has no next forecast.
+	in = startedInput(18144, 2016, 0)
+	in.expireTime = in.tipTime + 1000
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.Next != nil {
+		t.Errorf("unexpected next interval forecast for an expiring agenda")
+	}
+}
+
+func TestAgendaForecastStatus(t *testing.T) {
+	in := startedInput(0, 0, 0)
+	in.status = statusLocked
+	f := newAgendaForecast("a", in, testForecastParams)
+	if f.LockInProbability != 1 || f.EarliestActivation != 8164 || f.Current != nil {
+		t.Errorf("wrong locked in forecast: %+v", f)
+	}
+
+	in.status = statusDefined
+	in.voterProgress = 0.8
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.EarliestActivation != 8164+2*8064 {
+		t.Errorf("wrong defined agenda activation %d", f.EarliestActivation)
+	}
+	in.voterProgress = 0.5
+	f = newAgendaForecast("a", in, testForecastParams)
+	if f.EarliestActivation != 0 {
+		t.Errorf("unexpected activation before the upgrade: %d", f.EarliestActivation)
+	}
+
+	for _, status := range []string{statusActive, statusFailed} {
+		in.status = status
+		f = newAgendaForecast("a", in, testForecastParams)
+		if f.LockInProbability != 0 || f.EarliestActivation != 0 || f.Current != nil {
+			t.Errorf("wrong %s forecast: %+v", status, f)
+		}
+	}
+}
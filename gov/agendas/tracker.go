@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/decred/dcrd/chaincfg/v3"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
@@ -58,6 +59,8 @@ type AgendaSummary struct {
 	IsLocked        bool    `json:"is_locked"`
 	IsFailed        bool    `json:"is_failed"`
 	IsActive        bool    `json:"is_active"`
+	// Forecast is nil if the agenda status is not known to dcrd.
+	Forecast *AgendaForecast `json:"forecast"`
 }
 
 // VoteSummary summarizes the current state of consensus voting. VoteSummary is
@@ -125,6 +128,9 @@ type VoteTracker struct {
 	blockTime      int64
 	passThreshold  float32
 	rciVotes       uint32
+	// samples are the vote counts at the blocks of the current rule change
+	// interval, going back one stake version interval, for forecasts.
+	samples []voteSample
 }
 
 // NewVoteTracker is a constructor for a VoteTracker.
@@ -350,6 +356,7 @@ func (tracker *VoteTracker) update(voteInfo *chainjson.GetVoteInfoResult, blocks
 	tracker.blockVersion = tracker.blockRing[tracker.ringIndex]
 	tracker.stakeVersion = stakeVersion
 	tracker.ringHeight = voteInfo.CurrentHeight
+	tracker.addVoteSample()
 	tracker.summary = tracker.newVoteSummary()
 }
 
@@ -470,9 +477,109 @@ func (tracker *VoteTracker) newVoteSummary() *VoteSummary {
 
 	summary.TilNextRCI = int64(summary.RCIBlocks-summary.RCIMined) * tracker.blockTime
 
+	tracker.addForecasts(summary)
+
 	return summary
 }
 
+// Record the vote counts of the started agendas at the current height. The
+// samples are reset at the start of each rule change interval, and only the
+// newest sample at or before one stake version interval ago is kept. Must be
+// called with the mutex locked.
+func (tracker *VoteTracker) addVoteSample() {
+	height := tracker.voteInfo.CurrentHeight
+	samples := tracker.samples[:0]
+	for _, sample := range tracker.samples {
+		// Drop samples from a reorged chain or a previous interval.
+		if sample.height < height && sample.height >= tracker.voteInfo.StartHeight {
+			samples = append(samples, sample)
+		}
+	}
+	windowStart := height - int64(tracker.sviBlocks)
+	for len(samples) > 1 && samples[1].height <= windowStart {
+		samples = samples[1:]
+	}
+
+	sample := voteSample{
+		height: height,
+		counts: make(map[string]voteCount),
+	}
+	for idx := range tracker.voteInfo.Agendas {
+		agenda := &tracker.voteInfo.Agendas[idx]
+		if agenda.Status != statusStarted {
+			continue
+		}
+		var counts voteCount
+		for idy := range agenda.Choices {
+			choice := &agenda.Choices[idy]
+			if choice.IsNo {
+				counts.no = choice.Count
+			} else if choice.IsAbstain {
+				counts.abstain = choice.Count
+			} else {
+				counts.yes = choice.Count
+			}
+		}
+		sample.counts[agenda.ID] = counts
+	}
+	tracker.samples = append(samples, sample)
+}
+
+// Add a forecast to each agenda of the summary. The vote trend is taken over
+// the last stake version interval, or since the start of the rule change
+// interval if that is more recent or the tracker has not seen a full stake
+// version interval yet. Must be called with the mutex locked.
+func (tracker *VoteTracker) addForecasts(summary *VoteSummary) {
+	params := &forecastParams{
+		rciBlocks:      int64(tracker.rciBlocks),
+		votesPerBlock:  int64(tracker.params.TicketsPerBlock),
+		quorum:         tracker.params.RuleChangeActivationQuorum,
+		passThreshold:  float64(tracker.passThreshold),
+		blockTime:      tracker.blockTime,
+		voterThreshold: tracker.voterThreshold,
+	}
+	height := tracker.voteInfo.CurrentHeight
+	var base *voteSample
+	if len(tracker.samples) > 0 && tracker.samples[0].height <= height-int64(tracker.sviBlocks) {
+		base = &tracker.samples[0]
+	}
+	now := time.Now().Unix()
+	for idx := range tracker.voteInfo.Agendas {
+		agenda := &tracker.voteInfo.Agendas[idx]
+		agendaSummary := &summary.Agendas[idx]
+		in := &forecastInput{
+			status:         agenda.Status,
+			expireTime:     int64(agenda.ExpireTime),
+			aye:            agendaSummary.Aye,
+			nay:            agendaSummary.Nay,
+			abstain:        agendaSummary.Abstain,
+			baseHeight:     tracker.voteInfo.StartHeight - 1,
+			height:         height,
+			rciStart:       tracker.voteInfo.StartHeight,
+			rciEnd:         tracker.voteInfo.EndHeight,
+			tipTime:        now,
+			voterProgress:  summary.VoterProgress,
+			upgradeReached: summary.NetworkUpgraded,
+		}
+		if base != nil {
+			in.base, in.baseHeight = base.counts[agenda.ID], base.height
+		}
+		agendaSummary.Forecast = newAgendaForecast(agenda.ID, in, params)
+	}
+}
+
+// Forecast is the forecast for the agenda with the given ID, or nil if the
+// agenda is not known.
+func (tracker *VoteTracker) Forecast(agendaID string) *AgendaForecast {
+	summary := tracker.Summary()
+	for idx := range summary.Agendas {
+		if summary.Agendas[idx].ID == agendaID {
+			return summary.Agendas[idx].Forecast
+		}
+	}
+	return nil
+}
+
 // Summary is a getter for the cached VoteSummary. The summary returned will
 // never be modified by VoteTracker, so can be used read-only by any number
 // of threads.
@@ -16,6 +16,7 @@ import (
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+	"github.com/decred/dcrd/wire"
 
 	apitypes "github.com/decred/dcrdata/v8/api/types"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
@@ -35,6 +36,7 @@ type NodeClient interface {
 	GetStakeDifficulty(ctx context.Context) (*chainjson.GetStakeDifficultyResult, error)
 	GetBlockHeaderVerbose(ctx context.Context, hash *chainhash.Hash) (*chainjson.GetBlockHeaderVerboseResult, error)
 	TicketFeeInfo(ctx context.Context, blocks *uint32, windows *uint32) (*chainjson.TicketFeeInfoResult, error)
+	GetBlock(ctx context.Context, blockHash *chainhash.Hash) (*wire.MsgBlock, error)
 }
 
 // DataCollector is used for retrieving and processing data from a chain
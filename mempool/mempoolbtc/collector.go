@@ -10,11 +10,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
 	"github.com/decred/dcrdata/v8/txhelpers"
 )
 
@@ -25,6 +28,8 @@ type NodeClient interface {
 	txhelpers.BTCRawTransactionGetter
 	txhelpers.BTCVerboseTransactionGetter
 	GetBlockHeaderVerbose(hash *chainhash.Hash) (*btcjson.GetBlockHeaderVerboseResult, error)
+	GetMempoolEntry(txHash string) (*btcjson.GetMempoolEntryResult, error)
+	GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error)
 }
 
 // DataCollector is used for retrieving and processing data from a chain
@@ -118,6 +123,8 @@ func (t *DataCollector) Collect() (*BlockID, []exptypes.MempoolTx, txhelpers.BTC
 			Hash:     hashStr, // dup of TxID!
 			Time:     tx.Time,
 			Size:     tx.Size,
+			Weight:   tx.Weight,
+			Depends:  tx.Depends,
 			TotalOut: btcutil.Amount(totalOut).ToBTC(),
 		})
 	}
@@ -130,6 +137,10 @@ func (t *DataCollector) Collect() (*BlockID, []exptypes.MempoolTx, txhelpers.BTC
 // ParseTxns.
 const NumLatestMempoolTxns = 5
 
+// NumProjectedBlocks is the number of blocks projected from the mempool by
+// ParseTxns.
+const NumProjectedBlocks = 8
+
 // ParseTxns analyzes the mempool transactions in the txs slice, and generates a
 // MempoolInfo summary with categorized transactions.
 func ParseTxns(txs []exptypes.MempoolTx, params *chaincfg.Params, lastBlock *BlockID) *exptypes.MutilchainMempoolInfo {
@@ -183,5 +194,12 @@ func ParseTxns(txs []exptypes.MempoolTx, params *chaincfg.Params, lastBlock *Blo
 		OutputsCount:       totalVouts,
 	}
 
+	projTime := latestTime
+	if projTime == 0 {
+		projTime = time.Now().Unix()
+	}
+	mpInfo.Projection = exptypes.NewMempoolProjection(mutilchain.TYPEBTC, txs,
+		lastBlock.Height, projTime, blockchain.MaxBlockWeight, NumProjectedBlocks)
+
 	return &mpInfo
 }
@@ -207,3 +207,17 @@ func (c *DataCache) GetTicketPriceCountTime(feeAvgLength int) *apitypes.PriceCou
 		Time:  dbtypes.NewTimeDef(c.timestamp),
 	}
 }
+
+// GetTxns returns the mempool transactions. The slice must not be modified.
+func (c *DataCache) GetTxns() []exptypes.MempoolTx {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.txns
+}
+
+// GetTimestamp returns the time of the mempool data collection.
+func (c *DataCache) GetTimestamp() time.Time {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.timestamp
+}
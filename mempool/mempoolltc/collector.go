@@ -11,11 +11,14 @@ import (
 	"time"
 
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
 	"github.com/decred/dcrdata/v8/txhelpers"
+	"github.com/ltcsuite/ltcd/blockchain"
 	"github.com/ltcsuite/ltcd/btcjson"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
 )
 
 // txhelpers.VerboseTransactionPromiseGetter.
@@ -25,6 +28,8 @@ type NodeClient interface {
 	txhelpers.LTCRawTransactionGetter
 	txhelpers.LTCVerboseTransactionGetter
 	GetBlockHeaderVerbose(hash *chainhash.Hash) (*btcjson.GetBlockHeaderVerboseResult, error)
+	GetMempoolEntry(txHash string) (*btcjson.GetMempoolEntryResult, error)
+	GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error)
 }
 
 // DataCollector is used for retrieving and processing data from a chain
@@ -116,6 +121,8 @@ func (t *DataCollector) Collect() (*BlockID, []exptypes.MempoolTx, txhelpers.LTC
 			Hash:     hashStr, // dup of TxID!
 			Time:     tx.Time,
 			Size:     tx.Size,
+			Weight:   tx.Weight,
+			Depends:  tx.Depends,
 			TotalOut: ltcutil.Amount(totalOut).ToBTC(),
 		})
 	}
@@ -128,6 +135,10 @@ func (t *DataCollector) Collect() (*BlockID, []exptypes.MempoolTx, txhelpers.LTC
 // ParseTxns.
 const NumLatestMempoolTxns = 5
 
+// NumProjectedBlocks is the number of blocks projected from the mempool by
+// ParseTxns.
+const NumProjectedBlocks = 8
+
 // ParseTxns analyzes the mempool transactions in the txs slice, and generates a
 // MempoolInfo summary with categorized transactions.
 func ParseTxns(txs []exptypes.MempoolTx, params *chaincfg.Params, lastBlock *BlockID) *exptypes.MutilchainMempoolInfo {
@@ -181,5 +192,12 @@ func ParseTxns(txs []exptypes.MempoolTx, params *chaincfg.Params, lastBlock *Blo
 		OutputsCount:       totalVouts,
 	}
 
+	projTime := latestTime
+	if projTime == 0 {
+		projTime = time.Now().Unix()
+	}
+	mpInfo.Projection = exptypes.NewMempoolProjection(mutilchain.TYPELTC, txs,
+		lastBlock.Height, projTime, blockchain.MaxBlockWeight, NumProjectedBlocks)
+
 	return &mpInfo
 }
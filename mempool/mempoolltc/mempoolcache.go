@@ -26,6 +26,8 @@ type DataCache struct {
 	totalOut  float64
 	// All transactions
 	txns []exptypes.MempoolTx
+	// Projected blocks and fee rate histogram
+	projection *exptypes.MempoolProjection
 }
 
 // StoreMPData stores info from data in the mempool cache. It is advisable to
@@ -43,6 +45,7 @@ func (c *DataCache) StoreLTCMPData(txsCopy []exptypes.MempoolTx, info *exptypes.
 	c.timestamp = time.Unix(info.Time, 0)
 
 	c.txns = txsCopy
+	c.projection = info.Projection
 }
 
 // GetHeight returns the mempool height
@@ -58,3 +61,25 @@ func (c *DataCache) GetFees(N int) (uint32, float64) {
 	defer c.mtx.RUnlock()
 	return c.height, c.totalFee
 }
+
+// GetProjection returns the projected blocks and the fee rate histogram of the
+// mempool.
+func (c *DataCache) GetProjection() *exptypes.MempoolProjection {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.projection
+}
+
+// GetTxns returns the mempool transactions. The slice must not be modified.
+func (c *DataCache) GetTxns() []exptypes.MempoolTx {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.txns
+}
+
+// GetTimestamp returns the time of the mempool data collection.
+func (c *DataCache) GetTimestamp() time.Time {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.timestamp
+}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
 	"github.com/decred/dcrdata/v8/txhelpers"
 	"github.com/ltcsuite/ltcd/btcjson"
 	"github.com/ltcsuite/ltcd/chaincfg"
@@ -23,6 +25,14 @@ type MempoolDataSaver interface {
 	StoreLTCMPData([]exptypes.MempoolTx, *exptypes.MutilchainMempoolInfo)
 }
 
+// MaxTrackedReplacements is the number of most recent transaction
+// replacements kept by a MempoolMonitor.
+const MaxTrackedReplacements = 10000
+
+// MaxLedgerTxs is the number of transactions that left mempool kept by the
+// ledger of a MempoolMonitor.
+const MaxLedgerTxs = 250000
+
 // MempoolAddressStore wraps txhelpers.MempoolAddressStore with a Mutex.
 type MempoolAddressStore struct {
 	mtx   sync.Mutex
@@ -38,7 +48,9 @@ type MempoolAddressStore struct {
 // perform the collection and parsing, and an optional []MempoolDataSaver is
 // used to to forward the data to arbitrary destinations. The last block's
 // height, hash, and time are kept in memory in order to properly process votes
-// in mempool.
+// in mempool. Conflicting spends of the same outpoints are tracked to record
+// the replaced transactions, and a ledger records the fate of each transaction
+// that leaves mempool.
 type MempoolMonitor struct {
 	mtx        sync.RWMutex
 	ctx        context.Context
@@ -50,22 +62,30 @@ type MempoolMonitor struct {
 	params     *chaincfg.Params
 	collector  *DataCollector
 	dataSavers []MempoolDataSaver
+	signalOuts []chan<- pstypes.HubMessage
+
+	replacements *txhelpers.ReplacementTracker
+	ledger       *txhelpers.MempoolLedger
 }
 
 // NewMempoolMonitor creates a new MempoolMonitor. The MempoolMonitor receives
 // notifications of new transactions on newTxInChan, and of new blocks on the
 // same channel using a nil transaction message. Once TxHandler is started, the
-// MempoolMonitor will process incoming transactions, and forward new ones on
-// via the newTxOutChan following an appropriate signal on hubRelay.
+// MempoolMonitor will process incoming transactions, and signal new ones on
+// each of signalOuts.
 func NewMempoolMonitor(ctx context.Context, collector *DataCollector,
-	savers []MempoolDataSaver, params *chaincfg.Params, initialStore bool) (*MempoolMonitor, error) {
+	savers []MempoolDataSaver, params *chaincfg.Params,
+	signalOuts []chan<- pstypes.HubMessage, initialStore bool) (*MempoolMonitor, error) {
 
 	// Make the skeleton MempoolMonitor.
 	p := &MempoolMonitor{
-		ctx:        ctx,
-		params:     params,
-		collector:  collector,
-		dataSavers: savers,
+		ctx:          ctx,
+		params:       params,
+		collector:    collector,
+		dataSavers:   savers,
+		signalOuts:   signalOuts,
+		replacements: txhelpers.NewReplacementTracker(MaxTrackedReplacements),
+		ledger:       txhelpers.NewMempoolLedger(mutilchain.TYPELTC, MaxLedgerTxs),
 	}
 
 	if initialStore {
@@ -105,8 +125,9 @@ func (p *MempoolMonitor) LastBlockTime() int64 {
 func (p *MempoolMonitor) TxHandler(rawTx *btcjson.TxRawResult) error {
 	log.Tracef("TxHandler: new transaction: %v.", rawTx.Txid)
 
-	// Ignore this tx if it was received before the last block.
-	if rawTx.Time < p.LastBlockTime() {
+	// Ignore this tx if it was received before the last block. A notification
+	// without a time is checked against the time of the mempool entry.
+	if rawTx.Time != 0 && rawTx.Time < p.LastBlockTime() {
 		log.Debugf("Old: %d < %d", rawTx.Time, p.LastBlockTime())
 		return nil
 	}
@@ -117,6 +138,22 @@ func (p *MempoolMonitor) TxHandler(rawTx *btcjson.TxRawResult) error {
 		return err
 	}
 
+	// The mempool entry has the fee, the weight and the time the transaction
+	// entered mempool, which a verbose transaction notification lacks.
+	entry, err := p.collector.ltcdChainSvr.GetMempoolEntry(rawTx.Txid)
+	if err != nil {
+		// It was mined or replaced already.
+		log.Debugf("No mempool entry for %s: %v", rawTx.Txid, err)
+		return nil
+	}
+	if rawTx.Time == 0 {
+		rawTx.Time = entry.Time
+		if rawTx.Time < p.LastBlockTime() {
+			log.Debugf("Old: %d < %d", rawTx.Time, p.LastBlockTime())
+			return nil
+		}
+	}
+
 	hash := msgTx.TxHash().String()
 
 	// Maintain the list of unique stake and regular txns encountered.
@@ -153,6 +190,15 @@ func (p *MempoolMonitor) TxHandler(rawTx *btcjson.TxRawResult) error {
 	}
 	p.addrMap.mtx.Unlock()
 
+	// Send address signals.
+	for addr := range txAddresses {
+		p.hubSend(pstypes.SigAddressTx, &pstypes.AddressMessage{
+			Address:   addr,
+			TxHash:    hash,
+			ChainType: mutilchain.TYPELTC,
+		}, time.Second*10)
+	}
+
 	// Store the current mempool transaction, block info zeroed.
 	p.txnsStore[msgTx.TxHash()] = &txhelpers.LTCTxWithBlockData{
 		Tx:          msgTx,
@@ -163,12 +209,12 @@ func (p *MempoolMonitor) TxHandler(rawTx *btcjson.TxRawResult) error {
 		"%d out addrs (%d new), %d prev out addrs (%d new).", hash, newOuts, newPrevOuts,
 		len(addressesOut), newOutAddrs, len(addressesIn), newInAddrs)
 
-	fee, feeRate := txhelpers.LTCTxFeeRate(msgTx, p.collector.ltcdChainSvr)
+	_, feeRate := txhelpers.LTCTxFeeRate(msgTx, p.collector.ltcdChainSvr)
 
 	tx := exptypes.MempoolTx{
 		TxID:      hash,
 		Version:   int32(rawTx.Version),
-		Fees:      fee.ToBTC(),
+		Fees:      entry.Fee,
 		FeeRate:   feeRate.ToBTC(),
 		VinCount:  len(msgTx.TxIn),
 		VoutCount: len(msgTx.TxOut),
@@ -177,19 +223,64 @@ func (p *MempoolMonitor) TxHandler(rawTx *btcjson.TxRawResult) error {
 		Hash:     hash,
 		Time:     rawTx.Time,
 		Size:     int32(len(rawTx.Hex) / 2),
+		Weight:   int32(entry.Weight),
+		Depends:  entry.Depends,
 		TotalOut: txhelpers.LTCTotalOutFromMsgTx(msgTx).ToBTC(),
 	}
 
+	// Drop the transactions it replaced, and their descendants, from the
+	// inventory and its totals.
+	replacements := p.replacements.AddTx(exptypes.MempoolReplaceableTxs([]exptypes.MempoolTx{tx})[0])
+	for _, r := range replacements {
+		log.Debugf("Transaction %s replaced %s, fee bump %d.", r.ReplacedBy, r.TxID, r.FeeBump)
+	}
+	if len(replacements) > 0 {
+		txs := make([]exptypes.MempoolTx, 0, len(p.inventory.Transactions))
+		for _, t := range p.inventory.Transactions {
+			if p.replacements.Tracked(t.TxID) {
+				txs = append(txs, t)
+				continue
+			}
+			p.inventory.TotalSize -= t.Size
+			p.inventory.TotalFee -= t.Fees
+			p.inventory.TotalOut -= t.TotalOut
+		}
+		p.inventory.Transactions = txs
+	}
+
 	p.inventory.Transactions = append([]exptypes.MempoolTx{tx}, p.inventory.Transactions...)
+	p.inventory.TotalSize += tx.Size
+	p.inventory.TotalFee += tx.Fees
+	p.inventory.TotalOut += tx.TotalOut
 	p.inventory.TotalTransactions = int64(len(p.inventory.Transactions))
 	p.inventory.OutputsCount += 1
 	// Update latest transactions, popping the oldest transaction off
 	p.inventory.FormattedTotalSize = exptypes.BytesString(uint64(p.inventory.TotalSize))
 	p.inventory.Unlock()
 	p.mtx.RUnlock()
+
+	for _, ds := range p.ledger.Seen(exptypes.MempoolLedgerTxs([]exptypes.MempoolTx{tx}), tx.Time) {
+		log.Debugf("Transaction %s double spends %s.", ds.TxID, ds.ConflictsWith)
+	}
+
+	// Signal the new transaction.
+	p.hubSend(pstypes.SigNewChainTx, &pstypes.ChainTx{
+		ChainType: mutilchain.TYPELTC,
+		Tx:        &tx,
+	}, time.Second*10)
 	return nil
 }
 
+func (p *MempoolMonitor) hubSend(sig pstypes.HubSignal, msg interface{}, timeout time.Duration) {
+	for _, sigout := range p.signalOuts {
+		select {
+		case sigout <- pstypes.HubMessage{Signal: sig, Msg: msg}:
+		case <-time.After(timeout):
+			log.Errorf("send to signalOuts (%v) failed: Timeout waiting for WebsocketHub.", sig)
+		}
+	}
+}
+
 // Refresh collects mempool data, resets counters ticket counters and the timer,
 // but does not dispatch the MempoolDataSavers.
 func (p *MempoolMonitor) Refresh() ([]exptypes.MempoolTx, *exptypes.MutilchainMempoolInfo, error) {
@@ -207,6 +298,12 @@ func (p *MempoolMonitor) Refresh() ([]exptypes.MempoolTx, *exptypes.MutilchainMe
 	// Pre-sort the txs so other consumers will not have to do it.
 	sort.Sort(exptypes.MPTxsByTime(txs))
 	inventory := ParseTxns(txs, p.params, blockId)
+	replacements := p.replacements.Reset(exptypes.MempoolReplaceableTxs(txs))
+	if len(replacements) > 0 {
+		log.Debugf("%d transactions were replaced since the last mempool collection.",
+			len(replacements))
+	}
+	p.ledger.Snapshot(exptypes.MempoolLedgerTxs(txs), time.Now().Unix())
 
 	// Reset the counter for tickets since last report.
 	p.mtx.Lock()
@@ -252,6 +349,44 @@ func (p *MempoolMonitor) CollectAndStore() error {
 	return nil
 }
 
+// Replacements returns the tracker of the transactions replaced in mempool and
+// of the CPFP packages of the current mempool.
+func (p *MempoolMonitor) Replacements() *txhelpers.ReplacementTracker {
+	return p.replacements
+}
+
+// Ledger returns the ledger of the fates of the transactions seen in mempool.
+func (p *MempoolMonitor) Ledger() *txhelpers.MempoolLedger {
+	return p.ledger
+}
+
+// BlockHandler rebuilds the mempool data and dispatches the storers when a new
+// block is connected. It satisfies the block handler signature of the LTC
+// notifier.
+func (p *MempoolMonitor) BlockHandler(height uint32, hash string) error {
+	p.connectBlock(int64(height), hash)
+	log.Debugf("Refreshing the mempool after block %d.", height)
+	return p.CollectAndStore()
+}
+
+// connectBlock records the transactions of a new block in the ledger.
+func (p *MempoolMonitor) connectBlock(height int64, hash string) {
+	blockHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		log.Errorf("Invalid block hash %s: %v", hash, err)
+		return
+	}
+	msgBlock, err := p.collector.ltcdChainSvr.GetBlock(blockHash)
+	if err != nil {
+		log.Errorf("GetBlock failed for block %s: %v", hash, err)
+		return
+	}
+	doubleSpends := p.ledger.BlockConnected(height, txhelpers.LTCBlockLedgerTxs(msgBlock), time.Now().Unix())
+	for _, ds := range doubleSpends {
+		log.Debugf("Transaction %s was conflicted by %s mined at height %d.", ds.TxID, ds.ConflictsWith, height)
+	}
+}
+
 // UnconfirmedTxnsForAddress indexes (1) outpoints in mempool that pay to the
 // given address, (2) previous outpoint being consumed that paid to the address,
 // and (3) all relevant transactions. See txhelpers.AddressOutpoints for more
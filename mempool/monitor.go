@@ -17,6 +17,7 @@ import (
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
 	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
 	"github.com/decred/dcrdata/v8/txhelpers"
 )
@@ -26,6 +27,10 @@ type MempoolDataSaver interface {
 	StoreMPData(*StakeData, []exptypes.MempoolTx, *exptypes.MempoolInfo)
 }
 
+// MaxLedgerTxs is the number of transactions that left mempool kept by the
+// ledger of a MempoolMonitor.
+const MaxLedgerTxs = 250000
+
 // MempoolAddressStore wraps txhelpers.MempoolAddressStore with a Mutex.
 type MempoolAddressStore struct {
 	mtx   sync.Mutex
@@ -41,7 +46,8 @@ type MempoolAddressStore struct {
 // perform the collection and parsing, and an optional []MempoolDataSaver is
 // used to to forward the data to arbitrary destinations. The last block's
 // height, hash, and time are kept in memory in order to properly process votes
-// in mempool.
+// in mempool. A ledger records the fate of each transaction that leaves
+// mempool.
 type MempoolMonitor struct {
 	mtx        sync.RWMutex
 	ctx        context.Context
@@ -53,6 +59,7 @@ type MempoolMonitor struct {
 	params     *chaincfg.Params
 	collector  *DataCollector
 	dataSavers []MempoolDataSaver
+	ledger     *txhelpers.MempoolLedger
 
 	// Outgoing message
 	signalOuts []chan<- pstypes.HubMessage
@@ -74,6 +81,7 @@ func NewMempoolMonitor(ctx context.Context, collector *DataCollector,
 		collector:  collector,
 		dataSavers: savers,
 		signalOuts: signalOuts,
+		ledger:     txhelpers.NewMempoolLedger(mutilchain.TYPEDCR, MaxLedgerTxs),
 	}
 
 	if initialStore {
@@ -105,7 +113,8 @@ func (p *MempoolMonitor) LastBlockTime() int64 {
 }
 
 // BlockHandler satisfies notification.BlockHandler. Triggers a websocket update.
-func (p *MempoolMonitor) BlockHandler(height uint32, _ string) error {
+func (p *MempoolMonitor) BlockHandler(height uint32, hash string) error {
+	p.connectBlock(int64(height), hash)
 	// Signal a new block
 	log.Debugf("New block at height %d - starting CollectAndStore...", height)
 	_ = p.CollectAndStore()
@@ -356,12 +365,40 @@ func (p *MempoolMonitor) TxHandler(rawTx *chainjson.TxRawResult) error {
 	p.inventory.Unlock()
 	p.mtx.RUnlock()
 
+	for _, ds := range p.ledger.Seen(exptypes.MempoolLedgerTxs([]exptypes.MempoolTx{tx}), tx.Time) {
+		log.Debugf("Transaction %s double spends %s.", ds.TxID, ds.ConflictsWith)
+	}
+
 	// Broadcast the new transaction.
 	log.Tracef("Signaling new tx to hub relays...")
 	p.hubSend(pstypes.SigNewTx, &tx, time.Second*10)
 	return nil
 }
 
+// connectBlock records the regular and stake transactions of a new block in
+// the ledger.
+func (p *MempoolMonitor) connectBlock(height int64, hash string) {
+	blockHash, err := chainhash.NewHashFromStr(hash)
+	if err != nil {
+		log.Errorf("Invalid block hash %s: %v", hash, err)
+		return
+	}
+	msgBlock, err := p.collector.dcrdChainSvr.GetBlock(p.ctx, blockHash)
+	if err != nil {
+		log.Errorf("GetBlock failed for block %s: %v", hash, err)
+		return
+	}
+	doubleSpends := p.ledger.BlockConnected(height, txhelpers.BlockLedgerTxs(msgBlock), time.Now().Unix())
+	for _, ds := range doubleSpends {
+		log.Debugf("Transaction %s was conflicted by %s mined at height %d.", ds.TxID, ds.ConflictsWith, height)
+	}
+}
+
+// Ledger returns the ledger of the fates of the transactions seen in mempool.
+func (p *MempoolMonitor) Ledger() *txhelpers.MempoolLedger {
+	return p.ledger
+}
+
 func (p *MempoolMonitor) hubSend(sig pstypes.HubSignal, msg interface{}, timeout time.Duration) {
 	for _, sigout := range p.signalOuts {
 		select {
@@ -390,6 +427,7 @@ func (p *MempoolMonitor) Refresh() (*StakeData, []exptypes.MempoolTx, *exptypes.
 	// Pre-sort the txs so other consumers will not have to do it.
 	sort.Sort(exptypes.MPTxsByTime(txs))
 	inventory := ParseTxns(txs, p.params, &stakeData.LatestBlock)
+	p.ledger.Snapshot(exptypes.MempoolLedgerTxs(txs), time.Now().Unix())
 
 	// Reset the counter for tickets since last report.
 	p.mtx.Lock()
@@ -0,0 +1,197 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package mutilchain
+
+import (
+	"errors"
+	"math"
+)
+
+// AttackCostConfig holds the inputs of a 51% attack cost estimate for a PoW
+// chain. Hash rates of the hardware and the rental market are in HashUnit,
+// which is UnitHashes H/s.
+type AttackCostConfig struct {
+	ChainType string `json:"chain"`
+	// HashRate is the network hash rate in H/s, and Price the coin price in
+	// USD.
+	HashRate float64 `json:"hashrate"`
+	Price    float64 `json:"price"`
+	// AttackPercent is the share of the total hash rate held by the attacker
+	// during the attack, with the honest miners still mining.
+	AttackPercent float64 `json:"attack_percent"`
+	HashUnit      string  `json:"hash_unit"`
+	UnitHashes    float64 `json:"unit_hashes"`
+	// The mining device, with its hash rate in HashUnit, power in W and cost
+	// in USD.
+	Device         string  `json:"device"`
+	DeviceHashRate float64 `json:"device_hashrate"`
+	DevicePower    float64 `json:"device_power"`
+	DeviceCost     float64 `json:"device_cost"`
+	// ElectricityCost is in USD/kWh. OtherCosts is the facility cost as a
+	// percentage of the hardware and electricity costs.
+	ElectricityCost float64 `json:"electricity_cost"`
+	OtherCosts      float64 `json:"other_costs"`
+	// RentalPrice is the price in USD of one HashUnit for a day on the hash
+	// rate rental markets, and RentalAvailable the percentage of the network
+	// hash rate offered there.
+	RentalPrice     float64 `json:"rental_price"`
+	RentalAvailable float64 `json:"rental_available"`
+}
+
+// maxAttackDevices is the most mining devices of an estimate, far more than
+// could be built but few enough to be counted exactly.
+const maxAttackDevices = 1e15
+
+// DefaultAttackCostConfig returns the hardware, electricity and rental market
+// assumptions for the chain, without the network hash rate and price. It
+// returns nil for a chain that is not a PoW-only chain.
+func DefaultAttackCostConfig(chainType string) *AttackCostConfig {
+	cfg := &AttackCostConfig{
+		ChainType:       chainType,
+		AttackPercent:   51,
+		ElectricityCost: 0.1,
+		OtherCosts:      5,
+	}
+	switch chainType {
+	case TYPEBTC:
+		cfg.HashUnit, cfg.UnitHashes = "TH/s", 1e12
+		cfg.Device, cfg.DeviceHashRate, cfg.DevicePower, cfg.DeviceCost = "Antminer S21 Pro (SHA-256)", 234, 3510, 5500
+		cfg.RentalPrice, cfg.RentalAvailable = 0.055, 0.5
+	case TYPELTC:
+		cfg.HashUnit, cfg.UnitHashes = "GH/s", 1e9
+		cfg.Device, cfg.DeviceHashRate, cfg.DevicePower, cfg.DeviceCost = "Antminer L9 (Scrypt)", 16, 3360, 13000
+		cfg.RentalPrice, cfg.RentalAvailable = 0.3, 3
+	case TYPEXMR:
+		cfg.HashUnit, cfg.UnitHashes = "kH/s", 1e3
+		cfg.Device, cfg.DeviceHashRate, cfg.DevicePower, cfg.DeviceCost = "AMD Ryzen 9 7950X (RandomX)", 22, 200, 900
+		cfg.RentalPrice, cfg.RentalAvailable = 0.015, 5
+	default:
+		return nil
+	}
+	return cfg
+}
+
+// Validate checks that the assumptions can give an estimate.
+func (cfg *AttackCostConfig) Validate() error {
+	switch {
+	case cfg.HashRate <= 0:
+		return errors.New("no network hash rate")
+	case cfg.AttackPercent <= 0 || cfg.AttackPercent >= 100:
+		return errors.New("attack percent must be between 0 and 100")
+	case cfg.UnitHashes <= 0 || cfg.DeviceHashRate <= 0:
+		return errors.New("device hash rate must be positive")
+	case cfg.DevicePower < 0 || cfg.DeviceCost < 0 || cfg.ElectricityCost < 0 || cfg.OtherCosts < 0:
+		return errors.New("costs must not be negative")
+	case cfg.RentalPrice < 0 || cfg.RentalAvailable < 0 || cfg.RentalAvailable > 100:
+		return errors.New("rental price must not be negative and rental availability must be a percentage")
+	}
+	return nil
+}
+
+// AttackCostPeriod is the cost in USD of an attack lasting Hours. Buying the
+// hardware costs the devices, their electricity and the facility costs.
+// Renting takes as much of the attack hash rate as the rental market offers,
+// and buys hardware for the rest. The coin amounts are zero without a price.
+type AttackCostPeriod struct {
+	Hours           float64 `json:"hours"`
+	HardwareCost    float64 `json:"hardware_cost"`
+	ElectricityKWh  float64 `json:"electricity_kwh"`
+	ElectricityCost float64 `json:"electricity_cost"`
+	OtherCosts      float64 `json:"other_costs"`
+	BuyCost         float64 `json:"buy_cost"`
+	BuyCostCoins    float64 `json:"buy_cost_coins"`
+	RentalCost      float64 `json:"rental_cost"`
+	RemainderCost   float64 `json:"remainder_cost"`
+	RentCost        float64 `json:"rent_cost"`
+	RentCostCoins   float64 `json:"rent_cost_coins"`
+}
+
+// AttackCost is the estimated cost of a 51% attack. AttackHashRate and
+// RentedHashRate are in H/s, and RentalCoverage is the percentage of the
+// attack hash rate available for rent.
+type AttackCost struct {
+	Config         *AttackCostConfig `json:"config"`
+	AttackHashRate float64           `json:"attack_hashrate"`
+	Devices        int64             `json:"devices"`
+	RentedHashRate float64           `json:"rented_hashrate"`
+	RentalCoverage float64           `json:"rental_coverage"`
+	Hour           *AttackCostPeriod `json:"hour"`
+	Day            *AttackCostPeriod `json:"day"`
+}
+
+// EstimateAttackCost estimates the cost of holding AttackPercent of the total
+// hash rate with new hash rate, for an hour and for a day.
+func EstimateAttackCost(cfg *AttackCostConfig) (*AttackCost, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	attackHashRate := cfg.HashRate * cfg.AttackPercent / (100 - cfg.AttackPercent)
+	rented := math.Min(cfg.HashRate*cfg.RentalAvailable/100, attackHashRate)
+	deviceHashes := cfg.DeviceHashRate * cfg.UnitHashes
+	if attackHashRate/deviceHashes > maxAttackDevices {
+		return nil, errors.New("the attack needs too many devices, the device hash rate is too low")
+	}
+	devices := func(hashRate float64) int64 {
+		// Allow for floating point error before rounding up.
+		return int64(math.Ceil(hashRate/deviceHashes - 1e-9))
+	}
+	est := &AttackCost{
+		Config:         cfg,
+		AttackHashRate: attackHashRate,
+		Devices:        devices(attackHashRate),
+		RentedHashRate: rented,
+		RentalCoverage: 100 * rented / attackHashRate,
+	}
+	remainderDevices := devices(attackHashRate - rented)
+
+	// hardwareCost is the cost of running n devices for the hours.
+	hardwareCost := func(n int64, hours float64) (hardware, kwh, electricity, other float64) {
+		hardware = float64(n) * cfg.DeviceCost
+		kwh = float64(n) * cfg.DevicePower * hours / 1000
+		electricity = kwh * cfg.ElectricityCost
+		other = cfg.OtherCosts / 100 * (hardware + electricity)
+		return
+	}
+	coins := func(usd float64) float64 {
+		if cfg.Price <= 0 {
+			return 0
+		}
+		return usd / cfg.Price
+	}
+	period := func(hours float64) *AttackCostPeriod {
+		p := &AttackCostPeriod{Hours: hours}
+		p.HardwareCost, p.ElectricityKWh, p.ElectricityCost, p.OtherCosts = hardwareCost(est.Devices, hours)
+		p.BuyCost = p.HardwareCost + p.ElectricityCost + p.OtherCosts
+		p.BuyCostCoins = coins(p.BuyCost)
+		p.RentalCost = rented / cfg.UnitHashes * cfg.RentalPrice * hours / 24
+		hardware, _, electricity, other := hardwareCost(remainderDevices, hours)
+		p.RemainderCost = hardware + electricity + other
+		p.RentCost = p.RentalCost + p.RemainderCost
+		p.RentCostCoins = coins(p.RentCost)
+		return p
+	}
+	est.Hour = period(1)
+	est.Day = period(24)
+	if !est.finite() {
+		return nil, errors.New("the estimate is out of range")
+	}
+	return est, nil
+}
+
+// finite checks that the amounts of the estimate are finite numbers, which
+// can be encoded as JSON.
+func (est *AttackCost) finite() bool {
+	amounts := []float64{est.AttackHashRate, est.RentedHashRate, est.RentalCoverage}
+	for _, p := range []*AttackCostPeriod{est.Hour, est.Day} {
+		amounts = append(amounts, p.HardwareCost, p.ElectricityKWh, p.ElectricityCost,
+			p.OtherCosts, p.BuyCost, p.BuyCostCoins, p.RentalCost, p.RemainderCost,
+			p.RentCost, p.RentCostCoins)
+	}
+	for _, f := range amounts {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package mutilchain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateAttackCost(t *testing.T) {
+	cfg := &AttackCostConfig{
+		HashRate:        100e12,
+		Price:           50,
+		AttackPercent:   50,
+		UnitHashes:      1e12,
+		DeviceHashRate:  10,
+		DevicePower:     1000,
+		DeviceCost:      1000,
+		ElectricityCost: 0.1,
+		OtherCosts:      10,
+		RentalPrice:     2.4,
+		RentalAvailable: 25,
+	}
+	est, err := EstimateAttackCost(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Matching the network hash rate takes 10 devices, and 25% of it can be
+	// rented, leaving 8 devices to buy.
+	if est.AttackHashRate != 100e12 || est.Devices != 10 || est.RentalCoverage != 25 {
+		t.Errorf("wrong estimate %+v", est)
+	}
+	day := est.Day
+	if day.HardwareCost != 10000 || day.ElectricityKWh != 240 || math.Abs(day.ElectricityCost-24) > 1e-9 ||
+		math.Abs(day.BuyCost-11026.4) > 1e-9 || math.Abs(day.BuyCostCoins-day.BuyCost/50) > 1e-9 {
+		t.Errorf("wrong buy cost %+v", day)
+	}
+	if math.Abs(day.RentalCost-60) > 1e-9 || math.Abs(day.RemainderCost-8*(1000+2.4)*1.1) > 1e-9 ||
+		math.Abs(day.RentCost-day.RentalCost-day.RemainderCost) > 1e-9 {
+		t.Errorf("wrong rent cost %+v", day)
+	}
+	if hour := est.Hour; math.Abs(hour.RentalCost-2.5) > 1e-9 || hour.HardwareCost != 10000 {
+		t.Errorf("wrong hour cost %+v", hour)
+	}
+
+	// The rental market can cover the whole attack.
+	cfg.RentalAvailable = 100
+	if est, _ = EstimateAttackCost(cfg); est.RentalCoverage != 100 || est.Day.RemainderCost != 0 ||
+		math.Abs(est.Day.RentCost-240) > 1e-9 {
+		t.Errorf("wrong full rental estimate %+v", est.Day)
+	}
+
+	cfg.AttackPercent = 100
+	if _, err = EstimateAttackCost(cfg); err == nil {
+		t.Errorf("expected an error for a 100%% attack")
+	}
+
+	// Too many devices to count, and estimates too large to encode.
+	cfg.AttackPercent = 50
+	cfg.DeviceHashRate = 1e-15
+	if _, err = EstimateAttackCost(cfg); err == nil {
+		t.Errorf("expected an error for a tiny device hash rate")
+	}
+	cfg.DeviceHashRate = 10
+	cfg.DeviceCost = math.MaxFloat64
+	if _, err = EstimateAttackCost(cfg); err == nil {
+		t.Errorf("expected an error for an infinite hardware cost")
+	}
+	for _, chain := range []string{TYPEBTC, TYPELTC, TYPEXMR} {
+		if DefaultAttackCostConfig(chain) == nil {
+			t.Errorf("no defaults for %s", chain)
+		}
+	}
+	if DefaultAttackCostConfig(TYPEDCR) != nil {
+		t.Errorf("unexpected defaults for dcr")
+	}
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/decred/slog"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
+	"github.com/decred/dcrdata/v8/db/dbtypes"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
 	"github.com/decred/dcrdata/v8/pubsub/psclient"
 	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
 	"github.com/decred/dcrdata/v8/semver"
+	"github.com/decred/dcrdata/v8/txhelpers"
 )
 
 var cfg *config
@@ -42,6 +44,7 @@ func main() {
 	opts := psclient.Opts{
 		ReadTimeout:  psclient.DefaultReadTimeout,
 		WriteTimeout: 3 * time.Second,
+		Reconnect:    true,
 	}
 	cl, err := psclient.New(cfg.URL, ctx, &opts)
 	if err != nil {
@@ -54,7 +57,7 @@ func main() {
 
 	// Subscribe/unsubscribe to several events.
 	var currentSubs []string
-	allSubs := []string{"ping", "newtxs", "newblock", "newltcblock", "newbtcblock", "mempool", "address:Dcur2mcGjmENx4DhNqDctW5wJCVyT3Qeqkx", "address"}
+	allSubs := []string{"ping", "newtxs", "newblock", "newltcblock", "newbtcblock", "mempool", "tspend", "chainmempool", "doublespend", "newtxs:btc", "newtxs:ltc", "mempool:btc", "mempool:ltc", "mempool:xmr", "address:Dcur2mcGjmENx4DhNqDctW5wJCVyT3Qeqkx", "address"}
 	subscribe := func(newsubs []string) error {
 		for _, sub := range newsubs {
 			if subd, _ := strInSlice(currentSubs, sub); subd {
@@ -229,12 +232,28 @@ func main() {
 				msg.EventId, m.NumAll, t)
 		case *pstypes.TxList:
 			log.Printf("Message (%s): TxList(len=%d)", msg.EventId, len(*m))
+		case *pstypes.ChainTxList:
+			log.Printf("Message (%s): ChainTxList(chain=%s, len=%d)", msg.EventId, m.ChainType, len(m.Txs))
+		case *exptypes.MutilchainMempoolShort:
+			log.Printf("Message (%s): MutilchainMempoolShort(chain=%s, numTx=%d, time=%v)",
+				msg.EventId, m.ChainType, m.NumAll, time.Unix(m.Time, 0))
 		case *pstypes.AddressMessage:
 			log.Printf("Message (%s): AddressMessage(address=%s, txHash=%s)",
 				msg.EventId, m.Address, m.TxHash)
+		case *dbtypes.TSpendEvent:
+			log.Printf("Message (%s): TSpendEvent(txid=%s, event=%s, height=%d)",
+				msg.EventId, m.TSpend.TxHash, m.Event, m.Height)
+		case *exptypes.MempoolProjection:
+			log.Printf("Message (%s): MempoolProjection(chain=%s, height=%d, blocks=%d)",
+				msg.EventId, m.ChainType, m.Height, len(m.ProjectedBlocks))
+		case *txhelpers.DoubleSpend:
+			log.Printf("Message (%s): DoubleSpend(chain=%s, txid=%s, conflicts=%s, height=%d)",
+				msg.EventId, m.ChainType, m.TxID, m.ConflictsWith, m.Height)
+		case *psclient.ConnectionState:
+			log.Printf("Connection %v (attempt %d, error: %v, resubscribed: %v, failed: %v)",
+				m.State, m.Attempt, m.Err, m.Resubscribed, m.Failed)
 		case *pstypes.HangUp:
-			log.Printf("Hung up. Bye!")
-			return
+			log.Printf("Hung up. Reconnecting...")
 		default:
 			log.Printf("Message of type %v unhandled.", msg.EventId)
 		}
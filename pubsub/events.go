@@ -0,0 +1,490 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+	"github.com/decred/dcrdata/v8/txhelpers"
+)
+
+const (
+	// EventReplaySize is the number of recent events kept to resume the
+	// event streams of reconnecting clients.
+	EventReplaySize = 512
+
+	// eventQueueSize is the number of events queued for an event stream
+	// client. A client that falls further behind is dropped, and may resume
+	// with its Last-Event-ID.
+	eventQueueSize = 64
+
+	// eventRetry is the reconnection delay advised to event stream clients.
+	eventRetry = 3 * time.Second
+)
+
+// EventChains are the chains of the event stream, in the order of the chains
+// query parameter defaults.
+var EventChains = []string{mutilchain.TYPEDCR, mutilchain.TYPEBTC,
+	mutilchain.TYPELTC, mutilchain.TYPEXMR}
+
+// chainEvents are the websocket subscriptions of the event stream events of
+// each chain. The tspend, summaryinfo, summary24h and address events are for
+// Decred.
+var chainEvents = map[string]map[string]string{
+	"newblock": {
+		mutilchain.TYPEDCR: "newblock",
+		mutilchain.TYPEBTC: "newbtcblock",
+		mutilchain.TYPELTC: "newltcblock",
+	},
+	"newtxs": {
+		mutilchain.TYPEDCR: "newtxs",
+		mutilchain.TYPEBTC: "newtxs:" + mutilchain.TYPEBTC,
+		mutilchain.TYPELTC: "newtxs:" + mutilchain.TYPELTC,
+	},
+	"mempool": {
+		mutilchain.TYPEDCR: "mempool",
+		mutilchain.TYPEBTC: "mempool:" + mutilchain.TYPEBTC,
+		mutilchain.TYPELTC: "mempool:" + mutilchain.TYPELTC,
+		mutilchain.TYPEXMR: "mempool:" + mutilchain.TYPEXMR,
+	},
+	"chainmempool": {
+		mutilchain.TYPEDCR: "chainmempool",
+		mutilchain.TYPEBTC: "chainmempool",
+		mutilchain.TYPELTC: "chainmempool",
+	},
+	"doublespend": {
+		mutilchain.TYPEDCR: "doublespend",
+		mutilchain.TYPEBTC: "doublespend",
+		mutilchain.TYPELTC: "doublespend",
+	},
+	"tspend":      {mutilchain.TYPEDCR: "tspend"},
+	"summaryinfo": {mutilchain.TYPEDCR: "summaryinfo"},
+	"summary24h":  {mutilchain.TYPEDCR: "summary24h"},
+}
+
+// streamEvent is an event of the event stream. The events with a zero seq,
+// such as the hang-up, are not kept for replay.
+type streamEvent struct {
+	seq   uint64
+	msg   pstypes.HubMessage
+	event string
+	data  []byte
+}
+
+// eventClient is an event stream client. cl holds its websocket hub
+// subscriptions, and chains the chains of its chain-agnostic events.
+type eventClient struct {
+	cl     *client
+	chains map[string]bool
+	events chan *streamEvent
+}
+
+// newEventClient creates an event stream client for the comma-separated event
+// types and chains of the events and chains query parameters. The chains
+// default to all EventChains. The event types are those of chainEvents,
+// address:<Decred address>, or a websocket subscription such as newtxs:btc.
+func newEventClient(events, chains string) (*eventClient, error) {
+	ec := &eventClient{
+		cl:     newClient(),
+		chains: make(map[string]bool, len(EventChains)),
+		events: make(chan *streamEvent, eventQueueSize),
+	}
+	if chains == "" {
+		chains = strings.Join(EventChains, ",")
+	}
+	for _, chainType := range strings.Split(chains, ",") {
+		chainType = strings.ToLower(strings.TrimSpace(chainType))
+		if !isEventChain(chainType) {
+			return nil, fmt.Errorf("unknown chain %q", chainType)
+		}
+		ec.chains[chainType] = true
+	}
+
+	var subs []string
+	for _, event := range strings.Split(events, ",") {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		if scoped, found := chainEvents[event]; found {
+			for _, chainType := range EventChains {
+				if sub, found := scoped[chainType]; found && ec.chains[chainType] {
+					subs = append(subs, sub)
+				}
+			}
+			continue
+		}
+		subs = append(subs, event)
+	}
+	for _, sub := range subs {
+		sig, sigMsg, valid := pstypes.ValidateSubscription(sub)
+		if !valid || sig == sigPingAndUserCount {
+			return nil, fmt.Errorf("invalid event %q", sub)
+		}
+		if _, err := ec.cl.subscribe(pstypes.HubMessage{Signal: sig, Msg: sigMsg}); err != nil {
+			return nil, err
+		}
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("no events selected for the chains %s", chains)
+	}
+	return ec, nil
+}
+
+func isEventChain(chainType string) bool {
+	for _, c := range EventChains {
+		if c == chainType {
+			return true
+		}
+	}
+	return false
+}
+
+// wants checks if the client is subscribed to the event.
+func (ec *eventClient) wants(ev *streamEvent) bool {
+	if ev.msg.Signal == sigByeNow {
+		return true
+	}
+	if !ec.cl.isSubscribed(ev.msg) {
+		return false
+	}
+	switch msg := ev.msg.Msg.(type) {
+	case *exptypes.MempoolProjection:
+		return ec.chains[msg.ChainType]
+	case *txhelpers.DoubleSpend:
+		return ec.chains[msg.ChainType]
+	}
+	return true
+}
+
+// eventStream keeps the recent events of the websocket hub for replay, and
+// relays new events to the event stream clients.
+type eventStream struct {
+	mtx sync.Mutex
+	// epoch is the prefix of the event IDs, so that the IDs of a previous run
+	// are not resumed.
+	epoch   string
+	seq     uint64
+	ring    [EventReplaySize]*streamEvent
+	clients map[*eventClient]struct{}
+	closed  bool
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{
+		epoch:   strconv.FormatInt(time.Now().Unix(), 10),
+		clients: make(map[*eventClient]struct{}),
+	}
+}
+
+// eventID is the ID of the event with sequence number seq.
+func (s *eventStream) eventID(seq uint64) string {
+	return s.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseEventID gets the sequence number of an event ID of this run.
+func (s *eventStream) parseEventID(id string) (uint64, bool) {
+	epoch, seq, found := strings.Cut(id, "-")
+	if !found || epoch != s.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+// register adds a client, returning the events it missed since lastEventID,
+// or false if the stream is closed.
+func (s *eventStream) register(ec *eventClient, lastEventID string) ([]*streamEvent, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return nil, false
+	}
+	s.clients[ec] = struct{}{}
+
+	last, ok := s.parseEventID(lastEventID)
+	if !ok {
+		return nil, true
+	}
+	// Only the last EventReplaySize events are kept.
+	first := last + 1
+	if s.seq >= EventReplaySize && first <= s.seq-EventReplaySize {
+		first = s.seq - EventReplaySize + 1
+	}
+	var missed []*streamEvent
+	for seq := first; seq <= s.seq; seq++ {
+		ev := s.ring[seq%EventReplaySize]
+		if ev == nil || ev.seq != seq {
+			continue
+		}
+		if ec.wants(ev) {
+			missed = append(missed, ev)
+		}
+	}
+	return missed, true
+}
+
+// unregister removes a client, closing its events channel.
+func (s *eventStream) unregister(ec *eventClient) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, found := s.clients[ec]; found {
+		delete(s.clients, ec)
+		close(ec.events)
+	}
+}
+
+// publish keeps the event for replay unless it has a zero seq, and queues it
+// for the subscribed clients. The clients that are too far behind are dropped.
+func (s *eventStream) publish(ev *streamEvent) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if ev.seq != 0 {
+		s.seq = ev.seq
+		s.ring[ev.seq%EventReplaySize] = ev
+	}
+	for ec := range s.clients {
+		if !ec.wants(ev) {
+			continue
+		}
+		select {
+		case ec.events <- ev:
+		default:
+			log.Debugf("Dropping event stream client %d, %d events behind.", ec.cl.id, len(ec.events))
+			delete(s.clients, ec)
+			close(ec.events)
+		}
+	}
+}
+
+// close drops all the clients, and refuses new ones.
+func (s *eventStream) close() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	for ec := range s.clients {
+		delete(s.clients, ec)
+		close(ec.events)
+	}
+}
+
+// startEventStream registers the internal client of the websocket hub that
+// records its messages for the event stream. The hub must be running.
+func (psh *PubSubHub) startEventStream() {
+	recorder := newClient()
+	recorder.internal = true
+	// The recorder is subscribed to every event of every chain.
+	var subs []string
+	for _, scoped := range chainEvents {
+		for _, sub := range scoped {
+			subs = append(subs, sub)
+		}
+	}
+	for _, sub := range subs {
+		sig, sigMsg, _ := pstypes.ValidateSubscription(sub)
+		if _, err := recorder.subscribe(pstypes.HubMessage{Signal: sig, Msg: sigMsg}); err != nil {
+			log.Errorf("Event stream recorder failed to subscribe to %s: %v", sub, err)
+		}
+	}
+	recorder.subs[sigAddressTx] = struct{}{}
+
+	c := make(hubSpoke, 16)
+	ch := &clientHubSpoke{cl: recorder, c: &c}
+	psh.WsHub.Register <- ch
+	go psh.recordEvents(ch)
+}
+
+// recordEvents encodes the messages received by the recorder for the event
+// stream. It returns when the websocket hub unregisters the recorder.
+func (psh *PubSubHub) recordEvents(ch *clientHubSpoke) {
+	defer close(ch.cl.killed)
+	defer psh.events.close()
+
+	buff := new(bytes.Buffer)
+	var seq uint64
+	for sig := range *ch.c {
+		if !sig.IsValid() || sig.Signal == sigPingAndUserCount {
+			continue
+		}
+		msg, send := psh.encodeMessage(sig, ch.cl, buff)
+		if !send {
+			continue
+		}
+		data := []byte("null")
+		if len(msg) > 0 {
+			data = bytes.TrimSpace(msg)
+		}
+		ev := &streamEvent{
+			msg:   sig,
+			event: sig.EventID(),
+			data:  append([]byte(nil), data...),
+		}
+		if sig.Signal != sigByeNow {
+			seq++
+			ev.seq = seq
+		}
+		psh.events.publish(ev)
+	}
+}
+
+// writeEvent writes an event in the text/event-stream format.
+func (s *eventStream) writeEvent(w http.ResponseWriter, ev *streamEvent) error {
+	var b bytes.Buffer
+	if ev.seq != 0 {
+		b.WriteString("id: " + s.eventID(ev.seq) + "\n")
+	}
+	b.WriteString("event: " + ev.event + "\n")
+	b.WriteString("data: ")
+	b.Write(ev.data)
+	b.WriteString("\n\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// EventsHandler is the http.HandlerFunc of the Server-Sent Events stream of
+// the websocket hub messages. The comma-separated events and chains query
+// parameters select the events, e.g. ?events=newblock,mempool&chains=btc,ltc
+// for the new blocks and mempool updates of BTC and LTC. The chains default to
+// all chains. The event types and data are those of the websocket messages. A
+// client reconnecting with the Last-Event-ID header is sent the events it
+// missed that are still in the replay buffer of the last EventReplaySize
+// events.
+func (psh *PubSubHub) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported.", http.StatusInternalServerError)
+		return
+	}
+	ec, err := newEventClient(r.URL.Query().Get("events"), r.URL.Query().Get("chains"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	missed, ok := psh.events.register(ec, r.Header.Get("Last-Event-ID"))
+	if !ok {
+		http.Error(w, "The event stream is closed.", http.StatusServiceUnavailable)
+		return
+	}
+	defer psh.events.unregister(ec)
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Do not let proxies buffer the stream.
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Each write has its own deadline, since the stream outlives the
+	// server's write timeout.
+	rc := http.NewResponseController(w)
+	write := func(fn func() error) bool {
+		if err := rc.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+			log.Tracef("SetWriteDeadline failed: %v", err)
+		}
+		if err := fn(); err != nil {
+			log.Debugf("Event stream client %d write failed: %v", ec.cl.id, err)
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	ok = write(func() error {
+		_, err := fmt.Fprintf(w, "retry: %d\n\n", eventRetry.Milliseconds())
+		if err != nil {
+			return err
+		}
+		for _, ev := range missed {
+			if err = psh.events.writeEvent(w, ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+	log.Debugf("Event stream client %d connected, %d events replayed.", ec.cl.id, len(missed))
+
+	ticker := time.NewTicker(PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev, open := <-ec.events:
+			if !open {
+				return
+			}
+			if !write(func() error { return psh.events.writeEvent(w, ev) }) {
+				return
+			}
+		case <-ticker.C:
+			// A comment keeps the connection alive through proxies.
+			if !write(func() error { _, err := w.Write([]byte(": ping\n\n")); return err }) {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Event is an event of the event stream. ID is empty for the events that are
+// not kept for replay, such as the bye event of the hang-up.
+type Event struct {
+	ID    string
+	Event string
+	Data  json.RawMessage
+}
+
+// SubscribeEvents subscribes to the events of the event stream selected by the
+// comma-separated events and chains, as with the query parameters of
+// EventsHandler. The channel is closed when ctx is done, when the hub stops, or
+// when the subscriber falls too far behind.
+func (psh *PubSubHub) SubscribeEvents(ctx context.Context, events, chains string) (<-chan *Event, error) {
+	ec, err := newEventClient(events, chains)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := psh.events.register(ec, ""); !ok {
+		return nil, fmt.Errorf("the event stream is closed")
+	}
+
+	out := make(chan *Event)
+	go func() {
+		defer close(out)
+		defer psh.events.unregister(ec)
+		for {
+			select {
+			case ev, open := <-ec.events:
+				if !open {
+					return
+				}
+				e := &Event{Event: ev.event, Data: ev.data}
+				if ev.seq != 0 {
+					e.ID = psh.events.eventID(ev.seq)
+				}
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
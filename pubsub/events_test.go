@@ -0,0 +1,260 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package pubsub
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+	"github.com/decred/dcrdata/v8/txhelpers"
+)
+
+const testAddr = "DsfX4WrSecUwGoRd9B7Lz1JjYssYaVKnjGC"
+
+type sseEvent struct {
+	id, event, data string
+}
+
+// sseClient is a client of the event stream under test.
+type sseClient struct {
+	resp   *http.Response
+	events chan *sseEvent
+}
+
+func connectSSE(t *testing.T, url, lastEventID string) *sseClient {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type %q", ct)
+	}
+	c := &sseClient{resp: resp, events: make(chan *sseEvent, 16)}
+	go func() {
+		defer close(c.events)
+		sc := bufio.NewScanner(resp.Body)
+		ev := new(sseEvent)
+		for sc.Scan() {
+			line := sc.Text()
+			switch {
+			case line == "":
+				if ev.event != "" {
+					c.events <- ev
+				}
+				ev = new(sseEvent)
+			case strings.HasPrefix(line, "id: "):
+				ev.id = line[len("id: "):]
+			case strings.HasPrefix(line, "event: "):
+				ev.event = line[len("event: "):]
+			case strings.HasPrefix(line, "data: "):
+				ev.data = line[len("data: "):]
+			}
+		}
+	}()
+	t.Cleanup(func() { resp.Body.Close() })
+	return c
+}
+
+func (c *sseClient) next(t *testing.T) *sseEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-c.events:
+		if !ok {
+			t.Fatal("event stream closed")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return nil
+}
+
+func (c *sseClient) none(t *testing.T) {
+	t.Helper()
+	select {
+	case ev := <-c.events:
+		t.Fatalf("unexpected event %v", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func newTestPubSubHub(t *testing.T) *PubSubHub {
+	psh := &PubSubHub{
+		WsHub:     NewWebsocketHub(),
+		State:     new(State),
+		invs:      new(exptypes.MempoolInfo),
+		chainInvs: make(map[string]*exptypes.MutilchainMempoolShort),
+	}
+	go psh.WsHub.Run()
+	psh.events = newEventStream()
+	psh.startEventStream()
+	return psh
+}
+
+func doubleSpend(chainType, txid string) pstypes.HubMessage {
+	return pstypes.HubMessage{
+		Signal: sigDoubleSpend,
+		Msg:    &txhelpers.DoubleSpend{ChainType: chainType, TxID: txid},
+	}
+}
+
+func TestEventsHandler(t *testing.T) {
+	psh := newTestPubSubHub(t)
+	srv := httptest.NewServer(http.HandlerFunc(psh.EventsHandler))
+	defer srv.Close()
+
+	for _, query := range []string{"", "?events=bogus", "?events=ping",
+		"?events=tspend&chains=btc", "?events=newblock&chains=doge", "?events=address:xyz"} {
+		resp, err := http.Get(srv.URL + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%q: expected status %d, got %d", query, http.StatusBadRequest, resp.StatusCode)
+		}
+	}
+
+	if n := psh.WsHub.NumClients(); n != 0 {
+		t.Errorf("the recorder is counted as a client: %d", n)
+	}
+
+	url := srv.URL + "?events=doublespend,address:" + testAddr + "&chains=btc,dcr"
+	c := connectSSE(t, url, "")
+	addrs := connectSSE(t, srv.URL+"?events=address:"+testAddr, "")
+
+	psh.HubRelay() <- doubleSpend("ltc", "00")
+	psh.HubRelay() <- doubleSpend("btc", "01")
+	psh.HubRelay() <- pstypes.HubMessage{Signal: sigAddressTx,
+		Msg: &pstypes.AddressMessage{Address: testAddr, TxHash: "02", ChainType: "btc"}}
+	psh.HubRelay() <- pstypes.HubMessage{Signal: sigAddressTx,
+		Msg: &pstypes.AddressMessage{Address: testAddr, TxHash: "03"}}
+
+	first := c.next(t)
+	var ds txhelpers.DoubleSpend
+	if err := json.Unmarshal([]byte(first.data), &ds); err != nil {
+		t.Fatal(err)
+	}
+	if first.event != "doublespend" || ds.ChainType != "btc" || ds.TxID != "01" || first.id == "" {
+		t.Fatalf("unexpected event %v", first)
+	}
+	ev := c.next(t)
+	if ev.event != "address" || !strings.Contains(ev.data, `"transaction":"03"`) {
+		t.Fatalf("unexpected event %v", ev)
+	}
+	c.none(t)
+	if ev = addrs.next(t); ev.event != "address" || ev.id == first.id {
+		t.Fatalf("unexpected event %v", ev)
+	}
+	addrs.none(t)
+
+	// Reconnecting with the Last-Event-ID replays the missed events.
+	c.resp.Body.Close()
+	psh.HubRelay() <- doubleSpend("btc", "04")
+	psh.HubRelay() <- doubleSpend("dcr", "05")
+	c = connectSSE(t, url, first.id)
+	for _, want := range []string{`"transaction":"03"`, `"txid":"04"`, `"txid":"05"`} {
+		if ev = c.next(t); !strings.Contains(ev.data, want) {
+			t.Fatalf("expected %s, got event %v", want, ev)
+		}
+	}
+	psh.HubRelay() <- doubleSpend("btc", "06")
+	if ev = c.next(t); !strings.Contains(ev.data, `"txid":"06"`) {
+		t.Fatalf("unexpected event %v", ev)
+	}
+
+	// The IDs of another run are not resumed.
+	other := connectSSE(t, url, "1-1")
+	other.none(t)
+
+	// The clients are told of the hang-up.
+	psh.StopWebsocketHub()
+	for _, cl := range []*sseClient{c, addrs, other} {
+		if ev = cl.next(t); ev.event != "bye" || ev.id != "" {
+			t.Fatalf("unexpected event %v", ev)
+		}
+		if _, open := <-cl.events; open {
+			t.Fatal("event stream not closed")
+		}
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+}
+
+func TestEventStreamReplayLimit(t *testing.T) {
+	s := newEventStream()
+	for seq := uint64(1); seq <= EventReplaySize+10; seq++ {
+		s.publish(&streamEvent{seq: seq, msg: doubleSpend("btc", "00"), event: "doublespend"})
+	}
+	ec, err := newEventClient("doublespend", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	missed, ok := s.register(ec, s.eventID(1))
+	if !ok {
+		t.Fatal("stream closed")
+	}
+	if len(missed) != EventReplaySize || missed[0].seq != 11 {
+		t.Fatalf("replayed %d events from %d", len(missed), missed[0].seq)
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	psh := newTestPubSubHub(t)
+	if _, err := psh.SubscribeEvents(context.Background(), "newblock", "doge"); err == nil {
+		t.Fatal("expected an error for an unknown chain")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := psh.SubscribeEvents(ctx, "doublespend", "btc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	psh.HubRelay() <- doubleSpend("ltc", "00")
+	psh.HubRelay() <- doubleSpend("btc", "01")
+	select {
+	case ev := <-events:
+		if ev.Event != "doublespend" || ev.ID == "" || !strings.Contains(string(ev.Data), `"txid":"01"`) {
+			t.Fatalf("unexpected event %v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+
+	cancel()
+	select {
+	case _, open := <-events:
+		if open {
+			t.Fatal("unexpected event after cancel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events not closed after cancel")
+	}
+	psh.StopWebsocketHub()
+}
@@ -8,10 +8,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/decred/dcrdata/v8/db/dbtypes"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
 	pubsub "github.com/decred/dcrdata/v8/pubsub"
 	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
 	"github.com/decred/dcrdata/v8/semver"
+	"github.com/decred/dcrdata/v8/txhelpers"
 	"golang.org/x/net/websocket"
 )
 
@@ -95,24 +97,97 @@ func newPingMsg(reqID int64) []byte {
 const (
 	DefaultReadTimeout  = pubsub.PingInterval * 10 / 9
 	DefaultWriteTimeout = 5 * time.Second
+
+	// DefaultMinReconnectDelay and DefaultMaxReconnectDelay bound the
+	// exponential backoff between the reconnection attempts of a Client with
+	// Opts.Reconnect set.
+	DefaultMinReconnectDelay = time.Second
+	DefaultMaxReconnectDelay = time.Minute
 )
 
 // Opts defines the psclient Client options.
 type Opts struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+
+	// Reconnect makes a Client created with New resilient. Instead of shutting
+	// down when the connection is lost or goes stale (the server's pings stop
+	// arriving within ReadTimeout), the Client redials the server with
+	// exponential backoff and replays its active subscriptions. The changes of
+	// the connection state are sent on the Receive channel as ClientMessages
+	// with the ConnectionEventId EventId.
+	Reconnect bool
+	// MinReconnectDelay and MaxReconnectDelay bound the delay before each
+	// reconnection attempt, which doubles after every failed attempt. Zero
+	// values select DefaultMinReconnectDelay and DefaultMaxReconnectDelay.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+}
+
+// ConnectionEventId is the EventId of the ClientMessages that report the
+// connection state of a resilient Client. The Message is a *ConnectionState.
+const ConnectionEventId = "connection"
+
+// ConnState is the state of the connection of a resilient Client.
+type ConnState int
+
+const (
+	// ConnDisconnected indicates that the connection was lost or went stale.
+	ConnDisconnected ConnState = iota
+	// ConnReconnecting indicates that a reconnection attempt is pending.
+	ConnReconnecting
+	// ConnReconnected indicates that the Client reconnected, and replayed its
+	// subscriptions.
+	ConnReconnected
+)
+
+// String implements the Stringer interface.
+func (s ConnState) String() string {
+	switch s {
+	case ConnDisconnected:
+		return "disconnected"
+	case ConnReconnecting:
+		return "reconnecting"
+	case ConnReconnected:
+		return "reconnected"
+	default:
+		return "unknown"
+	}
 }
 
-// Client wraps a *websocket.Conn.
+// ConnectionState describes a change of the connection state of a resilient
+// Client. Attempt is the number of the reconnection attempt, starting at 1. Err
+// is the error that dropped the connection or failed the previous attempt. On
+// reconnection, Resubscribed lists the replayed subscriptions, and Failed those
+// the server did not accept again.
+type ConnectionState struct {
+	State        ConnState
+	Attempt      int
+	Err          error
+	Resubscribed []string
+	Failed       []string
+}
+
+// Client wraps a *websocket.Conn. A resilient Client replaces the embedded
+// Conn, under connMtx, when it reconnects, so the promoted methods act on the
+// connection of the time of the call.
 type Client struct {
 	*websocket.Conn
+	connMtx       sync.RWMutex
 	ourConn       bool
+	url           string
 	readTimeout   time.Duration
 	writeTimeout  time.Duration
+	reconnect     bool
+	minDelay      time.Duration
+	maxDelay      time.Duration
 	reqMtx        sync.Mutex
 	recvMsgChan   chan *ClientMessage
 	nextRequestID int64
 	requests      map[int64]chan *pstypes.ResponseMessage
+	reqsFailed    bool
+	subsMtx       sync.Mutex
+	subs          []string
 	sendMtx       sync.Mutex
 	ctx           context.Context
 	shutdown      context.CancelFunc
@@ -126,48 +201,51 @@ func New(url string, ctx context.Context, opts *Opts) (*Client, error) {
 	}
 
 	readTimeout, writeTimeout := DefaultReadTimeout, DefaultWriteTimeout
+	minDelay, maxDelay := DefaultMinReconnectDelay, DefaultMaxReconnectDelay
+	var reconnect bool
 	if opts != nil {
 		readTimeout = opts.ReadTimeout
 		writeTimeout = opts.WriteTimeout
+		reconnect = opts.Reconnect
+		if opts.MinReconnectDelay > 0 {
+			minDelay = opts.MinReconnectDelay
+		}
+		if opts.MaxReconnectDelay > 0 {
+			maxDelay = opts.MaxReconnectDelay
+		}
+		if maxDelay < minDelay {
+			maxDelay = minDelay
+		}
 	}
 
 	ctx, shutdown := context.WithCancel(ctx)
 	cl := &Client{
 		Conn:         ws,
 		ourConn:      true,
+		url:          url,
 		readTimeout:  readTimeout,
 		writeTimeout: writeTimeout,
+		reconnect:    reconnect,
+		minDelay:     minDelay,
+		maxDelay:     maxDelay,
 		recvMsgChan:  make(chan *ClientMessage, 16),
 		requests:     make(map[int64]chan *pstypes.ResponseMessage),
 		ctx:          ctx,
 		shutdown:     shutdown,
 	}
 
-	go cl.receiver()
-
-	// Query for the server's pubsub version.
-	serverVer, err := cl.ServerVersion()
-	if err != nil {
-		cl.Stop()
-		return nil, fmt.Errorf("failed to get server pubsub version: %v", err)
-	}
-	log.Infof("Server pubsub version: %s\n", serverVer)
+	go cl.run()
 
-	// Ensure the server's pubsub version (actual) is compatible with the
-	// client's version (required). This allows the client to have a high minor
-	// version for equal major versions.
-	clientSemVer := Version()
-	serverSemVer := semver.NewSemver(serverVer.Major, serverVer.Minor, serverVer.Patch)
-	if !semver.Compatible(clientSemVer, serverSemVer) {
+	if err = cl.checkServerVersion(); err != nil {
 		cl.Stop()
-		return nil, fmt.Errorf("server pubsub version is %v, but client is version %v",
-			serverSemVer, clientSemVer)
+		return nil, err
 	}
 
 	return cl, nil
 }
 
-// NewFromConn creates a new Client from a *websocket.Conn.
+// NewFromConn creates a new Client from a *websocket.Conn. Such a Client does
+// not reconnect, even with Opts.Reconnect set.
 func NewFromConn(ws *websocket.Conn, ctx context.Context, opts *Opts) *Client {
 	if ws == nil {
 		return nil
@@ -190,11 +268,35 @@ func NewFromConn(ws *websocket.Conn, ctx context.Context, opts *Opts) *Client {
 		shutdown:     shutdown,
 	}
 
-	go cl.receiver()
+	go cl.run()
 
 	return cl
 }
 
+// checkServerVersion queries for the server's pubsub version, and ensures it
+// is compatible with the client's version.
+func (c *Client) checkServerVersion() error {
+	serverVer, err := c.ServerVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get server pubsub version: %v", err)
+	}
+	return compatibleServer(serverVer)
+}
+
+// compatibleServer ensures the server's pubsub version (actual) is compatible
+// with the client's version (required). This allows the client to have a high
+// minor version for equal major versions.
+func compatibleServer(serverVer *pstypes.Ver) error {
+	log.Infof("Server pubsub version: %s\n", serverVer)
+	clientSemVer := Version()
+	serverSemVer := semver.NewSemver(serverVer.Major, serverVer.Minor, serverVer.Patch)
+	if !semver.Compatible(clientSemVer, serverSemVer) {
+		return fmt.Errorf("server pubsub version is %v, but client is version %v",
+			serverSemVer, clientSemVer)
+	}
+	return nil
+}
+
 // Stop shutsdown the Client. If the websocket connection was created during
 // Client construction, it is is shutdown too.
 func (c *Client) Stop() {
@@ -203,14 +305,40 @@ func (c *Client) Stop() {
 	// Cancel the Context to stop the receiver loop, which closes the channel.
 	c.shutdown()
 
-	// Close the websocket connection.
+	// Close the websocket connection. Holding connMtx after canceling the
+	// Context ensures a reconnection does not install a new one.
 	if c.ourConn {
+		c.connMtx.RLock()
+		defer c.connMtx.RUnlock()
 		if err := c.Conn.Close(); err != nil {
 			log.Errorf("Failed to Close websocket connection: %v", err)
 		}
 	}
 }
 
+// conn returns the current websocket connection.
+func (c *Client) conn() *websocket.Conn {
+	c.connMtx.RLock()
+	defer c.connMtx.RUnlock()
+	return c.Conn
+}
+
+// setConn replaces the websocket connection with ws, and closes the previous
+// one. If the Client is stopped, ws is closed instead, and false is returned.
+func (c *Client) setConn(ws *websocket.Conn) bool {
+	c.connMtx.Lock()
+	if c.ctx.Err() != nil {
+		c.connMtx.Unlock()
+		ws.Close()
+		return false
+	}
+	old := c.Conn
+	c.Conn = ws
+	c.connMtx.Unlock()
+	old.Close()
+	return true
+}
+
 // ClientMessage represents a message for a client connection.  The
 // (*Client).Receive method provides a channel such messages from the server.
 type ClientMessage struct {
@@ -224,30 +352,155 @@ func (c *Client) Receive() <-chan *ClientMessage {
 	return c.recvMsgChan
 }
 
-// receiver receives and decodes messages from the server. It is to be run as a
-// goroutine by the constructor of the Client. The decoded ClientMessages are
-// sent on the recvMsgChan, the channel that is obtained via Receive.
-func (c *Client) receiver() {
+// deliver sends the message on the recvMsgChan, unless the Client is stopped
+// first. It returns false in the latter case.
+func (c *Client) deliver(msg *ClientMessage) bool {
+	select {
+	case c.recvMsgChan <- msg:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
+// sendState delivers a connection state change.
+func (c *Client) sendState(state *ConnectionState) {
+	log.Infof("Connection %v (attempt %d): %v", state.State, state.Attempt, state.Err)
+	c.deliver(&ClientMessage{
+		EventId: ConnectionEventId,
+		Message: state,
+	})
+}
+
+// run runs the receiver for the current connection, and for a resilient
+// Client, reconnects when it is lost. It is to be run as a goroutine by the
+// constructor of the Client.
+func (c *Client) run() {
 	// This goroutine may return on its own (e.g. error), or if the parent
 	// context is cancelled. In case of the former, call the Context's cancel
 	// function. In the latter case, it is a no-op.
 	defer c.shutdown()
 
-	// This goroutine sends on recvMsgChan, so it is responsible for closing it.
+	// This goroutine and the receivers it runs send on recvMsgChan, so it is
+	// responsible for closing it once the last receiver has returned.
 	defer close(c.recvMsgChan)
 
+	var reconnected *ConnectionState
+	for {
+		c.openRequests()
+		done := make(chan error, 1)
+		go func() {
+			done <- c.receiver()
+		}()
+
+		// The resubscription requests need the receiver for their responses.
+		if reconnected != nil {
+			c.restore(reconnected)
+			reconnected = nil
+		}
+
+		err := <-done
+		if !c.reconnect || c.ctx.Err() != nil {
+			return
+		}
+
+		// Even a timeout should drop the connection since that indicates pings
+		// from the server did not arrive in time.
+		c.sendState(&ConnectionState{State: ConnDisconnected, Err: err})
+		if reconnected = c.redial(err); reconnected == nil {
+			return
+		}
+	}
+}
+
+// redial dials the server with exponential backoff until it succeeds, or the
+// Client is stopped, in which case it returns nil.
+func (c *Client) redial(err error) *ConnectionState {
+	delay := c.minDelay
+	for attempt := 1; ; attempt++ {
+		c.sendState(&ConnectionState{
+			State:   ConnReconnecting,
+			Attempt: attempt,
+			Err:     err,
+		})
+
+		select {
+		case <-time.After(delay):
+		case <-c.ctx.Done():
+			return nil
+		}
+
+		var ws *websocket.Conn
+		ws, err = websocket.Dial(c.url, "", "/")
+		if err == nil {
+			if !c.setConn(ws) {
+				return nil
+			}
+			return &ConnectionState{State: ConnReconnected, Attempt: attempt}
+		}
+		log.Warnf("Reconnection attempt %d failed: %v", attempt, err)
+
+		if delay *= 2; delay > c.maxDelay {
+			delay = c.maxDelay
+		}
+	}
+}
+
+// restore checks the version of the server after reconnecting, and replays the
+// active subscriptions before reporting the reconnection. If the server is no
+// longer compatible, the Client is stopped.
+func (c *Client) restore(state *ConnectionState) {
+	serverVer, err := c.ServerVersion()
+	if err != nil {
+		// Likely a stale connection. Drop it to reconnect again.
+		log.Warnf("Failed to get server pubsub version: %v", err)
+		c.conn().Close()
+		return
+	}
+	if err = compatibleServer(serverVer); err != nil {
+		log.Errorf("Reconnected to an unusable server: %v", err)
+		c.sendState(&ConnectionState{
+			State:   ConnDisconnected,
+			Attempt: state.Attempt,
+			Err:     err,
+		})
+		c.Stop()
+		return
+	}
+
+	for _, event := range c.Subscriptions() {
+		resp, err := c.Subscribe(event)
+		if err != nil || !resp.Success {
+			log.Warnf("Failed to resubscribe to %s: %v", event, err)
+			state.Failed = append(state.Failed, event)
+			continue
+		}
+		state.Resubscribed = append(state.Resubscribed, event)
+	}
+
+	c.sendState(state)
+}
+
+// receiver receives and decodes messages from the server on the current
+// connection until it fails, and returns the error. It is to be run as a
+// goroutine by run. The decoded ClientMessages are sent on the recvMsgChan,
+// the channel that is obtained via Receive.
+func (c *Client) receiver() error {
+	// The pending requests will not receive a response on another connection.
+	defer c.failRequests()
+
 	for {
 		if c.ctx.Err() != nil {
 			log.Trace("receiver: context canceled...")
-			return
+			return c.ctx.Err()
 		}
 
 		resp, err := c.receiveMsg()
 		if err != nil {
-			// Even a timeout should close shutdown the client since that
-			// indicates pings from the server did not arrive in time.
-			log.Errorf("ReceiveMsg failed: %v", err)
-			return
+			if c.ctx.Err() == nil {
+				log.Errorf("ReceiveMsg failed: %v", err)
+			}
+			return err
 		}
 
 		msg, err := DecodeMsg(resp)
@@ -260,7 +513,7 @@ func (c *Client) receiver() {
 		case *pstypes.ResponseMessage:
 			log.Debugf("Response to %s request ID=%d received. Success = %v. Data: %v",
 				m.RequestEventId, m.RequestId, m.Success, m.Data)
-			respChan := c.responseChan(m.RequestId)
+			respChan := c.takeResponseChan(m.RequestId)
 			if respChan == nil {
 				log.Errorf("receiver failed to find request ID %d", m.RequestId)
 				continue
@@ -268,16 +521,20 @@ func (c *Client) receiver() {
 			go func() {
 				respChan <- m // unbuffered
 				close(respChan)
-				c.deleteRequestID(m.RequestId)
 			}()
 			continue
 		case *pstypes.HangUp:
-			log.Infof("The server is hanging up on us! Shutting down.")
-			c.recvMsgChan <- &ClientMessage{
+			c.deliver(&ClientMessage{
 				EventId: resp.EventId,
 				Message: msg,
+			})
+			if c.reconnect {
+				// The server closes the connection next.
+				log.Infof("The server is hanging up on us! Reconnecting.")
+				continue
 			}
-			return
+			log.Infof("The server is hanging up on us! Shutting down.")
+			return fmt.Errorf("server hung up")
 		case string:
 			// generic "message"
 			log.Debugf("Message (%s): %s", resp.EventId, m)
@@ -292,6 +549,12 @@ func (c *Client) receiver() {
 				resp.EventId, m.NumAll, t)
 		case *pstypes.TxList:
 			log.Debugf("Message (%s): TxList(len=%d)", resp.EventId, len(*m))
+		case *pstypes.ChainTxList:
+			log.Debugf("Message (%s): ChainTxList(chain=%s, len=%d)", resp.EventId, m.ChainType, len(m.Txs))
+		case *exptypes.MutilchainMempoolShort:
+			t := time.Unix(m.Time, 0)
+			log.Debugf("Message (%s): MutilchainMempoolShort(chain=%s, numTx=%d, time=%v)",
+				resp.EventId, m.ChainType, m.NumAll, t)
 		case *pstypes.AddressMessage:
 			log.Debugf("Message (%s): AddressMessage(address=%s, txHash=%s)",
 				resp.EventId, m.Address, m.TxHash)
@@ -300,25 +563,30 @@ func (c *Client) receiver() {
 			continue
 		}
 
-		c.recvMsgChan <- &ClientMessage{
+		c.deliver(&ClientMessage{
 			EventId: resp.EventId,
 			Message: msg,
-		}
+		})
 	}
 }
 
 func (c *Client) send(msg []byte) error {
 	c.sendMtx.Lock()
 	defer c.sendMtx.Unlock()
-	_ = c.SetWriteDeadline(time.Now().Add(c.writeTimeout))
-	_, err := c.Write(msg)
+	ws := c.conn()
+	_ = ws.SetWriteDeadline(time.Now().Add(c.writeTimeout))
+	_, err := ws.Write(msg)
 	return err
 }
 
-func (c *Client) responseChan(reqID int64) chan *pstypes.ResponseMessage {
+// takeResponseChan removes the response channel for the request from the
+// pending requests, and returns it.
+func (c *Client) takeResponseChan(reqID int64) chan *pstypes.ResponseMessage {
 	c.reqMtx.Lock()
 	defer c.reqMtx.Unlock()
-	return c.requests[reqID]
+	respChan := c.requests[reqID]
+	delete(c.requests, reqID)
+	return respChan
 }
 
 func (c *Client) newResponseChan() (chan *pstypes.ResponseMessage, int64) {
@@ -326,7 +594,12 @@ func (c *Client) newResponseChan() (chan *pstypes.ResponseMessage, int64) {
 	reqID := c.nextRequestID
 	c.nextRequestID++
 	respChan := make(chan *pstypes.ResponseMessage)
-	c.requests[reqID] = respChan
+	if c.reqsFailed {
+		// No receiver will respond until reconnected.
+		close(respChan)
+	} else {
+		c.requests[reqID] = respChan
+	}
 	c.reqMtx.Unlock()
 	return respChan, reqID
 }
@@ -337,6 +610,55 @@ func (c *Client) deleteRequestID(reqID int64) {
 	c.reqMtx.Unlock()
 }
 
+// failRequests closes the response channels of the pending requests, and of
+// those made until openRequests is called for a new connection.
+func (c *Client) failRequests() {
+	c.reqMtx.Lock()
+	defer c.reqMtx.Unlock()
+	c.reqsFailed = true
+	for reqID, respChan := range c.requests {
+		close(respChan)
+		delete(c.requests, reqID)
+	}
+}
+
+// openRequests allows requests again once a receiver is started.
+func (c *Client) openRequests() {
+	c.reqMtx.Lock()
+	c.reqsFailed = false
+	c.reqMtx.Unlock()
+}
+
+// Subscriptions returns the events to which the Client is subscribed, in the
+// order of subscription. A resilient Client replays them on reconnection.
+func (c *Client) Subscriptions() []string {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	return append([]string(nil), c.subs...)
+}
+
+func (c *Client) addSubscription(event string) {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	for _, s := range c.subs {
+		if s == event {
+			return
+		}
+	}
+	c.subs = append(c.subs, event)
+}
+
+func (c *Client) removeSubscription(event string) {
+	c.subsMtx.Lock()
+	defer c.subsMtx.Unlock()
+	for i, s := range c.subs {
+		if s == event {
+			c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			return
+		}
+	}
+}
+
 // Subscribe sends a subscribe type WebSocketMessage for the given event name
 // after validating it. The response is returned.
 func (c *Client) Subscribe(event string) (*pstypes.ResponseMessage, error) {
@@ -369,6 +691,10 @@ func (c *Client) Subscribe(event string) (*pstypes.ResponseMessage, error) {
 		return nil, fmt.Errorf("Response channel closed.")
 	}
 
+	if resp.Success {
+		c.addSubscription(strings.Trim(event, `"`))
+	}
+
 	// Read the response.
 	return resp, nil
 }
@@ -382,6 +708,9 @@ func (c *Client) Unsubscribe(event string) (*pstypes.ResponseMessage, error) {
 		return nil, fmt.Errorf("invalid subscription %s", event)
 	}
 
+	// Not to be replayed on reconnection, even if the request fails.
+	c.removeSubscription(strings.Trim(event, `"`))
+
 	respChan, reqID := c.newResponseChan()
 	msg := newUnsubscribeMsg(event, reqID)
 	defer c.deleteRequestID(reqID)
@@ -392,7 +721,10 @@ func (c *Client) Unsubscribe(event string) (*pstypes.ResponseMessage, error) {
 	}
 
 	// Wait for a response with the requestID.
-	resp := <-respChan
+	resp, ok := <-respChan
+	if !ok {
+		return nil, fmt.Errorf("Response channel closed.")
+	}
 
 	// Read the response.
 	return resp, nil
@@ -410,7 +742,10 @@ func (c *Client) ServerVersion() (*pstypes.Ver, error) {
 	}
 
 	// Wait for a response with the requestID
-	resp := <-respChan
+	resp, ok := <-respChan
+	if !ok {
+		return nil, fmt.Errorf("Response channel closed.")
+	}
 	if !resp.Success {
 		return nil, fmt.Errorf("failed to obtain server version")
 	}
@@ -440,9 +775,10 @@ func (c *Client) Ping() error {
 // receiveMsgTimeout waits for the specified time Duration for a message,
 // returned decoded into a WebSocketMessage.
 func (c *Client) receiveMsgTimeout(timeout time.Duration) (*pstypes.WebSocketMessage, error) {
-	_ = c.SetReadDeadline(time.Now().Add(timeout))
+	ws := c.conn()
+	_ = ws.SetReadDeadline(time.Now().Add(timeout))
 	msg := new(pstypes.WebSocketMessage)
-	if err := websocket.JSON.Receive(c.Conn, &msg); err != nil {
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
 		return nil, err
 	}
 	return msg, nil
@@ -468,6 +804,22 @@ func DecodeMsg(msg *pstypes.WebSocketMessage) (interface{}, error) {
 		return &rm, err
 	}
 
+	// The chain-scoped events, e.g. "newtxs:btc" and "mempool:xmr".
+	if event, _, scoped := strings.Cut(msg.EventId, ":"); scoped {
+		switch event {
+		case "newtxs":
+			var txl pstypes.ChainTxList
+			err := json.Unmarshal(msg.Message, &txl)
+			return &txl, err
+		case "mempool":
+			var mpshort exptypes.MutilchainMempoolShort
+			err := json.Unmarshal(msg.Message, &mpshort)
+			return &mpshort, err
+		default:
+			return nil, fmt.Errorf("unrecognized event type")
+		}
+	}
+
 	switch msg.EventId {
 	case "message":
 		var message string
@@ -503,6 +855,18 @@ func DecodeMsg(msg *pstypes.WebSocketMessage) (interface{}, error) {
 		var mpshort exptypes.MempoolShort
 		err := json.Unmarshal(msg.Message, &mpshort)
 		return &mpshort, err
+	case "tspend":
+		var ev dbtypes.TSpendEvent
+		err := json.Unmarshal(msg.Message, &ev)
+		return &ev, err
+	case "chainmempool":
+		var proj exptypes.MempoolProjection
+		err := json.Unmarshal(msg.Message, &proj)
+		return &proj, err
+	case "doublespend":
+		var ds txhelpers.DoubleSpend
+		err := json.Unmarshal(msg.Message, &ds)
+		return &ds, err
 	default:
 		return nil, fmt.Errorf("unrecognized event type")
 	}
@@ -584,6 +948,35 @@ func DecodeMsgMempool(msg *pstypes.WebSocketMessage) (*exptypes.MempoolShort, er
 	return mpShort, nil
 }
 
+// DecodeMsgChainTxList attempts to decode the Message content of the given
+// WebSocketMessage as a chain-scoped newtxs message (*pstypes.ChainTxList).
+func DecodeMsgChainTxList(msg *pstypes.WebSocketMessage) (*pstypes.ChainTxList, error) {
+	txl, err := DecodeMsg(msg)
+	if err != nil {
+		return nil, err
+	}
+	txlist, ok := txl.(*pstypes.ChainTxList)
+	if !ok {
+		return nil, fmt.Errorf("content of Message was not of type *pstypes.ChainTxList")
+	}
+	return txlist, nil
+}
+
+// DecodeMsgChainMempool attempts to decode the Message content of the given
+// WebSocketMessage as a chain-scoped mempool message
+// (*exptypes.MutilchainMempoolShort).
+func DecodeMsgChainMempool(msg *pstypes.WebSocketMessage) (*exptypes.MutilchainMempoolShort, error) {
+	mps, err := DecodeMsg(msg)
+	if err != nil {
+		return nil, err
+	}
+	mpShort, ok := mps.(*exptypes.MutilchainMempoolShort)
+	if !ok {
+		return nil, fmt.Errorf("content of Message was not of type *exptypes.MutilchainMempoolShort")
+	}
+	return mpShort, nil
+}
+
 // DecodeMsgNewBlock attempts to decode the Message content of the given
 // WebSocketMessage as a newblock message (*exptypes.WebsocketBlock).
 func DecodeMsgNewBlock(msg *pstypes.WebSocketMessage) (*exptypes.WebsocketBlock, error) {
@@ -767,6 +767,48 @@ func TestDecodeMsgMempool(t *testing.T) {
 	}
 }
 
+func TestDecodeMsgChainTxList(t *testing.T) {
+	msg := &pstypes.WebSocketMessage{
+		EventId: "newtxs:btc",
+		Message: json.RawMessage(`{"chain":"btc","txs":[
+			{"txid":"9fe0a1ea8a7ef5e2e5b7cba7a06b16d53a6a7e5ba4c5aa3b3a2c63e8d0f1bd4c","size":225,"fees":0.0000452},
+			{"txid":"1c4e2af5b4d09f3fe3b1e3a3c5de0f7e7bc43fb0e0f2e8e6b77d53c3c6a09d21","size":141,"fees":0.0000282}]}`),
+	}
+	txlist, err := DecodeMsgChainTxList(msg)
+	if err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if txlist.ChainType != "btc" || len(txlist.Txs) != 2 || txlist.Txs[1].Size != 141 {
+		t.Errorf("wrong chain tx list %+v", txlist)
+	}
+
+	// A chain-scoped mempool message is not a tx list.
+	msg.EventId = "mempool:btc"
+	if _, err = DecodeMsgChainTxList(msg); err == nil {
+		t.Errorf("expected an error decoding a mempool message as a tx list")
+	}
+}
+
+func TestDecodeMsgChainMempool(t *testing.T) {
+	msg := &pstypes.WebSocketMessage{
+		EventId: "mempool:xmr",
+		Message: json.RawMessage(`{"chain":"xmr","time":1735689600,"size":52000,"num_all":17,
+			"xmr_latest":[{"id_hash":"5f2b4e0c3a1d","blob_size":1530,"fee":30600000}]}`),
+	}
+	mpShort, err := DecodeMsgChainMempool(msg)
+	if err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if mpShort.ChainType != "xmr" || mpShort.NumAll != 17 || len(mpShort.XmrLatest) != 1 {
+		t.Errorf("wrong chain mempool %+v", mpShort)
+	}
+
+	msg.EventId = "newblock:xmr"
+	if _, err = DecodeMsgChainMempool(msg); err == nil {
+		t.Errorf("expected an error decoding an unknown event")
+	}
+}
+
 func TestDecodeMsgNewBlock(t *testing.T) {
 	newBlock, err := DecodeMsgNewBlock(msgNewBlock312592)
 	if err != nil {
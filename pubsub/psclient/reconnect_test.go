@@ -0,0 +1,365 @@
+package psclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+	"golang.org/x/net/websocket"
+)
+
+// testServer is a minimal pubsub server that answers version and subscription
+// requests, pings its clients, and may refuse, kill, or stop pinging them.
+type testServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	conns    []*websocket.Conn
+	refuse   int  // number of upcoming connections to refuse
+	silent   bool // no pings or responses for new connections
+	subsRecv chan string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ts := &testServer{subsRecv: make(chan string, 16)}
+	wsHandler := websocket.Handler(ts.handle)
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mtx.Lock()
+		refuse := ts.refuse > 0
+		if refuse {
+			ts.refuse--
+		}
+		ts.mtx.Unlock()
+		if refuse {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		wsHandler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (ts *testServer) url() string {
+	return "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+func (ts *testServer) kill() {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, ws := range ts.conns {
+		ws.Close()
+	}
+	ts.conns = nil
+}
+
+func (ts *testServer) setSilent(silent bool) {
+	ts.mtx.Lock()
+	ts.silent = silent
+	ts.mtx.Unlock()
+}
+
+func (ts *testServer) send(eventID string, v interface{}) {
+	b, _ := json.Marshal(v)
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	for _, ws := range ts.conns {
+		_ = websocket.JSON.Send(ws, pstypes.WebSocketMessage{EventId: eventID, Message: b})
+	}
+}
+
+func (ts *testServer) handle(ws *websocket.Conn) {
+	ts.mtx.Lock()
+	silent := ts.silent
+	ts.conns = append(ts.conns, ws)
+	ts.mtx.Unlock()
+	defer ws.Close()
+
+	if silent {
+		// A stale connection: the client's read deadline must expire.
+		var msg pstypes.WebSocketMessage
+		for websocket.JSON.Receive(ws, &msg) == nil {
+		}
+		return
+	}
+
+	var sendMtx sync.Mutex
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sendMtx.Lock()
+				_ = websocket.JSON.Send(ws, pstypes.WebSocketMessage{EventId: "ping", Message: json.RawMessage("1")})
+				sendMtx.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		var msg pstypes.WebSocketMessage
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return
+		}
+		var req pstypes.RequestMessage
+		if err := json.Unmarshal(msg.Message, &req); err != nil {
+			return
+		}
+		resp := pstypes.ResponseMessage{
+			RequestEventId: msg.EventId,
+			RequestId:      req.RequestId,
+			Success:        true,
+		}
+		switch msg.EventId {
+		case "version":
+			ver := Version()
+			b, _ := json.Marshal(pstypes.NewVer(ver.Split()))
+			resp.Data = string(b)
+		case "subscribe":
+			ts.subsRecv <- req.Message
+		case "ping":
+			continue
+		}
+		b, _ := json.Marshal(resp)
+		sendMtx.Lock()
+		_ = websocket.JSON.Send(ws, pstypes.WebSocketMessage{EventId: msg.EventId + "Resp", Message: b})
+		sendMtx.Unlock()
+	}
+}
+
+var testOpts = Opts{
+	ReadTimeout:       200 * time.Millisecond,
+	WriteTimeout:      time.Second,
+	Reconnect:         true,
+	MinReconnectDelay: 10 * time.Millisecond,
+	MaxReconnectDelay: 40 * time.Millisecond,
+}
+
+// nextMsg receives the next message other than a ping.
+func nextMsg(t *testing.T, cl *Client) *ClientMessage {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg, ok := <-cl.Receive():
+			if !ok {
+				t.Fatal("Receive channel closed")
+			}
+			if msg.EventId == "ping" {
+				continue
+			}
+			return msg
+		case <-timeout:
+			t.Fatal("timed out waiting for a message")
+		}
+	}
+}
+
+// nextState receives the next connection state, skipping other messages.
+func nextState(t *testing.T, cl *Client) *ConnectionState {
+	t.Helper()
+	for {
+		msg := nextMsg(t, cl)
+		if msg.EventId != ConnectionEventId {
+			continue
+		}
+		return msg.Message.(*ConnectionState)
+	}
+}
+
+// awaitReconnected skips the reconnection attempts until reconnected.
+func awaitReconnected(t *testing.T, cl *Client) *ConnectionState {
+	t.Helper()
+	state := nextState(t, cl)
+	if state.State != ConnDisconnected {
+		t.Fatalf("expected %v, got %v", ConnDisconnected, state.State)
+	}
+	for {
+		state = nextState(t, cl)
+		switch state.State {
+		case ConnReconnecting:
+			continue
+		case ConnReconnected:
+			return state
+		default:
+			t.Fatalf("unexpected state %v", state.State)
+		}
+	}
+}
+
+func drainSubs(ts *testServer) {
+	for {
+		select {
+		case <-ts.subsRecv:
+		default:
+			return
+		}
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	ts := newTestServer(t)
+
+	opts := testOpts
+	cl, err := New(ts.url(), context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Stop()
+
+	for _, event := range []string{"newblock", "mempool:btc", "newtxs"} {
+		resp, err := cl.Subscribe(event)
+		if err != nil || !resp.Success {
+			t.Fatalf("Subscribe(%s) failed: %v", event, err)
+		}
+	}
+	if _, err = cl.Unsubscribe("newtxs"); err != nil {
+		t.Fatal(err)
+	}
+	drainSubs(ts)
+
+	// Refuse the first two reconnection attempts.
+	ts.mtx.Lock()
+	ts.refuse = 2
+	ts.mtx.Unlock()
+	ts.kill()
+
+	state := awaitReconnected(t, cl)
+	if state.Attempt != 3 {
+		t.Errorf("expected reconnection on attempt 3, got %d", state.Attempt)
+	}
+	wantSubs := []string{"newblock", "mempool:btc"}
+	if strings.Join(state.Resubscribed, ",") != strings.Join(wantSubs, ",") || len(state.Failed) > 0 {
+		t.Errorf("resubscribed to %v (failed %v), expected %v", state.Resubscribed, state.Failed, wantSubs)
+	}
+	for _, want := range wantSubs {
+		if got := <-ts.subsRecv; got != want {
+			t.Errorf("server received subscription %s, expected %s", got, want)
+		}
+	}
+
+	// Messages are received on the new connection.
+	ts.send("message", "hello")
+	msg := nextMsg(t, cl)
+	if msg.EventId != "message" || msg.Message.(string) != "hello" {
+		t.Errorf("unexpected message %v: %v", msg.EventId, msg.Message)
+	}
+
+	// Requests are served on the new connection.
+	if _, err = cl.ServerVersion(); err != nil {
+		t.Errorf("ServerVersion failed after reconnecting: %v", err)
+	}
+
+	cl.Stop()
+	for range cl.Receive() {
+	}
+}
+
+func TestClientReconnectStale(t *testing.T) {
+	ts := newTestServer(t)
+
+	opts := testOpts
+	cl, err := New(ts.url(), context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Stop()
+
+	if _, err = cl.Subscribe("newblock"); err != nil {
+		t.Fatal(err)
+	}
+	drainSubs(ts)
+
+	// The first reconnection is to a silent server, so it must be dropped
+	// again when its pings do not arrive in time.
+	ts.setSilent(true)
+	ts.kill()
+
+	state := nextState(t, cl)
+	if state.State != ConnDisconnected {
+		t.Fatalf("expected %v, got %v", ConnDisconnected, state.State)
+	}
+	if state = nextState(t, cl); state.State != ConnReconnecting {
+		t.Fatalf("expected %v, got %v", ConnReconnecting, state.State)
+	}
+	// Without pings, the read deadline expires on the silent connection and
+	// fails the version query, so the client reconnects again.
+	time.Sleep(opts.ReadTimeout / 2)
+	ts.setSilent(false)
+
+	state = awaitReconnected(t, cl)
+	if len(state.Resubscribed) != 1 || state.Resubscribed[0] != "newblock" {
+		t.Errorf("unexpected resubscriptions %v", state.Resubscribed)
+	}
+}
+
+func TestClientNoReconnect(t *testing.T) {
+	ts := newTestServer(t)
+
+	opts := testOpts
+	opts.Reconnect = false
+	cl, err := New(ts.url(), context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Stop()
+
+	ts.kill()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg, ok := <-cl.Receive():
+			if !ok {
+				return
+			}
+			if msg.EventId == ConnectionEventId {
+				t.Fatalf("unexpected connection state %v", msg.Message)
+			}
+		case <-timeout:
+			t.Fatal("Receive channel not closed")
+		}
+	}
+}
+
+func TestClientStopWhileReconnecting(t *testing.T) {
+	ts := newTestServer(t)
+
+	opts := testOpts
+	cl, err := New(ts.url(), context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts.mtx.Lock()
+	ts.refuse = 1 << 30
+	ts.mtx.Unlock()
+	ts.kill()
+
+	for nextState(t, cl).State != ConnReconnecting {
+	}
+	cl.Stop()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-cl.Receive():
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Receive channel not closed")
+		}
+	}
+}
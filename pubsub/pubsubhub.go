@@ -117,10 +117,12 @@ type PubSubHub struct {
 	btcParams  *btcchaincfg.Params
 	invsMtx    sync.RWMutex
 	invs       *exptypes.MempoolInfo
+	chainInvs  map[string]*exptypes.MutilchainMempoolShort
 	ver        pstypes.Ver
 	LtcCharts  *cache.MutilchainChartData
 	BtcCharts  *cache.MutilchainChartData
 	XmrCharts  *cache.MutilchainChartData
+	events     *eventStream
 }
 
 // NewPubSubHub constructs a PubSubHub given a data source. The WebSocketHub is
@@ -131,6 +133,7 @@ func NewPubSubHub(dataSource DataSource) (*PubSubHub, error) {
 
 	// Allocate Mempool fields.
 	psh.invs = new(exptypes.MempoolInfo)
+	psh.chainInvs = make(map[string]*exptypes.MutilchainMempoolShort, len(pstypes.MempoolChains))
 
 	// Retrieve chain parameters.
 	params := psh.sourceBase.GetChainParams()
@@ -173,6 +176,10 @@ func NewPubSubHub(dataSource DataSource) (*PubSubHub, error) {
 	psh.WsHub = NewWebsocketHub()
 	go psh.WsHub.Run()
 
+	// Record the hub messages for the event stream.
+	psh.events = newEventStream()
+	psh.startEventStream()
+
 	return psh, nil
 }
 
@@ -208,6 +215,14 @@ func (psh *PubSubHub) MempoolInventory() *exptypes.MempoolInfo {
 	return psh.invs
 }
 
+// ChainMempoolInventory safely retrieves the summary of the current BTC, LTC or
+// XMR mempool, or nil if there is none yet.
+func (psh *PubSubHub) ChainMempoolInventory(chainType string) *exptypes.MutilchainMempoolShort {
+	psh.invsMtx.RLock()
+	defer psh.invsMtx.RUnlock()
+	return psh.chainInvs[chainType]
+}
+
 // closeWS attempts to close a websocket.Conn, logging errors other than those
 // with messages containing ErrWsClosed.
 func closeWS(ws *websocket.Conn) {
@@ -469,180 +484,276 @@ loop:
 
 		// Respond to the websocket client.
 		pushMsg := pstypes.WebSocketMessage{
-			EventId: sig.Signal.String(),
-			// Message is set in switch statement below.
+			EventId: sig.EventID(),
+			// Message is set by encodeMessage below.
 		}
 
-		// JSON encoder for the Message.
-		buff.Reset()
-		enc := json.NewEncoder(buff)
+		var send bool
+		pushMsg.Message, send = psh.encodeMessage(sig, clientData, buff)
+		if !send {
+			continue loop
+		}
 
-		switch sig.Signal {
-		case sigAddressTx:
-			// sig was already validated, but do it again here in case the
-			// type changed without changing the type assertion here.
-			am, ok := sig.Msg.(*pstypes.AddressMessage)
-			if !ok {
-				log.Errorf("sigAddressTx did not store a *AddressMessage in Msg.")
-				continue loop
-			}
-			err := enc.Encode(am)
-			if err != nil {
-				log.Warnf("Encode(AddressMessage) failed: %v", err)
+		// Send the message.
+		err := ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err != nil && !pstypes.IsWSClosedErr(err) {
+			log.Warnf("SetWriteDeadline failed: %v", err)
+		}
+		if err = websocket.JSON.Send(ws, pushMsg); err != nil {
+			// Do not log the error if the connection is just closed.
+			if !pstypes.IsWSClosedErr(err) {
+				log.Debugf("Failed to encode WebSocketMessage (push) %v: %v", sig, err)
 			}
+			// If the send failed, the client is probably gone, quit the
+			// send loop, unregistering the client from the websocket hub.
+			log.Errorf("websocket.JSON.Send of %v type message failed: %v", sig, err)
+			return
+		}
+	} // for range { a.k.a. loop:
+}
 
-			log.Debugf("Sending sigAddressTx to client %d: %s", clientData.id, am)
+// encodeMessage encodes the message of a signal for a client, using buff. The
+// returned message is only valid until buff is reset. send is false if there is
+// nothing to send to the client, and the message is nil to send an empty
+// message.
+func (psh *PubSubHub) encodeMessage(sig pstypes.HubMessage, clientData *client, buff *bytes.Buffer) (msg json.RawMessage, send bool) {
+	// JSON encoder for the Message.
+	buff.Reset()
+	enc := json.NewEncoder(buff)
+
+	switch sig.Signal {
+	case sigAddressTx:
+		// sig was already validated, but do it again here in case the
+		// type changed without changing the type assertion here.
+		am, ok := sig.Msg.(*pstypes.AddressMessage)
+		if !ok {
+			log.Errorf("sigAddressTx did not store a *AddressMessage in Msg.")
+			return nil, false
+		}
+		err := enc.Encode(am)
+		if err != nil {
+			log.Warnf("Encode(AddressMessage) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigNewBlock:
-			psh.State.mtx.RLock()
-			if psh.State.BlockInfo == nil {
-				psh.State.mtx.RUnlock()
-				break // from switch to send empty message
-			}
-			err := enc.Encode(exptypes.WebsocketBlock{
-				Block: psh.State.BlockInfo,
-				Extra: psh.State.GeneralInfo,
-			})
+		log.Debugf("Sending sigAddressTx to client %d: %s", clientData.id, am)
+
+		return buff.Bytes(), true
+	case sigNewBlock:
+		psh.State.mtx.RLock()
+		if psh.State.BlockInfo == nil {
 			psh.State.mtx.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(WebsocketBlock) failed: %v", err)
-			}
+			return nil, true // send an empty message
+		}
+		err := enc.Encode(exptypes.WebsocketBlock{
+			Block: psh.State.BlockInfo,
+			Extra: psh.State.GeneralInfo,
+		})
+		psh.State.mtx.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(WebsocketBlock) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigSummaryInfo:
-			psh.State.mtx.RLock()
-			if psh.State.SummaryInfo == nil {
-				psh.State.mtx.RUnlock()
-				break // from switch to send empty message
-			}
-			err := enc.Encode(exptypes.WebsocketSummary{
-				SummaryInfo: psh.State.SummaryInfo,
-			})
+		return buff.Bytes(), true
+	case sigSummaryInfo:
+		psh.State.mtx.RLock()
+		if psh.State.SummaryInfo == nil {
 			psh.State.mtx.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(WebsocketSummary) failed: %v", err)
-			}
+			return nil, true // send an empty message
+		}
+		err := enc.Encode(exptypes.WebsocketSummary{
+			SummaryInfo: psh.State.SummaryInfo,
+		})
+		psh.State.mtx.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(WebsocketSummary) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigSummary24h:
-			psh.State.mtx.RLock()
-			if psh.State.Block24hInfo == nil {
-				psh.State.mtx.RUnlock()
-				break // from switch to send empty message
-			}
-			err := enc.Encode(exptypes.WebsocketSummary{
-				Summary24h: psh.State.Block24hInfo,
-			})
+		return buff.Bytes(), true
+	case sigSummary24h:
+		psh.State.mtx.RLock()
+		if psh.State.Block24hInfo == nil {
 			psh.State.mtx.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(WebsocketSummary) failed: %v", err)
-			}
+			return nil, true // send an empty message
+		}
+		err := enc.Encode(exptypes.WebsocketSummary{
+			Summary24h: psh.State.Block24hInfo,
+		})
+		psh.State.mtx.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(WebsocketSummary) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigNewLTCBlock:
-			psh.State.mtx.RLock()
-			if psh.State.LTCBlockInfo == nil {
-				psh.State.mtx.RUnlock()
-				break // from switch to send empty message
-			}
-			err := enc.Encode(exptypes.WebsocketBlock{
-				Block: psh.State.LTCBlockInfo,
-				Extra: psh.State.LTCGeneralInfo,
-			})
+		return buff.Bytes(), true
+	case sigNewLTCBlock:
+		psh.State.mtx.RLock()
+		if psh.State.LTCBlockInfo == nil {
 			psh.State.mtx.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(WebsocketLTCBlock) failed: %v", err)
-			}
+			return nil, true // send an empty message
+		}
+		err := enc.Encode(exptypes.WebsocketBlock{
+			Block: psh.State.LTCBlockInfo,
+			Extra: psh.State.LTCGeneralInfo,
+		})
+		psh.State.mtx.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(WebsocketLTCBlock) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigNewBTCBlock:
-			psh.State.mtx.RLock()
-			if psh.State.BTCBlockInfo == nil {
-				psh.State.mtx.RUnlock()
-				break // from switch to send empty message
-			}
-			err := enc.Encode(exptypes.WebsocketBlock{
-				Block: psh.State.BTCBlockInfo,
-				Extra: psh.State.BTCGeneralInfo,
-			})
+		return buff.Bytes(), true
+	case sigNewBTCBlock:
+		psh.State.mtx.RLock()
+		if psh.State.BTCBlockInfo == nil {
 			psh.State.mtx.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(WebsocketBTCBlock) failed: %v", err)
-			}
+			return nil, true // send an empty message
+		}
+		err := enc.Encode(exptypes.WebsocketBlock{
+			Block: psh.State.BTCBlockInfo,
+			Extra: psh.State.BTCGeneralInfo,
+		})
+		psh.State.mtx.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(WebsocketBTCBlock) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
-		case sigMempoolUpdate:
-			// You probably want the sigNewTxs event. sigMempoolUpdate sends
-			// a summary of mempool contents, and the NumLatestMempoolTxns
-			// latest transactions.
-			inv := psh.MempoolInventory()
-			if inv == nil {
-				break // from switch to send empty message
-			}
-			inv.RLock()
-			err := enc.Encode(inv.MempoolShort)
-			inv.RUnlock()
-			if err != nil {
-				log.Warnf("Encode(MempoolShort) failed: %v", err)
-			}
+		return buff.Bytes(), true
+	case sigMempoolUpdate:
+		// You probably want the sigNewTxs event. sigMempoolUpdate sends
+		// a summary of mempool contents, and the NumLatestMempoolTxns
+		// latest transactions.
+		inv := psh.MempoolInventory()
+		if inv == nil {
+			return nil, true // send an empty message
+		}
+		inv.RLock()
+		err := enc.Encode(inv.MempoolShort)
+		inv.RUnlock()
+		if err != nil {
+			log.Warnf("Encode(MempoolShort) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
+		return buff.Bytes(), true
 
-		case sigPingAndUserCount:
-			// ping and send user count
-			pushMsg.Message = json.RawMessage(strconv.Itoa(psh.WsHub.NumClients())) // No quotes as this is a JSON integer
+	case sigTSpend:
+		ev, ok := sig.Msg.(*dbtypes.TSpendEvent)
+		if !ok {
+			log.Errorf("sigTSpend did not store a *dbtypes.TSpendEvent in Msg.")
+			return nil, false
+		}
+		if err := enc.Encode(ev); err != nil {
+			log.Warnf("Encode(TSpendEvent) failed: %v", err)
+		}
 
-		case sigNewTxs:
-			// Marshal this client's tx buffer if it is not empty.
-			clientData.newTxs.Lock()
-			if len(clientData.newTxs.t) == 0 {
-				clientData.newTxs.Unlock()
-				continue loop // break sigselect
-			}
-			err := enc.Encode(clientData.newTxs.t)
+		return buff.Bytes(), true
 
-			// Reinit the tx buffer.
-			clientData.newTxs.t = make(pstypes.TxList, 0, NewTxBufferSize)
-			clientData.newTxs.Unlock()
-			if err != nil {
-				log.Warnf("Encode([]*exptypes.MempoolTx) failed: %v", err)
-			}
+	case sigChainMempool:
+		proj, ok := sig.Msg.(*exptypes.MempoolProjection)
+		if !ok {
+			log.Errorf("sigChainMempool did not store a *exptypes.MempoolProjection in Msg.")
+			return nil, false
+		}
+		if err := enc.Encode(proj); err != nil {
+			log.Warnf("Encode(MempoolProjection) failed: %v", err)
+		}
 
-			pushMsg.Message = buff.Bytes()
+		return buff.Bytes(), true
 
-		case sigByeNow:
-			pushMsg.Message = []byte(`"The dcrdata server is shutting down. Bye!"`)
-			log.Tracef("Sending %v", string(pushMsg.Message))
+	case sigDoubleSpend:
+		ds, ok := sig.Msg.(*txhelpers.DoubleSpend)
+		if !ok {
+			log.Errorf("sigDoubleSpend did not store a *txhelpers.DoubleSpend in Msg.")
+			return nil, false
+		}
+		if err := enc.Encode(ds); err != nil {
+			log.Warnf("Encode(DoubleSpend) failed: %v", err)
+		}
 
-		// case sigSyncStatus:
-		// 	err := enc.Encode(explorer.SyncStatus())
-		// 	if err != nil {
-		// 		log.Warnf("Encode(SyncStatus()) failed: %v", err)
-		// 	}
-		// 	pushMsg.Message = buff.String()
+		return buff.Bytes(), true
 
-		default:
-			log.Errorf("Not sending a %v to the client.", sig)
-			continue loop // break sigselect
-		} // switch sig
+	case sigChainMempoolUpdate:
+		// The summary of a BTC, LTC or XMR mempool, and its latest
+		// transactions.
+		cm, ok := sig.Msg.(*pstypes.ChainMessage)
+		if !ok {
+			log.Errorf("sigChainMempoolUpdate did not store a *pstypes.ChainMessage in Msg.")
+			return nil, false
+		}
+		inv := psh.ChainMempoolInventory(cm.ChainType)
+		if inv == nil {
+			return nil, true // send an empty message
+		}
+		if err := enc.Encode(inv); err != nil {
+			log.Warnf("Encode(MutilchainMempoolShort) failed: %v", err)
+		}
 
-		// Send the message.
-		err := ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
-		if err != nil && !pstypes.IsWSClosedErr(err) {
-			log.Warnf("SetWriteDeadline failed: %v", err)
+		return buff.Bytes(), true
+
+	case sigNewChainTxs:
+		cm, ok := sig.Msg.(*pstypes.ChainMessage)
+		if !ok {
+			log.Errorf("sigNewChainTxs did not store a *pstypes.ChainMessage in Msg.")
+			return nil, false
 		}
-		if err = websocket.JSON.Send(ws, pushMsg); err != nil {
-			// Do not log the error if the connection is just closed.
-			if !pstypes.IsWSClosedErr(err) {
-				log.Debugf("Failed to encode WebSocketMessage (push) %v: %v", sig, err)
-			}
-			// If the send failed, the client is probably gone, quit the
-			// send loop, unregistering the client from the websocket hub.
-			log.Errorf("websocket.JSON.Send of %v type message failed: %v", sig, err)
-			return
+		// Marshal this client's tx buffer of the chain if it is not empty.
+		txl := clientData.chainTxList(cm.ChainType)
+		if txl == nil {
+			return nil, false
 		}
-	} // for range { a.k.a. loop:
+		txl.Lock()
+		if len(txl.t) == 0 {
+			txl.Unlock()
+			return nil, false
+		}
+		err := enc.Encode(pstypes.ChainTxList{
+			ChainType: cm.ChainType,
+			Txs:       txl.t,
+		})
+
+		// Reinit the tx buffer.
+		txl.t = make(pstypes.TxList, 0, NewTxBufferSize)
+		txl.Unlock()
+		if err != nil {
+			log.Warnf("Encode(ChainTxList) failed: %v", err)
+		}
+
+		return buff.Bytes(), true
+
+	case sigPingAndUserCount:
+		// ping and send user count
+		return json.RawMessage(strconv.Itoa(psh.WsHub.NumClients())), true // No quotes as this is a JSON integer
+
+	case sigNewTxs:
+		// Marshal this client's tx buffer if it is not empty.
+		clientData.newTxs.Lock()
+		if len(clientData.newTxs.t) == 0 {
+			clientData.newTxs.Unlock()
+			return nil, false
+		}
+		err := enc.Encode(clientData.newTxs.t)
+
+		// Reinit the tx buffer.
+		clientData.newTxs.t = make(pstypes.TxList, 0, NewTxBufferSize)
+		clientData.newTxs.Unlock()
+		if err != nil {
+			log.Warnf("Encode([]*exptypes.MempoolTx) failed: %v", err)
+		}
+
+		return buff.Bytes(), true
+
+	case sigByeNow:
+		log.Tracef("Sending %v", sig)
+		return []byte(`"The dcrdata server is shutting down. Bye!"`), true
+
+	// case sigSyncStatus:
+	// 	err := enc.Encode(explorer.SyncStatus())
+	// 	if err != nil {
+	// 		log.Warnf("Encode(SyncStatus()) failed: %v", err)
+	// 	}
+	// 	pushMsg.Message = buff.String()
+
+	default:
+		log.Errorf("Not sending a %v to the client.", sig)
+		return nil, false
+	}
 }
 
 // WebSocketHandler is the http.HandlerFunc for new websocket connections. The
@@ -708,6 +819,59 @@ func (psh *PubSubHub) StoreMPData(_ *mempool.StakeData, _ []exptypes.MempoolTx,
 	log.Debugf("Updated mempool details for the pubsubhub.")
 }
 
+// StoreBTCMPData signals the BTC mempool summary, and the projected blocks and
+// the fee rate histogram of the BTC mempool, to the websocket clients.
+func (psh *PubSubHub) StoreBTCMPData(_ []exptypes.MempoolTx, inv *exptypes.MutilchainMempoolInfo) {
+	if inv == nil {
+		return
+	}
+	psh.storeChainMempool(mutilchain.TYPEBTC, inv.Short(mutilchain.TYPEBTC, mempool.NumLatestMempoolTxns))
+	psh.sendMempoolProjection(inv)
+}
+
+// StoreLTCMPData signals the LTC mempool summary, and the projected blocks and
+// the fee rate histogram of the LTC mempool, to the websocket clients.
+func (psh *PubSubHub) StoreLTCMPData(_ []exptypes.MempoolTx, inv *exptypes.MutilchainMempoolInfo) {
+	if inv == nil {
+		return
+	}
+	psh.storeChainMempool(mutilchain.TYPELTC, inv.Short(mutilchain.TYPELTC, mempool.NumLatestMempoolTxns))
+	psh.sendMempoolProjection(inv)
+}
+
+// StoreXMRMPData signals the XMR mempool summary to the websocket clients.
+func (psh *PubSubHub) StoreXMRMPData(mp *xmrutil.Mempool) {
+	psh.storeChainMempool(mutilchain.TYPEXMR,
+		exptypes.XMRMempoolShort(mp, time.Now().Unix(), mempool.NumLatestMempoolTxns))
+}
+
+func (psh *PubSubHub) storeChainMempool(chainType string, inv *exptypes.MutilchainMempoolShort) {
+	psh.invsMtx.Lock()
+	psh.chainInvs[chainType] = inv
+	psh.invsMtx.Unlock()
+	log.Debugf("Updated %s mempool details for the pubsubhub.", chainType)
+
+	select {
+	case psh.WsHub.HubRelay <- pstypes.HubMessage{
+		Signal: sigChainMempoolUpdate,
+		Msg:    &pstypes.ChainMessage{ChainType: chainType},
+	}:
+	case <-time.After(time.Second * 10):
+		log.Errorf("sigChainMempoolUpdate send failed: Timeout waiting for WebsocketHub.")
+	}
+}
+
+func (psh *PubSubHub) sendMempoolProjection(inv *exptypes.MutilchainMempoolInfo) {
+	if inv == nil || inv.Projection == nil {
+		return
+	}
+	select {
+	case psh.WsHub.HubRelay <- pstypes.HubMessage{Signal: sigChainMempool, Msg: inv.Projection}:
+	case <-time.After(time.Second * 10):
+		log.Errorf("sigChainMempool send failed: Timeout waiting for WebsocketHub.")
+	}
+}
+
 // Store processes and stores new block data, then signals to the WebSocketHub
 // that the new data is available.
 func (psh *PubSubHub) Store(blockData *blockdata.BlockData, msgBlock *wire.MsgBlock) error {
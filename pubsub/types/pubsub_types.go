@@ -8,7 +8,10 @@ import (
 	"strings"
 
 	"github.com/decred/base58"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	"github.com/decred/dcrdata/v8/txhelpers"
 )
 
 // Ver is a json tagged version type.
@@ -62,9 +65,12 @@ type WebSocketMessage struct {
 	Message json.RawMessage `json:"message"`
 }
 
+// AddressMessage signals a transaction involving an address. ChainType is
+// empty for Decred.
 type AddressMessage struct {
-	Address string `json:"address"`
-	TxHash  string `json:"transaction"`
+	Address   string `json:"address"`
+	TxHash    string `json:"transaction"`
+	ChainType string `json:"chain,omitempty"`
 }
 
 type RequestMessage struct {
@@ -85,6 +91,35 @@ func (am AddressMessage) String() string {
 
 type TxList []*exptypes.MempoolTx
 
+// ChainMessage scopes a subscription, or a signal, to the chain ChainType.
+type ChainMessage struct {
+	ChainType string `json:"chain"`
+}
+
+func (cm ChainMessage) String() string {
+	return cm.ChainType
+}
+
+// ChainTx is a new transaction in the mempool of the chain ChainType.
+type ChainTx struct {
+	ChainType string
+	Tx        *exptypes.MempoolTx
+}
+
+// ChainTxList is the list of new mempool transactions of the chain ChainType
+// sent to the chain-scoped newtxs subscribers.
+type ChainTxList struct {
+	ChainType string `json:"chain"`
+	Txs       TxList `json:"txs"`
+}
+
+// Chains of the chain-scoped newtxs and mempool subscriptions, e.g.
+// "newtxs:btc" and "mempool:xmr". The unscoped subscriptions are for Decred.
+var (
+	NewTxsChains  = []string{mutilchain.TYPEBTC, mutilchain.TYPELTC}
+	MempoolChains = []string{mutilchain.TYPEBTC, mutilchain.TYPELTC, mutilchain.TYPEXMR}
+)
+
 type HangUp struct{}
 
 type HubSignal int
@@ -113,6 +148,12 @@ const (
 	SigSummary24h
 	SigNewXMRBlock
 	SigXmrMempoolStatus
+	SigTSpend
+	SigChainMempool
+	SigDoubleSpend
+	SigNewChainTx
+	SigNewChainTxs
+	SigChainMempoolUpdate
 )
 
 var Subscriptions = map[string]HubSignal{
@@ -128,6 +169,9 @@ var Subscriptions = map[string]HubSignal{
 	"summary24h":       SigSummary24h,
 	"xmrMempoolStatus": SigXmrMempoolStatus,
 	"newxmrblock":      SigNewXMRBlock,
+	"tspend":           SigTSpend,
+	"chainmempool":     SigChainMempool,
+	"doublespend":      SigDoubleSpend,
 }
 
 // Event type field for an event.
@@ -153,8 +197,21 @@ var eventIDs = map[HubSignal]string{
 	SigSummary24h:       "summary24h",
 	SigNewXMRBlock:      "newxmrblock",
 	SigXmrMempoolStatus: "xmrMempoolStatus",
+	SigTSpend:           "tspend",
+	SigChainMempool:     "chainmempool",
+	SigDoubleSpend:      "doublespend",
+	// The chain-scoped events are sent as "<event>:<chain>".
+	SigNewChainTx:         "newchaintx",
+	SigNewChainTxs:        "newtxs",
+	SigChainMempoolUpdate: "mempool",
 }
 
+// ValidateSubscription parses a subscribe or unsubscribe request event, e.g.
+// "address:<addr>" or "newtxs:btc", into its hub signal and message. Only the
+// newtxs and mempool subscriptions are chain-scoped. decodetx and sendtx are
+// request events answered on the requesting connection rather than
+// subscriptions, so they are never valid here, and they are not chain-scoped
+// since the pubsub DataSource only decodes and sends Decred transactions.
 func ValidateSubscription(event string) (sub HubSignal, msg interface{}, valid bool) {
 	sig, msgStr := event, ""
 	idx := strings.Index(event, ":")
@@ -179,6 +236,19 @@ func ValidateSubscription(event string) (sub HubSignal, msg interface{}, valid b
 		msg = &AddressMessage{
 			Address: msgStr,
 		}
+	case SigNewTxs, SigMempoolUpdate:
+		// Unscoped for Decred, or scoped to another chain.
+		if msgStr == "" {
+			break
+		}
+		chains, scoped := NewTxsChains, SigNewChainTxs
+		if sub == SigMempoolUpdate {
+			chains, scoped = MempoolChains, SigChainMempoolUpdate
+		}
+		if !isChain(chains, msgStr) {
+			return SigUnknown, nil, false
+		}
+		sub, msg = scoped, &ChainMessage{ChainType: msgStr}
 	default:
 		// Other signals do not have a message.
 		if msgStr != "" {
@@ -189,6 +259,15 @@ func ValidateSubscription(event string) (sub HubSignal, msg interface{}, valid b
 	return
 }
 
+func isChain(chains []string, chainType string) bool {
+	for _, c := range chains {
+		if c == chainType {
+			return true
+		}
+	}
+	return false
+}
+
 func (s HubSignal) String() string {
 	str, found := eventIDs[s]
 	if !found {
@@ -221,11 +300,41 @@ func (m HubMessage) IsValid() bool {
 		_, ok = m.Msg.(*exptypes.MempoolTx)
 	case SigNewTxs:
 		_, ok = m.Msg.([]*exptypes.MempoolTx)
+	case SigTSpend:
+		_, ok = m.Msg.(*dbtypes.TSpendEvent)
+	case SigChainMempool:
+		_, ok = m.Msg.(*exptypes.MempoolProjection)
+	case SigDoubleSpend:
+		_, ok = m.Msg.(*txhelpers.DoubleSpend)
+	case SigNewChainTx:
+		_, ok = m.Msg.(*ChainTx)
+	case SigNewChainTxs:
+		var cm *ChainMessage
+		if cm, ok = m.Msg.(*ChainMessage); ok {
+			ok = isChain(NewTxsChains, cm.ChainType)
+		}
+	case SigChainMempoolUpdate:
+		var cm *ChainMessage
+		if cm, ok = m.Msg.(*ChainMessage); ok {
+			ok = isChain(MempoolChains, cm.ChainType)
+		}
 	}
 
 	return ok
 }
 
+// EventID is the event type field of the message sent to the websocket
+// clients, with the chain of the chain-scoped events.
+func (m HubMessage) EventID() string {
+	switch m.Signal {
+	case SigNewChainTxs, SigChainMempoolUpdate:
+		if cm, ok := m.Msg.(*ChainMessage); ok {
+			return m.Signal.String() + ":" + cm.ChainType
+		}
+	}
+	return m.Signal.String()
+}
+
 func (m HubMessage) String() string {
 	if !m.IsValid() {
 		return "invalid"
@@ -243,6 +352,23 @@ func (m HubMessage) String() string {
 	case SigNewTxs:
 		txs := m.Msg.([]*exptypes.MempoolTx)
 		sigStr += ":len=" + strconv.Itoa(len(txs))
+	case SigTSpend:
+		ev := m.Msg.(*dbtypes.TSpendEvent)
+		sigStr += ":" + ev.Event + ":" + ev.TSpend.TxHash
+	case SigChainMempool:
+		proj := m.Msg.(*exptypes.MempoolProjection)
+		sigStr += ":" + proj.ChainType + ":" + strconv.FormatInt(proj.Height, 10)
+	case SigDoubleSpend:
+		ds := m.Msg.(*txhelpers.DoubleSpend)
+		sigStr += ":" + ds.ChainType + ":" + ds.TxID
+	case SigNewChainTx:
+		ct := m.Msg.(*ChainTx)
+		sigStr += ":" + ct.ChainType
+		if ct.Tx != nil {
+			sigStr += ":" + ct.Tx.Hash
+		}
+	case SigNewChainTxs, SigChainMempoolUpdate:
+		sigStr += ":" + m.Msg.(*ChainMessage).String()
 	}
 
 	return sigStr
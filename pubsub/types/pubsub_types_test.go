@@ -1,8 +1,10 @@
 package types
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/decred/dcrdata/v8/db/dbtypes"
 	exptypes "github.com/decred/dcrdata/v8/explorer/types"
 )
 
@@ -66,6 +68,44 @@ func TestHubMessage_String(t *testing.T) {
 			HubMessage{Signal: SigNewTxs, Msg: &exptypes.MempoolTx{Hash: "4811246cb13f6e74c8c661242064664aba79e0baaae273c320b884cf461b28d7"}},
 			"invalid",
 		},
+		{
+			"ok tspend",
+			HubMessage{Signal: SigTSpend, Msg: &dbtypes.TSpendEvent{
+				Event:  dbtypes.TSpendApproved,
+				TSpend: &dbtypes.TSpendLifecycle{TxHash: "4811246cb13f6e74c8c661242064664aba79e0baaae273c320b884cf461b28d7"},
+			}},
+			"tspend:approved:4811246cb13f6e74c8c661242064664aba79e0baaae273c320b884cf461b28d7",
+		},
+		{
+			"wrong Msg type tspend",
+			HubMessage{Signal: SigTSpend, Msg: dbtypes.TSpendEvent{}},
+			"invalid",
+		},
+		{
+			"ok newtxs:btc",
+			HubMessage{Signal: SigNewChainTxs, Msg: &ChainMessage{ChainType: "btc"}},
+			"newtxs:btc",
+		},
+		{
+			"ok mempool:xmr",
+			HubMessage{Signal: SigChainMempoolUpdate, Msg: &ChainMessage{ChainType: "xmr"}},
+			"mempool:xmr",
+		},
+		{
+			"ok newchaintx",
+			HubMessage{Signal: SigNewChainTx, Msg: &ChainTx{ChainType: "ltc", Tx: &exptypes.MempoolTx{Hash: "4811246cb13f6e74c8c661242064664aba79e0baaae273c320b884cf461b28d7"}}},
+			"newchaintx:ltc:4811246cb13f6e74c8c661242064664aba79e0baaae273c320b884cf461b28d7",
+		},
+		{
+			"unsupported chain newtxs:xmr",
+			HubMessage{Signal: SigNewChainTxs, Msg: &ChainMessage{ChainType: "xmr"}},
+			"invalid",
+		},
+		{
+			"wrong Msg type mempool:ltc",
+			HubMessage{Signal: SigChainMempoolUpdate, Msg: ChainMessage{ChainType: "ltc"}},
+			"invalid",
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,3 +116,41 @@ func TestHubMessage_String(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSubscription(t *testing.T) {
+	tests := []struct {
+		event   string
+		wantSub HubSignal
+		wantMsg interface{}
+		valid   bool
+	}{
+		{"newtxs", SigNewTxs, nil, true},
+		{"mempool", SigMempoolUpdate, nil, true},
+		{"newtxs:btc", SigNewChainTxs, &ChainMessage{ChainType: "btc"}, true},
+		{"newtxs:ltc", SigNewChainTxs, &ChainMessage{ChainType: "ltc"}, true},
+		{"mempool:ltc", SigChainMempoolUpdate, &ChainMessage{ChainType: "ltc"}, true},
+		{"mempool:xmr", SigChainMempoolUpdate, &ChainMessage{ChainType: "xmr"}, true},
+		{"newtxs:xmr", SigUnknown, nil, false},
+		{"mempool:doge", SigUnknown, nil, false},
+		{"newblock:btc", SigUnknown, nil, false},
+		{"newchaintx", SigUnknown, nil, false},
+		{"decodetx", SigUnknown, nil, false},
+		{"sendtx:btc", SigUnknown, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event, func(t *testing.T) {
+			sub, msg, valid := ValidateSubscription(tt.event)
+			if sub != tt.wantSub || valid != tt.valid || !reflect.DeepEqual(msg, tt.wantMsg) {
+				t.Errorf("ValidateSubscription(%q) = %v, %v, %v, want %v, %v, %v",
+					tt.event, sub, msg, valid, tt.wantSub, tt.wantMsg, tt.valid)
+			}
+			if !valid {
+				return
+			}
+			// The outgoing event is the subscribed event.
+			if got := (HubMessage{Signal: sub, Msg: msg}).EventID(); got != tt.event {
+				t.Errorf("HubMessage.EventID() = %q, want %q", got, tt.event)
+			}
+		})
+	}
+}
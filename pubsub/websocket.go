@@ -50,6 +50,13 @@ var (
 	sigByeNow           = pstypes.SigByeNow
 	sigSummaryInfo      = pstypes.SigSummaryInfo
 	sigSummary24h       = pstypes.SigSummary24h
+	sigTSpend           = pstypes.SigTSpend
+	sigChainMempool     = pstypes.SigChainMempool
+	sigDoubleSpend      = pstypes.SigDoubleSpend
+	// The chain-scoped signals.
+	sigNewChainTx         = pstypes.SigNewChainTx
+	sigNewChainTxs        = pstypes.SigNewChainTxs
+	sigChainMempoolUpdate = pstypes.SigChainMempoolUpdate
 )
 
 type txList struct {
@@ -88,6 +95,10 @@ type WebsocketHub struct {
 	killed             chan struct{}
 	requestLimit       int
 	ready              atomic.Value
+
+	// timeToSendChainTxBuffer are the "time to send" flags of the tx buffers
+	// of each chain of the chain-scoped newtxs subscriptions.
+	timeToSendChainTxBuffer map[string]*atomic.Bool
 }
 
 func (wsh *WebsocketHub) TimeToSendTxBuffer() bool {
@@ -99,6 +110,21 @@ func (wsh *WebsocketHub) SetTimeToSendTxBuffer(ready bool) {
 	wsh.timeToSendTxBuffer.Store(ready)
 }
 
+// TimeToSendChainTxBuffer indicates if the periodic send of the tx buffers of
+// a chain of the chain-scoped newtxs subscriptions is due.
+func (wsh *WebsocketHub) TimeToSendChainTxBuffer(chainType string) bool {
+	ready, ok := wsh.timeToSendChainTxBuffer[chainType]
+	return ok && ready.Load()
+}
+
+// SetTimeToSendChainTxBuffer sets if the periodic send of the tx buffers of a
+// chain of the chain-scoped newtxs subscriptions is due.
+func (wsh *WebsocketHub) SetTimeToSendChainTxBuffer(chainType string, ready bool) {
+	if flag, ok := wsh.timeToSendChainTxBuffer[chainType]; ok {
+		flag.Store(ready)
+	}
+}
+
 // Ready is a thread-safe way to fetch the boolean in ready.
 func (wsh *WebsocketHub) Ready() bool {
 	syncing, ok := wsh.ready.Load().(bool)
@@ -117,15 +143,24 @@ type client struct {
 	addrs  map[string]struct{}
 	killed chan struct{}
 	newTxs *txList
+	// chains are the chains of the chain-scoped subscriptions, and chainTxs
+	// the new transaction buffers of the chains of the newtxs subscriptions.
+	chains   map[pstypes.HubSignal]map[string]struct{}
+	chainTxs map[string]*txList
+	// internal clients, such as the event stream recorder, are not counted
+	// as users, and receive the transactions of every address.
+	internal bool
 }
 
 func newClient() *client {
 	return &client{
-		id:     newClientID(),
-		subs:   make(map[pstypes.HubSignal]struct{}, 16),
-		addrs:  make(map[string]struct{}, 16),
-		killed: make(chan struct{}),
-		newTxs: newTxList(NewTxBufferSize),
+		id:       newClientID(),
+		subs:     make(map[pstypes.HubSignal]struct{}, 16),
+		addrs:    make(map[string]struct{}, 16),
+		killed:   make(chan struct{}),
+		newTxs:   newTxList(NewTxBufferSize),
+		chains:   make(map[pstypes.HubSignal]map[string]struct{}, 2),
+		chainTxs: make(map[string]*txList, 2),
 	}
 }
 
@@ -145,13 +180,30 @@ func (c *client) isSubscribed(msg pstypes.HubMessage) bool {
 			log.Errorf("n AddressMessage (SigAddressTx): %T", msg.Msg)
 			return false
 		}
+		// The address subscriptions are for Decred addresses.
 		_, subd = c.addrs[am.Address]
+		subd = (subd || c.internal) && am.ChainType == ""
+	case sigNewChainTxs, sigChainMempoolUpdate:
+		cm, ok := msg.Msg.(*pstypes.ChainMessage)
+		if !ok {
+			log.Errorf("msg.Msg not a ChainMessage (%v): %T", msg.Signal, msg.Msg)
+			return false
+		}
+		_, subd = c.chains[msg.Signal][cm.ChainType]
 	default:
 	}
 
 	return subd
 }
 
+// chainTxList returns the new transaction buffer of a chain, or nil if the
+// client is not subscribed to the new transactions of the chain.
+func (c *client) chainTxList(chainType string) *txList {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.chainTxs[chainType]
+}
+
 func (c *client) subscribe(msg pstypes.HubMessage) (bool, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
@@ -163,7 +215,19 @@ func (c *client) subscribe(msg pstypes.HubMessage) (bool, error) {
 			return false, fmt.Errorf("msg.Msg not a string (SigAddressTx): %T", msg.Msg)
 		}
 		c.addrs[am.Address] = struct{}{}
-	case sigPingAndUserCount, sigByeNow, sigDecodeTx, sigSentTx, sigSubscribe, sigUnsubscribe:
+	case sigNewChainTxs, sigChainMempoolUpdate:
+		cm, ok := msg.Msg.(*pstypes.ChainMessage)
+		if !ok {
+			return false, fmt.Errorf("msg.Msg not a ChainMessage (%v): %T", msg.Signal, msg.Msg)
+		}
+		if c.chains[msg.Signal] == nil {
+			c.chains[msg.Signal] = make(map[string]struct{}, 1)
+		}
+		c.chains[msg.Signal][cm.ChainType] = struct{}{}
+		if msg.Signal == sigNewChainTxs && c.chainTxs[cm.ChainType] == nil {
+			c.chainTxs[cm.ChainType] = newTxList(NewTxBufferSize)
+		}
+	case sigPingAndUserCount, sigByeNow, sigDecodeTx, sigSentTx, sigSubscribe, sigUnsubscribe, sigNewChainTx:
 		// These are not subscription-based events, do not clutter the subs map.
 		return false, nil
 	default:
@@ -189,6 +253,20 @@ func (c *client) unsubscribe(msg pstypes.HubMessage) error {
 		if len(c.addrs) == 0 {
 			delete(c.subs, pstypes.SigAddressTx)
 		}
+	case sigNewChainTxs, sigChainMempoolUpdate:
+		cm, ok := msg.Msg.(*pstypes.ChainMessage)
+		if !ok {
+			return fmt.Errorf("msg.Msg not a ChainMessage (%v): %T", msg.Signal, msg.Msg)
+		}
+		delete(c.chains[msg.Signal], cm.ChainType)
+		if msg.Signal == sigNewChainTxs {
+			delete(c.chainTxs, cm.ChainType)
+		}
+		// Unsubscribe from the signal ONLY if this client has no more
+		// subscribed chains.
+		if len(c.chains[msg.Signal]) == 0 {
+			delete(c.subs, msg.Signal)
+		}
 	default:
 		delete(c.subs, msg.Signal)
 	}
@@ -205,19 +283,30 @@ func (c *client) unsubscribeAll() {
 	for addr := range c.addrs {
 		delete(c.addrs, addr)
 	}
+	for sig := range c.chains {
+		delete(c.chains, sig)
+	}
+	for chainType := range c.chainTxs {
+		delete(c.chainTxs, chainType)
+	}
 }
 
 // NewWebsocketHub creates a new WebsocketHub.
 func NewWebsocketHub() *WebsocketHub {
+	chainTxBufferFlags := make(map[string]*atomic.Bool, len(pstypes.NewTxsChains))
+	for _, chainType := range pstypes.NewTxsChains {
+		chainTxBufferFlags[chainType] = new(atomic.Bool)
+	}
 	return &WebsocketHub{
-		clients:          make(map[*hubSpoke]*client),
-		Register:         make(chan *clientHubSpoke),
-		Unregister:       make(chan *hubSpoke),
-		HubRelay:         make(chan pstypes.HubMessage),
-		bufferTickerChan: make(chan int, 6),
-		quitWSHandler:    make(chan struct{}),
-		killed:           make(chan struct{}),
-		requestLimit:     maxPayloadBytes, // 1 MB
+		clients:                 make(map[*hubSpoke]*client),
+		Register:                make(chan *clientHubSpoke),
+		Unregister:              make(chan *hubSpoke),
+		HubRelay:                make(chan pstypes.HubMessage),
+		bufferTickerChan:        make(chan int, 6),
+		timeToSendChainTxBuffer: chainTxBufferFlags,
+		quitWSHandler:           make(chan struct{}),
+		killed:                  make(chan struct{}),
+		requestLimit:            maxPayloadBytes, // 1 MB
 	}
 }
 
@@ -254,14 +343,21 @@ func (wsh *WebsocketHub) NumClients() int {
 	return n
 }
 
-func (wsh *WebsocketHub) setNumClients(n int) {
+// setNumClients counts the registered clients other than the internal ones.
+func (wsh *WebsocketHub) setNumClients() {
+	var n int
+	for _, cl := range wsh.clients {
+		if !cl.internal {
+			n++
+		}
+	}
 	wsh.numClients.Store(n)
 }
 
 // registerClient should only be called from the run loop.
 func (wsh *WebsocketHub) registerClient(ch *clientHubSpoke) {
 	wsh.clients[ch.c] = ch.cl
-	wsh.setNumClients(len(wsh.clients))
+	wsh.setNumClients()
 	log.Debugf("Registered new websocket client (%d).", wsh.NumClients())
 }
 
@@ -281,7 +377,7 @@ func (wsh *WebsocketHub) unregisterClient(c *hubSpoke) {
 		return
 	}
 	delete(wsh.clients, c)
-	wsh.setNumClients(len(wsh.clients))
+	wsh.setNumClients()
 
 	close(*c)
 	<-cl.killed
@@ -306,7 +402,7 @@ func (wsh *WebsocketHub) unregisterAllClients() {
 		delete(wsh.clients, c)
 		close(*c) // will terminate the connection's (*PubSubHub).sendLoop
 	}
-	wsh.setNumClients(0)
+	wsh.setNumClients()
 
 	// Wait for each client to shutdown. The client.killed channels are closed
 	// as the http.HandlerFunc of each connection returns.
@@ -467,6 +563,31 @@ func (wsh *WebsocketHub) Run() {
 				continue // break events
 			case sigMempoolUpdate:
 				log.Infof("Signaling mempool inventory refresh to %d websocket clients.", clientsCount)
+			case sigTSpend:
+				log.Infof("Signaling tspend event %s to %d websocket clients.", hubMsg, clientsCount)
+			case sigChainMempool:
+				log.Debugf("Signaling mempool projection %s to %d websocket clients.", hubMsg, clientsCount)
+			case sigDoubleSpend:
+				log.Infof("Signaling double spend %s to %d websocket clients.", hubMsg, clientsCount)
+			case sigChainMempoolUpdate:
+				log.Debugf("Signaling mempool refresh %s to %d websocket clients.", hubMsg, clientsCount)
+			case sigNewChainTx:
+				newTx, ok := hubMsg.Msg.(*pstypes.ChainTx)
+				if !ok || newTx.Tx == nil {
+					continue
+				}
+				log.Tracef("Received new %s tx %s. Queueing in the send buffers...", newTx.ChainType, newTx.Tx.Hash)
+				// Only signal clients if there are tx buffers ready to send or
+				// the ticker has fired.
+				if !(wsh.addChainTxToBuffer(newTx) || wsh.TimeToSendChainTxBuffer(newTx.ChainType)) {
+					continue
+				}
+				hubMsg = pstypes.HubMessage{
+					Signal: sigNewChainTxs,
+					Msg:    &pstypes.ChainMessage{ChainType: newTx.ChainType},
+				}
+			case sigNewChainTxs:
+				log.Tracef("Signaling %s tx buffers to %d websocket clients.", hubMsg, clientsCount)
 			case sigAddressTx:
 				// AddressMessage already validated, but check again.
 				addrMsg, ok := hubMsg.Msg.(*pstypes.AddressMessage)
@@ -524,9 +645,15 @@ func (wsh *WebsocketHub) Run() {
 				sendMsg(spoke, client, hubMsg)
 			}
 
-			if hubMsg.Signal == sigNewTxs {
+			switch hubMsg.Signal {
+			case sigNewTxs:
 				// The Tx buffers were just sent.
 				wsh.SetTimeToSendTxBuffer(false)
+			case sigNewChainTxs:
+				// The Tx buffers of the chain were just sent.
+				if cm, ok := hubMsg.Msg.(*pstypes.ChainMessage); ok {
+					wsh.SetTimeToSendChainTxBuffer(cm.ChainType, false)
+				}
 			}
 
 		case ch := <-wsh.Register:
@@ -568,14 +695,31 @@ func (wsh *WebsocketHub) addTxToBuffer(tx *exptypes.MempoolTx) (someReadyToSend
 	return
 }
 
+// addChainTxToBuffer adds a tx to the tx buffer of its chain of each client
+// subscribed to the new transactions of the chain. The return boolean value
+// indicates if at least one buffer is ready to be sent.
+func (wsh *WebsocketHub) addChainTxToBuffer(tx *pstypes.ChainTx) (someReadyToSend bool) {
+	for _, client := range wsh.clients {
+		txl := client.chainTxList(tx.ChainType)
+		if txl != nil && txl.addTxToBuffer(tx.Tx) {
+			someReadyToSend = true
+		}
+	}
+	return
+}
+
 // periodicTxBufferSend initiates a transaction buffer send via sendTxBufferChan
-// every bufferTickerInterval seconds.
+// every bufferTickerInterval seconds. The tx buffers of the chain-scoped newtxs
+// subscriptions are sent with the next transaction of their chain.
 func (wsh *WebsocketHub) periodicTxBufferSend() {
 	ticker := time.NewTicker(bufferTickerInterval * time.Second)
 	for {
 		select {
 		case <-ticker.C:
 			wsh.SetTimeToSendTxBuffer(true)
+			for _, chainType := range pstypes.NewTxsChains {
+				wsh.SetTimeToSendChainTxBuffer(chainType, true)
+			}
 		case sig := <-wsh.bufferTickerChan:
 			switch sig {
 			case tickerSigReset:
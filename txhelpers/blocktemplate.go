@@ -0,0 +1,270 @@
+package txhelpers
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// CoinbaseReservedWeight is the block weight left for the coinbase transaction
+// when projecting blocks from a mempool.
+const CoinbaseReservedWeight = 4000
+
+// TemplateTx is a mempool transaction of a segwit chain for block projection.
+// Fee is in atoms, and Depends lists the txids of the unconfirmed parents.
+type TemplateTx struct {
+	TxID    string
+	Weight  int64
+	Fee     int64
+	Depends []string
+}
+
+// vsize is the virtual size in vbytes of a weight.
+func vsize(weight int64) int64 {
+	return (weight + 3) / 4
+}
+
+// ProjectedBlock summarizes a block projected from a mempool. The fee rates
+// are in atoms/vB and are the ancestor package fee rates the transactions were
+// selected with.
+type ProjectedBlock struct {
+	Index         int     `json:"index"`
+	Weight        int64   `json:"weight"`
+	VSize         int64   `json:"vsize"`
+	TxCount       int     `json:"tx_count"`
+	TotalFees     int64   `json:"total_fees"`
+	MinFeeRate    float64 `json:"min_fee_rate"`
+	MedianFeeRate float64 `json:"median_fee_rate"`
+	MaxFeeRate    float64 `json:"max_fee_rate"`
+}
+
+// FeeRateBucket counts the mempool transactions with a fee rate in atoms/vB
+// from MinFeeRate up to MaxFeeRate, and their total virtual size. The last
+// bucket has no MaxFeeRate.
+type FeeRateBucket struct {
+	MinFeeRate float64 `json:"min_fee_rate"`
+	MaxFeeRate float64 `json:"max_fee_rate,omitempty"`
+	Count      int     `json:"count"`
+	VSize      int64   `json:"vsize"`
+}
+
+// FeeRateBuckets are the lower bounds in atoms/vB of the FeeRateHistogram
+// buckets.
+var FeeRateBuckets = []float64{0, 1, 2, 3, 4, 5, 6, 8, 10, 12, 15, 20, 30, 40, 50,
+	60, 70, 80, 90, 100, 125, 150, 175, 200, 250, 300, 350, 400, 500, 600, 700,
+	800, 1000, 1200, 1400, 1700, 2000}
+
+// FeeRateHistogram buckets the transactions by their own fee rate.
+func FeeRateHistogram(txs []*TemplateTx) []*FeeRateBucket {
+	buckets := make([]*FeeRateBucket, len(FeeRateBuckets))
+	for i, min := range FeeRateBuckets {
+		buckets[i] = &FeeRateBucket{MinFeeRate: min}
+		if i+1 < len(FeeRateBuckets) {
+			buckets[i].MaxFeeRate = FeeRateBuckets[i+1]
+		}
+	}
+	for _, tx := range txs {
+		size := vsize(tx.Weight)
+		if size <= 0 {
+			continue
+		}
+		rate := float64(tx.Fee) / float64(size)
+		i := sort.SearchFloat64s(FeeRateBuckets, rate)
+		if i == len(FeeRateBuckets) || FeeRateBuckets[i] > rate {
+			i--
+		}
+		buckets[i].Count++
+		buckets[i].VSize += size
+	}
+	return buckets
+}
+
+// templateNode is a transaction with the fee and weight of its ancestor
+// package, which is the transaction and its ancestors not yet in a block.
+type templateNode struct {
+	tx          *TemplateTx
+	ancestors   map[*templateNode]struct{}
+	descendants []*templateNode
+	pkgFee      int64
+	pkgWeight   int64
+	included    bool
+	// block is the index of the projected block that includes the
+	// transaction, and rate the package fee rate it was selected with.
+	block int
+	rate  float64
+	// version invalidates the heap entries of an updated package.
+	version int
+}
+
+func (n *templateNode) feeRate() float64 {
+	return float64(n.pkgFee) / float64(vsize(n.pkgWeight))
+}
+
+type packageEntry struct {
+	node    *templateNode
+	version int
+	rate    float64
+}
+
+type packageHeap []packageEntry
+
+func (h packageHeap) Len() int { return len(h) }
+func (h packageHeap) Less(i, j int) bool {
+	if h[i].rate == h[j].rate {
+		return h[i].node.tx.TxID < h[j].node.tx.TxID
+	}
+	return h[i].rate > h[j].rate
+}
+func (h packageHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *packageHeap) Push(x interface{}) { *h = append(*h, x.(packageEntry)) }
+func (h *packageHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// ProjectBlocks projects the next blocks from the mempool transactions by
+// greedily selecting the ancestor packages with the highest fee rate, as
+// miners do, up to maxWeight less the coinbase reserve per block. A package
+// that does not fit waits for the next block. The last of the maxBlocks blocks
+// takes all the remaining transactions, so it may be over the weight limit.
+// Parents missing from txs are taken as confirmed.
+func ProjectBlocks(txs []*TemplateTx, maxWeight int64, maxBlocks int) []*ProjectedBlock {
+	blocks, _ := projectBlocks(txs, maxWeight, maxBlocks)
+	return blocks
+}
+
+// projectBlocks is ProjectBlocks, also returning the nodes of the transactions
+// by txid. The nodes of the transactions in the projected blocks are included.
+func projectBlocks(txs []*TemplateTx, maxWeight int64, maxBlocks int) ([]*ProjectedBlock, map[string]*templateNode) {
+	nodes := make(map[string]*templateNode, len(txs))
+	for _, tx := range txs {
+		nodes[tx.TxID] = &templateNode{tx: tx}
+	}
+	// Collect the ancestors of each transaction, with a memo for the shared
+	// ancestry. Cycles are not possible in a valid mempool, but the onStack
+	// guard keeps a bad input from recursing forever.
+	onStack := make(map[*templateNode]bool)
+	var ancestorsOf func(n *templateNode) map[*templateNode]struct{}
+	ancestorsOf = func(n *templateNode) map[*templateNode]struct{} {
+		if n.ancestors != nil || onStack[n] {
+			return n.ancestors
+		}
+		onStack[n] = true
+		ancestors := make(map[*templateNode]struct{})
+		for _, dep := range n.tx.Depends {
+			parent := nodes[dep]
+			if parent == nil {
+				continue
+			}
+			ancestors[parent] = struct{}{}
+			for a := range ancestorsOf(parent) {
+				ancestors[a] = struct{}{}
+			}
+		}
+		onStack[n] = false
+		n.ancestors = ancestors
+		return ancestors
+	}
+
+	pending := make(packageHeap, 0, len(nodes))
+	for _, n := range nodes {
+		n.pkgFee, n.pkgWeight = n.tx.Fee, n.tx.Weight
+		for a := range ancestorsOf(n) {
+			n.pkgFee += a.tx.Fee
+			n.pkgWeight += a.tx.Weight
+			a.descendants = append(a.descendants, n)
+		}
+		if n.pkgWeight <= 0 {
+			n.pkgWeight = 1
+		}
+		pending = append(pending, packageEntry{node: n, rate: n.feeRate()})
+	}
+	heap.Init(&pending)
+
+	blocks := make([]*ProjectedBlock, 0, maxBlocks)
+	limit := maxWeight - CoinbaseReservedWeight
+	var stuck bool
+	for len(pending) > 0 && len(blocks) < maxBlocks {
+		last := stuck || len(blocks) == maxBlocks-1
+		block := &ProjectedBlock{Index: len(blocks)}
+		var rates []float64
+		var deferred []packageEntry
+		for len(pending) > 0 {
+			e := heap.Pop(&pending).(packageEntry)
+			n := e.node
+			if n.included || e.version != n.version {
+				continue // stale entry
+			}
+			if !last && block.Weight+n.pkgWeight > limit {
+				deferred = append(deferred, e)
+				continue
+			}
+
+			// Add the package, ancestors first.
+			pkg := make([]*templateNode, 0, len(n.ancestors)+1)
+			for a := range n.ancestors {
+				if !a.included {
+					pkg = append(pkg, a)
+				}
+			}
+			sort.Slice(pkg, func(i, j int) bool { return len(pkg[i].ancestors) < len(pkg[j].ancestors) })
+			pkg = append(pkg, n)
+			for _, p := range pkg {
+				p.included = true
+				p.block = block.Index
+				p.rate = e.rate
+				block.Weight += p.tx.Weight
+				block.TotalFees += p.tx.Fee
+				block.TxCount++
+				rates = append(rates, e.rate)
+			}
+
+			// Remove the package from the packages of its descendants.
+			for _, p := range pkg {
+				for _, d := range p.descendants {
+					if d.included {
+						continue
+					}
+					d.pkgFee -= p.tx.Fee
+					d.pkgWeight -= p.tx.Weight
+					if d.pkgWeight <= 0 {
+						d.pkgWeight = 1
+					}
+					d.version++
+					heap.Push(&pending, packageEntry{node: d, version: d.version, rate: d.feeRate()})
+				}
+			}
+		}
+		for _, e := range deferred {
+			if !e.node.included && e.version == e.node.version {
+				heap.Push(&pending, e)
+			}
+		}
+		if block.TxCount == 0 {
+			// Every package left is heavier than a block, so they all go to
+			// the last block.
+			stuck = true
+			continue
+		}
+		block.VSize = vsize(block.Weight)
+		sort.Float64s(rates)
+		block.MinFeeRate = rates[0]
+		block.MaxFeeRate = rates[len(rates)-1]
+		block.MedianFeeRate = median(rates)
+		blocks = append(blocks, block)
+	}
+	return blocks, nodes
+}
+
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
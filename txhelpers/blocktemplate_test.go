@@ -0,0 +1,79 @@
+package txhelpers
+
+import (
+	"testing"
+)
+
+func TestProjectBlocks(t *testing.T) {
+	// Blocks fit 4000 WU (1000 vB) besides the coinbase reserve.
+	const maxWeight = 4000 + CoinbaseReservedWeight
+	txs := []*TemplateTx{
+		{TxID: "a", Weight: 1600, Fee: 4000}, // 10/vB
+		{TxID: "b", Weight: 1600, Fee: 2000}, // 5/vB
+		// A 1/vB parent with a 21/vB child makes an 11/vB package that goes
+		// before a.
+		{TxID: "p", Weight: 800, Fee: 200},
+		{TxID: "c", Weight: 800, Fee: 4200, Depends: []string{"p"}},
+		{TxID: "d", Weight: 2800, Fee: 1400}, // 2/vB
+		{TxID: "e", Weight: 400, Fee: 100, Depends: []string{"confirmed"}},
+	}
+	blocks := ProjectBlocks(txs, maxWeight, 8)
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+
+	// The first block takes the p+c package and a, and b does not fit, but
+	// e does.
+	first := blocks[0]
+	if first.TxCount != 4 || first.Weight != 3600 || first.VSize != 900 || first.TotalFees != 8500 {
+		t.Errorf("wrong first block %+v", first)
+	}
+	if first.MinFeeRate != 1 || first.MaxFeeRate != 11 || first.MedianFeeRate != 10.5 {
+		t.Errorf("wrong first block fee rates %+v", first)
+	}
+	if second := blocks[1]; second.TxCount != 1 || second.TotalFees != 2000 || second.MinFeeRate != 5 {
+		t.Errorf("wrong second block %+v", second)
+	}
+	if third := blocks[2]; third.TxCount != 1 || third.TotalFees != 1400 {
+		t.Errorf("wrong third block %+v", third)
+	}
+
+	// The last block takes the rest over the limit.
+	blocks = ProjectBlocks(txs, maxWeight, 2)
+	if len(blocks) != 2 || blocks[1].TxCount != 2 || blocks[1].Weight != 4400 {
+		t.Errorf("wrong last block %+v", blocks[len(blocks)-1])
+	}
+
+	// A package heavier than a block goes to the last block.
+	blocks = ProjectBlocks([]*TemplateTx{{TxID: "big", Weight: 9000, Fee: 9000}}, maxWeight, 3)
+	if len(blocks) != 1 || blocks[0].TxCount != 1 || blocks[0].Index != 0 {
+		t.Errorf("wrong blocks for a heavy package %+v", blocks)
+	}
+}
+
+func TestFeeRateHistogram(t *testing.T) {
+	buckets := FeeRateHistogram([]*TemplateTx{
+		{TxID: "a", Weight: 400, Fee: 50},     // 0.5/vB
+		{TxID: "b", Weight: 400, Fee: 100},    // 1/vB
+		{TxID: "c", Weight: 800, Fee: 1400},   // 7/vB
+		{TxID: "d", Weight: 400, Fee: 500000}, // 5000/vB
+	})
+	if len(buckets) != len(FeeRateBuckets) {
+		t.Fatalf("wrong bucket count %d", len(buckets))
+	}
+	check := func(i, count int, vsize int64) {
+		t.Helper()
+		if buckets[i].Count != count || buckets[i].VSize != vsize {
+			t.Errorf("bucket %d (%v-%v): count %d, vsize %d", i, buckets[i].MinFeeRate,
+				buckets[i].MaxFeeRate, buckets[i].Count, buckets[i].VSize)
+		}
+	}
+	check(0, 1, 100)
+	check(1, 1, 100)
+	check(6, 1, 200) // 6-8
+	last := len(buckets) - 1
+	check(last, 1, 100)
+	if buckets[last].MaxFeeRate != 0 || buckets[6].MaxFeeRate != 8 {
+		t.Errorf("wrong bucket bounds")
+	}
+}
@@ -0,0 +1,81 @@
+package txhelpers
+
+import "math"
+
+// ConfirmationProjectionBlocks is the number of blocks projected from the
+// mempool for confirmation estimates. A transaction beyond them confirms in
+// more blocks.
+const ConfirmationProjectionBlocks = 24
+
+// ConfirmationEstimate estimates when an unconfirmed transaction confirms.
+// FeeRate is the fee rate in atoms/vB, atoms/B for chains without segwit, of
+// the package of the transaction and its unconfirmed ancestors. MempoolBlocks
+// is the block projected from the current mempool that includes the package,
+// counting from 1, and 0 beyond the projected blocks. InclusionRate is the
+// share of the recently confirmed blocks that confirmed the fee rate. Blocks is
+// the larger of MempoolBlocks and the blocks expected to confirm the fee rate
+// at the inclusion rate, and it is a lower bound when AtLeast is set.
+type ConfirmationEstimate struct {
+	TxID          string  `json:"txid"`
+	FeeRate       float64 `json:"fee_rate"`
+	MempoolBlocks int     `json:"mempool_blocks"`
+	InclusionRate float64 `json:"inclusion_rate"`
+	Blocks        int     `json:"blocks"`
+	AtLeast       bool    `json:"at_least,omitempty"`
+	Seconds       int64   `json:"seconds"`
+}
+
+// EstimateConfirmations estimates when each mempool transaction confirms from
+// its position in the blocks projected with ProjectBlocks, and from the
+// recently confirmed blocks, oldest first. A recent block confirmed a fee rate
+// if its fee floor was not higher, and the expected number of blocks at an
+// inclusion rate p is the median of the geometric distribution of p.
+func EstimateConfirmations(mempool []*TemplateTx, blocks []*BlockFeeRates, params *FeeEstimatorParams) map[string]*ConfirmationEstimate {
+	floors := make([]float64, 0, len(blocks))
+	for _, b := range blocks {
+		floors = append(floors, blockFeeFloor(b.FeeRates))
+	}
+
+	// The last block takes all the transactions beyond the projection.
+	_, nodes := projectBlocks(mempool, params.MaxBlockWeight, ConfirmationProjectionBlocks+1)
+	estimates := make(map[string]*ConfirmationEstimate, len(nodes))
+	for txid, n := range nodes {
+		est := &ConfirmationEstimate{TxID: txid, FeeRate: n.rate}
+		if n.included && n.block < ConfirmationProjectionBlocks {
+			est.MempoolBlocks = n.block + 1
+			est.Blocks = est.MempoolBlocks
+		} else {
+			if !n.included {
+				est.FeeRate = n.feeRate()
+			}
+			est.Blocks = ConfirmationProjectionBlocks + 1
+			est.AtLeast = true
+		}
+
+		if len(floors) > 0 {
+			var confirmed int
+			for _, floor := range floors {
+				if floor <= est.FeeRate {
+					confirmed++
+				}
+			}
+			est.InclusionRate = float64(confirmed) / float64(len(floors))
+			blocksExpected := len(floors) + 1
+			switch {
+			case confirmed == len(floors):
+				blocksExpected = 1
+			case confirmed > 0:
+				blocksExpected = int(math.Ceil(math.Log(0.5) / math.Log(1-est.InclusionRate)))
+			default:
+				// No recent block confirmed the fee rate.
+				est.AtLeast = true
+			}
+			if blocksExpected > est.Blocks {
+				est.Blocks = blocksExpected
+			}
+		}
+		est.Seconds = int64(est.Blocks) * params.BlockTime
+		estimates[txid] = est
+	}
+	return estimates
+}
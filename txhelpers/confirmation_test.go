@@ -0,0 +1,54 @@
+package txhelpers
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEstimateConfirmations(t *testing.T) {
+	txs := []*TemplateTx{
+		{TxID: "a", Weight: 1600, Fee: 4000}, // 10/vB
+		{TxID: "b", Weight: 1600, Fee: 2000}, // 5/vB
+		{TxID: "p", Weight: 800, Fee: 200},
+		{TxID: "c", Weight: 800, Fee: 4200, Depends: []string{"p"}},
+		{TxID: "d", Weight: 2800, Fee: 1400}, // 2/vB
+	}
+	// Fee floors of 3, 3, 6 and 3 atoms/vB.
+	blocks := []*BlockFeeRates{
+		{Height: 1, FeeRates: []float64{3}},
+		{Height: 2, FeeRates: []float64{3}},
+		{Height: 3, FeeRates: []float64{6}},
+		{Height: 4, FeeRates: []float64{3}},
+	}
+	ests := EstimateConfirmations(txs, blocks, testFeeParams)
+	if len(ests) != len(txs) {
+		t.Fatalf("expected %d estimates, got %d", len(txs), len(ests))
+	}
+	for _, want := range []ConfirmationEstimate{
+		{TxID: "a", FeeRate: 10, MempoolBlocks: 1, InclusionRate: 1, Blocks: 1, Seconds: 600},
+		// The parent confirms with its child at the package fee rate.
+		{TxID: "p", FeeRate: 11, MempoolBlocks: 1, InclusionRate: 1, Blocks: 1, Seconds: 600},
+		{TxID: "b", FeeRate: 5, MempoolBlocks: 2, InclusionRate: 0.75, Blocks: 2, Seconds: 1200},
+		// No recent block confirmed 2/vB.
+		{TxID: "d", FeeRate: 2, MempoolBlocks: 3, Blocks: 5, AtLeast: true, Seconds: 3000},
+	} {
+		if got := ests[want.TxID]; *got != want {
+			t.Errorf("wrong estimate %+v, expected %+v", got, want)
+		}
+	}
+
+	// A backlog of full blocks, confirmed by half the recent blocks.
+	txs = txs[:0]
+	for i := 0; i < ConfirmationProjectionBlocks+5; i++ {
+		txs = append(txs, &TemplateTx{TxID: fmt.Sprint(i), Weight: 4000, Fee: int64(10000 - i)})
+	}
+	blocks = []*BlockFeeRates{{FeeRates: []float64{1}}, {FeeRates: []float64{100}}}
+	ests = EstimateConfirmations(txs, blocks, testFeeParams)
+	if est := ests["0"]; est.MempoolBlocks != 1 || est.Blocks != 1 || est.InclusionRate != 0.5 {
+		t.Errorf("wrong estimate for the first block %+v", est)
+	}
+	last := ests[fmt.Sprint(ConfirmationProjectionBlocks+4)]
+	if last.MempoolBlocks != 0 || !last.AtLeast || last.Blocks != ConfirmationProjectionBlocks+1 {
+		t.Errorf("wrong estimate beyond the projection %+v", last)
+	}
+}
@@ -27,6 +27,15 @@ func init() {
 	}
 }
 
+// MixDenominations lists the CSPP mix denominations in atoms, largest first.
+func MixDenominations() []int64 {
+	denoms := make([]int64, 0, len(splitPoints))
+	for _, amt := range splitPoints {
+		denoms = append(denoms, int64(amt))
+	}
+	return denoms
+}
+
 // IsMixTx tests if a transaction is a CSPP-mixed transaction, which must have 3
 // or more outputs of the same amount, which is one of the pre-defined mix
 // denominations. mixDenom is the largest of such denominations. mixCount is the
@@ -648,3 +648,18 @@ const (
 		"cf63390d82f25f4cb4d35668172e43bd38d5bd91bffb90e1d40121036e19fde113020f8afe936d29db" +
 		"215053cf8513ce5bdde7a7c59d45541d69b498"
 )
+
+func TestMixDenominations(t *testing.T) {
+	denoms := MixDenominations()
+	if len(denoms) != len(splitPoints) || denoms[0] != 1<<36 {
+		t.Fatalf("unexpected denominations %v", denoms)
+	}
+	for i, denom := range denoms {
+		if _, ok := splitPointMap[denom]; !ok {
+			t.Fatalf("denomination %d is not a split point", denom)
+		}
+		if i > 0 && denom >= denoms[i-1] {
+			t.Fatalf("denominations are not sorted large to small")
+		}
+	}
+}
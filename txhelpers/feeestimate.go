@@ -0,0 +1,153 @@
+package txhelpers
+
+import (
+	"math"
+	"sort"
+)
+
+// The confirmation targets in blocks of the low, medium and high priority fee
+// estimates.
+const (
+	FeeTargetHigh   = 1
+	FeeTargetMedium = 3
+	FeeTargetLow    = 6
+)
+
+// FeeEstimateWindow is the number of recent blocks sampled for fee estimates.
+const FeeEstimateWindow = 30
+
+// feeFloorPercentile is the percentile of the fee rates of a block taken as
+// the lowest fee rate the block confirmed. The lowest fee rate itself is often
+// a transaction of the miner or a child paying for its parent.
+const feeFloorPercentile = 10
+
+// BlockFeeRates are the fee rates, in atoms/vB, of the fee paying transactions
+// of a confirmed block. The virtual size is the size for chains without segwit.
+type BlockFeeRates struct {
+	Height   int64     `json:"height"`
+	FeeRates []float64 `json:"fee_rates"`
+}
+
+// FeeEstimatorParams are the chain parameters of a fee estimate. The block
+// weight is four times the block size for chains without segwit.
+type FeeEstimatorParams struct {
+	MaxBlockWeight int64
+	// MinFeeRate is the minimum relay fee rate in atoms/vB.
+	MinFeeRate float64
+	// BlockTime is the target block time in seconds.
+	BlockTime int64
+	// Confidence is the probability of confirmation within the target that
+	// the fee rate from the confirmed blocks aims for.
+	Confidence float64
+}
+
+// FeeEstimate is a fee rate in atoms/vB to confirm within TargetBlocks blocks.
+// FeeRate is the highest of the rates from the recently confirmed blocks and
+// from the mempool backlog, and the minimum relay fee rate.
+type FeeEstimate struct {
+	FeeRate        float64 `json:"fee_rate"`
+	TargetBlocks   int     `json:"target_blocks"`
+	TargetSeconds  int64   `json:"target_seconds"`
+	BlocksFeeRate  float64 `json:"blocks_fee_rate"`
+	MempoolFeeRate float64 `json:"mempool_fee_rate"`
+}
+
+// FeeEstimates are the low, medium and high priority fee estimates of a chain
+// at a height.
+type FeeEstimates struct {
+	ChainType     string       `json:"chain"`
+	Height        int64        `json:"height"`
+	Time          int64        `json:"time"`
+	Unit          string       `json:"unit"`
+	BlocksSampled int          `json:"blocks_sampled"`
+	MempoolTxs    int          `json:"mempool_txs"`
+	MempoolVSize  int64        `json:"mempool_vsize"`
+	Low           *FeeEstimate `json:"low"`
+	Medium        *FeeEstimate `json:"medium"`
+	High          *FeeEstimate `json:"high"`
+}
+
+// EstimateFees estimates the fee rates to confirm within the low, medium and
+// high priority targets from the recently confirmed blocks, oldest first, and
+// the mempool. The confirmed blocks give the fee rate that at least one block
+// of the target confirms with probability params.Confidence, over the whole
+// sample or its last blocks, and the mempool gives the fee rate that beats the
+// backlog of the target blocks, projected with ProjectBlocks. The caller sets
+// the chain, height, time and unit.
+func EstimateFees(blocks []*BlockFeeRates, mempool []*TemplateTx, params *FeeEstimatorParams) *FeeEstimates {
+	floors := make([]float64, 0, len(blocks))
+	for _, b := range blocks {
+		floors = append(floors, blockFeeFloor(b.FeeRates))
+	}
+	// The last blocks catch a rising market before the whole window does.
+	recent := make([]float64, len(floors))
+	copy(recent, floors)
+	if len(recent) > FeeTargetLow {
+		recent = recent[len(recent)-FeeTargetLow:]
+	}
+	sort.Float64s(recent)
+	sort.Float64s(floors)
+
+	projected := ProjectBlocks(mempool, params.MaxBlockWeight, FeeTargetLow+1)
+	var mempoolVSize int64
+	for _, tx := range mempool {
+		mempoolVSize += vsize(tx.Weight)
+	}
+
+	estimate := func(target int) *FeeEstimate {
+		est := &FeeEstimate{
+			TargetBlocks:  target,
+			TargetSeconds: int64(target) * params.BlockTime,
+		}
+		if len(floors) > 0 {
+			// The fee rate confirms in one of target blocks with probability
+			// Confidence when each block confirms it with probability q.
+			q := 1 - math.Pow(1-params.Confidence, 1/float64(target))
+			est.BlocksFeeRate = math.Max(quantile(floors, q), quantile(recent, q))
+		}
+		// A backlog of more than target blocks must be outbid.
+		if len(projected) > target {
+			est.MempoolFeeRate = projected[target-1].MinFeeRate
+		}
+		est.FeeRate = math.Max(params.MinFeeRate, math.Max(est.BlocksFeeRate, est.MempoolFeeRate))
+		return est
+	}
+
+	return &FeeEstimates{
+		BlocksSampled: len(blocks),
+		MempoolTxs:    len(mempool),
+		MempoolVSize:  mempoolVSize,
+		Low:           estimate(FeeTargetLow),
+		Medium:        estimate(FeeTargetMedium),
+		High:          estimate(FeeTargetHigh),
+	}
+}
+
+// blockFeeFloor is the lowest fee rate a block confirmed, ignoring outliers. A
+// block without fee paying transactions had room for any fee rate.
+func blockFeeFloor(feeRates []float64) float64 {
+	if len(feeRates) == 0 {
+		return 0
+	}
+	sorted := feeRates
+	if !sort.Float64sAreSorted(sorted) {
+		sorted = make([]float64, len(feeRates))
+		copy(sorted, feeRates)
+		sort.Float64s(sorted)
+	}
+	return quantile(sorted, feeFloorPercentile/100.0)
+}
+
+// quantile interpolates the q quantile, 0 <= q <= 1, of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(n-1)
+	i := int(pos)
+	if i >= n-1 {
+		return sorted[n-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
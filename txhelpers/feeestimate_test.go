@@ -0,0 +1,189 @@
+package txhelpers
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+var testFeeParams = &FeeEstimatorParams{
+	MaxBlockWeight: 4000 + CoinbaseReservedWeight,
+	MinFeeRate:     1,
+	BlockTime:      600,
+	Confidence:     0.9,
+}
+
+func loadBlockFeeRates(t *testing.T) []*BlockFeeRates {
+	t.Helper()
+	// blockfeerates.json holds the fee rates, in atoms/vB, of 144 consecutive
+	// blocks of a segwit chain, through a fee spike and its decay.
+	b, err := os.ReadFile("blockfeerates.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks []*BlockFeeRates
+	if err = json.Unmarshal(b, &blocks); err != nil {
+		t.Fatal(err)
+	}
+	return blocks
+}
+
+// testBlockFeeRates makes blocks that each confirm the eleven fee rates from
+// the floor of the block to ten more, so that the block fee floor, the 10th
+// percentile, is one more than the floor.
+func testBlockFeeRates(floors ...float64) []*BlockFeeRates {
+	blocks := make([]*BlockFeeRates, 0, len(floors))
+	for i, floor := range floors {
+		b := &BlockFeeRates{Height: int64(i)}
+		for r := 0.; r <= 10; r++ {
+			b.FeeRates = append(b.FeeRates, floor+r)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+// risingFloors are the block fee floors of a window of FeeEstimateWindow
+// blocks through a rising fee market.
+func risingFloors() []float64 {
+	floors := make([]float64, FeeEstimateWindow)
+	for i := range floors {
+		floors[i] = float64(i + 1)
+	}
+	return floors
+}
+
+func TestBlockFeeFloor(t *testing.T) {
+	// The lowest fee rate, of a miner's transaction, is ignored.
+	feeRates := []float64{12, 0.1, 10, 11, 13, 14, 15, 16, 17, 18, 19}
+	if floor := blockFeeFloor(feeRates); floor != 10 {
+		t.Errorf("wrong fee floor %v", floor)
+	}
+	if feeRates[1] != 0.1 {
+		t.Errorf("the fee rates were sorted in place")
+	}
+	if floor := blockFeeFloor(nil); floor != 0 {
+		t.Errorf("wrong fee floor of an empty block %v", floor)
+	}
+}
+
+func TestEstimateFeesBlocks(t *testing.T) {
+	check := func(name string, est *FeeEstimates, low, medium, high float64) {
+		t.Helper()
+		for _, tier := range []struct {
+			est  *FeeEstimate
+			want float64
+		}{{est.Low, low}, {est.Medium, medium}, {est.High, high}} {
+			if math.Abs(tier.est.FeeRate-tier.want) > 0.01 {
+				t.Errorf("%s: wrong %d block fee rate %v, expected %v", name,
+					tier.est.TargetBlocks, tier.est.FeeRate, tier.want)
+			}
+		}
+	}
+
+	// In a rising market the last blocks set the fee rates.
+	floors := risingFloors()
+	rising := EstimateFees(testBlockFeeRates(floors...), nil, testFeeParams)
+	if rising.BlocksSampled != FeeEstimateWindow {
+		t.Errorf("wrong blocks sampled %d", rising.BlocksSampled)
+	}
+	check("rising", rising, 27.59, 28.68, 30.5)
+
+	// In a falling market the whole window keeps the fee rates up.
+	for i, j := 0, len(floors)-1; i < j; i, j = i+1, j-1 {
+		floors[i], floors[j] = floors[j], floors[i]
+	}
+	falling := EstimateFees(testBlockFeeRates(floors...), nil, testFeeParams)
+	check("falling", falling, 11.24, 17.54, 28.1)
+}
+
+// confirmsWithin checks if a fee rate would have confirmed in one of the
+// blocks, which is when a block confirmed a lower fee rate or had room left.
+func confirmsWithin(blocks []*BlockFeeRates, feeRate float64) bool {
+	for _, b := range blocks {
+		if len(b.FeeRates) == 0 || b.FeeRates[0] <= feeRate {
+			return true
+		}
+	}
+	return false
+}
+
+// TestEstimateFeesBacktest estimates the fees at each height of the recorded
+// blocks from the blocks before it, and checks how often the estimates would
+// have confirmed within their targets.
+func TestEstimateFeesBacktest(t *testing.T) {
+	blocks := loadBlockFeeRates(t)
+	const window = FeeEstimateWindow
+	var runs, lowHits, mediumHits, highHits int
+	var lowSum, mediumSum, highSum float64
+	for i := window; i+FeeTargetLow <= len(blocks); i++ {
+		est := EstimateFees(blocks[i-window:i], nil, testFeeParams)
+		if est.Low.FeeRate > est.Medium.FeeRate || est.Medium.FeeRate > est.High.FeeRate {
+			t.Fatalf("fee rates out of order at %d: %v, %v, %v", blocks[i].Height,
+				est.Low.FeeRate, est.Medium.FeeRate, est.High.FeeRate)
+		}
+		runs++
+		if confirmsWithin(blocks[i:i+FeeTargetLow], est.Low.FeeRate) {
+			lowHits++
+		}
+		if confirmsWithin(blocks[i:i+FeeTargetMedium], est.Medium.FeeRate) {
+			mediumHits++
+		}
+		if confirmsWithin(blocks[i:i+FeeTargetHigh], est.High.FeeRate) {
+			highHits++
+		}
+		lowSum += est.Low.FeeRate
+		mediumSum += est.Medium.FeeRate
+		highSum += est.High.FeeRate
+	}
+
+	// The estimates aim for 90% and lag the rise of the spike a little.
+	for _, tier := range []struct {
+		name string
+		hits int
+	}{{"low", lowHits}, {"medium", mediumHits}, {"high", highHits}} {
+		rate := float64(tier.hits) / float64(runs)
+		t.Logf("%s priority confirmed within its target in %.1f%% of %d runs", tier.name, 100*rate, runs)
+		if rate < 0.85 {
+			t.Errorf("%s priority confirmed in only %.1f%% of runs", tier.name, 100*rate)
+		}
+	}
+	t.Logf("mean fee rates: low %.2f, medium %.2f, high %.2f", lowSum/float64(runs),
+		mediumSum/float64(runs), highSum/float64(runs))
+	if !(lowSum < mediumSum && mediumSum < highSum) {
+		t.Errorf("the higher priorities should cost more")
+	}
+}
+
+func TestEstimateFeesMempool(t *testing.T) {
+	blocks := testBlockFeeRates(risingFloors()...)
+	quiet := EstimateFees(blocks, nil, testFeeParams)
+	if quiet.High.MempoolFeeRate != 0 || quiet.High.FeeRate != quiet.High.BlocksFeeRate {
+		t.Errorf("wrong estimate without a mempool %+v", quiet.High)
+	}
+	if quiet.High.TargetSeconds != 600 || quiet.Low.TargetBlocks != FeeTargetLow {
+		t.Errorf("wrong targets %+v, %+v", quiet.High, quiet.Low)
+	}
+
+	// A backlog of ten blocks of 1000 vB at 50 atoms/vB outbids the confirmed
+	// blocks for every target.
+	var mempool []*TemplateTx
+	for i := 0; i < 10; i++ {
+		mempool = append(mempool, &TemplateTx{TxID: string(rune('a' + i)), Weight: 4000, Fee: 50000})
+	}
+	busy := EstimateFees(blocks, mempool, testFeeParams)
+	if busy.MempoolTxs != 10 || busy.MempoolVSize != 10000 {
+		t.Errorf("wrong mempool size %+v", busy)
+	}
+	for _, est := range []*FeeEstimate{busy.Low, busy.Medium, busy.High} {
+		if est.MempoolFeeRate != 50 || est.FeeRate != 50 {
+			t.Errorf("wrong estimate with a backlog %+v", est)
+		}
+	}
+
+	// Without blocks or a mempool, the estimate is the minimum fee rate.
+	if est := EstimateFees(nil, nil, testFeeParams); est.Low.FeeRate != 1 || est.BlocksSampled != 0 {
+		t.Errorf("wrong estimate without data %+v", est.Low)
+	}
+}
@@ -0,0 +1,343 @@
+package txhelpers
+
+import (
+	"sort"
+	"sync"
+)
+
+// The fates of a transaction seen in mempool.
+const (
+	TxFateMempool    = "mempool"
+	TxFateMined      = "mined"
+	TxFateReplaced   = "replaced"
+	TxFateConflicted = "conflicted"
+	TxFateEvicted    = "evicted"
+)
+
+// maxLedgerDoubleSpends is the number of most recent double spends kept by a
+// MempoolLedger.
+const maxLedgerDoubleSpends = 1000
+
+// LedgerTx is a transaction of a mempool or a block, with the previous
+// outpoints it spends formatted by Outpoint. The null previous outpoints of
+// coinbases, stakebases and treasurybases are left out.
+type LedgerTx struct {
+	TxID      string
+	Outpoints []string
+}
+
+// LedgerEntry is the record of a transaction seen in mempool. FateTime is when
+// the transaction left mempool. Height is set for a mined transaction,
+// ReplacedBy for a transaction replaced by a conflicting spend in mempool, and
+// ConflictedBy for a transaction conflicting with a mined transaction or
+// spending the outputs of a replaced or conflicted transaction.
+type LedgerEntry struct {
+	TxID         string `json:"txid"`
+	FirstSeen    int64  `json:"first_seen"`
+	LastSeen     int64  `json:"last_seen"`
+	Fate         string `json:"fate"`
+	FateTime     int64  `json:"fate_time,omitempty"`
+	Height       int64  `json:"height,omitempty"`
+	ReplacedBy   string `json:"replaced_by,omitempty"`
+	ConflictedBy string `json:"conflicted_by,omitempty"`
+
+	outpoints    []string
+	missingSince int64
+	resolvedSeq  uint64
+	// reported is set once the entry was passed to the resolved handler.
+	reported bool
+}
+
+// DoubleSpend is a transaction spending outpoints already spent by another
+// transaction. Without a Height, TxID entered mempool and replaced
+// ConflictsWith. With a Height, ConflictsWith was mined at Height and TxID left
+// mempool.
+type DoubleSpend struct {
+	ChainType     string   `json:"chain"`
+	TxID          string   `json:"txid"`
+	ConflictsWith string   `json:"conflicts_with"`
+	Outpoints     []string `json:"outpoints"`
+	Height        int64    `json:"height,omitempty"`
+	Time          int64    `json:"time"`
+}
+
+// MempoolLedger records when each transaction of a mempool was first and last
+// seen, and its fate once it leaves mempool: mined, replaced, conflicted or
+// evicted. A transaction missing from two mempool snapshots in a row without
+// being mined or double spent was evicted, or expired. The ledger keeps up to
+// maxResolved transactions that left mempool. It is safe for concurrent use.
+type MempoolLedger struct {
+	mtx                sync.RWMutex
+	chainType          string
+	maxResolved        int
+	entries            map[string]*LedgerEntry
+	index              spendIndex      // of the transactions in mempool
+	resolved           []resolvedEntry // oldest first
+	resolvedSeq        uint64
+	doubleSpends       []*DoubleSpend // oldest first
+	resolvedHandler    func([]*LedgerEntry)
+	doubleSpendHandler func(*DoubleSpend)
+}
+
+// resolvedEntry is a resolution of an entry. An entry back in mempool may be
+// resolved again, and only its latest resolution counts.
+type resolvedEntry struct {
+	entry *LedgerEntry
+	seq   uint64
+}
+
+// NewMempoolLedger creates a MempoolLedger for a chain that keeps up to
+// maxResolved transactions that left mempool.
+func NewMempoolLedger(chainType string, maxResolved int) *MempoolLedger {
+	return &MempoolLedger{
+		chainType:   chainType,
+		maxResolved: maxResolved,
+		entries:     make(map[string]*LedgerEntry),
+		index:       newSpendIndex(),
+	}
+}
+
+// SetResolvedHandler sets the function called with copies of the entries whose
+// fate changed, except the transactions mined straight from mempool, which the
+// chain itself records.
+func (l *MempoolLedger) SetResolvedHandler(handler func([]*LedgerEntry)) {
+	l.mtx.Lock()
+	l.resolvedHandler = handler
+	l.mtx.Unlock()
+}
+
+// SetDoubleSpendHandler sets the function called with each double spend.
+func (l *MempoolLedger) SetDoubleSpendHandler(handler func(*DoubleSpend)) {
+	l.mtx.Lock()
+	l.doubleSpendHandler = handler
+	l.mtx.Unlock()
+}
+
+// Seen records transactions seen in mempool at time t. A mempool holds no
+// conflicting spends, so a transaction spending an outpoint spent by another
+// transaction in mempool replaced it. The double spends are returned.
+func (l *MempoolLedger) Seen(txs []*LedgerTx, t int64) []*DoubleSpend {
+	l.mtx.Lock()
+	var changes []*LedgerEntry
+	doubleSpends := l.seen(txs, t, &changes)
+	return l.notify(changes, doubleSpends)
+}
+
+// Snapshot records the transactions of a full mempool snapshot at time t, like
+// Seen. Transactions missing from the previous snapshot and this one were
+// evicted at the time of the previous snapshot. The double spends are
+// returned.
+func (l *MempoolLedger) Snapshot(txs []*LedgerTx, t int64) []*DoubleSpend {
+	l.mtx.Lock()
+	var changes []*LedgerEntry
+	doubleSpends := l.seen(txs, t, &changes)
+
+	inSnapshot := make(map[string]struct{}, len(txs))
+	for _, tx := range txs {
+		inSnapshot[tx.TxID] = struct{}{}
+	}
+	var missing []*LedgerEntry
+	for txid, e := range l.entries {
+		if e.Fate != TxFateMempool {
+			continue
+		}
+		if _, found := inSnapshot[txid]; found {
+			continue
+		}
+		if e.missingSince == 0 {
+			e.missingSince = t
+			continue
+		}
+		missing = append(missing, e)
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].TxID < missing[j].TxID })
+	for _, e := range missing {
+		if e.Fate == TxFateMempool {
+			l.resolve(e, TxFateEvicted, e.missingSince, &changes)
+		}
+	}
+	return l.notify(changes, doubleSpends)
+}
+
+// BlockConnected records the transactions mined in a block at height, at time
+// t. Transactions in mempool spending the same outpoints as the block's are
+// conflicted, and returned as double spends.
+func (l *MempoolLedger) BlockConnected(height int64, txs []*LedgerTx, t int64) []*DoubleSpend {
+	l.mtx.Lock()
+	var changes []*LedgerEntry
+	for _, tx := range txs {
+		e := l.entries[tx.TxID]
+		if e == nil || e.Fate == TxFateMined {
+			continue
+		}
+		e.Height = height
+		l.resolve(e, TxFateMined, t, &changes)
+	}
+
+	var doubleSpends []*DoubleSpend
+	for _, tx := range txs {
+		conflicts, spenders := l.index.conflicts(tx.TxID, tx.Outpoints)
+		for _, spender := range spenders {
+			ds := &DoubleSpend{
+				ChainType:     l.chainType,
+				TxID:          spender,
+				ConflictsWith: tx.TxID,
+				Outpoints:     conflicts[spender],
+				Height:        height,
+				Time:          t,
+			}
+			doubleSpends = append(doubleSpends, ds)
+			e := l.entries[spender]
+			e.ConflictedBy = tx.TxID
+			l.resolve(e, TxFateConflicted, t, &changes)
+			l.conflictDescendants(spender, tx.TxID, t, &changes)
+		}
+	}
+	return l.notify(changes, doubleSpends)
+}
+
+// Entry returns a copy of the entry of a transaction, or nil if the ledger has
+// none.
+func (l *MempoolLedger) Entry(txid string) *LedgerEntry {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	e := l.entries[txid]
+	if e == nil {
+		return nil
+	}
+	return e.copy()
+}
+
+// RecentDoubleSpends returns up to n of the most recent double spends, most
+// recent first.
+func (l *MempoolLedger) RecentDoubleSpends(n int) []*DoubleSpend {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	if n > len(l.doubleSpends) || n <= 0 {
+		n = len(l.doubleSpends)
+	}
+	recent := make([]*DoubleSpend, 0, n)
+	for i := len(l.doubleSpends) - 1; i >= len(l.doubleSpends)-n; i-- {
+		recent = append(recent, l.doubleSpends[i])
+	}
+	return recent
+}
+
+// seen records transactions seen in mempool. The lock must be held for
+// writing.
+func (l *MempoolLedger) seen(txs []*LedgerTx, t int64, changes *[]*LedgerEntry) []*DoubleSpend {
+	var doubleSpends []*DoubleSpend
+	for _, tx := range txs {
+		e := l.entries[tx.TxID]
+		if e != nil && e.Fate == TxFateMempool {
+			e.LastSeen = t
+			e.missingSince = 0
+			continue
+		}
+		if e == nil {
+			e = &LedgerEntry{TxID: tx.TxID, FirstSeen: t}
+			l.entries[tx.TxID] = e
+		} else {
+			// Back in mempool, as after a reorg.
+			e.FateTime, e.Height, e.ReplacedBy, e.ConflictedBy = 0, 0, "", ""
+			if e.reported {
+				*changes = append(*changes, e)
+			}
+		}
+		e.LastSeen = t
+		e.Fate = TxFateMempool
+
+		conflicts, spenders := l.index.conflicts(tx.TxID, tx.Outpoints)
+		for _, spender := range spenders {
+			ds := &DoubleSpend{
+				ChainType:     l.chainType,
+				TxID:          tx.TxID,
+				ConflictsWith: spender,
+				Outpoints:     conflicts[spender],
+				Time:          t,
+			}
+			doubleSpends = append(doubleSpends, ds)
+			old := l.entries[spender]
+			old.ReplacedBy = tx.TxID
+			l.resolve(old, TxFateReplaced, t, changes)
+			l.conflictDescendants(spender, tx.TxID, t, changes)
+		}
+
+		e.outpoints = tx.Outpoints
+		l.index.add(tx.TxID, tx.Outpoints)
+	}
+	return doubleSpends
+}
+
+// resolve records that a transaction left mempool with a fate at time t. The
+// lock must be held for writing.
+func (l *MempoolLedger) resolve(e *LedgerEntry, fate string, t int64, changes *[]*LedgerEntry) {
+	l.index.remove(e.TxID, e.outpoints)
+	e.outpoints = nil
+	e.missingSince = 0
+	e.Fate = fate
+	e.FateTime = t
+	if fate != TxFateMined || e.reported {
+		e.reported = true
+		*changes = append(*changes, e)
+	}
+
+	l.resolvedSeq++
+	e.resolvedSeq = l.resolvedSeq
+	l.resolved = append(l.resolved, resolvedEntry{e, l.resolvedSeq})
+	for l.maxResolved > 0 && len(l.resolved) > l.maxResolved {
+		oldest := l.resolved[0]
+		l.resolved[0] = resolvedEntry{}
+		l.resolved = l.resolved[1:]
+		e := oldest.entry
+		if l.entries[e.TxID] == e && e.resolvedSeq == oldest.seq && e.Fate != TxFateMempool {
+			delete(l.entries, e.TxID)
+		}
+	}
+}
+
+// conflictDescendants records that the transactions in mempool spending the
+// outputs of a replaced or conflicted transaction, and their descendants, were
+// conflicted by the transaction by. The lock must be held for writing.
+func (l *MempoolLedger) conflictDescendants(txid, by string, t int64, changes *[]*LedgerEntry) {
+	for _, child := range l.index.childrenOf(txid) {
+		e := l.entries[child]
+		if e == nil || e.Fate != TxFateMempool {
+			continue
+		}
+		e.ConflictedBy = by
+		l.resolve(e, TxFateConflicted, t, changes)
+		l.conflictDescendants(child, by, t, changes)
+	}
+}
+
+// notify keeps the double spends, releases the lock held for writing, and
+// calls the handlers.
+func (l *MempoolLedger) notify(changes []*LedgerEntry, doubleSpends []*DoubleSpend) []*DoubleSpend {
+	l.doubleSpends = append(l.doubleSpends, doubleSpends...)
+	if over := len(l.doubleSpends) - maxLedgerDoubleSpends; over > 0 {
+		l.doubleSpends = append([]*DoubleSpend(nil), l.doubleSpends[over:]...)
+	}
+	copies := make([]*LedgerEntry, 0, len(changes))
+	for _, e := range changes {
+		copies = append(copies, e.copy())
+	}
+	resolvedHandler, doubleSpendHandler := l.resolvedHandler, l.doubleSpendHandler
+	l.mtx.Unlock()
+
+	if resolvedHandler != nil && len(copies) > 0 {
+		resolvedHandler(copies)
+	}
+	if doubleSpendHandler != nil {
+		for _, ds := range doubleSpends {
+			doubleSpendHandler(ds)
+		}
+	}
+	return doubleSpends
+}
+
+func (e *LedgerEntry) copy() *LedgerEntry {
+	c := *e
+	c.outpoints = nil
+	return &c
+}
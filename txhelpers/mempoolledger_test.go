@@ -0,0 +1,102 @@
+package txhelpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMempoolLedgerDoubleSpends(t *testing.T) {
+	l := NewMempoolLedger("btc", 100)
+	var resolved []*LedgerEntry
+	var events []*DoubleSpend
+	l.SetResolvedHandler(func(es []*LedgerEntry) { resolved = append(resolved, es...) })
+	l.SetDoubleSpendHandler(func(ds *DoubleSpend) { events = append(events, ds) })
+
+	l.Seen([]*LedgerTx{
+		{TxID: "a", Outpoints: []string{"f:0", "f:1"}},
+		{TxID: "c", Outpoints: []string{"a:0"}},
+		{TxID: "m", Outpoints: []string{"g:0"}},
+		{TxID: "x", Outpoints: []string{"h:0"}},
+	}, 10)
+
+	// b spends f:1 too, replacing a and conflicting its child c.
+	dss := l.Seen([]*LedgerTx{{TxID: "b", Outpoints: []string{"f:1"}}}, 20)
+	want := &DoubleSpend{ChainType: "btc", TxID: "b", ConflictsWith: "a", Outpoints: []string{"f:1"}, Time: 20}
+	if len(dss) != 1 || !reflect.DeepEqual(dss[0], want) {
+		t.Fatalf("wrong double spends %v", dss)
+	}
+	if e := l.Entry("a"); e.Fate != TxFateReplaced || e.ReplacedBy != "b" || e.FateTime != 20 || e.FirstSeen != 10 {
+		t.Errorf("wrong replaced entry %+v", e)
+	}
+	if e := l.Entry("c"); e.Fate != TxFateConflicted || e.ConflictedBy != "b" {
+		t.Errorf("wrong conflicted child %+v", e)
+	}
+
+	// m is mined, and y in the same block conflicts x.
+	dss = l.BlockConnected(100, []*LedgerTx{
+		{TxID: "m", Outpoints: []string{"g:0"}},
+		{TxID: "y", Outpoints: []string{"h:0"}},
+	}, 30)
+	if len(dss) != 1 || dss[0].TxID != "x" || dss[0].ConflictsWith != "y" || dss[0].Height != 100 {
+		t.Fatalf("wrong double spends %v", dss)
+	}
+	if e := l.Entry("m"); e.Fate != TxFateMined || e.Height != 100 {
+		t.Errorf("wrong mined entry %+v", e)
+	}
+	if e := l.Entry("x"); e.Fate != TxFateConflicted || e.ConflictedBy != "y" {
+		t.Errorf("wrong conflicted entry %+v", e)
+	}
+	if l.Entry("y") != nil {
+		t.Errorf("a tx never seen in mempool has an entry")
+	}
+
+	if len(events) != 2 || len(l.RecentDoubleSpends(5)) != 2 || l.RecentDoubleSpends(1)[0].TxID != "x" {
+		t.Errorf("wrong double spend events %v", events)
+	}
+	// The mined tx is not reported.
+	var txids []string
+	for _, e := range resolved {
+		txids = append(txids, e.TxID)
+	}
+	if !reflect.DeepEqual(txids, []string{"a", "c", "x"}) {
+		t.Errorf("wrong resolved entries %v", txids)
+	}
+}
+
+func TestMempoolLedgerEviction(t *testing.T) {
+	l := NewMempoolLedger("dcr", 2)
+	l.Snapshot([]*LedgerTx{{TxID: "a"}, {TxID: "b"}}, 10)
+	// a is missing once, and kept.
+	l.Snapshot([]*LedgerTx{{TxID: "b"}}, 20)
+	if e := l.Entry("a"); e.Fate != TxFateMempool || e.LastSeen != 10 {
+		t.Fatalf("wrong entry after one missed snapshot %+v", e)
+	}
+	// a is missing twice, and evicted when first missed.
+	l.Snapshot([]*LedgerTx{{TxID: "b"}}, 30)
+	if e := l.Entry("a"); e.Fate != TxFateEvicted || e.FateTime != 20 {
+		t.Fatalf("wrong evicted entry %+v", e)
+	}
+	if e := l.Entry("b"); e.Fate != TxFateMempool || e.LastSeen != 30 {
+		t.Errorf("wrong entry %+v", e)
+	}
+
+	// An evicted tx mined later is corrected.
+	l.BlockConnected(5, []*LedgerTx{{TxID: "a"}}, 40)
+	if e := l.Entry("a"); e.Fate != TxFateMined || e.Height != 5 {
+		t.Errorf("wrong entry of the mined evicted tx %+v", e)
+	}
+
+	// Back in mempool after a reorg.
+	l.Seen([]*LedgerTx{{TxID: "a"}}, 50)
+	if e := l.Entry("a"); e.Fate != TxFateMempool || e.Height != 0 || e.FirstSeen != 10 {
+		t.Errorf("wrong revived entry %+v", e)
+	}
+
+	// Only the last two resolved txs are kept.
+	l.BlockConnected(6, []*LedgerTx{{TxID: "a"}, {TxID: "b"}}, 60)
+	l.Seen([]*LedgerTx{{TxID: "c"}}, 70)
+	l.BlockConnected(7, []*LedgerTx{{TxID: "c"}}, 80)
+	if l.Entry("a") != nil || l.Entry("b") == nil || l.Entry("c") == nil {
+		t.Errorf("wrong resolved entries kept")
+	}
+}
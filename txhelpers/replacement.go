@@ -0,0 +1,499 @@
+package txhelpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ReplaceableTx is a mempool transaction tracked by a ReplacementTracker. Fee
+// is in atoms, and Outpoints are the previous outpoints it spends, formatted by
+// Outpoint.
+type ReplaceableTx struct {
+	TxID      string
+	Fee       int64
+	VSize     int64
+	Time      int64
+	Outpoints []string
+}
+
+func (tx *ReplaceableTx) feeRate() float64 {
+	if tx.VSize <= 0 {
+		return 0
+	}
+	return float64(tx.Fee) / float64(tx.VSize)
+}
+
+// Outpoint formats a previous outpoint for ReplaceableTx.Outpoints.
+func Outpoint(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
+}
+
+// outpointTxID is the txid of an outpoint formatted by Outpoint.
+func outpointTxID(outpoint string) string {
+	if i := strings.LastIndexByte(outpoint, ':'); i >= 0 {
+		return outpoint[:i]
+	}
+	return outpoint
+}
+
+// spendIndex indexes the previous outpoints spent by the transactions of a
+// mempool, and the transactions spending the outputs of each transaction, to
+// find conflicting spends. It is not safe for concurrent use.
+type spendIndex struct {
+	spends   map[string]string              // outpoint => spending txid
+	children map[string]map[string]struct{} // txid => spending txids
+}
+
+func newSpendIndex() spendIndex {
+	return spendIndex{
+		spends:   make(map[string]string),
+		children: make(map[string]map[string]struct{}),
+	}
+}
+
+// add indexes the outpoints spent by a transaction.
+func (si *spendIndex) add(txid string, outpoints []string) {
+	for _, op := range outpoints {
+		si.spends[op] = txid
+		parent := outpointTxID(op)
+		if si.children[parent] == nil {
+			si.children[parent] = make(map[string]struct{})
+		}
+		si.children[parent][txid] = struct{}{}
+	}
+}
+
+// remove drops the outpoints spent by a transaction from the index.
+func (si *spendIndex) remove(txid string, outpoints []string) {
+	for _, op := range outpoints {
+		if si.spends[op] == txid {
+			delete(si.spends, op)
+		}
+		parent := outpointTxID(op)
+		if siblings := si.children[parent]; siblings != nil {
+			delete(siblings, txid)
+			if len(siblings) == 0 {
+				delete(si.children, parent)
+			}
+		}
+	}
+}
+
+// conflicts groups the outpoints of a transaction spent by other indexed
+// transactions by spender, and returns the sorted txids of the spenders.
+func (si *spendIndex) conflicts(txid string, outpoints []string) (map[string][]string, []string) {
+	conflicts := make(map[string][]string)
+	var spenders []string
+	for _, op := range outpoints {
+		spender, found := si.spends[op]
+		if !found || spender == txid {
+			continue
+		}
+		if conflicts[spender] == nil {
+			spenders = append(spenders, spender)
+		}
+		conflicts[spender] = append(conflicts[spender], op)
+	}
+	sort.Strings(spenders)
+	return conflicts, spenders
+}
+
+// childrenOf returns the sorted txids of the indexed transactions spending the
+// outputs of a transaction.
+func (si *spendIndex) childrenOf(txid string) []string {
+	return sortedKeys(si.children[txid])
+}
+
+// TxReplacement is the replacement of a mempool transaction by a transaction
+// spending one or more of the same outpoints, either by replace-by-fee or by a
+// double spend. Fees are in atoms and fee rates in atoms/vB. Time is when the
+// replacement was first seen.
+type TxReplacement struct {
+	TxID       string   `json:"txid"`
+	Fee        int64    `json:"fee"`
+	FeeRate    float64  `json:"fee_rate"`
+	FirstSeen  int64    `json:"first_seen"`
+	ReplacedBy string   `json:"replaced_by"`
+	NewFee     int64    `json:"new_fee"`
+	NewFeeRate float64  `json:"new_fee_rate"`
+	FeeBump    int64    `json:"fee_bump"`
+	Outpoints  []string `json:"outpoints"`
+	Time       int64    `json:"time"`
+}
+
+// ReplacementHistory is the chain of replacements a transaction is part of,
+// oldest first. Original is the first transaction of the chain, and Latest is
+// the last replacement, which may be in mempool, mined, or never seen again.
+type ReplacementHistory struct {
+	TxID         string           `json:"txid"`
+	Replaced     bool             `json:"replaced"`
+	Original     string           `json:"original"`
+	Latest       string           `json:"latest"`
+	TotalFeeBump int64            `json:"total_fee_bump"`
+	Replacements []*TxReplacement `json:"replacements"`
+}
+
+// CPFPPackage is a mempool transaction with its unconfirmed ancestors and
+// descendants. The ancestor fee rate is that of the transaction with all of
+// its ancestors, and the effective fee rate is the best ancestor fee rate of
+// the transaction or one of its descendants, which is the fee rate a miner
+// selects it at when a child pays for its parent. Fees are in atoms and fee
+// rates in atoms/vB.
+type CPFPPackage struct {
+	TxID             string   `json:"txid"`
+	Fee              int64    `json:"fee"`
+	VSize            int64    `json:"vsize"`
+	FeeRate          float64  `json:"fee_rate"`
+	Ancestors        []string `json:"ancestors,omitempty"`
+	Descendants      []string `json:"descendants,omitempty"`
+	AncestorFee      int64    `json:"ancestor_fee"`
+	AncestorVSize    int64    `json:"ancestor_vsize"`
+	AncestorFeeRate  float64  `json:"ancestor_fee_rate"`
+	EffectiveFeeRate float64  `json:"effective_fee_rate"`
+}
+
+// ReplacementTracker tracks the outpoints spent by the transactions in a
+// mempool to detect transactions replaced by conflicting spends, and keeps the
+// most recent replacements, including those of transactions that were never
+// mined. It is safe for concurrent use.
+type ReplacementTracker struct {
+	mtx        sync.RWMutex
+	maxHistory int
+	txs        map[string]*ReplaceableTx
+	index      spendIndex
+	replacedBy map[string]*TxReplacement   // replaced txid
+	replaces   map[string][]*TxReplacement // replacement txid
+	history    []*TxReplacement            // oldest first
+}
+
+// NewReplacementTracker creates a ReplacementTracker that keeps up to
+// maxHistory replacements.
+func NewReplacementTracker(maxHistory int) *ReplacementTracker {
+	return &ReplacementTracker{
+		maxHistory: maxHistory,
+		txs:        make(map[string]*ReplaceableTx),
+		index:      newSpendIndex(),
+		replacedBy: make(map[string]*TxReplacement),
+		replaces:   make(map[string][]*TxReplacement),
+	}
+}
+
+// AddTx tracks a new mempool transaction. Tracked transactions spending any of
+// the same outpoints are replaced by it, and are removed along with their
+// descendants. The replacements are returned.
+func (rt *ReplacementTracker) AddTx(tx *ReplaceableTx) []*TxReplacement {
+	rt.mtx.Lock()
+	defer rt.mtx.Unlock()
+	if _, found := rt.txs[tx.TxID]; found {
+		return nil
+	}
+
+	conflicts, replaced := rt.index.conflicts(tx.TxID, tx.Outpoints)
+	replacements := make([]*TxReplacement, 0, len(replaced))
+	for _, txid := range replaced {
+		if old := rt.txs[txid]; old != nil {
+			replacements = append(replacements, rt.record(old, tx, conflicts[txid]))
+		}
+		rt.remove(txid)
+	}
+	rt.add(tx)
+	return replacements
+}
+
+// Reset replaces the tracked transactions with those of a fresh mempool
+// snapshot. A transaction of the snapshot spending an outpoint of a tracked
+// transaction that left the mempool replaced it, which catches replacements
+// missed between snapshots. The replacements are returned.
+func (rt *ReplacementTracker) Reset(txs []*ReplaceableTx) []*TxReplacement {
+	rt.mtx.Lock()
+	defer rt.mtx.Unlock()
+	oldTxs, oldSpends := rt.txs, rt.index.spends
+	rt.txs = make(map[string]*ReplaceableTx, len(txs))
+	rt.index = newSpendIndex()
+	for _, tx := range txs {
+		rt.add(tx)
+	}
+
+	var replacements []*TxReplacement
+	for _, tx := range txs {
+		if _, found := oldTxs[tx.TxID]; found {
+			continue
+		}
+		conflicts := make(map[string][]string)
+		var replaced []string
+		for _, op := range tx.Outpoints {
+			spender, found := oldSpends[op]
+			if !found || spender == tx.TxID {
+				continue
+			}
+			if _, stillIn := rt.txs[spender]; stillIn {
+				continue
+			}
+			if _, recorded := rt.replacedBy[spender]; recorded {
+				continue
+			}
+			if conflicts[spender] == nil {
+				replaced = append(replaced, spender)
+			}
+			conflicts[spender] = append(conflicts[spender], op)
+		}
+		sort.Strings(replaced)
+		for _, txid := range replaced {
+			replacements = append(replacements, rt.record(oldTxs[txid], tx, conflicts[txid]))
+		}
+	}
+	return replacements
+}
+
+// add tracks a transaction. The lock must be held for writing.
+func (rt *ReplacementTracker) add(tx *ReplaceableTx) {
+	rt.txs[tx.TxID] = tx
+	rt.index.add(tx.TxID, tx.Outpoints)
+}
+
+// remove stops tracking a transaction and its descendants, which leave the
+// mempool with it. The lock must be held for writing.
+func (rt *ReplacementTracker) remove(txid string) {
+	tx := rt.txs[txid]
+	if tx == nil {
+		return
+	}
+	delete(rt.txs, txid)
+	rt.index.remove(txid, tx.Outpoints)
+	for _, child := range rt.index.childrenOf(txid) {
+		rt.remove(child)
+	}
+}
+
+// record stores the replacement of old by tx, dropping the oldest replacement
+// beyond maxHistory. The lock must be held for writing.
+func (rt *ReplacementTracker) record(old, tx *ReplaceableTx, outpoints []string) *TxReplacement {
+	sort.Strings(outpoints)
+	r := &TxReplacement{
+		TxID:       old.TxID,
+		Fee:        old.Fee,
+		FeeRate:    old.feeRate(),
+		FirstSeen:  old.Time,
+		ReplacedBy: tx.TxID,
+		NewFee:     tx.Fee,
+		NewFeeRate: tx.feeRate(),
+		FeeBump:    tx.Fee - old.Fee,
+		Outpoints:  outpoints,
+		Time:       tx.Time,
+	}
+	rt.history = append(rt.history, r)
+	rt.replacedBy[r.TxID] = r
+	rt.replaces[r.ReplacedBy] = append(rt.replaces[r.ReplacedBy], r)
+
+	for rt.maxHistory > 0 && len(rt.history) > rt.maxHistory {
+		oldest := rt.history[0]
+		rt.history[0] = nil
+		rt.history = rt.history[1:]
+		if rt.replacedBy[oldest.TxID] == oldest {
+			delete(rt.replacedBy, oldest.TxID)
+		}
+		rs := rt.replaces[oldest.ReplacedBy]
+		for i := range rs {
+			if rs[i] == oldest {
+				rs = append(rs[:i], rs[i+1:]...)
+				break
+			}
+		}
+		if len(rs) == 0 {
+			delete(rt.replaces, oldest.ReplacedBy)
+		} else {
+			rt.replaces[oldest.ReplacedBy] = rs
+		}
+	}
+	return r
+}
+
+// Tracked checks if a transaction is tracked as in mempool.
+func (rt *ReplacementTracker) Tracked(txid string) bool {
+	rt.mtx.RLock()
+	defer rt.mtx.RUnlock()
+	_, found := rt.txs[txid]
+	return found
+}
+
+// History returns the replacement history of a transaction, which may have
+// been replaced or replaced others, or nil if it has none.
+func (rt *ReplacementTracker) History(txid string) *ReplacementHistory {
+	rt.mtx.RLock()
+	defer rt.mtx.RUnlock()
+
+	seen := make(map[*TxReplacement]struct{})
+	var events []*TxReplacement
+	// The transactions txid replaced, directly or not.
+	queue := []string{txid}
+	visited := map[string]struct{}{txid: {}}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, r := range rt.replaces[id] {
+			if _, found := seen[r]; found {
+				continue
+			}
+			seen[r] = struct{}{}
+			events = append(events, r)
+			if _, found := visited[r.TxID]; !found {
+				visited[r.TxID] = struct{}{}
+				queue = append(queue, r.TxID)
+			}
+		}
+	}
+	// The replacements of txid.
+	latest := txid
+	for r := rt.replacedBy[latest]; r != nil; r = rt.replacedBy[latest] {
+		if _, found := seen[r]; found {
+			break
+		}
+		seen[r] = struct{}{}
+		events = append(events, r)
+		latest = r.ReplacedBy
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+	original := txid
+	walked := map[string]struct{}{txid: {}}
+	for rs := rt.replaces[original]; len(rs) > 0; rs = rt.replaces[original] {
+		if _, found := walked[rs[0].TxID]; found {
+			break
+		}
+		original = rs[0].TxID
+		walked[original] = struct{}{}
+	}
+	var totalBump int64
+	for _, r := range events {
+		totalBump += r.FeeBump
+	}
+	_, replaced := rt.replacedBy[txid]
+	return &ReplacementHistory{
+		TxID:         txid,
+		Replaced:     replaced,
+		Original:     original,
+		Latest:       latest,
+		TotalFeeBump: totalBump,
+		Replacements: events,
+	}
+}
+
+// Recent returns up to n of the most recent replacements, most recent first.
+func (rt *ReplacementTracker) Recent(n int) []*TxReplacement {
+	rt.mtx.RLock()
+	defer rt.mtx.RUnlock()
+	if n > len(rt.history) || n <= 0 {
+		n = len(rt.history)
+	}
+	recent := make([]*TxReplacement, 0, n)
+	for i := len(rt.history) - 1; i >= len(rt.history)-n; i-- {
+		recent = append(recent, rt.history[i])
+	}
+	return recent
+}
+
+// Package returns the CPFP package of a tracked transaction, or nil if it is
+// not tracked.
+func (rt *ReplacementTracker) Package(txid string) *CPFPPackage {
+	rt.mtx.RLock()
+	defer rt.mtx.RUnlock()
+	tx := rt.txs[txid]
+	if tx == nil {
+		return nil
+	}
+
+	ancestors := rt.ancestors(txid)
+	pkg := &CPFPPackage{
+		TxID:          txid,
+		Fee:           tx.Fee,
+		VSize:         tx.VSize,
+		FeeRate:       tx.feeRate(),
+		Ancestors:     ancestors,
+		Descendants:   rt.descendants(txid),
+		AncestorFee:   tx.Fee,
+		AncestorVSize: tx.VSize,
+	}
+	for _, id := range ancestors {
+		pkg.AncestorFee += rt.txs[id].Fee
+		pkg.AncestorVSize += rt.txs[id].VSize
+	}
+	pkg.AncestorFeeRate = packageFeeRate(pkg.AncestorFee, pkg.AncestorVSize)
+	pkg.EffectiveFeeRate = pkg.AncestorFeeRate
+	for _, id := range pkg.Descendants {
+		fee, size := rt.txs[id].Fee, rt.txs[id].VSize
+		for _, a := range rt.ancestors(id) {
+			fee += rt.txs[a].Fee
+			size += rt.txs[a].VSize
+		}
+		if rate := packageFeeRate(fee, size); rate > pkg.EffectiveFeeRate {
+			pkg.EffectiveFeeRate = rate
+		}
+	}
+	return pkg
+}
+
+func packageFeeRate(fee, vsize int64) float64 {
+	if vsize <= 0 {
+		return 0
+	}
+	return float64(fee) / float64(vsize)
+}
+
+// ancestors are the sorted txids of the tracked unconfirmed ancestors of a
+// transaction. The lock must be held.
+func (rt *ReplacementTracker) ancestors(txid string) []string {
+	visited := make(map[string]struct{})
+	var walk func(id string)
+	walk = func(id string) {
+		for _, op := range rt.txs[id].Outpoints {
+			parent := outpointTxID(op)
+			if _, tracked := rt.txs[parent]; !tracked {
+				continue
+			}
+			if _, found := visited[parent]; found {
+				continue
+			}
+			visited[parent] = struct{}{}
+			walk(parent)
+		}
+	}
+	walk(txid)
+	return sortedKeys(visited)
+}
+
+// descendants are the sorted txids of the tracked descendants of a
+// transaction. The lock must be held.
+func (rt *ReplacementTracker) descendants(txid string) []string {
+	visited := make(map[string]struct{})
+	var walk func(id string)
+	walk = func(id string) {
+		for child := range rt.index.children[id] {
+			if _, found := visited[child]; found {
+				continue
+			}
+			visited[child] = struct{}{}
+			walk(child)
+		}
+	}
+	walk(txid)
+	return sortedKeys(visited)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
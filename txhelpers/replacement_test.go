@@ -0,0 +1,110 @@
+package txhelpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplacementTrackerRBF(t *testing.T) {
+	rt := NewReplacementTracker(100)
+	a := &ReplaceableTx{TxID: "a", Fee: 1000, VSize: 200, Time: 10,
+		Outpoints: []string{Outpoint("f", 0), Outpoint("f", 1)}}
+	child := &ReplaceableTx{TxID: "c", Fee: 500, VSize: 100, Time: 11,
+		Outpoints: []string{Outpoint("a", 0)}}
+	if rs := rt.AddTx(a); len(rs) != 0 {
+		t.Fatalf("unexpected replacements %v", rs)
+	}
+	rt.AddTx(child)
+
+	// b bumps the fee of a, which evicts the child of a too.
+	b := &ReplaceableTx{TxID: "b", Fee: 3000, VSize: 200, Time: 20,
+		Outpoints: []string{Outpoint("f", 1)}}
+	rs := rt.AddTx(b)
+	if len(rs) != 1 {
+		t.Fatalf("expected 1 replacement, got %d", len(rs))
+	}
+	want := &TxReplacement{TxID: "a", Fee: 1000, FeeRate: 5, FirstSeen: 10, ReplacedBy: "b",
+		NewFee: 3000, NewFeeRate: 15, FeeBump: 2000, Outpoints: []string{"f:1"}, Time: 20}
+	if !reflect.DeepEqual(rs[0], want) {
+		t.Errorf("wrong replacement %+v", rs[0])
+	}
+	if rt.Package("a") != nil || rt.Package("c") != nil {
+		t.Errorf("the replaced tx and its child are still tracked")
+	}
+
+	// d replaces b, and the history of each tx of the chain is the same.
+	d := &ReplaceableTx{TxID: "d", Fee: 5000, VSize: 250, Time: 30,
+		Outpoints: []string{Outpoint("f", 1), Outpoint("g", 0)}}
+	rt.AddTx(d)
+	for _, txid := range []string{"a", "b", "d"} {
+		h := rt.History(txid)
+		if h == nil {
+			t.Fatalf("no history for %s", txid)
+		}
+		if h.Original != "a" || h.Latest != "d" || h.TotalFeeBump != 4000 ||
+			len(h.Replacements) != 2 || h.Replacements[0].TxID != "a" || h.Replacements[1].TxID != "b" {
+			t.Errorf("wrong history for %s: %+v", txid, h)
+		}
+		if h.Replaced != (txid != "d") {
+			t.Errorf("wrong replaced flag for %s", txid)
+		}
+	}
+	if rt.History("c") != nil {
+		t.Errorf("unexpected history for an evicted child")
+	}
+	if recent := rt.Recent(5); len(recent) != 2 || recent[0].TxID != "b" {
+		t.Errorf("wrong recent replacements %v", recent)
+	}
+}
+
+func TestReplacementTrackerReset(t *testing.T) {
+	rt := NewReplacementTracker(2)
+	rt.Reset([]*ReplaceableTx{
+		{TxID: "a", Fee: 100, VSize: 100, Outpoints: []string{"f:0"}},
+		{TxID: "m", Fee: 100, VSize: 100, Outpoints: []string{"f:1"}},
+	})
+	// a was replaced by b between the snapshots, and m was mined.
+	rs := rt.Reset([]*ReplaceableTx{
+		{TxID: "b", Fee: 300, VSize: 100, Outpoints: []string{"f:0"}},
+	})
+	if len(rs) != 1 || rs[0].TxID != "a" || rs[0].ReplacedBy != "b" || rs[0].FeeBump != 200 {
+		t.Fatalf("wrong replacements %v", rs)
+	}
+	if h := rt.History("m"); h != nil {
+		t.Errorf("the mined tx was replaced %+v", h)
+	}
+
+	// Only the last two replacements are kept.
+	rt.AddTx(&ReplaceableTx{TxID: "c", Fee: 400, VSize: 100, Outpoints: []string{"f:0"}})
+	rt.AddTx(&ReplaceableTx{TxID: "d", Fee: 500, VSize: 100, Outpoints: []string{"f:0"}})
+	if rt.History("a") != nil {
+		t.Errorf("the oldest replacement was kept")
+	}
+	if h := rt.History("d"); h == nil || h.Original != "b" || len(h.Replacements) != 2 {
+		t.Errorf("wrong history %+v", h)
+	}
+}
+
+func TestReplacementTrackerCPFP(t *testing.T) {
+	rt := NewReplacementTracker(10)
+	// A 1 atom/vB parent with a 21 atoms/vB child, and a confirmed input.
+	rt.Reset([]*ReplaceableTx{
+		{TxID: "p", Fee: 200, VSize: 200, Outpoints: []string{"x:0"}},
+		{TxID: "c", Fee: 2100, VSize: 100, Outpoints: []string{"p:0"}},
+		{TxID: "s", Fee: 100, VSize: 100, Outpoints: []string{"p:1"}},
+	})
+	parent := rt.Package("p")
+	if parent.FeeRate != 1 || parent.AncestorFeeRate != 1 || parent.EffectiveFeeRate != 23.0/3 ||
+		!reflect.DeepEqual(parent.Descendants, []string{"c", "s"}) || parent.Ancestors != nil {
+		t.Errorf("wrong parent package %+v", parent)
+	}
+	child := rt.Package("c")
+	if child.AncestorFee != 2300 || child.AncestorVSize != 300 ||
+		child.EffectiveFeeRate != child.AncestorFeeRate || !reflect.DeepEqual(child.Ancestors, []string{"p"}) {
+		t.Errorf("wrong child package %+v", child)
+	}
+	// The sibling paying 1 atom/vB is mined at its own ancestor fee rate.
+	if sibling := rt.Package("s"); sibling.EffectiveFeeRate != 1 {
+		t.Errorf("wrong sibling package %+v", sibling)
+	}
+}
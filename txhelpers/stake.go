@@ -24,3 +24,89 @@ func CalcMeanVotingBlocks(params *chaincfg.Params) int64 {
 	}
 	return int64(v)
 }
+
+// VoteChance returns the probability that a live ticket is called to vote in
+// a block, for a ticket pool of poolSize tickets. Each block calls
+// TicketsPerBlock tickets from the pool.
+func VoteChance(poolSize int64, params *chaincfg.Params) float64 {
+	if poolSize <= 0 {
+		return 0
+	}
+	return math.Min(float64(params.TicketsPerBlock)/float64(poolSize), 1)
+}
+
+// ProbVoteWithin returns the probability that a live ticket is called within
+// the given number of blocks after it matures, for a ticket pool of poolSize
+// tickets. Tickets expire after TicketExpiry blocks, so the probability does
+// not grow past that.
+func ProbVoteWithin(blocks, poolSize int64, params *chaincfg.Params) float64 {
+	if blocks <= 0 {
+		return 0
+	}
+	if blocks > int64(params.TicketExpiry) {
+		blocks = int64(params.TicketExpiry)
+	}
+	return 1 - math.Pow(1-VoteChance(poolSize, params), float64(blocks))
+}
+
+// ProbTicketExpiry returns the probability that a ticket expires without being
+// called, for a ticket pool of poolSize tickets.
+func ProbTicketExpiry(poolSize int64, params *chaincfg.Params) float64 {
+	return 1 - ProbVoteWithin(int64(params.TicketExpiry), poolSize, params)
+}
+
+// ExpectedVoteBlocks returns the mean number of blocks from maturity to the
+// call of the tickets that are called before expiry, for a ticket pool of
+// poolSize tickets. This is the mean of a geometric distribution truncated at
+// TicketExpiry.
+func ExpectedVoteBlocks(poolSize int64, params *chaincfg.Params) float64 {
+	p := VoteChance(poolSize, params)
+	if p <= 0 {
+		return 0
+	}
+	expiry := float64(params.TicketExpiry)
+	miss := math.Pow(1-p, expiry)
+	if miss >= 1 {
+		return 0
+	}
+	return 1/p - expiry*miss/(1-miss)
+}
+
+// VoteBlocksQuantile returns the number of blocks after maturity within which
+// a live ticket is called with probability q, for a ticket pool of poolSize
+// tickets, or -1 if the ticket is more likely to expire than that.
+func VoteBlocksQuantile(q float64, poolSize int64, params *chaincfg.Params) int64 {
+	p := VoteChance(poolSize, params)
+	switch {
+	case q <= 0:
+		return 0
+	case q >= 1 || p <= 0:
+		return -1
+	case p >= 1:
+		return 1
+	}
+	blocks := int64(math.Ceil(math.Log(1-q) / math.Log(1-p)))
+	if blocks > int64(params.TicketExpiry) {
+		return -1
+	}
+	return blocks
+}
+
+// VoteBlocksDistribution returns the expected distribution of the number of
+// blocks from maturity to the call of the tickets that are called before
+// expiry, for a ticket pool of poolSize tickets. Element i is the fraction of
+// the called tickets with a wait of i*bucketBlocks+1 to (i+1)*bucketBlocks
+// blocks, and the buckets cover TicketExpiry.
+func VoteBlocksDistribution(bucketBlocks, poolSize int64, params *chaincfg.Params) []float64 {
+	called := ProbVoteWithin(int64(params.TicketExpiry), poolSize, params)
+	if bucketBlocks <= 0 || called <= 0 {
+		return []float64{}
+	}
+	expiry := int64(params.TicketExpiry)
+	dist := make([]float64, 0, (expiry+bucketBlocks-1)/bucketBlocks)
+	for start := int64(0); start < expiry; start += bucketBlocks {
+		p := ProbVoteWithin(start+bucketBlocks, poolSize, params) - ProbVoteWithin(start, poolSize, params)
+		dist = append(dist, p/called)
+	}
+	return dist
+}
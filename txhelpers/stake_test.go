@@ -1,6 +1,7 @@
 package txhelpers
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -69,3 +70,51 @@ func TestRewardPeriods(t *testing.T) {
 			lockedDuration, lockedDuration.Hours()/24)
 	}
 }
+
+// TestVoteModel checks the vote probabilities against the mean voting blocks
+// of a full mainnet ticket pool.
+func TestVoteModel(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	poolSize := int64(params.TicketPoolSize) * int64(params.TicketsPerBlock)
+
+	if p := VoteChance(poolSize, params); p != 1/float64(params.TicketPoolSize) {
+		t.Errorf("wrong vote chance %v", p)
+	}
+	// About 0.67% of the tickets of a full pool expire.
+	expiry := ProbTicketExpiry(poolSize, params)
+	if math.Abs(expiry-math.Exp(-5)) > 1e-4 {
+		t.Errorf("wrong expiry probability %v", expiry)
+	}
+	if p := ProbVoteWithin(int64(params.TicketExpiry)*2, poolSize, params); math.Abs(p+expiry-1) > 1e-12 {
+		t.Errorf("vote probability past expiry %v", p)
+	}
+
+	// CalcMeanVotingBlocks does not condition on the ticket being called.
+	mean := ExpectedVoteBlocks(poolSize, params)
+	if unconditional := mean * (1 - expiry); math.Abs(unconditional-float64(CalcMeanVotingBlocks(params))) > 1 {
+		t.Errorf("ExpectedVoteBlocks %v inconsistent with CalcMeanVotingBlocks", mean)
+	}
+
+	median := VoteBlocksQuantile(0.5, poolSize, params)
+	if p := ProbVoteWithin(median, poolSize, params); p < 0.5 || ProbVoteWithin(median-1, poolSize, params) >= 0.5 {
+		t.Errorf("wrong median %d", median)
+	}
+	if q := VoteBlocksQuantile(0.999, poolSize, params); q != -1 {
+		t.Errorf("expected -1 for a quantile past expiry, got %d", q)
+	}
+
+	dist := VoteBlocksDistribution(288, poolSize, params)
+	if len(dist) != 143 {
+		t.Errorf("expected 143 buckets, got %d", len(dist))
+	}
+	var sum float64
+	for i, p := range dist {
+		if i > 0 && p > dist[i-1] {
+			t.Errorf("distribution not decreasing at %d", i)
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("distribution sums to %v", sum)
+	}
+}
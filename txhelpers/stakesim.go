@@ -0,0 +1,223 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package txhelpers
+
+import (
+	"math"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// Compounding policies of the staking simulation.
+const (
+	// CompoundAll reinvests the returned ticket price and the vote rewards.
+	CompoundAll = "all"
+	// CompoundPrincipal reinvests the returned ticket price and withdraws the
+	// vote rewards.
+	CompoundPrincipal = "principal"
+)
+
+// StakeSimConfig is the input of SimulateStaking. Amounts are in DCR.
+type StakeSimConfig struct {
+	Params *chaincfg.Params
+	// Height, CoinSupply and TicketPrice are the current block height, coin
+	// supply and ticket price. StakedFraction is the current fraction of the
+	// coin supply locked in tickets.
+	Height         int64
+	CoinSupply     float64
+	TicketPrice    float64
+	StakedFraction float64
+	// SSV returns the subsidy split variant in effect at a height.
+	SSV func(height int64) standalone.SubsidySplitVariant
+
+	// Balance is the starting balance, and Days the simulated time.
+	Balance        float64
+	Days           float64
+	Compounding    string
+	IntegerTickets bool
+	// VSPFee is the percentage of the vote reward paid to the voting service
+	// provider.
+	VSPFee float64
+	// Participation is the assumed fraction of the coin supply locked in
+	// tickets at the end of the simulation. The ticket price moves with the
+	// fraction, which goes linearly from StakedFraction to Participation. Zero
+	// keeps the current fraction.
+	Participation float64
+	// PriceChange is the assumed yearly change of the ticket price as a
+	// fraction, in addition to the changes with the coin supply and the
+	// participation.
+	PriceChange float64
+}
+
+// StakeSimPeriod is one ticket purchase of a staking simulation, from the
+// purchase to the maturity of the vote. Balance is the spendable balance after
+// the period, and Withdrawn the total withdrawn rewards.
+type StakeSimPeriod struct {
+	Period      int     `json:"period"`
+	BuyHeight   int64   `json:"buy_height"`
+	VoteHeight  int64   `json:"vote_height"`
+	Days        float64 `json:"days"`
+	TicketPrice float64 `json:"ticket_price"`
+	Tickets     float64 `json:"tickets"`
+	Staked      float64 `json:"staked"`
+	Reward      float64 `json:"reward"`
+	VSPFee      float64 `json:"vsp_fee"`
+	Balance     float64 `json:"balance"`
+	Withdrawn   float64 `json:"withdrawn"`
+}
+
+// StakeSimResult is the projection of a staking simulation for tickets that
+// vote VoteBlocks blocks after maturity. Reward is net of the VSP fees. ROI is
+// the percent return on the starting balance, and AnnualRate the ROI scaled to
+// a year.
+type StakeSimResult struct {
+	Scenario   string            `json:"scenario"`
+	VoteBlocks int64             `json:"vote_blocks"`
+	Periods    []*StakeSimPeriod `json:"periods"`
+	Days       float64           `json:"days"`
+	EndBalance float64           `json:"end_balance"`
+	Reward     float64           `json:"reward"`
+	VSPFees    float64           `json:"vsp_fees"`
+	ROI        float64           `json:"roi"`
+	AnnualRate float64           `json:"annual_rate"`
+}
+
+// StakeSimulation is the staking projection with the mean vote time, and with
+// the tickets voting at the first (Best) and third (Worst) quartile of the vote
+// time of a full ticket pool.
+type StakeSimulation struct {
+	Expected *StakeSimResult `json:"expected"`
+	Best     *StakeSimResult `json:"best"`
+	Worst    *StakeSimResult `json:"worst"`
+}
+
+// SimulateStaking projects the returns of staking the balance for the
+// configured time. Each period buys as many tickets as the balance allows, and
+// ends when the vote matures. The vote rewards follow the subsidy split of the
+// vote height. The coin supply grows with the block subsidies, using the
+// subsidy split at the start of each subsidy reduction interval.
+func SimulateStaking(cfg *StakeSimConfig) *StakeSimulation {
+	poolSize := int64(cfg.Params.TicketPoolSize) * int64(cfg.Params.TicketsPerBlock)
+	quantile := func(q float64) int64 {
+		if blocks := VoteBlocksQuantile(q, poolSize, cfg.Params); blocks >= 0 {
+			return blocks
+		}
+		return int64(cfg.Params.TicketExpiry)
+	}
+	return &StakeSimulation{
+		Expected: simulateStaking(cfg, "expected", int64(math.Round(ExpectedVoteBlocks(poolSize, cfg.Params)))),
+		Best:     simulateStaking(cfg, "best", quantile(0.25)),
+		Worst:    simulateStaking(cfg, "worst", quantile(0.75)),
+	}
+}
+
+func simulateStaking(cfg *StakeSimConfig, scenario string, voteBlocks int64) *StakeSimResult {
+	params := cfg.Params
+	blocksPerDay := 24 * 3600 / params.TargetTimePerBlock.Seconds()
+	end := cfg.Height + int64(cfg.Days*blocksPerDay)
+	votes := params.VotesPerBlock()
+	supply := &supplyProjection{params: params, ssv: cfg.SSV, height: cfg.Height, supply: cfg.CoinSupply}
+
+	targetFraction := cfg.Participation
+	if targetFraction <= 0 {
+		targetFraction = cfg.StakedFraction
+	}
+	ticketPrice := func(height int64) float64 {
+		price := cfg.TicketPrice * supply.at(height) / cfg.CoinSupply
+		years := float64(height-cfg.Height) / (365 * blocksPerDay)
+		price *= math.Pow(1+cfg.PriceChange, years)
+		if cfg.StakedFraction > 0 && end > cfg.Height {
+			progress := math.Min(float64(height-cfg.Height)/float64(end-cfg.Height), 1)
+			fraction := cfg.StakedFraction + (targetFraction-cfg.StakedFraction)*progress
+			price *= fraction / cfg.StakedFraction
+		}
+		return price
+	}
+
+	res := &StakeSimResult{
+		Scenario:   scenario,
+		VoteBlocks: voteBlocks,
+		Periods:    make([]*StakeSimPeriod, 0),
+	}
+	balance, withdrawn := cfg.Balance, 0.0
+	height := cfg.Height
+	for height < end {
+		price := ticketPrice(height)
+		if price <= 0 {
+			break
+		}
+		tickets := balance / price
+		if cfg.IntegerTickets {
+			tickets = math.Floor(tickets)
+		}
+		if tickets <= 0 {
+			break
+		}
+
+		voteHeight := height + int64(params.TicketMaturity) + voteBlocks
+		_, stake, _ := RewardsAtBlock(voteHeight, votes, params, cfg.SSV(voteHeight))
+		gross := tickets * float64(stake) / 1e8
+		fee := gross * cfg.VSPFee / 100
+		reward := gross - fee
+		if cfg.Compounding == CompoundPrincipal {
+			withdrawn += reward
+		} else {
+			balance += reward
+		}
+		res.Reward += reward
+		res.VSPFees += fee
+
+		// The vote outputs can be spent after coinbase maturity.
+		next := voteHeight + int64(params.CoinbaseMaturity) + 1
+		res.Periods = append(res.Periods, &StakeSimPeriod{
+			Period:      len(res.Periods) + 1,
+			BuyHeight:   height,
+			VoteHeight:  voteHeight,
+			Days:        float64(next-cfg.Height) / blocksPerDay,
+			TicketPrice: price,
+			Tickets:     tickets,
+			Staked:      tickets * price,
+			Reward:      reward,
+			VSPFee:      fee,
+			Balance:     balance,
+			Withdrawn:   withdrawn,
+		})
+		height = next
+	}
+
+	res.Days = float64(height-cfg.Height) / blocksPerDay
+	res.EndBalance = balance + withdrawn
+	if cfg.Balance > 0 {
+		res.ROI = 100 * res.Reward / cfg.Balance
+	}
+	if res.Days > 0 {
+		res.AnnualRate = res.ROI * 365 / res.Days
+	}
+	return res
+}
+
+// supplyProjection projects the coin supply in DCR at increasing heights from
+// the block subsidies, assuming full votes.
+type supplyProjection struct {
+	params *chaincfg.Params
+	ssv    func(height int64) standalone.SubsidySplitVariant
+	height int64
+	supply float64
+}
+
+func (s *supplyProjection) at(height int64) float64 {
+	votes := s.params.VotesPerBlock()
+	interval := s.params.SubsidyReductionInterval
+	for s.height < height {
+		next := (s.height/interval + 1) * interval
+		if next > height {
+			next = height
+		}
+		work, stake, tax := RewardsAtBlock(s.height, votes, s.params, s.ssv(s.height))
+		s.supply += float64(next-s.height) * float64(work+stake*int64(votes)+tax) / 1e8
+		s.height = next
+	}
+	return s.supply
+}
@@ -0,0 +1,79 @@
+package txhelpers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/decred/dcrd/blockchain/standalone/v2"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestSimulateStaking(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	newConfig := func() *StakeSimConfig {
+		return &StakeSimConfig{
+			Params:         params,
+			Height:         900000,
+			CoinSupply:     16e6,
+			TicketPrice:    200,
+			StakedFraction: 0.6,
+			SSV: func(int64) standalone.SubsidySplitVariant {
+				return standalone.SSVDCP0012
+			},
+			Balance:     1000,
+			Days:        365,
+			Compounding: CompoundAll,
+		}
+	}
+
+	sim := SimulateStaking(newConfig())
+	exp := sim.Expected
+	if len(exp.Periods) == 0 || exp.Reward <= 0 {
+		t.Fatalf("no rewards: %+v", exp)
+	}
+	if !(sim.Best.AnnualRate > exp.AnnualRate && exp.AnnualRate > sim.Worst.AnnualRate) {
+		t.Errorf("rates not ordered: best %v, expected %v, worst %v",
+			sim.Best.AnnualRate, exp.AnnualRate, sim.Worst.AnnualRate)
+	}
+	if exp.Days < 365 {
+		t.Errorf("simulation ended after %v days", exp.Days)
+	}
+
+	// The first period stakes the whole balance and earns the vote reward of
+	// the vote height.
+	first := exp.Periods[0]
+	_, stake, _ := RewardsAtBlock(first.VoteHeight, params.VotesPerBlock(), params, standalone.SSVDCP0012)
+	if first.TicketPrice != 200 || first.Tickets != 5 || math.Abs(first.Reward-5*float64(stake)/1e8) > 1e-9 {
+		t.Errorf("wrong first period %+v", first)
+	}
+	if first.VoteHeight-first.BuyHeight != int64(params.TicketMaturity)+exp.VoteBlocks {
+		t.Errorf("wrong vote height %d", first.VoteHeight)
+	}
+	// Ticket prices grow with the coin supply.
+	if exp.Periods[1].TicketPrice <= first.TicketPrice {
+		t.Errorf("ticket price did not grow: %v", exp.Periods[1].TicketPrice)
+	}
+
+	cfg := newConfig()
+	cfg.VSPFee = 10
+	cfg.Compounding = CompoundPrincipal
+	cfg.IntegerTickets = true
+	cfg.Balance = 1100
+	withFee := SimulateStaking(cfg).Expected
+	first = withFee.Periods[0]
+	if first.Tickets != 5 || first.Balance != 1100 || math.Abs(first.VSPFee-first.Reward/9) > 1e-9 {
+		t.Errorf("wrong first period with fee %+v", first)
+	}
+	last := withFee.Periods[len(withFee.Periods)-1]
+	if last.Balance != 1100 || math.Abs(last.Withdrawn-withFee.Reward) > 1e-9 ||
+		math.Abs(withFee.EndBalance-1100-withFee.Reward) > 1e-9 {
+		t.Errorf("rewards not withdrawn: %+v", last)
+	}
+
+	// More participation means pricier tickets and lower returns.
+	cfg = newConfig()
+	cfg.Participation = 0.7
+	if rate := SimulateStaking(cfg).Expected.AnnualRate; rate >= exp.AnnualRate {
+		t.Errorf("rate %v with more participation not below %v", rate, exp.AnnualRate)
+	}
+}
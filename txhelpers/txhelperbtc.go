@@ -363,3 +363,19 @@ func BTCTxFeeRate(msgTx *btcwire.MsgTx, client BTCVerboseTransactionGetter) (btc
 	txSize := int64(msgTx.SerializeSize())
 	return btcutil.Amount(amtIn - amtOut), btcutil.Amount(FeeRate(amtIn, amtOut, txSize))
 }
+
+// BTCBlockLedgerTxs returns the transactions of a block for a MempoolLedger.
+func BTCBlockLedgerTxs(msgBlock *btcwire.MsgBlock) []*LedgerTx {
+	txs := make([]*LedgerTx, 0, len(msgBlock.Transactions))
+	for _, msgTx := range msgBlock.Transactions {
+		outpoints := make([]string, 0, len(msgTx.TxIn))
+		for _, txIn := range msgTx.TxIn {
+			if IsBTCZeroHash(txIn.PreviousOutPoint.Hash) {
+				continue
+			}
+			outpoints = append(outpoints, Outpoint(txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index))
+		}
+		txs = append(txs, &LedgerTx{TxID: msgTx.TxHash().String(), Outpoints: outpoints})
+	}
+	return txs
+}
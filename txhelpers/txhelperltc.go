@@ -364,3 +364,19 @@ func CalculateLTCTxFee(client *rpcclient.Client, msgTx *wire.MsgTx) (ltcutil.Amo
 	fee := totalInput - totalOutput
 	return fee, nil
 }
+
+// LTCBlockLedgerTxs returns the transactions of a block for a MempoolLedger.
+func LTCBlockLedgerTxs(msgBlock *ltcwire.MsgBlock) []*LedgerTx {
+	txs := make([]*LedgerTx, 0, len(msgBlock.Transactions))
+	for _, msgTx := range msgBlock.Transactions {
+		outpoints := make([]string, 0, len(msgTx.TxIn))
+		for _, txIn := range msgTx.TxIn {
+			if IsLTCZeroHash(txIn.PreviousOutPoint.Hash) {
+				continue
+			}
+			outpoints = append(outpoints, Outpoint(txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index))
+		}
+		txs = append(txs, &LedgerTx{TxID: msgTx.TxHash().String(), Outpoints: outpoints})
+	}
+	return txs
+}
@@ -1437,3 +1437,35 @@ func ParsingTime(str string) (time.Time, error) {
 	layout := "2006-01-02T15:04:05.000Z"
 	return time.Parse(layout, str)
 }
+
+// BlockLedgerTxs returns the regular and stake transactions of a block for a
+// MempoolLedger, except the votes, which the ledger does not track.
+func BlockLedgerTxs(msgBlock *wire.MsgBlock) []*LedgerTx {
+	txs := make([]*LedgerTx, 0, len(msgBlock.Transactions)+len(msgBlock.STransactions))
+	for _, tree := range [][]*wire.MsgTx{msgBlock.Transactions, msgBlock.STransactions} {
+		for _, msgTx := range tree {
+			if DetermineTxType(msgTx) == stake.TxTypeSSGen {
+				continue
+			}
+			txs = append(txs, &LedgerTx{
+				TxID:      msgTx.TxHash().String(),
+				Outpoints: MsgTxLedgerOutpoints(msgTx),
+			})
+		}
+	}
+	return txs
+}
+
+// MsgTxLedgerOutpoints returns the previous outpoints spent by a transaction,
+// formatted by Outpoint, leaving out the null outpoints of coinbases,
+// stakebases and treasurybases.
+func MsgTxLedgerOutpoints(msgTx *wire.MsgTx) []string {
+	outpoints := make([]string, 0, len(msgTx.TxIn))
+	for _, txIn := range msgTx.TxIn {
+		if IsZeroHash(txIn.PreviousOutPoint.Hash) {
+			continue
+		}
+		outpoints = append(outpoints, Outpoint(txIn.PreviousOutPoint.Hash.String(), txIn.PreviousOutPoint.Index))
+	}
+	return outpoints
+}
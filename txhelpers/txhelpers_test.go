@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/decred/dcrd/blockchain/stake/v5"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
@@ -501,6 +502,29 @@ func TestMsgTxFromHex(t *testing.T) {
 	}
 }
 
+func TestBlockLedgerTxs(t *testing.T) {
+	block, _ := LoadTestBlockAndSSTX(t)
+	msgBlock := block.MsgBlock()
+	var votes int
+	for _, msgTx := range msgBlock.STransactions {
+		if DetermineTxType(msgTx) == stake.TxTypeSSGen {
+			votes++
+		}
+	}
+	if votes == 0 {
+		t.Fatal("the test block has no votes")
+	}
+	txs := BlockLedgerTxs(msgBlock)
+	if len(txs) != len(msgBlock.Transactions)+len(msgBlock.STransactions)-votes {
+		t.Errorf("expected %d ledger txs without the %d votes, got %d",
+			len(msgBlock.Transactions)+len(msgBlock.STransactions)-votes, votes, len(txs))
+	}
+	// The coinbase spends no previous outpoint.
+	if txs[0].TxID != msgBlock.Transactions[0].TxHash().String() || len(txs[0].Outpoints) != 0 {
+		t.Errorf("wrong coinbase ledger tx %+v", txs[0])
+	}
+}
+
 func BenchmarkMsgTxFromHex(b *testing.B) {
 	txHex :=
 		"0100000002000000000000000000000000000000000000000000000000000000" +
@@ -0,0 +1,149 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+// Package delivery posts JSON event bodies to webhook URLs, retrying failed
+// deliveries with exponential backoff, for the chain event webhooks.
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is wrapped by the errors of the connections of a
+// PublicClient to an address that is not public.
+var ErrPrivateAddress = errors.New("not a public address")
+
+// Doer is satisfied by *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Attempt is the outcome of a delivery attempt.
+type Attempt struct {
+	Number     int
+	Time       time.Time
+	StatusCode int
+	Err        error
+	// Retry is the delay before the next attempt, or zero if this is the
+	// last attempt.
+	Retry time.Duration
+}
+
+// Poster delivers webhook requests.
+type Poster struct {
+	Client Doer
+	// Retries is the number of retries after a failed attempt.
+	Retries int
+	// Backoff is the delay before the first retry. The delay doubles on each
+	// subsequent retry, up to MaxBackoff if it is set.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Deliver posts the body to the URL with the given headers, retrying with
+// exponential backoff until an attempt succeeds, the retries are exhausted or
+// the Context is cancelled. logAttempt, if not nil, is called after every
+// attempt. The last attempt is returned.
+func (p *Poster) Deliver(ctx context.Context, url string, header http.Header, body []byte,
+	logAttempt func(*Attempt)) *Attempt {
+	delay := p.Backoff
+	for n := 1; ; n++ {
+		attempt := &Attempt{
+			Number: n,
+			Time:   time.Now(),
+		}
+		attempt.StatusCode, attempt.Err = Post(ctx, p.Client, url, header, body)
+		last := attempt.Err == nil || n > p.Retries
+		if !last {
+			attempt.Retry = delay
+		}
+		if logAttempt != nil {
+			logAttempt(attempt)
+		}
+		if last {
+			return attempt
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return attempt
+		}
+		delay *= 2
+		if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+			delay = p.MaxBackoff
+		}
+	}
+}
+
+// Post sends a single JSON request with the given headers. Any non-2xx
+// response is an error.
+func Post(ctx context.Context, client Doer, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// carrierGradeNAT is the shared address space of RFC 6598.
+var carrierGradeNAT = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublic checks that an IP address is a public unicast address, i.e. not a
+// loopback, private, link-local, shared, multicast or unspecified address.
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && ip.IsGlobalUnicast() && !ip.IsPrivate() &&
+		!carrierGradeNAT.Contains(ip)
+}
+
+// PublicClient makes an *http.Client with the given timeout that only
+// connects to public IP addresses. The address is checked after the DNS
+// resolution of the URL host, and for every redirect, so that a webhook URL
+// cannot reach the host or its private networks.
+func PublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: publicOnly,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would connect to the destination itself.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// publicOnly is a net.Dialer Control function that rejects connections to
+// addresses that are not public.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !IsPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestDeliver(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("X-Test") != "yes" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		if hits < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	p := &Poster{
+		Client:  srv.Client(),
+		Retries: 3,
+		Backoff: time.Millisecond,
+	}
+	header := http.Header{"X-Test": []string{"yes"}}
+	var attempts []*Attempt
+	last := p.Deliver(context.Background(), srv.URL, header, []byte(`{}`), func(a *Attempt) {
+		attempts = append(attempts, a)
+	})
+	if len(attempts) != 3 || last != attempts[2] {
+		t.Fatalf("expected 3 attempts, got %d", len(attempts))
+	}
+	if first := attempts[0]; first.Err == nil || first.StatusCode != http.StatusBadGateway || first.Retry != time.Millisecond {
+		t.Errorf("unexpected first attempt %+v", first)
+	}
+	if attempts[1].Retry != 2*time.Millisecond {
+		t.Errorf("unexpected backoff %v", attempts[1].Retry)
+	}
+	if last.Err != nil || last.Number != 3 || last.Retry != 0 {
+		t.Errorf("unexpected last attempt %+v", last)
+	}
+
+	// Abandoned after the retries.
+	hits = -100
+	p.Retries = 1
+	last = p.Deliver(context.Background(), srv.URL, header, []byte(`{}`), nil)
+	if last.Err == nil || last.Number != 2 || last.Retry != 0 {
+		t.Errorf("unexpected last attempt %+v", last)
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := IsPublic(netip.MustParseAddr(tt.ip)); got != tt.public {
+			t.Errorf("IsPublic(%s) = %v, want %v", tt.ip, got, tt.public)
+		}
+	}
+}
+
+func TestPublicClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("a loopback server was reached")
+	}))
+	defer srv.Close()
+
+	_, err := Post(context.Background(), PublicClient(time.Second), srv.URL, nil, []byte(`{}`))
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("expected ErrPrivateAddress, got %v", err)
+	}
+}
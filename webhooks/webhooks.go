@@ -0,0 +1,1012 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+// Package webhooks delivers the block, reorg, address activity and transaction
+// confirmation depth events of the enabled chains to registered HTTP
+// callbacks. The Dispatcher is fed by the same block data savers and mempool
+// signals as the PubSubHub.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	btcchaincfg "github.com/btcsuite/btcd/chaincfg"
+	btctxscript "github.com/btcsuite/btcd/txscript"
+	btcwire "github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+	ltcchaincfg "github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	ltctxscript "github.com/ltcsuite/ltcd/txscript"
+	ltcwire "github.com/ltcsuite/ltcd/wire"
+
+	"github.com/decred/dcrdata/v8/blockdata"
+	"github.com/decred/dcrdata/v8/blockdata/blockdatabtc"
+	"github.com/decred/dcrdata/v8/blockdata/blockdataltc"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+	"github.com/decred/dcrdata/v8/webhooks/delivery"
+	"github.com/decred/dcrdata/v8/xmr/xmrutil"
+)
+
+const (
+	// DefaultRetries is the number of times a failed delivery is retried
+	// before it is abandoned.
+	DefaultRetries = 5
+	// DefaultBackoff is the delay before the first retry of a failed delivery.
+	// The delay doubles on each subsequent retry.
+	DefaultBackoff = 5 * time.Second
+	// DefaultMaxHooks is the default limit of active webhooks.
+	DefaultMaxHooks = 1000
+	// DefaultMaxOwnerHooks is the default limit of active webhooks managed
+	// with the same token.
+	DefaultMaxOwnerHooks = 100
+	// MaxConfirmationDepth is the deepest confirmation depth that a
+	// confirmations hook may wait for.
+	MaxConfirmationDepth = 10000
+	// DefaultDeliveryRetention is the default age after which the delivery
+	// log entries are deleted.
+	DefaultDeliveryRetention = 30 * 24 * time.Hour
+
+	// SignatureHeader is the header carrying the signature of a payload, the
+	// hex encoded HMAC-SHA256 of the request body keyed by the webhook secret,
+	// prefixed by "sha256=".
+	SignatureHeader = "X-Dcrdata-Signature"
+	// EventHeader is the header carrying the event of a payload.
+	EventHeader = "X-Dcrdata-Event"
+	// DeliveryHeader is the header carrying the ID of a delivery. The retries
+	// of a delivery have the same ID.
+	DeliveryHeader = "X-Dcrdata-Delivery"
+
+	maxBackoff = 10 * time.Minute
+
+	// The interval of the deletions of the expired delivery log entries.
+	deliveryPurgeInterval = 24 * time.Hour
+)
+
+// ErrInvalidHook is wrapped by the errors of AddHook for a hook that is
+// invalid or over the limit, as opposed to a Store failure.
+var ErrInvalidHook = errors.New("invalid webhook")
+
+// Store persists the webhooks and their delivery logs. It is satisfied by
+// *dcrpg.ChainDB.
+type Store interface {
+	Webhooks() ([]*dbtypes.Webhook, error)
+	InsertWebhook(*dbtypes.Webhook) (int64, error)
+	DeactivateWebhook(id int64) error
+	DeleteWebhook(id int64) error
+	InsertWebhookDelivery(*dbtypes.WebhookDelivery) error
+	WebhookDeliveries(hookID int64, limit int) ([]*dbtypes.WebhookDelivery, error)
+	// DeleteWebhookDeliveriesBefore deletes the delivery log entries older
+	// than the given time, and returns the number deleted.
+	DeleteWebhookDeliveriesBefore(t int64) (int64, error)
+	// TxBlockHeight returns the height of the block that mined a transaction,
+	// or an error if it is not mined.
+	TxBlockHeight(chainType, txid string) (int64, error)
+	// VoutsByTxHashes retrieves the outputs of the transactions of a chain,
+	// for the addresses of the outputs spent by a block.
+	VoutsByTxHashes(ctx context.Context, chainType string, txids []string) ([]*dbtypes.Vout, error)
+}
+
+// Config is the configuration for a Dispatcher.
+type Config struct {
+	Store Store
+	// Chains are the enabled chains. Webhooks may only be registered for them.
+	Chains []string
+	// The network parameters used to decode the addresses of the hooks and
+	// the block outputs. Required for the respective enabled chains.
+	DCRParams *chaincfg.Params
+	BTCParams *btcchaincfg.Params
+	LTCParams *ltcchaincfg.Params
+	// Client is used for webhook requests. Defaults to a client with a 10
+	// second timeout that only connects to public addresses, unless
+	// AllowPrivateURLs is set.
+	Client delivery.Doer
+	// AllowPrivateURLs allows webhook URLs on loopback, private and
+	// link-local addresses, e.g. for testing.
+	AllowPrivateURLs bool
+	// Retries is the number of retries after a failed delivery. Defaults to
+	// DefaultRetries. A negative value disables retries.
+	Retries int
+	// Backoff is the initial retry delay. Defaults to DefaultBackoff.
+	Backoff time.Duration
+	// MaxHooks is the limit of active webhooks. Defaults to DefaultMaxHooks.
+	MaxHooks int
+	// MaxOwnerHooks is the limit of active webhooks managed with the same
+	// token. Defaults to DefaultMaxOwnerHooks.
+	MaxOwnerHooks int
+	// DeliveryRetention is the age after which the delivery log entries are
+	// deleted. Defaults to DefaultDeliveryRetention.
+	DeliveryRetention time.Duration
+}
+
+// Payload is the JSON body posted to a webhook. The type of Data depends on
+// the Event.
+type Payload struct {
+	HookID    int64       `json:"hook_id"`
+	Event     string      `json:"event"`
+	ChainType string      `json:"chain"`
+	Time      int64       `json:"time"`
+	Data      interface{} `json:"data"`
+}
+
+// BlockEvent is the Data of a block event.
+type BlockEvent struct {
+	Height   int64  `json:"height"`
+	Hash     string `json:"hash"`
+	PrevHash string `json:"prev_hash"`
+	Time     int64  `json:"time"`
+	NumTx    int    `json:"num_tx"`
+}
+
+// ReorgEvent is the Data of a reorg event, sent when a new block does not
+// extend the previous chain tip.
+type ReorgEvent struct {
+	OldHeight int64  `json:"old_height"`
+	OldHash   string `json:"old_hash"`
+	NewHeight int64  `json:"new_height"`
+	NewHash   string `json:"new_hash"`
+}
+
+// AddressEvent is the Data of an address event. A mempool transaction that
+// involves the address is sent with a zero Height. A block transaction is sent
+// when one of its outputs pays to the address, or one of its inputs spends
+// from it.
+type AddressEvent struct {
+	Address   string `json:"address"`
+	TxID      string `json:"txid"`
+	Height    int64  `json:"height"`
+	BlockHash string `json:"block_hash,omitempty"`
+}
+
+// ConfirmationsEvent is the Data of a confirmations event, sent once when the
+// transaction reaches the confirmation depth of the hook.
+type ConfirmationsEvent struct {
+	TxID          string `json:"txid"`
+	BlockHeight   int64  `json:"block_height"`
+	Confirmations int64  `json:"confirmations"`
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body keyed by the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the SignatureHeader value of a payload.
+func Verify(secret string, body []byte, signature string) bool {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(Sign(secret, body))
+	return hmac.Equal(sig, expected)
+}
+
+// outpoint is a transaction output of a chain.
+type outpoint struct {
+	txid  string
+	index uint32
+}
+
+// spend is an output spent by a transaction.
+type spend struct {
+	prevOut outpoint
+	txid    string
+}
+
+// chainBlock is a new block of a chain, with the transactions that pay to or
+// spend from the addresses.
+type chainBlock struct {
+	chainType string
+	BlockEvent
+	txids   map[string]struct{}
+	addrTxs map[string][]string
+	// The addresses of the block outputs, and the outputs spent by the block
+	// transactions, when the addresses are watched.
+	outAddrs map[outpoint][]string
+	spends   []spend
+}
+
+func newChainBlock(chainType string, height int64, hash, prevHash string, t time.Time) *chainBlock {
+	return &chainBlock{
+		chainType: chainType,
+		BlockEvent: BlockEvent{
+			Height:   height,
+			Hash:     hash,
+			PrevHash: prevHash,
+			Time:     t.Unix(),
+		},
+		txids:    make(map[string]struct{}),
+		addrTxs:  make(map[string][]string),
+		outAddrs: make(map[outpoint][]string),
+	}
+}
+
+func (b *chainBlock) addTx(txid string) {
+	b.txids[txid] = struct{}{}
+	b.NumTx++
+}
+
+func (b *chainBlock) addAddress(addr, txid string) {
+	txids := b.addrTxs[addr]
+	if slices.Contains(txids, txid) {
+		return
+	}
+	b.addrTxs[addr] = append(txids, txid)
+}
+
+// addOutput adds the addresses of an output of a block transaction.
+func (b *chainBlock) addOutput(txid string, index uint32, addrs []string) {
+	b.outAddrs[outpoint{txid: txid, index: index}] = addrs
+	for _, addr := range addrs {
+		b.addAddress(addr, txid)
+	}
+}
+
+// addSpend adds an output spent by a block transaction. The addresses of the
+// spent outputs are added by (*Dispatcher).addSpentAddresses. The null
+// previous outpoints of coinbase and stakebase inputs are skipped.
+func (b *chainBlock) addSpend(txid, prevTxid string, prevIndex uint32) {
+	if strings.Trim(prevTxid, "0") == "" {
+		return
+	}
+	b.spends = append(b.spends, spend{
+		prevOut: outpoint{txid: prevTxid, index: prevIndex},
+		txid:    txid,
+	})
+}
+
+// The last block seen on a chain.
+type chainTip struct {
+	height int64
+	hash   string
+}
+
+// The mined height of the transaction of a confirmations hook. The height is
+// looked up in the Store when the hook is loaded or added, and after a reorg.
+type confState struct {
+	height int64
+	lookup bool
+}
+
+// An event to be delivered to a hook.
+type hookDelivery struct {
+	hook    dbtypes.Webhook
+	payload *Payload
+}
+
+// Dispatcher delivers chain events to the registered webhooks. Make a
+// Dispatcher with NewDispatcher.
+type Dispatcher struct {
+	ctx          context.Context
+	store        Store
+	poster       *delivery.Poster
+	allowPrivate bool
+	retention    time.Duration
+	maxHooks     int
+	maxOwner     int
+	chains       map[string]bool
+	dcrParams    *chaincfg.Params
+	btcParams    *btcchaincfg.Params
+	ltcParams    *ltcchaincfg.Params
+
+	mtx   sync.RWMutex
+	hooks []*dbtypes.Webhook
+	confs map[int64]*confState
+	tips  map[string]chainTip
+
+	relay chan pstypes.HubMessage
+	wg    sync.WaitGroup
+}
+
+// NewDispatcher loads the registered webhooks from the Store, and constructs
+// a new Dispatcher. Deliveries are abandoned when the Context is cancelled.
+func NewDispatcher(ctx context.Context, cfg *Config) (*Dispatcher, error) {
+	if cfg.Store == nil {
+		return nil, fmt.Errorf("no webhook Store provided")
+	}
+	d := &Dispatcher{
+		ctx:   ctx,
+		store: cfg.Store,
+		poster: &delivery.Poster{
+			Client:     cfg.Client,
+			Retries:    cfg.Retries,
+			Backoff:    cfg.Backoff,
+			MaxBackoff: maxBackoff,
+		},
+		allowPrivate: cfg.AllowPrivateURLs,
+		retention:    cfg.DeliveryRetention,
+		maxHooks:     cfg.MaxHooks,
+		maxOwner:     cfg.MaxOwnerHooks,
+		chains:       make(map[string]bool, len(cfg.Chains)),
+		dcrParams:    cfg.DCRParams,
+		btcParams:    cfg.BTCParams,
+		ltcParams:    cfg.LTCParams,
+		confs:        make(map[int64]*confState),
+		tips:         make(map[string]chainTip),
+		relay:        make(chan pstypes.HubMessage, 64),
+	}
+	if d.poster.Client == nil {
+		if d.allowPrivate {
+			d.poster.Client = &http.Client{Timeout: 10 * time.Second}
+		} else {
+			d.poster.Client = delivery.PublicClient(10 * time.Second)
+		}
+	}
+	if d.poster.Retries == 0 {
+		d.poster.Retries = DefaultRetries
+	} else if d.poster.Retries < 0 {
+		d.poster.Retries = 0
+	}
+	if d.poster.Backoff <= 0 {
+		d.poster.Backoff = DefaultBackoff
+	}
+	if d.retention <= 0 {
+		d.retention = DefaultDeliveryRetention
+	}
+	if d.maxHooks <= 0 {
+		d.maxHooks = DefaultMaxHooks
+	}
+	if d.maxOwner <= 0 {
+		d.maxOwner = DefaultMaxOwnerHooks
+	}
+	for _, chainType := range cfg.Chains {
+		d.chains[chainType] = true
+	}
+
+	hooks, err := d.store.Webhooks()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load webhooks: %w", err)
+	}
+	d.hooks = hooks
+	for _, h := range hooks {
+		if h.Active && h.Event == dbtypes.WebhookConfirmations {
+			d.confs[h.ID] = &confState{lookup: true}
+		}
+	}
+	return d, nil
+}
+
+// HubRelay is the channel on which the Dispatcher receives the signals of the
+// mempool monitors. The SigAddressTx signals are delivered as address events.
+func (d *Dispatcher) HubRelay() chan pstypes.HubMessage {
+	return d.relay
+}
+
+// Run is the Dispatcher loop. It handles the relayed mempool signals, and
+// deletes the expired delivery log entries daily, until the Context is
+// cancelled, and then waits for the pending deliveries to be abandoned.
+func (d *Dispatcher) Run(wg *sync.WaitGroup) {
+	if wg != nil {
+		defer wg.Done()
+	}
+	d.purgeDeliveries()
+	purge := time.NewTicker(deliveryPurgeInterval)
+	defer purge.Stop()
+	for {
+		select {
+		case <-purge.C:
+			d.purgeDeliveries()
+		case msg := <-d.relay:
+			if msg.Signal != pstypes.SigAddressTx {
+				continue
+			}
+			am, ok := msg.Msg.(*pstypes.AddressMessage)
+			if !ok {
+				log.Errorf("msg.Msg not an AddressMessage (SigAddressTx): %T", msg.Msg)
+				continue
+			}
+			chainType := am.ChainType
+			if chainType == "" {
+				chainType = mutilchain.TYPEDCR
+			}
+			d.mempoolAddressTx(chainType, am.Address, am.TxHash)
+		case <-d.ctx.Done():
+			d.wg.Wait()
+			return
+		}
+	}
+}
+
+// purgeDeliveries deletes the delivery log entries older than the retention.
+func (d *Dispatcher) purgeDeliveries() {
+	n, err := d.store.DeleteWebhookDeliveriesBefore(time.Now().Add(-d.retention).Unix())
+	if err != nil {
+		log.Errorf("Failed to delete the expired webhook deliveries: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Debugf("Deleted %d expired webhook deliveries.", n)
+	}
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// validate checks and normalizes the fields of a new hook.
+func (d *Dispatcher) validate(h *dbtypes.Webhook) error {
+	h.ChainType = strings.ToLower(h.ChainType)
+	if !d.chains[h.ChainType] {
+		return fmt.Errorf("unknown or disabled chain %q", h.ChainType)
+	}
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q", h.URL)
+	}
+	if !d.allowPrivate && !publicHost(u.Hostname()) {
+		return fmt.Errorf("webhook URL %q is not on a public host", h.URL)
+	}
+	switch h.Event {
+	case dbtypes.WebhookBlock, dbtypes.WebhookReorg:
+		h.Address, h.TxID, h.Depth = "", "", 0
+	case dbtypes.WebhookAddress:
+		h.TxID, h.Depth = "", 0
+		if err = d.validateAddress(h.ChainType, h.Address); err != nil {
+			return err
+		}
+	case dbtypes.WebhookConfirmations:
+		h.Address = ""
+		if _, err = hex.DecodeString(h.TxID); err != nil || len(h.TxID) != 64 {
+			return fmt.Errorf("invalid txid %q", h.TxID)
+		}
+		h.TxID = strings.ToLower(h.TxID)
+		if h.Depth < 1 || h.Depth > MaxConfirmationDepth {
+			return fmt.Errorf("confirmation depth must be between 1 and %d", MaxConfirmationDepth)
+		}
+	default:
+		return fmt.Errorf("unknown event %q", h.Event)
+	}
+	return nil
+}
+
+// publicHost checks that a URL host is not localhost, or an IP address that is
+// not public. The addresses that a host name resolves to are checked by the
+// delivery.PublicClient when it connects.
+func publicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return delivery.IsPublic(ip)
+	}
+	return true
+}
+
+// validateAddress checks that the address is valid for the chain.
+func (d *Dispatcher) validateAddress(chainType, addr string) error {
+	if addr == "" {
+		return fmt.Errorf("no address")
+	}
+	var err error
+	switch chainType {
+	case mutilchain.TYPEDCR:
+		_, err = stdaddr.DecodeAddress(addr, d.dcrParams)
+	case mutilchain.TYPEBTC:
+		_, err = btcutil.DecodeAddress(addr, d.btcParams)
+	case mutilchain.TYPELTC:
+		_, err = ltcutil.DecodeAddress(addr, d.ltcParams)
+	default:
+		return fmt.Errorf("address events are not supported for %s", chainType)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s address %q: %w", chainType, addr, err)
+	}
+	return nil
+}
+
+// tokenOwner is the owner of the hooks managed with a token, the hex encoded
+// SHA-256 of the token. An empty token owns no hooks.
+func tokenOwner(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidToken checks the format of a management token, 32 hex encoded bytes as
+// generated by AddHook.
+func ValidToken(token string) bool {
+	b, err := hex.DecodeString(token)
+	return err == nil && len(b) == 32
+}
+
+// AddHook validates and stores a new webhook, managed with the token. A new
+// token is generated if none is given, and a secret if none is given. The
+// stored hook is returned with its token and secret, which are omitted from
+// the hooks returned by Hooks and Hook.
+func (d *Dispatcher) AddHook(h *dbtypes.Webhook, token string) (*dbtypes.Webhook, error) {
+	hook := *h
+	if err := d.validate(&hook); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHook, err)
+	}
+	if token == "" {
+		token = randomHex(32)
+	} else if !ValidToken(token) {
+		return nil, fmt.Errorf("%w: invalid management token", ErrInvalidHook)
+	}
+	if hook.Secret == "" {
+		hook.Secret = randomHex(32)
+	}
+	hook.Owner = tokenOwner(token)
+	hook.Token = ""
+	hook.Active = true
+	hook.Created = time.Now().Unix()
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	var active, owned int
+	for _, h := range d.hooks {
+		if h.Active {
+			active++
+			if h.Owner == hook.Owner {
+				owned++
+			}
+		}
+	}
+	if active >= d.maxHooks {
+		return nil, fmt.Errorf("%w: the limit of %d webhooks is reached", ErrInvalidHook, d.maxHooks)
+	}
+	if owned >= d.maxOwner {
+		return nil, fmt.Errorf("%w: the limit of %d webhooks of a token is reached", ErrInvalidHook, d.maxOwner)
+	}
+	id, err := d.store.InsertWebhook(&hook)
+	if err != nil {
+		return nil, err
+	}
+	hook.ID = id
+	d.hooks = append(d.hooks, &hook)
+	if hook.Event == dbtypes.WebhookConfirmations {
+		d.confs[id] = &confState{lookup: true}
+	}
+	log.Infof("Added %s %s webhook %d.", hook.ChainType, hook.Event, id)
+	added := hook
+	added.Token = token
+	return &added, nil
+}
+
+// RemoveHook deletes a webhook managed with the token, and its delivery log.
+// It returns false if there is no such hook.
+func (d *Dispatcher) RemoveHook(token string, id int64) (bool, error) {
+	owner := tokenOwner(token)
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for i, h := range d.hooks {
+		if h.ID == id && owner != "" && h.Owner == owner {
+			if err := d.store.DeleteWebhook(id); err != nil {
+				return false, err
+			}
+			d.hooks = append(d.hooks[:i], d.hooks[i+1:]...)
+			delete(d.confs, id)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Hooks is a copy of the webhooks managed with the token of a chain, or of all
+// chains if chainType is empty, without their secrets.
+func (d *Dispatcher) Hooks(token, chainType string) []dbtypes.Webhook {
+	owner := tokenOwner(token)
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	hooks := make([]dbtypes.Webhook, 0)
+	if owner == "" {
+		return hooks
+	}
+	for _, h := range d.hooks {
+		if h.Owner == owner && (chainType == "" || h.ChainType == chainType) {
+			hook := *h
+			hook.Secret = ""
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// Hook is a copy of a webhook managed with the token without its secret, if it
+// exists.
+func (d *Dispatcher) Hook(token string, id int64) (*dbtypes.Webhook, bool) {
+	owner := tokenOwner(token)
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	for _, h := range d.hooks {
+		if h.ID == id && owner != "" && h.Owner == owner {
+			hook := *h
+			hook.Secret = ""
+			return &hook, true
+		}
+	}
+	return nil, false
+}
+
+// Deliveries retrieves up to limit delivery attempts of a webhook, most recent
+// first.
+func (d *Dispatcher) Deliveries(id int64, limit int) ([]*dbtypes.WebhookDelivery, error) {
+	return d.store.WebhookDeliveries(id, limit)
+}
+
+// watchesAddresses checks for active address hooks on a chain, so that the
+// addresses of a block's outputs are only decoded when needed.
+func (d *Dispatcher) watchesAddresses(chainType string) bool {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	for _, h := range d.hooks {
+		if h.Active && h.ChainType == chainType && h.Event == dbtypes.WebhookAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// Store delivers the events of a new Decred block. It satisfies
+// blockdata.BlockDataSaver.
+func (d *Dispatcher) Store(_ *blockdata.BlockData, msgBlock *wire.MsgBlock) error {
+	hdr := &msgBlock.Header
+	b := newChainBlock(mutilchain.TYPEDCR, int64(hdr.Height), msgBlock.BlockHash().String(),
+		hdr.PrevBlock.String(), hdr.Timestamp)
+	addrs := d.watchesAddresses(b.chainType)
+	for _, txs := range [][]*wire.MsgTx{msgBlock.Transactions, msgBlock.STransactions} {
+		for _, tx := range txs {
+			txid := tx.TxHash().String()
+			b.addTx(txid)
+			if !addrs {
+				continue
+			}
+			for _, in := range tx.TxIn {
+				prev := &in.PreviousOutPoint
+				b.addSpend(txid, prev.Hash.String(), prev.Index)
+			}
+			for i, out := range tx.TxOut {
+				_, outAddrs := stdscript.ExtractAddrs(out.Version, out.PkScript, d.dcrParams)
+				addrStrs := make([]string, 0, len(outAddrs))
+				for _, addr := range outAddrs {
+					addrStrs = append(addrStrs, addr.String())
+				}
+				b.addOutput(txid, uint32(i), addrStrs)
+			}
+		}
+	}
+	if addrs {
+		d.addSpentAddresses(b)
+	}
+	d.connectBlock(b)
+	return nil
+}
+
+// BTCStore delivers the events of a new Bitcoin block. It satisfies
+// blockdatabtc.BlockDataSaver.
+func (d *Dispatcher) BTCStore(blockData *blockdatabtc.BlockData, msgBlock *btcwire.MsgBlock) error {
+	hdr := &msgBlock.Header
+	b := newChainBlock(mutilchain.TYPEBTC, int64(blockData.Header.Height), msgBlock.BlockHash().String(),
+		hdr.PrevBlock.String(), hdr.Timestamp)
+	addrs := d.watchesAddresses(b.chainType)
+	for _, tx := range msgBlock.Transactions {
+		txid := tx.TxHash().String()
+		b.addTx(txid)
+		if !addrs {
+			continue
+		}
+		for _, in := range tx.TxIn {
+			prev := &in.PreviousOutPoint
+			b.addSpend(txid, prev.Hash.String(), prev.Index)
+		}
+		for i, out := range tx.TxOut {
+			_, outAddrs, _, _ := btctxscript.ExtractPkScriptAddrs(out.PkScript, d.btcParams)
+			addrStrs := make([]string, 0, len(outAddrs))
+			for _, addr := range outAddrs {
+				addrStrs = append(addrStrs, addr.String())
+			}
+			b.addOutput(txid, uint32(i), addrStrs)
+		}
+	}
+	if addrs {
+		d.addSpentAddresses(b)
+	}
+	d.connectBlock(b)
+	return nil
+}
+
+// LTCStore delivers the events of a new Litecoin block. It satisfies
+// blockdataltc.BlockDataSaver.
+func (d *Dispatcher) LTCStore(blockData *blockdataltc.BlockData, msgBlock *ltcwire.MsgBlock) error {
+	hdr := &msgBlock.Header
+	b := newChainBlock(mutilchain.TYPELTC, int64(blockData.Header.Height), msgBlock.BlockHash().String(),
+		hdr.PrevBlock.String(), hdr.Timestamp)
+	addrs := d.watchesAddresses(b.chainType)
+	for _, tx := range msgBlock.Transactions {
+		txid := tx.TxHash().String()
+		b.addTx(txid)
+		if !addrs {
+			continue
+		}
+		for _, in := range tx.TxIn {
+			prev := &in.PreviousOutPoint
+			b.addSpend(txid, prev.Hash.String(), prev.Index)
+		}
+		for i, out := range tx.TxOut {
+			_, outAddrs, _, _ := ltctxscript.ExtractPkScriptAddrs(out.PkScript, d.ltcParams)
+			addrStrs := make([]string, 0, len(outAddrs))
+			for _, addr := range outAddrs {
+				addrStrs = append(addrStrs, addr.String())
+			}
+			b.addOutput(txid, uint32(i), addrStrs)
+		}
+	}
+	if addrs {
+		d.addSpentAddresses(b)
+	}
+	d.connectBlock(b)
+	return nil
+}
+
+// XMRStore delivers the events of a new Monero block. The outputs of Monero
+// transactions do not reveal addresses, so there are no address events. It
+// satisfies blockdataxmr.BlockDataSaver.
+func (d *Dispatcher) XMRStore(blockData *xmrutil.BlockData) error {
+	hdr := &blockData.Header
+	b := newChainBlock(mutilchain.TYPEXMR, int64(hdr.Height), hdr.Hash, hdr.PrevHash,
+		time.Unix(int64(hdr.Timestamp), 0))
+	for _, txid := range blockData.TxHashes {
+		b.addTx(txid)
+	}
+	d.connectBlock(b)
+	return nil
+}
+
+// addSpentAddresses adds the addresses of the outputs spent by the block
+// transactions. The outputs of earlier blocks are looked up in the Store.
+func (d *Dispatcher) addSpentAddresses(b *chainBlock) {
+	var lookups []string
+	seen := make(map[string]bool)
+	for _, sp := range b.spends {
+		if _, found := b.outAddrs[sp.prevOut]; !found && !seen[sp.prevOut.txid] {
+			seen[sp.prevOut.txid] = true
+			lookups = append(lookups, sp.prevOut.txid)
+		}
+	}
+	if len(lookups) > 0 {
+		vouts, err := d.store.VoutsByTxHashes(d.ctx, b.chainType, lookups)
+		if err != nil {
+			log.Errorf("Failed to retrieve the %s outputs spent in block %d: %v", b.chainType, b.Height, err)
+		}
+		for _, vout := range vouts {
+			b.outAddrs[outpoint{txid: vout.TxHash, index: vout.TxIndex}] = vout.ScriptPubKeyData.Addresses
+		}
+	}
+	for _, sp := range b.spends {
+		for _, addr := range b.outAddrs[sp.prevOut] {
+			b.addAddress(addr, sp.txid)
+		}
+	}
+}
+
+// connectBlock delivers the events of a new block to the hooks of its chain. A
+// block that does not extend the previous tip is a reorg.
+func (d *Dispatcher) connectBlock(b *chainBlock) {
+	d.mtx.Lock()
+	prev, seen := d.tips[b.chainType]
+	if seen && prev.hash == b.Hash {
+		d.mtx.Unlock()
+		return
+	}
+	d.tips[b.chainType] = chainTip{height: b.Height, hash: b.Hash}
+	reorg := seen && (b.Height <= prev.height ||
+		(b.Height == prev.height+1 && b.PrevHash != prev.hash))
+	if reorg {
+		log.Infof("Reorg of the %s chain from %d (%s) to %d (%s).", b.chainType,
+			prev.height, prev.hash, b.Height, b.Hash)
+	}
+
+	// The mined heights of the confirmations hook transactions are looked up
+	// without holding the lock.
+	lookups := make(map[int64]string)
+	for _, h := range d.hooks {
+		cs := d.confs[h.ID]
+		if cs == nil || h.ChainType != b.chainType {
+			continue
+		}
+		if reorg {
+			*cs = confState{lookup: true}
+		}
+		if cs.lookup {
+			lookups[h.ID] = h.TxID
+		}
+	}
+	d.mtx.Unlock()
+
+	heights := make(map[int64]int64, len(lookups))
+	for id, txid := range lookups {
+		height, err := d.store.TxBlockHeight(b.chainType, txid)
+		if err != nil {
+			// Not mined yet, or not yet stored.
+			continue
+		}
+		heights[id] = height
+	}
+
+	d.mtx.Lock()
+	var deliveries []*hookDelivery
+	var fired []int64
+	newDelivery := func(h *dbtypes.Webhook, data interface{}) {
+		deliveries = append(deliveries, &hookDelivery{
+			hook: *h,
+			payload: &Payload{
+				HookID:    h.ID,
+				Event:     h.Event,
+				ChainType: h.ChainType,
+				Time:      time.Now().Unix(),
+				Data:      data,
+			},
+		})
+	}
+	for _, h := range d.hooks {
+		if !h.Active || h.ChainType != b.chainType {
+			continue
+		}
+		switch h.Event {
+		case dbtypes.WebhookBlock:
+			newDelivery(h, &b.BlockEvent)
+		case dbtypes.WebhookReorg:
+			if reorg {
+				newDelivery(h, &ReorgEvent{
+					OldHeight: prev.height,
+					OldHash:   prev.hash,
+					NewHeight: b.Height,
+					NewHash:   b.Hash,
+				})
+			}
+		case dbtypes.WebhookAddress:
+			for _, txid := range b.addrTxs[h.Address] {
+				newDelivery(h, &AddressEvent{
+					Address:   h.Address,
+					TxID:      txid,
+					Height:    b.Height,
+					BlockHash: b.Hash,
+				})
+			}
+		case dbtypes.WebhookConfirmations:
+			cs := d.confs[h.ID]
+			if cs == nil {
+				continue
+			}
+			if _, found := lookups[h.ID]; found && cs.lookup {
+				cs.height, cs.lookup = heights[h.ID], false
+			}
+			if _, found := b.txids[h.TxID]; found {
+				cs.height, cs.lookup = b.Height, false
+			}
+			if cs.height <= 0 || cs.height > b.Height {
+				continue
+			}
+			confirmations := b.Height - cs.height + 1
+			if confirmations < h.Depth {
+				continue
+			}
+			newDelivery(h, &ConfirmationsEvent{
+				TxID:          h.TxID,
+				BlockHeight:   cs.height,
+				Confirmations: confirmations,
+			})
+			h.Active = false
+			delete(d.confs, h.ID)
+			fired = append(fired, h.ID)
+		}
+	}
+	d.mtx.Unlock()
+
+	for _, id := range fired {
+		if err := d.store.DeactivateWebhook(id); err != nil {
+			log.Errorf("Failed to deactivate webhook %d: %v", id, err)
+		}
+	}
+	d.dispatch(deliveries)
+}
+
+// mempoolAddressTx delivers the address events of a new mempool transaction.
+func (d *Dispatcher) mempoolAddressTx(chainType, addr, txid string) {
+	d.mtx.RLock()
+	var deliveries []*hookDelivery
+	for _, h := range d.hooks {
+		if h.Active && h.Event == dbtypes.WebhookAddress && h.ChainType == chainType && h.Address == addr {
+			deliveries = append(deliveries, &hookDelivery{
+				hook: *h,
+				payload: &Payload{
+					HookID:    h.ID,
+					Event:     h.Event,
+					ChainType: h.ChainType,
+					Time:      time.Now().Unix(),
+					Data: &AddressEvent{
+						Address: addr,
+						TxID:    txid,
+					},
+				},
+			})
+		}
+	}
+	d.mtx.RUnlock()
+	d.dispatch(deliveries)
+}
+
+// dispatch starts the deliveries.
+func (d *Dispatcher) dispatch(deliveries []*hookDelivery) {
+	if d.ctx.Err() != nil {
+		return
+	}
+	for _, dl := range deliveries {
+		d.wg.Add(1)
+		go d.deliver(dl)
+	}
+}
+
+// deliver posts the signed payload to the webhook URL, retrying with
+// exponential backoff on failure. Every attempt is recorded in the delivery
+// log, with the payload on the first attempt.
+func (d *Dispatcher) deliver(dl *hookDelivery) {
+	defer d.wg.Done()
+	body, err := json.Marshal(dl.payload)
+	if err != nil {
+		log.Errorf("Failed to encode webhook payload: %v", err)
+		return
+	}
+	hook := &dl.hook
+	deliveryID := randomHex(16)
+	header := make(http.Header)
+	header.Set(EventHeader, hook.Event)
+	header.Set(DeliveryHeader, deliveryID)
+	header.Set(SignatureHeader, "sha256="+Sign(hook.Secret, body))
+	d.poster.Deliver(d.ctx, hook.URL, header, body, func(attempt *delivery.Attempt) {
+		entry := &dbtypes.WebhookDelivery{
+			HookID:     hook.ID,
+			DeliveryID: deliveryID,
+			Event:      hook.Event,
+			Attempt:    attempt.Number,
+			StatusCode: attempt.StatusCode,
+			Success:    attempt.Err == nil,
+			Time:       attempt.Time.Unix(),
+		}
+		if attempt.Number == 1 {
+			entry.Payload = string(body)
+		}
+		if attempt.Err != nil {
+			entry.Error = attempt.Err.Error()
+		}
+		if err := d.store.InsertWebhookDelivery(entry); err != nil {
+			log.Errorf("Failed to store the delivery log of webhook %d: %v", hook.ID, err)
+		}
+		switch {
+		case entry.Success:
+		case attempt.Retry == 0:
+			log.Errorf("Webhook %d delivery to %s failed after %d attempts: %v", hook.ID, hook.URL, attempt.Number, attempt.Err)
+		default:
+			log.Debugf("Webhook %d delivery to %s failed. Retrying in %s: %v", hook.ID, hook.URL, attempt.Retry, attempt.Err)
+		}
+	})
+}
@@ -0,0 +1,647 @@
+// Copyright (c) 2026, The Decred developers
+// See LICENSE for details.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	btcchaincfg "github.com/btcsuite/btcd/chaincfg"
+	btcchainhash "github.com/btcsuite/btcd/chaincfg/chainhash"
+	btctxscript "github.com/btcsuite/btcd/txscript"
+	btcwire "github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/chaincfg/v3"
+
+	"github.com/decred/dcrdata/v8/blockdata/blockdatabtc"
+	"github.com/decred/dcrdata/v8/db/dbtypes"
+	"github.com/decred/dcrdata/v8/mutilchain"
+	pstypes "github.com/decred/dcrdata/v8/pubsub/types"
+	"github.com/decred/dcrdata/v8/xmr/xmrutil"
+)
+
+const testBTCAddress = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+
+// testToken is the management token of the test hooks.
+var testToken = strings.Repeat("5a", 32)
+
+// memStore is an in-memory Store.
+type memStore struct {
+	mtx        sync.Mutex
+	hooks      []*dbtypes.Webhook
+	deliveries []*dbtypes.WebhookDelivery
+	txHeights  map[string]int64
+	vouts      []*dbtypes.Vout
+}
+
+func (s *memStore) Webhooks() ([]*dbtypes.Webhook, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	hooks := make([]*dbtypes.Webhook, 0, len(s.hooks))
+	for _, h := range s.hooks {
+		hook := *h
+		hooks = append(hooks, &hook)
+	}
+	return hooks, nil
+}
+
+func (s *memStore) InsertWebhook(h *dbtypes.Webhook) (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	hook := *h
+	hook.ID = int64(len(s.hooks) + 1)
+	s.hooks = append(s.hooks, &hook)
+	return hook.ID, nil
+}
+
+func (s *memStore) DeactivateWebhook(id int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, h := range s.hooks {
+		if h.ID == id {
+			h.Active = false
+		}
+	}
+	return nil
+}
+
+func (s *memStore) DeleteWebhook(id int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for i, h := range s.hooks {
+		if h.ID == id {
+			s.hooks = append(s.hooks[:i], s.hooks[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *memStore) InsertWebhookDelivery(d *dbtypes.WebhookDelivery) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.deliveries = append(s.deliveries, d)
+	return nil
+}
+
+func (s *memStore) WebhookDeliveries(hookID int64, limit int) ([]*dbtypes.WebhookDelivery, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var deliveries []*dbtypes.WebhookDelivery
+	for i := len(s.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if s.deliveries[i].HookID == hookID {
+			deliveries = append(deliveries, s.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (s *memStore) DeleteWebhookDeliveriesBefore(t int64) (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	kept := s.deliveries[:0]
+	for _, d := range s.deliveries {
+		if d.Time >= t {
+			kept = append(kept, d)
+		}
+	}
+	n := int64(len(s.deliveries) - len(kept))
+	s.deliveries = kept
+	return n, nil
+}
+
+func (s *memStore) VoutsByTxHashes(_ context.Context, _ string, txids []string) ([]*dbtypes.Vout, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var vouts []*dbtypes.Vout
+	for _, vout := range s.vouts {
+		for _, txid := range txids {
+			if vout.TxHash == txid {
+				vouts = append(vouts, vout)
+			}
+		}
+	}
+	return vouts, nil
+}
+
+func (s *memStore) TxBlockHeight(_, txid string) (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if height, found := s.txHeights[txid]; found {
+		return height, nil
+	}
+	return 0, dbtypes.ErrNoResult
+}
+
+// receiver is a local webhook receiver that verifies the payload signatures.
+// The first failures requests are answered with a 500.
+type receiver struct {
+	t        *testing.T
+	secrets  map[int64]string
+	mtx      sync.Mutex
+	failures int
+	hits     int
+	payloads []*Payload
+	ids      []string
+}
+
+func (rcv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rcv.t.Errorf("failed to read the request body: %v", err)
+		return
+	}
+	var p Payload
+	if err = json.Unmarshal(body, &p); err != nil {
+		rcv.t.Errorf("failed to decode the payload: %v", err)
+		return
+	}
+	rcv.mtx.Lock()
+	defer rcv.mtx.Unlock()
+	if !Verify(rcv.secrets[p.HookID], body, r.Header.Get(SignatureHeader)) {
+		rcv.t.Errorf("invalid signature for hook %d", p.HookID)
+	}
+	if r.Header.Get(EventHeader) != p.Event {
+		rcv.t.Errorf("event header %q, payload event %q", r.Header.Get(EventHeader), p.Event)
+	}
+	rcv.hits++
+	rcv.ids = append(rcv.ids, r.Header.Get(DeliveryHeader))
+	if rcv.hits <= rcv.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rcv.payloads = append(rcv.payloads, &p)
+}
+
+// received takes the payloads received so far.
+func (rcv *receiver) received() []*Payload {
+	rcv.mtx.Lock()
+	defer rcv.mtx.Unlock()
+	payloads := rcv.payloads
+	rcv.payloads = nil
+	return payloads
+}
+
+func newTestDispatcher(t *testing.T, ctx context.Context, store *memStore) (*Dispatcher, *receiver, string) {
+	t.Helper()
+	rcv := &receiver{t: t, secrets: make(map[int64]string)}
+	srv := httptest.NewServer(rcv)
+	t.Cleanup(srv.Close)
+	d, err := NewDispatcher(ctx, &Config{
+		Store:            store,
+		Chains:           []string{mutilchain.TYPEDCR, mutilchain.TYPEBTC, mutilchain.TYPEXMR},
+		DCRParams:        chaincfg.MainNetParams(),
+		BTCParams:        &btcchaincfg.MainNetParams,
+		Backoff:          time.Millisecond,
+		AllowPrivateURLs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, rcv, srv.URL
+}
+
+func addTestHook(t *testing.T, d *Dispatcher, rcv *receiver, h *dbtypes.Webhook) int64 {
+	t.Helper()
+	hook, err := d.AddHook(h, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcv.mtx.Lock()
+	rcv.secrets[hook.ID] = hook.Secret
+	rcv.mtx.Unlock()
+	return hook.ID
+}
+
+// btcBlock makes a block of the given transactions paying 1 BTC to each
+// address.
+func btcBlock(t *testing.T, prev *btcchainhash.Hash, nonce uint32, addrs ...string) *btcwire.MsgBlock {
+	t.Helper()
+	block := btcwire.NewMsgBlock(&btcwire.BlockHeader{
+		PrevBlock: *prev,
+		Timestamp: time.Unix(1600000000+int64(nonce), 0),
+		Nonce:     nonce,
+	})
+	for i, a := range addrs {
+		tx := btcwire.NewMsgTx(1)
+		tx.LockTime = nonce*100 + uint32(i)
+		tx.AddTxOut(btcwire.NewTxOut(1e8, btcPkScript(t, a)))
+		block.AddTransaction(tx)
+	}
+	return block
+}
+
+func btcPkScript(t *testing.T, a string) []byte {
+	t.Helper()
+	addr, err := btcutil.DecodeAddress(a, &btcchaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := btctxscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkScript
+}
+
+func storeBTC(t *testing.T, d *Dispatcher, height int64, block *btcwire.MsgBlock) {
+	t.Helper()
+	bd := new(blockdatabtc.BlockData)
+	bd.Header.Height = int32(height)
+	if err := d.BTCStore(bd, block); err != nil {
+		t.Fatal(err)
+	}
+	d.wg.Wait()
+}
+
+func TestAddHookValidation(t *testing.T) {
+	d, _, url := newTestDispatcher(t, context.Background(), &memStore{})
+	txid := strings.Repeat("ab", 32)
+	tests := []struct {
+		name string
+		hook dbtypes.Webhook
+		ok   bool
+	}{
+		{"block", dbtypes.Webhook{ChainType: "BTC", Event: dbtypes.WebhookBlock, URL: url}, true},
+		{"disabled chain", dbtypes.Webhook{ChainType: "ltc", Event: dbtypes.WebhookBlock, URL: url}, false},
+		{"bad url", dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock, URL: "ftp://x"}, false},
+		{"bad event", dbtypes.Webhook{ChainType: "btc", Event: "halving", URL: url}, false},
+		{"address", dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookAddress, URL: url, Address: testBTCAddress}, true},
+		{"dcr address on btc", dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookAddress, URL: url, Address: "Dcur2mcGjmENx4DhNqDctW5wJCVyT3Qeqkx"}, false},
+		{"dcr address", dbtypes.Webhook{ChainType: "dcr", Event: dbtypes.WebhookAddress, URL: url, Address: "Dcur2mcGjmENx4DhNqDctW5wJCVyT3Qeqkx"}, true},
+		{"xmr address", dbtypes.Webhook{ChainType: "xmr", Event: dbtypes.WebhookAddress, URL: url, Address: "4"}, false},
+		{"confirmations", dbtypes.Webhook{ChainType: "xmr", Event: dbtypes.WebhookConfirmations, URL: url, TxID: txid, Depth: 10}, true},
+		{"bad txid", dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookConfirmations, URL: url, TxID: "abc", Depth: 1}, false},
+		{"bad depth", dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookConfirmations, URL: url, TxID: txid}, false},
+	}
+	for _, tt := range tests {
+		hook, err := d.AddHook(&tt.hook, testToken)
+		if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidHook)) {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if err == nil && (hook.Secret == "" || hook.Token != testToken || !hook.Active ||
+			hook.ChainType != strings.ToLower(tt.hook.ChainType)) {
+			t.Errorf("%s: unexpected hook %+v", tt.name, hook)
+		}
+	}
+
+	for _, h := range d.Hooks(testToken, "") {
+		if h.Secret != "" || h.Token != "" {
+			t.Errorf("hook %d secret or token not omitted", h.ID)
+		}
+	}
+	if n := len(d.Hooks(testToken, mutilchain.TYPEBTC)); n != 2 {
+		t.Errorf("expected 2 btc hooks, got %d", n)
+	}
+
+	// Only public hosts are accepted by default.
+	d.allowPrivate = false
+	for _, u := range []string{url, "http://localhost:8080/hook", "http://10.0.0.1/hook",
+		"http://[fe80::1]/hook", "http://169.254.169.254/latest"} {
+		_, err := d.AddHook(&dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock, URL: u}, testToken)
+		if !errors.Is(err, ErrInvalidHook) {
+			t.Errorf("private URL %s accepted: %v", u, err)
+		}
+	}
+	if _, err := d.AddHook(&dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock,
+		URL: "https://example.com/hook"}, testToken); err != nil {
+		t.Errorf("public URL rejected: %v", err)
+	}
+}
+
+func TestHookTokens(t *testing.T) {
+	d, _, url := newTestDispatcher(t, context.Background(), &memStore{})
+	hook := &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock, URL: url}
+
+	if _, err := d.AddHook(hook, "guessable"); !errors.Is(err, ErrInvalidHook) {
+		t.Errorf("invalid token accepted: %v", err)
+	}
+	// A new token is generated for a new owner.
+	added, err := d.AddHook(hook, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := added.Token
+	if !ValidToken(token) || token == testToken {
+		t.Fatalf("unexpected token %q", token)
+	}
+	other, err := d.AddHook(hook, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hooks are only listed, retrieved and removed with their token.
+	for _, tok := range []string{token, testToken} {
+		if hooks := d.Hooks(tok, ""); len(hooks) != 1 {
+			t.Errorf("expected 1 hook, got %d", len(hooks))
+		}
+	}
+	if hooks := d.Hooks("", ""); len(hooks) != 0 {
+		t.Errorf("hooks listed without a token: %v", hooks)
+	}
+	if _, found := d.Hook(testToken, added.ID); found {
+		t.Error("hook retrieved with another token")
+	}
+	if _, found := d.Hook(token, added.ID); !found {
+		t.Error("hook not retrieved with its token")
+	}
+	if removed, _ := d.RemoveHook(token, other.ID); removed {
+		t.Error("hook removed with another token")
+	}
+	if removed, err := d.RemoveHook(testToken, other.ID); err != nil || !removed {
+		t.Errorf("hook not removed with its token: %v", err)
+	}
+
+	// A token may only manage a few hooks, which leaves room for the others.
+	d.maxOwner = 2
+	if _, err = d.AddHook(hook, token); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.AddHook(hook, token); !errors.Is(err, ErrInvalidHook) {
+		t.Errorf("hook added over the limit of its token: %v", err)
+	}
+	if _, err = d.AddHook(hook, testToken); err != nil {
+		t.Errorf("hook of another token not added: %v", err)
+	}
+}
+
+func TestBlockReorgAndAddressEvents(t *testing.T) {
+	d, rcv, url := newTestDispatcher(t, context.Background(), &memStore{})
+	blockHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock, URL: url})
+	reorgHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookReorg, URL: url})
+	addrHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookAddress, URL: url, Address: testBTCAddress})
+	addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "xmr", Event: dbtypes.WebhookBlock, URL: url})
+
+	other := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	b1 := btcBlock(t, &btcchainhash.Hash{}, 1, other)
+	storeBTC(t, d, 100, b1)
+	payloads := rcv.received()
+	if len(payloads) != 1 || payloads[0].HookID != blockHook {
+		t.Fatalf("expected a block event, got %v", payloads)
+	}
+
+	// The next block pays to the watched address.
+	b1Hash := b1.BlockHash()
+	b2 := btcBlock(t, &b1Hash, 2, testBTCAddress, other)
+	storeBTC(t, d, 101, b2)
+	payloads = rcv.received()
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(payloads))
+	}
+	for _, p := range payloads {
+		if p.HookID == addrHook {
+			data := p.Data.(map[string]interface{})
+			if data["address"] != testBTCAddress || data["txid"] != b2.Transactions[0].TxHash().String() ||
+				data["height"].(float64) != 101 {
+				t.Errorf("unexpected address event %v", data)
+			}
+		}
+	}
+
+	// A competing block at the same height is a reorg.
+	b2Alt := btcBlock(t, &b1Hash, 3, other)
+	storeBTC(t, d, 101, b2Alt)
+	var reorged bool
+	for _, p := range rcv.received() {
+		if p.HookID != reorgHook {
+			continue
+		}
+		reorged = true
+		data := p.Data.(map[string]interface{})
+		if data["old_hash"] != b2.BlockHash().String() || data["new_hash"] != b2Alt.BlockHash().String() {
+			t.Errorf("unexpected reorg event %v", data)
+		}
+	}
+	if !reorged {
+		t.Error("no reorg event")
+	}
+
+	// Mempool activity of the address, relayed by the mempool monitor.
+	ctx, cancel := context.WithCancel(context.Background())
+	d.ctx = ctx
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go d.Run(&wg)
+	d.HubRelay() <- pstypes.HubMessage{Signal: pstypes.SigAddressTx, Msg: &pstypes.AddressMessage{
+		Address: testBTCAddress, TxHash: "aa", ChainType: mutilchain.TYPEBTC}}
+	// A Decred address message with the same address is not for the hook.
+	d.HubRelay() <- pstypes.HubMessage{Signal: pstypes.SigAddressTx, Msg: &pstypes.AddressMessage{
+		Address: testBTCAddress, TxHash: "bb"}}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rcv.mtx.Lock()
+		n := len(rcv.payloads)
+		rcv.mtx.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	wg.Wait()
+	payloads = rcv.received()
+	if len(payloads) != 1 || payloads[0].Data.(map[string]interface{})["txid"] != "aa" {
+		t.Fatalf("expected a mempool address event, got %v", payloads)
+	}
+
+	// XMR blocks are delivered to the xmr hooks only.
+	d.ctx = context.Background()
+	bd := &xmrutil.BlockData{TxHashes: []string{"cc"}}
+	bd.Header.Height, bd.Header.Hash = 3000000, "xmrhash"
+	if err := d.XMRStore(bd); err != nil {
+		t.Fatal(err)
+	}
+	d.wg.Wait()
+	payloads = rcv.received()
+	if len(payloads) != 1 || payloads[0].ChainType != mutilchain.TYPEXMR {
+		t.Fatalf("expected an xmr block event, got %v", payloads)
+	}
+}
+
+func TestConfirmations(t *testing.T) {
+	minedTx := strings.Repeat("01", 32)
+	store := &memStore{txHeights: map[string]int64{minedTx: 99}}
+	d, rcv, url := newTestDispatcher(t, context.Background(), store)
+
+	b1 := btcBlock(t, &btcchainhash.Hash{}, 1, testBTCAddress)
+	pendingTx := b1.Transactions[0].TxHash().String()
+	minedHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookConfirmations,
+		URL: url, TxID: minedTx, Depth: 3})
+	pendingHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookConfirmations,
+		URL: url, TxID: pendingTx, Depth: 2})
+
+	// The mined tx has 2 confirmations, and the pending tx is mined with 1.
+	storeBTC(t, d, 100, b1)
+	if payloads := rcv.received(); len(payloads) != 0 {
+		t.Fatalf("unexpected events %v", payloads)
+	}
+
+	b1Hash := b1.BlockHash()
+	b2 := btcBlock(t, &b1Hash, 2)
+	storeBTC(t, d, 101, b2)
+	payloads := rcv.received()
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(payloads))
+	}
+	for _, p := range payloads {
+		data := p.Data.(map[string]interface{})
+		switch p.HookID {
+		case minedHook:
+			if data["block_height"].(float64) != 99 || data["confirmations"].(float64) != 3 {
+				t.Errorf("unexpected event %v", data)
+			}
+		case pendingHook:
+			if data["block_height"].(float64) != 100 || data["confirmations"].(float64) != 2 {
+				t.Errorf("unexpected event %v", data)
+			}
+		}
+	}
+
+	// The hooks fire once.
+	b2Hash := b2.BlockHash()
+	storeBTC(t, d, 102, btcBlock(t, &b2Hash, 3))
+	if payloads := rcv.received(); len(payloads) != 0 {
+		t.Fatalf("unexpected events %v", payloads)
+	}
+	for _, h := range store.hooks {
+		if h.Active {
+			t.Errorf("hook %d still active", h.ID)
+		}
+	}
+
+	// Reloaded, the fired hooks stay inactive.
+	d2, err := NewDispatcher(context.Background(), &Config{Store: store, Chains: []string{mutilchain.TYPEBTC}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d2.confs) != 0 || len(d2.Hooks(testToken, "")) != 2 {
+		t.Errorf("unexpected reloaded hooks %v", d2.Hooks(testToken, ""))
+	}
+}
+
+func TestDeliveryRetries(t *testing.T) {
+	store := &memStore{}
+	d, rcv, url := newTestDispatcher(t, context.Background(), store)
+	rcv.failures = 2
+	id := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookBlock, URL: url})
+
+	storeBTC(t, d, 100, btcBlock(t, &btcchainhash.Hash{}, 1))
+	if payloads := rcv.received(); len(payloads) != 1 {
+		t.Fatalf("expected 1 delivered event, got %d", len(payloads))
+	}
+	for _, deliveryID := range rcv.ids {
+		if deliveryID != rcv.ids[0] {
+			t.Errorf("retries have different delivery IDs %v", rcv.ids)
+		}
+	}
+
+	deliveries, err := d.Deliveries(id, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 3 {
+		t.Fatalf("expected 3 logged attempts, got %d", len(deliveries))
+	}
+	// Most recent first.
+	if last := deliveries[0]; last.Attempt != 3 || !last.Success {
+		t.Errorf("unexpected final attempt %+v", last)
+	}
+	if first := deliveries[2]; first.Attempt != 1 || first.Success || first.StatusCode != http.StatusInternalServerError {
+		t.Errorf("unexpected first attempt %+v", first)
+	}
+	// The payload is only logged with the first attempt.
+	if deliveries[2].Payload == "" || deliveries[1].Payload != "" || deliveries[0].Payload != "" {
+		t.Error("the payload is not logged with the first attempt only")
+	}
+
+	// Abandoned after the retries.
+	d.poster.Retries = 1
+	rcv.failures = 100
+	storeBTC(t, d, 101, btcBlock(t, &btcchainhash.Hash{}, 2))
+	deliveries, _ = d.Deliveries(id, 10)
+	if last := deliveries[0]; last.Attempt != 2 || last.Success {
+		t.Errorf("unexpected final attempt %+v", last)
+	}
+
+	// A removed hook is not delivered to.
+	if removed, err := d.RemoveHook(testToken, id); err != nil || !removed {
+		t.Fatalf("RemoveHook failed: %v", err)
+	}
+	hits := rcv.hits
+	storeBTC(t, d, 102, btcBlock(t, &btcchainhash.Hash{}, 3))
+	if rcv.hits != hits {
+		t.Error("removed hook delivered to")
+	}
+}
+
+func TestSpendAddressEvents(t *testing.T) {
+	store := &memStore{}
+	d, rcv, url := newTestDispatcher(t, context.Background(), store)
+	addrHook := addTestHook(t, d, rcv, &dbtypes.Webhook{ChainType: "btc", Event: dbtypes.WebhookAddress,
+		URL: url, Address: testBTCAddress})
+
+	// An output of an earlier block pays to the watched address.
+	funding := strings.Repeat("cd", 32)
+	store.vouts = []*dbtypes.Vout{{
+		TxHash:           funding,
+		TxIndex:          1,
+		ScriptPubKeyData: dbtypes.ScriptPubKeyData{Addresses: []string{testBTCAddress}},
+	}}
+	fundingHash, err := btcchainhash.NewHashFromStr(funding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The block spends it, and pays to the address with another transaction
+	// that is spent in the same block.
+	other := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	block := btcBlock(t, &btcchainhash.Hash{}, 1, other, testBTCAddress)
+	spend := block.Transactions[0]
+	spend.AddTxIn(btcwire.NewTxIn(btcwire.NewOutPoint(fundingHash, 1), nil, nil))
+	inBlockHash := block.Transactions[1].TxHash()
+	inBlockSpend := btcwire.NewMsgTx(1)
+	inBlockSpend.AddTxIn(btcwire.NewTxIn(btcwire.NewOutPoint(&inBlockHash, 0), nil, nil))
+	inBlockSpend.AddTxOut(btcwire.NewTxOut(1e8, btcPkScript(t, other)))
+	block.AddTransaction(inBlockSpend)
+	storeBTC(t, d, 100, block)
+
+	want := map[string]bool{
+		spend.TxHash().String():        true,
+		inBlockHash.String():           true,
+		inBlockSpend.TxHash().String(): true,
+	}
+	payloads := rcv.received()
+	if len(payloads) != len(want) {
+		t.Fatalf("expected %d address events, got %d", len(want), len(payloads))
+	}
+	for _, p := range payloads {
+		txid := p.Data.(map[string]interface{})["txid"].(string)
+		if p.HookID != addrHook || !want[txid] {
+			t.Errorf("unexpected address event %v", p.Data)
+		}
+		delete(want, txid)
+	}
+}
+
+func TestPurgeDeliveries(t *testing.T) {
+	now := time.Now()
+	store := &memStore{deliveries: []*dbtypes.WebhookDelivery{
+		{HookID: 1, Time: now.Add(-2 * DefaultDeliveryRetention).Unix()},
+		{HookID: 1, Time: now.Add(-time.Hour).Unix()},
+	}}
+	d, _, _ := newTestDispatcher(t, context.Background(), store)
+	d.purgeDeliveries()
+	if len(store.deliveries) != 1 || store.deliveries[0].Time != now.Add(-time.Hour).Unix() {
+		t.Errorf("unexpected deliveries after the purge %v", store.deliveries)
+	}
+}